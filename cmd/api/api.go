@@ -5,16 +5,15 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"os"
-	"os/signal"
-	"syscall"
 	"time"
 
 	"github.com/barimehdi77/cupid-api/docs"
 	"github.com/barimehdi77/cupid-api/internal/api"
 	"github.com/barimehdi77/cupid-api/internal/logger"
+	"github.com/barimehdi77/cupid-api/internal/metrics"
 	"github.com/barimehdi77/cupid-api/internal/store"
 	"github.com/barimehdi77/cupid-api/internal/sync"
+	"github.com/barimehdi77/cupid-api/internal/tracing"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -27,11 +26,14 @@ type application struct {
 	storage     store.Storage
 	handlers    *api.Handlers
 	syncService *sync.SyncService
+	// databaseHealth reports whether the background database checks succeed, for the health endpoint
+	databaseHealth func() bool
 }
 
 type config struct {
-	port int
-	env  string
+	port        int
+	env         string
+	adminAPIKey string
 }
 
 // mount configures all routes, middleware, and handlers
@@ -45,42 +47,107 @@ func (app *application) mount() *gin.Engine {
 	r := gin.New()
 
 	// Add enhanced logging middleware
+	r.Use(logger.RequestIDMiddleware())   // Request ID generation and propagation
 	r.Use(logger.GinMiddleware())         // Enhanced HTTP request logging
+	r.Use(metrics.GinMiddleware())        // Prometheus request metrics, outside recovery so panics count as 500s
 	r.Use(logger.GinRecoveryMiddleware()) // Enhanced panic recovery logging
+	r.Use(tracing.GinMiddleware())        // OpenTelemetry server spans
+	r.Use(api.SkippedRowsMiddleware())    // Partial result reporting
 
 	// Initialize Swagger docs
 	docs.SwaggerInfo.BasePath = "/api/v1"
 
 	// Create handlers
-	app.handlers = api.NewHandlers(app.storage)
+	apiConfig := api.ConfigFromEnv()
+	if err := r.SetTrustedProxies(apiConfig.TrustedProxies); err != nil {
+		logger.Fatal("Invalid trusted proxies", zap.Error(err))
+	}
+	app.handlers = api.NewHandlersWithConfig(app.storage, apiConfig)
+	app.handlers.SetDatabaseHealth(app.databaseHealth)
+
+	// Answer known paths requested with an unsupported method with 405 and an Allow header
+	if apiConfig.HandleMethodNotAllowed {
+		r.HandleMethodNotAllowed = true
+		r.NoMethod(app.handlers.MethodNotAllowedHandler)
+	}
 
-	// API v1 routes
-	v1 := r.Group("/api/v1")
+	// Read endpoints also answer HEAD, running the GET handler without sending the body, so infrastructure can warm
+	// caches and check existence. /search has its own HEAD handler returning only the match count.
+	readRoute := func(group *gin.RouterGroup, path string, handler gin.HandlerFunc) {
+		group.GET(path, handler)
+		group.HEAD(path, api.HeadMiddleware(), handler)
+	}
+
+	// Describe the API at the root and answer unknown paths with the JSON error envelope
+	readRoute(&r.RouterGroup, "/", app.handlers.RootHandler)
+	r.NoRoute(app.handlers.NotFoundHandler)
+
+	// API v1 routes. Public reads get the short request timeout; admin operations, which can scan or rewrite
+	// every property, get their own.
+	v1 := r.Group("/api/v1", api.RateLimitMiddleware(apiConfig))
 	{
+		public := v1.Group("", api.RequestTimeoutMiddleware(apiConfig.RequestTimeout))
+
 		// Health check routes
-		v1.GET("/health", app.handlers.HealthCheckHandler)
+		readRoute(public, "/health", app.handlers.HealthCheckHandler)
 
 		// Property routes
-		v1.GET("/properties", app.handlers.ListPropertiesHandler)
-		v1.GET("/properties/:id", app.handlers.GetPropertyHandler)
-		v1.GET("/properties/:id/reviews", app.handlers.GetPropertyReviewsHandler)
-		v1.GET("/properties/:id/translations", app.handlers.GetPropertyTranslationsHandler)
-		v1.GET("/properties/location", app.handlers.GetPropertiesByLocationHandler)
-		v1.GET("/properties/rating", app.handlers.GetPropertiesByRatingHandler)
+		readRoute(public, "/properties", app.handlers.ListPropertiesHandler)
+		readRoute(public, "/properties/:id", app.handlers.GetPropertyHandler)
+		readRoute(public, "/properties/:id/summary", app.handlers.GetPropertySummaryHandler)
+		readRoute(public, "/properties/:id/reviews", app.handlers.GetPropertyReviewsHandler)
+		readRoute(public, "/properties/:id/reviews/stats", app.handlers.GetPropertyReviewStatsHandler)
+		readRoute(public, "/properties/:id/translations", app.handlers.GetPropertyTranslationsHandler)
+		readRoute(public, "/properties/:id/nearby", app.handlers.GetNearbyPropertiesHandler)
+		readRoute(public, "/properties/location", app.handlers.GetPropertiesByLocationHandler)
+		readRoute(public, "/properties/postal", app.handlers.GetPropertiesByPostalCodeHandler)
+		readRoute(public, "/properties/rating", app.handlers.GetPropertiesByRatingHandler)
+		readRoute(public, "/properties/best", app.handlers.GetBestPropertiesHandler)
+		readRoute(public, "/properties/top-by-city", app.handlers.GetTopRatedByCityHandler)
 
 		// Search routes
-		v1.GET("/search", app.handlers.SearchPropertiesHandler)
+		public.GET("/search", app.handlers.SearchPropertiesHandler)
+		public.HEAD("/search", app.handlers.SearchPropertiesHeadHandler)
 
-		// Admin sync routes (only if sync service is available)
-		if app.syncService != nil {
-			syncHandlers := api.NewSyncHandlers(app.syncService)
-			admin := v1.Group("/admin")
-			{
+		// Facility routes
+		readRoute(public, "/facilities", app.handlers.ListFacilitiesHandler)
+		readRoute(public, "/room-amenities", app.handlers.ListRoomAmenitiesHandler)
+		readRoute(public, "/chains", app.handlers.ListChainsHandler)
+
+		// Statistics routes
+		readRoute(public, "/stats/rating-by-stars", app.handlers.GetAverageRatingByStarsHandler)
+
+		// Admin routes
+		if app.config.adminAPIKey == "" {
+			logger.Warn("ADMIN_API_KEY is not set, admin routes will reject all requests")
+		}
+		admin := v1.Group("/admin",
+			api.AdminAuthMiddleware(app.config.adminAPIKey),
+			api.RequestTimeoutMiddleware(apiConfig.AdminRequestTimeout),
+		)
+		{
+			admin.DELETE("/properties/:id", app.handlers.DeletePropertyHandler)
+			admin.PATCH("/properties/:id", app.handlers.UpdatePropertyOverridesHandler)
+			admin.GET("/properties/:id/sync-history", app.handlers.GetPropertySyncHistoryHandler)
+			admin.GET("/properties/:id/raw", app.handlers.GetRawPropertyHandler)
+			admin.POST("/properties/:id/reviews/import", app.handlers.ImportPropertyReviewsHandler)
+			admin.GET("/properties/stale", app.handlers.GetStalePropertiesHandler)
+			admin.POST("/maintenance/purge-orphans", app.handlers.PurgeOrphansHandler)
+			admin.POST("/maintenance/prune-reviews", app.handlers.PruneReviewsHandler)
+			admin.GET("/maintenance/duplicate-coordinates", app.handlers.GetDuplicateCoordinatesHandler)
+			admin.GET("/maintenance/chain-conflicts", app.handlers.GetChainConflictsHandler)
+
+			// Admin sync routes (only if sync service is available)
+			if app.syncService != nil {
+				syncHandlers := api.NewSyncHandlersWithConfig(app.syncService, apiConfig)
 				admin.POST("/sync", syncHandlers.TriggerSyncHandler)
+				admin.POST("/sync/country", syncHandlers.SyncCountryHandler)
 				admin.GET("/sync/status", syncHandlers.GetSyncStatusHandler)
+				admin.GET("/sync/schedule", syncHandlers.GetSyncScheduleHandler)
 				admin.POST("/sync/start", syncHandlers.StartSyncHandler)
 				admin.POST("/sync/stop", syncHandlers.StopSyncHandler)
 				admin.GET("/sync/logs", syncHandlers.GetSyncLogsHandler)
+				admin.GET("/sync/logs/export", syncHandlers.ExportSyncLogsHandler)
 				admin.GET("/sync/settings", syncHandlers.GetSyncSettingsHandler)
 				admin.PUT("/sync/settings", syncHandlers.UpdateSyncSettingsHandler)
 				admin.GET("/sync/health", syncHandlers.GetSyncHealthHandler)
@@ -91,11 +158,14 @@ func (app *application) mount() *gin.Engine {
 	// Swagger endpoint
 	r.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Prometheus metrics endpoint
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
 	return r
 }
 
 // run starts the server and handles graceful shutdown
-func (app *application) run() error {
+func (app *application) run(ctx context.Context) error {
 	// Mount routes
 	router := app.mount()
 
@@ -108,10 +178,6 @@ func (app *application) run() error {
 		IdleTimeout:  time.Minute,
 	}
 
-	// Channel to listen for interrupt signals
-	shutdown := make(chan os.Signal, 1)
-	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
-
 	// Start server in a goroutine
 	go func() {
 		logger.LogStartup("HTTP Server",
@@ -124,16 +190,16 @@ func (app *application) run() error {
 		}
 	}()
 
-	// Wait for interrupt signal
-	<-shutdown
+	// Wait for the shutdown context, cancelled by an interrupt signal
+	<-ctx.Done()
 	logger.LogShutdown("HTTP Server", zap.String("reason", "interrupt signal received"))
 
 	// Create context with timeout for graceful shutdown
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	// Shutdown server
-	if err := srv.Shutdown(ctx); err != nil {
+	if err := srv.Shutdown(shutdownCtx); err != nil {
 		logger.LogError("Graceful shutdown", err)
 		return err
 	}
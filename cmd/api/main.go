@@ -14,19 +14,27 @@
 // @BasePath  /api/v1
 
 // @securityDefinitions.basic  BasicAuth
+
+// @securityDefinitions.apikey  AdminKey
+// @in                          header
+// @name                        X-Admin-Key
 package main
 
 import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/barimehdi77/cupid-api/cmd/migrate/migrations"
 	"github.com/barimehdi77/cupid-api/internal/cupid"
 	"github.com/barimehdi77/cupid-api/internal/database"
 	"github.com/barimehdi77/cupid-api/internal/env"
 	"github.com/barimehdi77/cupid-api/internal/logger"
 	"github.com/barimehdi77/cupid-api/internal/store"
 	"github.com/barimehdi77/cupid-api/internal/sync"
+	"github.com/barimehdi77/cupid-api/internal/tracing"
 	"github.com/joho/godotenv"
 	"go.uber.org/zap"
 )
@@ -44,6 +52,23 @@ func main() {
 	}
 	defer logger.Sync()
 
+	// Initialize tracing (no-op unless OTEL_EXPORTER_OTLP_ENDPOINT is set)
+	shutdownTracing, err := tracing.Init(context.Background(), "cupid-api")
+	if err != nil {
+		logger.Fatal("Failed to initialize tracing", zap.Error(err))
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.LogError("Tracing shutdown", err)
+		}
+	}()
+
+	// Fail fast instead of syncing with unauthenticated requests that are all rejected
+	cupidClient := cupid.NewClient()
+	if err := cupidClient.CheckAPIKey(); err != nil {
+		logger.Fatal("Invalid Cupid API configuration", zap.Error(err))
+	}
+
 	// Initialize database
 	db, err := database.NewDB()
 	if err != nil {
@@ -51,34 +76,53 @@ func main() {
 	}
 	defer db.Close()
 
+	// Cancelled on SIGINT or SIGTERM, stopping the background work and then the server
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	db.StartHealthCheck(ctx)
+
+	// Apply pending migrations before serving traffic when enabled
+	if env.GetEnvBool("DB_AUTO_MIGRATE", false) {
+		if err := db.MigrateUp(context.Background(), migrations.FS); err != nil {
+			logger.Fatal("Failed to apply database migrations", zap.Error(err))
+		}
+	}
+
 	// Initialize storage
-	storage := store.NewStorage(db)
+	storage := store.NewStorageWithConfig(db, store.ConfigFromEnv())
 
-	// Create sync service
-	cupidService := cupid.NewService()
-	syncConfig := sync.DefaultConfig()
+	// Create sync service, refusing to start with a misconfigured hotel roster
+	if _, err := cupid.LoadPropertyIDs(); err != nil {
+		logger.Fatal("Invalid property ID list", zap.Error(err))
+	}
+	cupidService := cupid.NewService(cupidClient)
+	syncConfig := sync.ConfigFromEnv()
+	if err := syncConfig.Validate(); err != nil {
+		logger.Fatal("Invalid sync configuration", zap.Error(err))
+	}
 	syncService := sync.NewSyncService(cupidService, storage, syncConfig)
 
 	// Create application instance with dependencies
 	app := &application{
 		config: config{
-			port: env.GetEnvInt("SERVER_PORT", 8080),
-			env:  env.GetEnvString("GO_ENV", "development"),
+			port:        env.GetEnvInt("SERVER_PORT", 8080),
+			env:         env.GetEnvString("GO_ENV", "development"),
+			adminAPIKey: env.GetEnvString("ADMIN_API_KEY", ""),
 		},
-		logger:      logger.Logger,
-		storage:     storage,
-		syncService: syncService,
+		logger:         logger.Logger,
+		storage:        storage,
+		syncService:    syncService,
+		databaseHealth: db.Healthy,
 	}
 
 	// Start the sync service
-	ctx := context.Background()
 	if err := app.syncService.Start(ctx); err != nil {
 		logger.LogError("Failed to start sync service", err)
 		// Don't exit, just log the error and continue
 	}
 
 	// Start the server
-	if err := app.run(); err != nil {
+	if err := app.run(ctx); err != nil {
 		logger.Fatal("Server failed", zap.Error(err))
 	}
 }
@@ -2,11 +2,15 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/barimehdi77/cupid-api/internal/cupid"
 	"github.com/barimehdi77/cupid-api/internal/database"
+	"github.com/barimehdi77/cupid-api/internal/env"
 	"github.com/barimehdi77/cupid-api/internal/logger"
 	"github.com/barimehdi77/cupid-api/internal/store"
 	"github.com/joho/godotenv"
@@ -14,6 +18,15 @@ import (
 )
 
 func main() {
+	opts, err := parseOptions(os.Args[1:], os.Stderr)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Fprintf(os.Stderr, "Invalid arguments: %v\n", err)
+		os.Exit(2)
+	}
+
 	// Load environment variables
 	if err := godotenv.Load(); err != nil {
 		fmt.Printf("Warning: Could not load .env file: %v\n", err)
@@ -28,8 +41,23 @@ func main() {
 
 	logger.LogStartup("Cupid API Data Fetcher")
 
-	// Create context
-	ctx := context.Background()
+	// Fail fast instead of sending unauthenticated requests that are all rejected
+	client := cupid.NewClient()
+	if err := client.CheckAPIKey(); err != nil {
+		logger.LogError("Invalid Cupid API configuration", err)
+		os.Exit(1)
+	}
+
+	// Fail fast on a misconfigured hotel roster instead of silently fetching the embedded list
+	propertyIDs, err := opts.selectPropertyIDs(cupid.LoadPropertyIDs)
+	if err != nil {
+		logger.LogError("Invalid property ID list", err)
+		os.Exit(1)
+	}
+	logger.Info("Property IDs loaded",
+		zap.Int("count", len(propertyIDs)),
+		zap.Int("store_workers", opts.workers),
+	)
 
 	// Initialize database
 	db, err := database.NewDB()
@@ -40,52 +68,32 @@ func main() {
 	defer db.Close()
 
 	// Create storage
-	storage := store.NewStorage(db)
+	storage := store.NewStorageWithConfig(db, store.ConfigFromEnv())
 
 	// Create service
-	service := cupid.NewService()
-
-	// Fetch all properties
-	properties, err := service.FetchAllProperties(ctx)
+	service := cupid.NewService(client)
+
+	// Fetch and store everything under one overall deadline so a stuck upstream cannot hang the job
+	timeout := env.GetEnvDuration("FETCH_TIMEOUT", 30*time.Minute)
+	summary := &fetchSummary{requested: len(propertyIDs)}
+	err = runWithTimeout(context.Background(), timeout, func(ctx context.Context) error {
+		return fetchAndStore(ctx, service, storage, propertyIDs, opts.workers, summary)
+	})
+	summary.print(os.Stdout)
 	if err != nil {
-		logger.LogError("Failed to fetch properties", err)
-		os.Exit(1)
-	}
-
-	logger.LogSuccess("Data fetching completed",
-		zap.Int("total_properties", len(properties)),
-	)
-
-	// Store properties in database
-	successCount := 0
-	errorCount := 0
-
-	for i, propertyData := range properties {
-		logger.LogProgress("Storing property",
-			zap.Int("current", i+1),
-			zap.Int("total", len(properties)),
-			zap.Int64("property_id", propertyData.Property.HotelID),
+		fetched, stored, failed := summary.counts()
+		logger.LogError("Data fetcher did not complete", err,
+			zap.Duration("timeout", timeout),
+			zap.Int("fetched", fetched),
+			zap.Int("stored", stored),
+			zap.Int("failed", failed),
 		)
-
-		if err := storage.StoreProperty(ctx, propertyData); err != nil {
-			logger.LogError("Failed to store property", err,
-				zap.Int64("property_id", propertyData.Property.HotelID),
-			)
-			errorCount++
-		} else {
-			successCount++
-		}
+		os.Exit(1)
 	}
 
-	logger.LogSuccess("Data storage completed",
-		zap.Int("successful", successCount),
-		zap.Int("failed", errorCount),
-		zap.Int("total", len(properties)),
-	)
-
 	// Test fetching a single property
 	logger.Info("Testing property retrieval...")
-	testProperty, err := storage.GetProperty(ctx, 1018946)
+	testProperty, err := storage.GetProperty(context.Background(), propertyIDs[0])
 	if err != nil {
 		logger.LogError("Failed to retrieve test property", err)
 	} else {
@@ -97,3 +105,37 @@ func main() {
 		)
 	}
 }
+
+// fetchAndStore fetches the given properties and stores them with a pool of workers, stopping as soon as ctx is done
+func fetchAndStore(ctx context.Context, service *cupid.Service, storage propertyStorer, propertyIDs []int64, workers int, summary *fetchSummary) error {
+	start := time.Now()
+	properties, err := service.FetchProperties(ctx, propertyIDs)
+	summary.recordFetch(len(properties), time.Since(start))
+	if err != nil {
+		return fmt.Errorf("failed to fetch properties: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	logger.LogSuccess("Data fetching completed",
+		zap.Int("total_properties", len(properties)),
+	)
+
+	// Store properties in database
+	start = time.Now()
+	err = storeProperties(ctx, storage, properties, workers, summary)
+	summary.recordStoreDuration(time.Since(start))
+	if err != nil {
+		return err
+	}
+
+	_, stored, failed := summary.counts()
+	logger.LogSuccess("Data storage completed",
+		zap.Int("successful", stored),
+		zap.Int("failed", failed),
+		zap.Int("total", len(properties)),
+	)
+
+	return nil
+}
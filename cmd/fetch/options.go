@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/barimehdi77/cupid-api/internal/cupid"
+)
+
+// defaultStoreWorkers is the number of properties stored at once when -workers is not given
+const defaultStoreWorkers = 4
+
+// options are the command line flags of the data fetcher
+type options struct {
+	// workers is the number of properties stored concurrently, each in its own transaction
+	workers int
+	// limit caps the number of properties fetched and stored; 0 processes them all
+	limit int
+	// ids replaces the configured property roster, in the formats accepted by PROPERTY_IDS
+	ids string
+}
+
+// parseOptions parses the command line flags of the data fetcher
+func parseOptions(args []string, output io.Writer) (*options, error) {
+	opts := &options{}
+
+	flags := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.IntVar(&opts.workers, "workers", defaultStoreWorkers, "number of properties stored concurrently")
+	flags.IntVar(&opts.limit, "limit", 0, "fetch and store at most this many properties (0 processes them all)")
+	flags.StringVar(&opts.ids, "ids", "", "comma-separated property IDs to fetch instead of the configured roster")
+
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if flags.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %v", flags.Args())
+	}
+	if opts.workers < 1 {
+		return nil, fmt.Errorf("-workers must be at least 1, got %d", opts.workers)
+	}
+	if opts.limit < 0 {
+		return nil, fmt.Errorf("-limit must not be negative, got %d", opts.limit)
+	}
+
+	return opts, nil
+}
+
+// selectPropertyIDs returns the property IDs to process: the -ids flag when given, the configured roster
+// otherwise, truncated to -limit
+func (o *options) selectPropertyIDs(roster func() ([]int64, error)) ([]int64, error) {
+	var ids []int64
+	var err error
+	if o.ids != "" {
+		ids, err = cupid.ParsePropertyIDs(o.ids)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -ids: %w", err)
+		}
+	} else {
+		ids, err = roster()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if o.limit > 0 && len(ids) > o.limit {
+		ids = ids[:o.limit]
+	}
+	return ids, nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseOptions tests parsing the data fetcher flags
+func TestParseOptions(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		// Act
+		opts, err := parseOptions(nil, io.Discard)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, &options{workers: defaultStoreWorkers}, opts)
+	})
+
+	t.Run("AllFlags", func(t *testing.T) {
+		// Act
+		opts, err := parseOptions([]string{"-workers", "8", "-limit", "10", "-ids", "1,2,3"}, io.Discard)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, &options{workers: 8, limit: 10, ids: "1,2,3"}, opts)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		tests := map[string][]string{
+			"no workers":     {"-workers", "0"},
+			"negative limit": {"-limit", "-1"},
+			"unknown flag":   {"-verbose"},
+			"extra argument": {"all"},
+		}
+		for name, args := range tests {
+			// Act
+			_, err := parseOptions(args, io.Discard)
+
+			// Assert
+			assert.Error(t, err, name)
+		}
+	})
+}
+
+// TestOptions_SelectPropertyIDs tests choosing the properties a run processes
+func TestOptions_SelectPropertyIDs(t *testing.T) {
+	roster := func() ([]int64, error) { return []int64{10, 20, 30, 40}, nil }
+
+	t.Run("Roster", func(t *testing.T) {
+		// Act
+		ids, err := (&options{}).selectPropertyIDs(roster)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []int64{10, 20, 30, 40}, ids)
+	})
+
+	t.Run("LimitedRoster", func(t *testing.T) {
+		// Act
+		ids, err := (&options{limit: 2}).selectPropertyIDs(roster)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []int64{10, 20}, ids)
+	})
+
+	t.Run("IDsReplaceRoster", func(t *testing.T) {
+		// Act
+		ids, err := (&options{ids: "7, 8,7", limit: 5}).selectPropertyIDs(func() ([]int64, error) {
+			t.Fatal("roster should not be loaded when -ids is given")
+			return nil, nil
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []int64{7, 8}, ids)
+	})
+
+	t.Run("InvalidIDs", func(t *testing.T) {
+		// Act
+		_, err := (&options{ids: "7,abc"}).selectPropertyIDs(roster)
+
+		// Assert
+		assert.ErrorContains(t, err, "invalid -ids")
+	})
+
+	t.Run("InvalidFirstID", func(t *testing.T) {
+		// Act
+		_, err := (&options{ids: "abc,7"}).selectPropertyIDs(roster)
+
+		// Assert
+		assert.ErrorContains(t, err, `invalid property ID "abc"`)
+	})
+
+	t.Run("RosterError", func(t *testing.T) {
+		// Arrange
+		expected := errors.New("unreadable file")
+
+		// Act
+		_, err := (&options{}).selectPropertyIDs(func() ([]int64, error) { return nil, expected })
+
+		// Assert
+		assert.ErrorIs(t, err, expected)
+	})
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/barimehdi77/cupid-api/internal/cupid"
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"go.uber.org/zap"
+)
+
+// propertyStorer stores a fetched property, as store.Storage does
+type propertyStorer interface {
+	StoreProperty(ctx context.Context, propertyData *cupid.PropertyData) error
+}
+
+// fetchSummary tracks progress so a timed out run can still report how far it got.
+// It is updated by the store workers concurrently.
+type fetchSummary struct {
+	mu        sync.Mutex
+	requested int
+	fetched   int
+	stored    int
+	failed    int
+	// failures holds the store error of each property that could not be stored
+	failures      map[int64]error
+	fetchDuration time.Duration
+	storeDuration time.Duration
+}
+
+// recordFetch records the number of properties fetched and how long fetching took
+func (s *fetchSummary) recordFetch(fetched int, duration time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fetched = fetched
+	s.fetchDuration = duration
+}
+
+// recordStoreDuration records how long storing the fetched properties took
+func (s *fetchSummary) recordStoreDuration(duration time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.storeDuration = duration
+}
+
+// recordStore records the outcome of storing a property
+func (s *fetchSummary) recordStore(propertyID int64, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err == nil {
+		s.stored++
+		return
+	}
+	s.failed++
+	if s.failures == nil {
+		s.failures = make(map[int64]error)
+	}
+	s.failures[propertyID] = err
+}
+
+// counts returns the fetched, stored and failed counts
+func (s *fetchSummary) counts() (int, int, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetched, s.stored, s.failed
+}
+
+// print writes the summary as a table, followed by the properties that failed to store
+func (s *fetchSummary) print(w io.Writer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "STEP\tPROPERTIES\tDURATION")
+	fmt.Fprintf(table, "requested\t%d\t\n", s.requested)
+	fmt.Fprintf(table, "fetched\t%d\t%s\n", s.fetched, s.fetchDuration.Round(time.Millisecond))
+	fmt.Fprintf(table, "fetch failed\t%d\t\n", s.requested-s.fetched)
+	fmt.Fprintf(table, "stored\t%d\t%s\n", s.stored, s.storeDuration.Round(time.Millisecond))
+	fmt.Fprintf(table, "store failed\t%d\t\n", s.failed)
+	table.Flush()
+
+	if len(s.failures) == 0 {
+		return
+	}
+	ids := make([]int64, 0, len(s.failures))
+	for id := range s.failures {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	fmt.Fprintln(w)
+	table = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "FAILED PROPERTY\tERROR")
+	for _, id := range ids {
+		fmt.Fprintf(table, "%d\t%v\n", id, s.failures[id])
+	}
+	table.Flush()
+}
+
+// storeProperties stores properties with a pool of workers, each property in its own transaction.
+// Properties are handed out through an unbuffered channel, so no more than workers are in flight and
+// a slow database holds back the dispatch instead of piling up work. A failed property is recorded in
+// summary and does not stop the others; only ctx being done does.
+func storeProperties(ctx context.Context, storage propertyStorer, properties []*cupid.PropertyData, workers int, summary *fetchSummary) error {
+	jobs := make(chan *cupid.PropertyData)
+
+	var wg sync.WaitGroup
+	for range min(workers, len(properties)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for propertyData := range jobs {
+				propertyID := propertyData.Property.HotelID
+				err := storage.StoreProperty(ctx, propertyData)
+				if err != nil {
+					logger.LogError("Failed to store property", err,
+						zap.Int64("property_id", propertyID),
+					)
+				}
+				summary.recordStore(propertyID, err)
+			}
+		}()
+	}
+
+	var err error
+dispatch:
+	for i, propertyData := range properties {
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			break dispatch
+		case jobs <- propertyData:
+			logger.LogProgress("Storing property",
+				zap.Int("current", i+1),
+				zap.Int("total", len(properties)),
+				zap.Int64("property_id", propertyData.Property.HotelID),
+			)
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return err
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/barimehdi77/cupid-api/internal/cupid"
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeStorer records stored properties, failing the ones listed in fail
+type fakeStorer struct {
+	delay    time.Duration
+	fail     map[int64]bool
+	inFlight atomic.Int32
+	peak     atomic.Int32
+
+	mu     sync.Mutex
+	stored []int64
+}
+
+func (f *fakeStorer) StoreProperty(ctx context.Context, propertyData *cupid.PropertyData) error {
+	current := f.inFlight.Add(1)
+	defer f.inFlight.Add(-1)
+	for {
+		peak := f.peak.Load()
+		if current <= peak || f.peak.CompareAndSwap(peak, current) {
+			break
+		}
+	}
+	time.Sleep(f.delay)
+
+	if f.fail[propertyData.Property.HotelID] {
+		return errors.New("value too long")
+	}
+	f.mu.Lock()
+	f.stored = append(f.stored, propertyData.Property.HotelID)
+	f.mu.Unlock()
+	return nil
+}
+
+// testProperties creates properties with hotel IDs 1 to count
+func testProperties(count int) []*cupid.PropertyData {
+	properties := make([]*cupid.PropertyData, count)
+	for i := range properties {
+		properties[i] = &cupid.PropertyData{Property: cupid.Property{HotelID: int64(i + 1)}}
+	}
+	return properties
+}
+
+// TestStoreProperties tests storing properties with a bounded worker pool
+func TestStoreProperties(t *testing.T) {
+	logger.Logger = zap.NewNop()
+
+	t.Run("BoundedConcurrencyAndFailures", func(t *testing.T) {
+		// Arrange
+		storer := &fakeStorer{delay: 5 * time.Millisecond, fail: map[int64]bool{3: true, 7: true}}
+		summary := &fetchSummary{}
+
+		// Act
+		err := storeProperties(context.Background(), storer, testProperties(20), 4, summary)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Len(t, storer.stored, 18)
+		assert.LessOrEqual(t, storer.peak.Load(), int32(4))
+		assert.Greater(t, storer.peak.Load(), int32(1))
+		_, stored, failed := summary.counts()
+		assert.Equal(t, 18, stored)
+		assert.Equal(t, 2, failed)
+		assert.ErrorContains(t, summary.failures[3], "value too long")
+		assert.Contains(t, summary.failures, int64(7))
+	})
+
+	t.Run("StopsWhenContextDone", func(t *testing.T) {
+		// Arrange
+		storer := &fakeStorer{delay: 20 * time.Millisecond}
+		summary := &fetchSummary{}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+		defer cancel()
+
+		// Act
+		err := storeProperties(ctx, storer, testProperties(50), 2, summary)
+
+		// Assert
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		_, stored, _ := summary.counts()
+		assert.Less(t, stored, 50)
+	})
+
+	t.Run("NoProperties", func(t *testing.T) {
+		// Act
+		err := storeProperties(context.Background(), &fakeStorer{}, nil, 4, &fetchSummary{})
+
+		// Assert
+		assert.NoError(t, err)
+	})
+}
+
+// TestFetchSummary_Print tests the final summary table
+func TestFetchSummary_Print(t *testing.T) {
+	// Arrange
+	summary := &fetchSummary{requested: 5}
+	summary.recordFetch(4, 1500*time.Millisecond)
+	summary.recordStore(2, nil)
+	summary.recordStore(9, errors.New("value too long"))
+	summary.recordStore(1, nil)
+	summary.recordStoreDuration(250 * time.Millisecond)
+
+	var output bytes.Buffer
+
+	// Act
+	summary.print(&output)
+
+	// Assert
+	expected := `STEP          PROPERTIES  DURATION
+requested     5           
+fetched       4           1.5s
+fetch failed  1           
+stored        2           250ms
+store failed  1           
+
+FAILED PROPERTY  ERROR
+9                value too long
+`
+	assert.Equal(t, expected, output.String())
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// runWithTimeout runs fn with a context that is cancelled after timeout.
+// A timeout of zero or less disables the deadline. When the deadline is hit the returned
+// error wraps context.DeadlineExceeded, even if fn itself gave up without reporting it.
+func runWithTimeout(parent context.Context, timeout time.Duration, fn func(ctx context.Context) error) error {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(parent, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(parent)
+	}
+	defer cancel()
+
+	err := fn(ctx)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s: %w", timeout, context.DeadlineExceeded)
+	}
+	return err
+}
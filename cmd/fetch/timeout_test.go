@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRunWithTimeout tests the overall deadline applied to the fetch job
+func TestRunWithTimeout(t *testing.T) {
+	t.Run("CompletesBeforeDeadline", func(t *testing.T) {
+		// Act
+		err := runWithTimeout(context.Background(), time.Second, func(ctx context.Context) error {
+			return nil
+		})
+
+		// Assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("StuckWorkTimesOut", func(t *testing.T) {
+		// Act
+		start := time.Now()
+		err := runWithTimeout(context.Background(), 20*time.Millisecond, func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "timed out after 20ms")
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("PropagatesErrors", func(t *testing.T) {
+		// Arrange
+		expected := errors.New("database unavailable")
+
+		// Act
+		err := runWithTimeout(context.Background(), time.Second, func(ctx context.Context) error {
+			return expected
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, expected)
+	})
+
+	t.Run("ZeroDisablesDeadline", func(t *testing.T) {
+		// Act
+		err := runWithTimeout(context.Background(), 0, func(ctx context.Context) error {
+			_, hasDeadline := ctx.Deadline()
+			assert.False(t, hasDeadline)
+			return nil
+		})
+
+		// Assert
+		assert.NoError(t, err)
+	})
+}
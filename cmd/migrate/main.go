@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/barimehdi77/cupid-api/cmd/migrate/migrations"
+	"github.com/barimehdi77/cupid-api/internal/database"
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"github.com/joho/godotenv"
+	"go.uber.org/zap"
+)
+
+const usage = "usage: migrate [up|down|status]"
+
+func main() {
+	// Load environment variables
+	if err := godotenv.Load(); err != nil {
+		fmt.Printf("Warning: Could not load .env file: %v\n", err)
+	}
+
+	command := "up"
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
+
+	// Initialize logger
+	if err := logger.InitLogger(); err != nil {
+		fmt.Printf("Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer logger.Sync()
+
+	// Initialize database
+	db, err := database.NewDB()
+	if err != nil {
+		logger.Fatal("Failed to connect to database", zap.Error(err))
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	switch command {
+	case "up":
+		err = db.MigrateUp(ctx, migrations.FS)
+	case "down":
+		err = db.MigrateDown(ctx, migrations.FS)
+	case "status":
+		err = db.MigrationStatus(ctx, migrations.FS)
+	default:
+		fmt.Println(usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		logger.LogError("Migration command failed", err, zap.String("command", command))
+		os.Exit(1)
+	}
+}
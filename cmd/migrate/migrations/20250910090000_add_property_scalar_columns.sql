@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Promote scalar property fields out of the property_details JSONB blob
+ALTER TABLE properties ADD COLUMN phone VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE properties ADD COLUMN fax VARCHAR(50) NOT NULL DEFAULT '';
+ALTER TABLE properties ADD COLUMN email VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE properties ADD COLUMN description TEXT NOT NULL DEFAULT '';
+ALTER TABLE properties ADD COLUMN markdown_description TEXT NOT NULL DEFAULT '';
+ALTER TABLE properties ADD COLUMN important_info TEXT NOT NULL DEFAULT '';
+ALTER TABLE properties ADD COLUMN parking TEXT;
+ALTER TABLE properties ADD COLUMN group_room_min INTEGER;
+ALTER TABLE properties ADD COLUMN child_allowed BOOLEAN;
+ALTER TABLE properties ADD COLUMN pets_allowed BOOLEAN;
+
+-- Backfill the contact and policy fields kept in the JSONB details, either as the whole details object or as
+-- the column's own section; descriptions were never stored and are filled in by the next sync
+UPDATE properties p
+SET phone = LEFT(COALESCE(c.contact ->> 'phone', ''), 50),
+    fax = LEFT(COALESCE(c.contact ->> 'fax', ''), 50),
+    email = LEFT(COALESCE(c.contact ->> 'email', ''), 255),
+    parking = c.metadata ->> 'parking',
+    group_room_min = (c.metadata ->> 'group_room_min')::INTEGER,
+    child_allowed = (c.metadata ->> 'child_allowed')::BOOLEAN,
+    pets_allowed = (c.metadata ->> 'pets_allowed')::BOOLEAN
+FROM (
+    SELECT property_id,
+           COALESCE(contact_info -> 'contact_info', contact_info) AS contact,
+           COALESCE(metadata -> 'metadata', metadata) AS metadata
+    FROM property_details
+) c
+WHERE c.property_id = p.hotel_id;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE properties DROP COLUMN IF EXISTS pets_allowed;
+ALTER TABLE properties DROP COLUMN IF EXISTS child_allowed;
+ALTER TABLE properties DROP COLUMN IF EXISTS group_room_min;
+ALTER TABLE properties DROP COLUMN IF EXISTS parking;
+ALTER TABLE properties DROP COLUMN IF EXISTS important_info;
+ALTER TABLE properties DROP COLUMN IF EXISTS markdown_description;
+ALTER TABLE properties DROP COLUMN IF EXISTS description;
+ALTER TABLE properties DROP COLUMN IF EXISTS email;
+ALTER TABLE properties DROP COLUMN IF EXISTS fax;
+ALTER TABLE properties DROP COLUMN IF EXISTS phone;
+
+-- +goose StatementEnd
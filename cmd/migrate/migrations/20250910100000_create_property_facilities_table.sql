@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE property_facilities (
+    property_id BIGINT NOT NULL REFERENCES properties(hotel_id) ON DELETE CASCADE,
+    facility_id INTEGER NOT NULL,
+    name VARCHAR(255),
+    PRIMARY KEY (property_id, facility_id)
+);
+
+-- Create indexes for facility filtering
+CREATE INDEX idx_property_facilities_facility_id ON property_facilities(facility_id);
+
+-- Backfill from the facilities stored in property_details
+INSERT INTO property_facilities (property_id, facility_id, name)
+SELECT d.property_id, (f->>'facility_id')::INTEGER, f->>'name'
+FROM property_details d,
+     jsonb_array_elements(COALESCE(d.facilities->'facilities', d.facilities)) AS f
+WHERE jsonb_typeof(COALESCE(d.facilities->'facilities', d.facilities)) = 'array'
+ON CONFLICT (property_id, facility_id) DO NOTHING;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS property_facilities;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE property_sync_history (
+    id BIGSERIAL PRIMARY KEY,
+    property_id BIGINT NOT NULL REFERENCES properties(hotel_id) ON DELETE CASCADE,
+    synced_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    changes TEXT[] NOT NULL DEFAULT '{}'
+);
+
+-- Create index for per-property history lookups, newest first
+CREATE INDEX idx_property_sync_history_property_synced ON property_sync_history(property_id, synced_at DESC);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS property_sync_history;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- last_synced was never written by the sync; it becomes last_synced_at, set on every sync of the property
+ALTER TABLE properties RENAME COLUMN last_synced TO last_synced_at;
+ALTER TABLE properties ALTER COLUMN last_synced_at DROP DEFAULT;
+ALTER INDEX idx_properties_last_synced RENAME TO idx_properties_last_synced_at;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER INDEX idx_properties_last_synced_at RENAME TO idx_properties_last_synced;
+ALTER TABLE properties ALTER COLUMN last_synced_at SET DEFAULT NOW();
+ALTER TABLE properties RENAME COLUMN last_synced_at TO last_synced;
+
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE property_overrides (
+    property_id BIGINT NOT NULL REFERENCES properties(hotel_id) ON DELETE CASCADE,
+    field VARCHAR(50) NOT NULL,
+    value TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (property_id, field)
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS property_overrides;
+-- +goose StatementEnd
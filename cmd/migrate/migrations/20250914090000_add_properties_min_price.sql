@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- min_price is the lowest nightly room rate of the property, in currency, derived from its rooms on every store
+ALTER TABLE properties ADD COLUMN min_price NUMERIC(10,2);
+ALTER TABLE properties ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT '';
+CREATE INDEX idx_properties_min_price ON properties(min_price);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_properties_min_price;
+ALTER TABLE properties DROP COLUMN IF EXISTS currency;
+ALTER TABLE properties DROP COLUMN IF EXISTS min_price;
+
+-- +goose StatementEnd
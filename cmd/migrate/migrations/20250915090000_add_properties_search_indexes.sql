@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The street address was only kept in the JSONB details; a column makes it searchable and indexable
+ALTER TABLE properties ADD COLUMN address VARCHAR(255) NOT NULL DEFAULT '';
+UPDATE properties p
+SET address = COALESCE(d.address -> 'address' ->> 'address', d.address ->> 'address', '')
+FROM property_details d
+WHERE d.property_id = p.hotel_id;
+
+-- Trigram indexes back the ILIKE '%term%' predicates of every searchable column
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX idx_properties_hotel_name_trgm ON properties USING GIN (hotel_name gin_trgm_ops);
+CREATE INDEX idx_properties_city_trgm ON properties USING GIN (city gin_trgm_ops);
+CREATE INDEX idx_properties_country_trgm ON properties USING GIN (country gin_trgm_ops);
+CREATE INDEX idx_properties_address_trgm ON properties USING GIN (address gin_trgm_ops);
+CREATE INDEX idx_properties_state_trgm ON properties USING GIN (state gin_trgm_ops);
+CREATE INDEX idx_properties_description_trgm ON properties USING GIN (description gin_trgm_ops);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS idx_properties_description_trgm;
+DROP INDEX IF EXISTS idx_properties_state_trgm;
+DROP INDEX IF EXISTS idx_properties_address_trgm;
+DROP INDEX IF EXISTS idx_properties_country_trgm;
+DROP INDEX IF EXISTS idx_properties_city_trgm;
+DROP INDEX IF EXISTS idx_properties_hotel_name_trgm;
+ALTER TABLE properties DROP COLUMN IF EXISTS address;
+
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- One row per amenity offered by at least one room of a property, with the number of rooms offering it
+CREATE TABLE property_room_amenities (
+    property_id BIGINT NOT NULL REFERENCES properties(hotel_id) ON DELETE CASCADE,
+    amenity_id INTEGER NOT NULL,
+    name VARCHAR(255),
+    room_count INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (property_id, amenity_id)
+);
+
+-- Create indexes for room amenity filtering
+CREATE INDEX idx_property_room_amenities_amenity_id ON property_room_amenities(amenity_id);
+
+-- Backfill from the rooms stored in property_details
+INSERT INTO property_room_amenities (property_id, amenity_id, name, room_count)
+SELECT d.property_id, (a->>'amenities_id')::INTEGER, MAX(a->>'name'), COUNT(DISTINCT r->>'id')
+FROM property_details d,
+     jsonb_array_elements(COALESCE(d.rooms->'rooms', d.rooms)) AS r,
+     jsonb_array_elements(r->'room_amenities') AS a
+WHERE jsonb_typeof(COALESCE(d.rooms->'rooms', d.rooms)) = 'array'
+  AND jsonb_typeof(r->'room_amenities') = 'array'
+GROUP BY d.property_id, (a->>'amenities_id')::INTEGER
+ON CONFLICT (property_id, amenity_id) DO NOTHING;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS property_room_amenities;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Raw Cupid API property response, captured when CUPID_CAPTURE_RAW is enabled, for debugging upstream data issues
+ALTER TABLE property_details ADD COLUMN raw_property_json JSONB;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE property_details DROP COLUMN IF EXISTS raw_property_json;
+
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Review date exactly as received from upstream, for display; the typed date column is parsed from it
+-- and is NULL when the upstream format is not recognized
+ALTER TABLE reviews ADD COLUMN date_raw TEXT;
+UPDATE reviews SET date_raw = to_char(date, 'YYYY-MM-DD') WHERE date IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE reviews DROP COLUMN IF EXISTS date_raw;
+
+-- +goose StatementEnd
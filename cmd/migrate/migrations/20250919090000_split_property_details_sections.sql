@@ -0,0 +1,46 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Every details column used to hold the whole details object; keep only the column's own section
+UPDATE property_details
+SET address = address -> 'address',
+    checkin_info = checkin_info -> 'checkin',
+    facilities = facilities -> 'facilities',
+    policies = policies -> 'policies',
+    rooms = rooms -> 'rooms',
+    photos = photos -> 'photos',
+    contact_info = contact_info -> 'contact_info',
+    metadata = metadata -> 'metadata'
+WHERE metadata ? 'metadata';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+UPDATE property_details
+SET address = d.details,
+    checkin_info = d.details,
+    facilities = d.details,
+    policies = d.details,
+    rooms = d.details,
+    photos = d.details,
+    contact_info = d.details,
+    metadata = d.details
+FROM (
+    SELECT property_id, jsonb_build_object(
+        'address', address,
+        'checkin', checkin_info,
+        'facilities', facilities,
+        'policies', policies,
+        'rooms', rooms,
+        'photos', photos,
+        'contact_info', contact_info,
+        'metadata', metadata
+    ) AS details
+    FROM property_details
+    WHERE NOT (metadata ? 'metadata')
+) d
+WHERE property_details.property_id = d.property_id;
+
+-- +goose StatementEnd
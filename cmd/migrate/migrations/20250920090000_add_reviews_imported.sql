@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Reviews stored by a review import rather than a sync; a sync never removes them
+ALTER TABLE reviews ADD COLUMN imported BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE reviews DROP COLUMN IF EXISTS imported;
+
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEmbeddedMigrations tests that the embedded migrations are complete goose files
+func TestEmbeddedMigrations(t *testing.T) {
+	files, err := fs.Glob(FS, "*.sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	var all strings.Builder
+	for _, file := range files {
+		content, err := fs.ReadFile(FS, file)
+		require.NoError(t, err)
+
+		assert.Contains(t, string(content), "-- +goose Up", file)
+		assert.Contains(t, string(content), "-- +goose Down", file)
+		all.Write(content)
+	}
+
+	// Every table the store queries must be created by some migration
+	for _, table := range []string{"properties", "property_details", "reviews", "translations", "property_facilities", "property_room_amenities", "sync_logs", "sync_settings", "property_sync_history"} {
+		assert.Contains(t, all.String(), "CREATE TABLE "+table+" (", table)
+	}
+}
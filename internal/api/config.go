@@ -0,0 +1,148 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/barimehdi77/cupid-api/internal/env"
+)
+
+// Config holds API handler configuration
+type Config struct {
+	// MaxBatchSize caps the number of items, such as IDs or imported reviews, accepted by any endpoint taking a list
+	MaxBatchSize int
+
+	// MaxPageSize caps the limit of every paginated endpoint; larger limits are clamped to it.
+	// Zero disables the cap.
+	MaxPageSize int
+
+	// MaxResponseBytes replaces JSON, GeoJSON, XML and CSV responses larger than this many bytes with a 500 error.
+	// Rendering stops as soon as the limit is reached. Zero disables the limit.
+	MaxResponseBytes int
+
+	// MaxOffset rejects page/limit combinations skipping more rows than this, since deep OFFSET scans are slow.
+	// Zero disables the limit.
+	MaxOffset int
+
+	// RequestTimeout bounds how long a public request, and the storage queries it runs, may take.
+	// Zero or less disables the deadline.
+	RequestTimeout time.Duration
+
+	// AdminRequestTimeout is the RequestTimeout of admin requests, whose maintenance operations can take longer.
+	// Zero or less disables the deadline.
+	AdminRequestTimeout time.Duration
+
+	// RateLimit is the number of requests a client IP may make per RateLimitWindow.
+	// Zero disables rate limiting.
+	RateLimit       int
+	RateLimitWindow time.Duration
+
+	// RateLimitKeys overrides RateLimit for requests carrying one of these API keys in the X-API-Key header,
+	// counted per key instead of per IP, so internal integrations can get higher limits
+	RateLimitKeys map[string]int
+
+	// TrustedProxies lists the proxy addresses or CIDRs whose X-Forwarded-For and X-Real-IP headers are
+	// trusted for the client IP. Empty trusts none, so the client IP is always the remote address
+	TrustedProxies []string
+
+	// IncludeCheckIn adds the check-in and check-out times and instructions to the property detail response
+	IncludeCheckIn bool
+
+	// DefaultLanguage is the translation the property detail response is localized to when a request names no
+	// language through ?lang or Accept-Language; empty keeps the base language
+	DefaultLanguage string
+
+	// BaseLanguage is the language of the stored, untranslated property text. A request preferring it gets the
+	// untranslated property even when a translation in a less preferred language exists
+	BaseLanguage string
+
+	// ServiceName is the API name reported at the root path
+	ServiceName string
+
+	// HandleMethodNotAllowed answers requests to a known path with an unsupported method with 405 and an
+	// Allow header instead of 404
+	HandleMethodNotAllowed bool
+
+	// Content-Type header values written for each response format
+	JSONContentType    string
+	XMLContentType     string
+	CSVContentType     string
+	GeoJSONContentType string
+}
+
+// DefaultConfig returns default API handler configuration
+func DefaultConfig() *Config {
+	return &Config{
+		MaxBatchSize:           100,
+		MaxPageSize:            100,
+		MaxOffset:              10000,
+		MaxResponseBytes:       50 << 20,
+		RequestTimeout:         5 * time.Second,
+		AdminRequestTimeout:    30 * time.Second,
+		RateLimitWindow:        time.Minute,
+		IncludeCheckIn:         true,
+		HandleMethodNotAllowed: true,
+		BaseLanguage:           "en",
+		ServiceName:            "Cupid API",
+		JSONContentType:        "application/json; charset=utf-8",
+		XMLContentType:         "application/xml; charset=utf-8",
+		CSVContentType:         "text/csv; charset=utf-8",
+		GeoJSONContentType:     "application/geo+json",
+	}
+}
+
+// ConfigFromEnv returns API handler configuration overridden by environment variables
+func ConfigFromEnv() *Config {
+	config := DefaultConfig()
+	config.MaxBatchSize = env.GetEnvInt("API_MAX_BATCH_SIZE", config.MaxBatchSize)
+	config.MaxPageSize = env.GetEnvInt("API_MAX_PAGE_SIZE", config.MaxPageSize)
+	config.MaxOffset = env.GetEnvInt("API_MAX_OFFSET", config.MaxOffset)
+	config.MaxResponseBytes = env.GetEnvInt("API_MAX_RESPONSE_BYTES", config.MaxResponseBytes)
+	config.RequestTimeout = env.GetEnvDuration("API_REQUEST_TIMEOUT", config.RequestTimeout)
+	config.AdminRequestTimeout = env.GetEnvDuration("API_ADMIN_REQUEST_TIMEOUT", config.AdminRequestTimeout)
+	config.RateLimit = env.GetEnvInt("API_RATE_LIMIT", config.RateLimit)
+	config.RateLimitWindow = env.GetEnvDuration("API_RATE_LIMIT_WINDOW", config.RateLimitWindow)
+	config.RateLimitKeys = parseRateLimitKeys(env.GetEnvString("API_RATE_LIMIT_KEYS", ""))
+	config.TrustedProxies = parseTrustedProxies(env.GetEnvString("API_TRUSTED_PROXIES", ""))
+	config.IncludeCheckIn = env.GetEnvBool("API_INCLUDE_CHECKIN", config.IncludeCheckIn)
+	config.DefaultLanguage = strings.ToLower(strings.TrimSpace(env.GetEnvString("API_DEFAULT_LANGUAGE", config.DefaultLanguage)))
+	config.BaseLanguage = strings.ToLower(strings.TrimSpace(env.GetEnvString("API_BASE_LANGUAGE", config.BaseLanguage)))
+	config.HandleMethodNotAllowed = env.GetEnvBool("API_HANDLE_METHOD_NOT_ALLOWED", config.HandleMethodNotAllowed)
+	config.ServiceName = env.GetEnvString("API_SERVICE_NAME", config.ServiceName)
+	config.JSONContentType = env.GetEnvString("API_JSON_CONTENT_TYPE", config.JSONContentType)
+	config.XMLContentType = env.GetEnvString("API_XML_CONTENT_TYPE", config.XMLContentType)
+	config.CSVContentType = env.GetEnvString("API_CSV_CONTENT_TYPE", config.CSVContentType)
+	config.GeoJSONContentType = env.GetEnvString("API_GEOJSON_CONTENT_TYPE", config.GeoJSONContentType)
+	return config
+}
+
+// parseRateLimitKeys parses a comma-separated list of key:limit pairs.
+// Entries without a key or with a limit that is not a positive integer are ignored.
+func parseRateLimitKeys(raw string) map[string]int {
+	keys := make(map[string]int)
+	for _, entry := range strings.Split(raw, ",") {
+		separator := strings.LastIndex(entry, ":")
+		if separator < 0 {
+			continue
+		}
+		key := strings.TrimSpace(entry[:separator])
+		limit, err := strconv.Atoi(strings.TrimSpace(entry[separator+1:]))
+		if key == "" || err != nil || limit <= 0 {
+			continue
+		}
+		keys[key] = limit
+	}
+	return keys
+}
+
+// parseTrustedProxies parses a comma-separated list of proxy addresses or CIDRs.
+func parseTrustedProxies(raw string) []string {
+	var proxies []string
+	for _, entry := range strings.Split(raw, ",") {
+		if proxy := strings.TrimSpace(entry); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
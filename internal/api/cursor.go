@@ -0,0 +1,49 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/barimehdi77/cupid-api/internal/cupid"
+	"github.com/barimehdi77/cupid-api/internal/store"
+)
+
+// errInvalidCursor is returned when a listing cursor cannot be decoded
+var errInvalidCursor = errors.New("invalid cursor")
+
+// cursorPayload is the JSON form of a property listing cursor before encoding
+type cursorPayload struct {
+	Rating      float64 `json:"r"`
+	ReviewCount int     `json:"c"`
+	HotelID     int64   `json:"id"`
+}
+
+// encodePropertyCursor returns the opaque cursor pointing just after property in listings
+func encodePropertyCursor(property *cupid.Property) string {
+	payload, _ := json.Marshal(cursorPayload{
+		Rating:      property.Rating,
+		ReviewCount: property.ReviewCount,
+		HotelID:     property.HotelID,
+	})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// decodePropertyCursor parses a cursor produced by encodePropertyCursor
+func decodePropertyCursor(cursor string) (*store.PropertyCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil || payload.HotelID <= 0 {
+		return nil, errInvalidCursor
+	}
+
+	return &store.PropertyCursor{
+		Rating:      payload.Rating,
+		ReviewCount: payload.ReviewCount,
+		HotelID:     payload.HotelID,
+	}, nil
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/barimehdi77/cupid-api/internal/cupid"
+	"github.com/barimehdi77/cupid-api/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPropertyCursor tests that cursors round-trip and malformed ones are rejected
+func TestPropertyCursor(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		// Arrange
+		property := &cupid.Property{HotelID: 1641879, Rating: 8.75, ReviewCount: 1024}
+
+		// Act
+		cursor := encodePropertyCursor(property)
+		after, err := decodePropertyCursor(cursor)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, store.PropertyCursor{Rating: 8.75, ReviewCount: 1024, HotelID: 1641879}, *after)
+		assert.NotContains(t, cursor, "=")
+	})
+
+	invalid := []struct {
+		name   string
+		cursor string
+	}{
+		{"NotBase64", "%%%"},
+		{"NotJSON", "bm90IGpzb24"},
+		{"MissingHotelID", "eyJyIjo5fQ"},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			after, err := decodePropertyCursor(tt.cursor)
+
+			// Assert
+			assert.Nil(t, after)
+			assert.ErrorIs(t, err, errInvalidCursor)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Detail response sections selectable by name next to the property fields
+const (
+	fieldReviews      = "reviews"
+	fieldTranslations = "translations"
+)
+
+// propertyFieldNames are the JSON keys of PropertyResponse, the property fields a detail request may select
+var propertyFieldNames = jsonFieldNames(reflect.TypeOf(PropertyResponse{}))
+
+// jsonFieldNames returns the JSON keys of the fields of a struct type, skipping fields not serialized
+func jsonFieldNames(structType reflect.Type) []string {
+	names := make([]string, 0, structType.NumField())
+	for i := range structType.NumField() {
+		name, _, _ := strings.Cut(structType.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// parsePropertyFields parses the comma-separated fields of a sparse property detail request: property fields named
+// by their JSON key, plus the reviews and translations sections. An empty value returns nil, meaning everything.
+func parsePropertyFields(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	for _, part := range strings.Split(raw, ",") {
+		field := strings.ToLower(strings.TrimSpace(part))
+		if field != fieldReviews && field != fieldTranslations && !slices.Contains(propertyFieldNames, field) {
+			return nil, fmt.Errorf("invalid field %q: must be a property field, %s or %s", part, fieldReviews, fieldTranslations)
+		}
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
+
+// selectPropertyFields reduces a property detail response to the selected fields after serialization, so the
+// property keeps only the requested keys and unrequested sections are dropped. The hotel ID is always kept so
+// clients can tell which property they got. Selected fields left out by omitempty stay absent.
+func selectPropertyFields(response PropertyWithDetailsResponse, fields []string) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(response.Property)
+	if err != nil {
+		return nil, err
+	}
+	var property map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &property); err != nil {
+		return nil, err
+	}
+
+	selected := map[string]json.RawMessage{"hotel_id": property["hotel_id"]}
+	for _, field := range fields {
+		if value, ok := property[field]; ok {
+			selected[field] = value
+		}
+	}
+
+	sparse := map[string]interface{}{"property": selected}
+	if slices.Contains(fields, fieldReviews) {
+		sparse[fieldReviews] = response.Reviews
+	}
+	if slices.Contains(fields, fieldTranslations) {
+		sparse[fieldTranslations] = response.Translations
+	}
+	return sparse, nil
+}
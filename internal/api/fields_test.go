@@ -0,0 +1,69 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test parsePropertyFields
+func TestParsePropertyFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected []string
+		wantErr  bool
+	}{
+		{name: "Empty", raw: " ", expected: nil},
+		{name: "PropertyFields", raw: "hotel_name, Rating,main_image_th", expected: []string{"hotel_name", "rating", "main_image_th"}},
+		{name: "Sections", raw: "reviews,translations", expected: []string{"reviews", "translations"}},
+		{name: "Deduplicated", raw: "rating,rating", expected: []string{"rating"}},
+		{name: "UnknownField", raw: "hotel_name,password", wantErr: true},
+		{name: "GoFieldName", raw: "HotelName", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := parsePropertyFields(tt.raw)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, fields)
+		})
+	}
+}
+
+// Test selectPropertyFields
+func TestSelectPropertyFields(t *testing.T) {
+	// Arrange
+	rating := 9.5
+	response := PropertyWithDetailsResponse{
+		Property: PropertyResponse{
+			HotelID:        1,
+			HotelName:      "Hotel Paris",
+			ComputedRating: &rating,
+			Address:        AddressResponse{City: "Paris"},
+		},
+		Reviews:      []ReviewResponse{{ReviewID: 7}},
+		Translations: []TranslationResponse{},
+	}
+
+	// Act
+	sparse, err := selectPropertyFields(response, []string{"hotel_name", "address", "min_price", "reviews"})
+
+	// Assert
+	require.NoError(t, err)
+	encoded, err := json.Marshal(sparse["property"])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"hotel_id": 1,
+		"hotel_name": "Hotel Paris",
+		"address": {"address": "", "city": "Paris", "state": "", "country": "", "postal_code": ""}
+	}`, string(encoded))
+	assert.Equal(t, response.Reviews, sparse["reviews"])
+	assert.NotContains(t, sparse, "translations")
+}
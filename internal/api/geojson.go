@@ -0,0 +1,110 @@
+package api
+
+// GeoJSON object types used in responses
+const (
+	geoJSONFeatureCollection = "FeatureCollection"
+	geoJSONFeature           = "Feature"
+	geoJSONPoint             = "Point"
+)
+
+// GeoJSONFeatureCollection represents a GeoJSON FeatureCollection of properties
+type GeoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []GeoJSONFeature `json:"features"`
+	Meta     *Meta            `json:"meta,omitempty"`
+}
+
+// GeoJSONFeature represents a single property as a GeoJSON Feature
+type GeoJSONFeature struct {
+	Type       string                    `json:"type"`
+	Geometry   *GeoJSONPoint             `json:"geometry"`
+	Properties GeoJSONPropertyAttributes `json:"properties"`
+}
+
+// GeoJSONPoint represents a GeoJSON Point geometry.
+// Coordinates are ordered longitude then latitude, as required by RFC 7946.
+type GeoJSONPoint struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+// GeoJSONPropertyAttributes holds the scalar fields of a property exposed as Feature properties
+type GeoJSONPropertyAttributes struct {
+	HotelID             int64    `json:"hotel_id"`
+	CupidID             int64    `json:"cupid_id"`
+	HotelName           string   `json:"hotel_name"`
+	HotelType           string   `json:"hotel_type"`
+	Chain               string   `json:"chain"`
+	Stars               int      `json:"stars"`
+	Rating              float64  `json:"rating"`
+	ComputedRating      *float64 `json:"computed_rating,omitempty"`
+	ReviewCount         int      `json:"review_count"`
+	AirportCode         string   `json:"airport_code"`
+	Address             string   `json:"address"`
+	City                string   `json:"city"`
+	State               string   `json:"state"`
+	Country             string   `json:"country"`
+	PostalCode          string   `json:"postal_code"`
+	MainImageTh         string   `json:"main_image_th"`
+	Description         string   `json:"description"`
+	MarkdownDescription string   `json:"markdown_description"`
+	ImportantInfo       string   `json:"important_info"`
+	Phone               string   `json:"phone"`
+	Fax                 string   `json:"fax"`
+	Email               string   `json:"email"`
+}
+
+// ConvertPropertyToFeature converts a PropertyResponse to a GeoJSON Feature.
+// Properties without coordinates get a null geometry.
+func ConvertPropertyToFeature(property PropertyResponse) GeoJSONFeature {
+	feature := GeoJSONFeature{
+		Type: geoJSONFeature,
+		Properties: GeoJSONPropertyAttributes{
+			HotelID:             property.HotelID,
+			CupidID:             property.CupidID,
+			HotelName:           property.HotelName,
+			HotelType:           property.HotelType,
+			Chain:               property.Chain,
+			Stars:               property.Stars,
+			Rating:              property.Rating,
+			ComputedRating:      property.ComputedRating,
+			ReviewCount:         property.ReviewCount,
+			AirportCode:         property.AirportCode,
+			Address:             property.Address.Address,
+			City:                property.Address.City,
+			State:               property.Address.State,
+			Country:             property.Address.Country,
+			PostalCode:          property.Address.PostalCode,
+			MainImageTh:         property.MainImageTh,
+			Description:         property.Description,
+			MarkdownDescription: property.MarkdownDescription,
+			ImportantInfo:       property.ImportantInfo,
+			Phone:               property.Phone,
+			Fax:                 property.Fax,
+			Email:               property.Email,
+		},
+	}
+
+	if property.Latitude != 0 || property.Longitude != 0 {
+		feature.Geometry = &GeoJSONPoint{
+			Type:        geoJSONPoint,
+			Coordinates: [2]float64{property.Longitude, property.Latitude},
+		}
+	}
+
+	return feature
+}
+
+// ConvertPropertiesToFeatureCollection converts properties to a GeoJSON FeatureCollection
+func ConvertPropertiesToFeatureCollection(properties []PropertyResponse, meta *Meta) GeoJSONFeatureCollection {
+	features := make([]GeoJSONFeature, 0, len(properties))
+	for _, property := range properties {
+		features = append(features, ConvertPropertyToFeature(property))
+	}
+
+	return GeoJSONFeatureCollection{
+		Type:     geoJSONFeatureCollection,
+		Features: features,
+		Meta:     meta,
+	}
+}
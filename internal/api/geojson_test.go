@@ -0,0 +1,51 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test ConvertPropertyToFeature
+func TestConvertPropertyToFeature(t *testing.T) {
+	t.Run("WithCoordinates", func(t *testing.T) {
+		// Arrange
+		property := ConvertPropertyToResponse(createTestProperty())
+
+		// Act
+		feature := ConvertPropertyToFeature(property)
+
+		// Assert
+		assert.Equal(t, "Feature", feature.Type)
+		require.NotNil(t, feature.Geometry)
+		assert.Equal(t, "Point", feature.Geometry.Type)
+		assert.Equal(t, [2]float64{property.Longitude, property.Latitude}, feature.Geometry.Coordinates)
+		assert.Equal(t, property.HotelID, feature.Properties.HotelID)
+		assert.Equal(t, property.HotelName, feature.Properties.HotelName)
+		assert.Equal(t, property.Address.City, feature.Properties.City)
+	})
+
+	t.Run("WithoutCoordinates", func(t *testing.T) {
+		// Arrange
+		property := PropertyResponse{HotelID: 1, HotelName: "Unmapped Hotel"}
+
+		// Act
+		feature := ConvertPropertyToFeature(property)
+
+		// Assert
+		assert.Nil(t, feature.Geometry)
+		assert.Equal(t, "Unmapped Hotel", feature.Properties.HotelName)
+	})
+}
+
+// Test ConvertPropertiesToFeatureCollection
+func TestConvertPropertiesToFeatureCollection(t *testing.T) {
+	// Act
+	collection := ConvertPropertiesToFeatureCollection(nil, nil)
+
+	// Assert
+	assert.Equal(t, "FeatureCollection", collection.Type)
+	assert.NotNil(t, collection.Features)
+	assert.Empty(t, collection.Features)
+}
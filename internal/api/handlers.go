@@ -1,8 +1,13 @@
 package api
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/barimehdi77/cupid-api/internal/cupid"
@@ -12,15 +17,33 @@ import (
 	"go.uber.org/zap"
 )
 
+// TotalCountHeader carries the total number of matching items on HEAD requests
+const TotalCountHeader = "X-Total-Count"
+
 // Handlers contains all API handlers
 type Handlers struct {
 	storage      store.Storage
 	syncHandlers *SyncHandlers
+	config       *Config
+	// databaseHealthy reports the outcome of the background database checks; nil assumes a healthy database
+	databaseHealthy func() bool
 }
 
-// NewHandlers creates a new handlers instance
+// NewHandlers creates a new handlers instance with the default configuration
 func NewHandlers(storage store.Storage) *Handlers {
-	return &Handlers{storage: storage}
+	return NewHandlersWithConfig(storage, DefaultConfig())
+}
+
+// NewHandlersWithConfig creates a new handlers instance with the given configuration
+func NewHandlersWithConfig(storage store.Storage, config *Config) *Handlers {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	return &Handlers{
+		storage: storage,
+		config:  config,
+	}
 }
 
 // SetSyncHandlers sets the sync handlers
@@ -28,23 +51,86 @@ func (h *Handlers) SetSyncHandlers(syncHandlers *SyncHandlers) {
 	h.syncHandlers = syncHandlers
 }
 
+// SetDatabaseHealth sets the function reporting whether the database is reachable, for the health check
+func (h *Handlers) SetDatabaseHealth(healthy func() bool) {
+	h.databaseHealthy = healthy
+}
+
+// logError logs a failed operation with the current request ID attached
+func logError(c *gin.Context, operation string, err error, fields ...zap.Field) {
+	logger.LogError(operation, err, append(fields, logger.RequestIDField(c.Request.Context()))...)
+}
+
+// storageErrorStatus maps a failed storage call to an HTTP status.
+// Queries cut off by the request deadline are reported as 504 and missing rows as 404.
+func storageErrorStatus(c *gin.Context, err error) int {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(c.Request.Context().Err(), context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// respondStorageError writes the error response for a failed storage call.
+// message is only used for unexpected failures, timeouts and missing rows get their own message.
+func (h *Handlers) respondStorageError(c *gin.Context, err error, message string) {
+	code := storageErrorStatus(c, err)
+	switch code {
+	case http.StatusGatewayTimeout:
+		message = "Request timed out"
+	case http.StatusNotFound:
+		message = "Resource not found"
+	}
+
+	h.respondJSON(c, code, APIResponse{
+		Success: false,
+		Error:   message,
+	})
+}
+
+// apiVersion is the version of the API reported by the health and root endpoints
+const apiVersion = "1.0.0"
+
+// Error codes set in APIResponse.Code
+const (
+	errorCodeNotFound         = "NOT_FOUND"
+	errorCodeSyncInProgress   = "SYNC_IN_PROGRESS"
+	errorCodeRateLimited      = "RATE_LIMITED"
+	errorCodeResponseTooLarge = "RESPONSE_TOO_LARGE"
+)
+
 // HealthCheckHandler handles health check requests
 // @Summary Health check
-// @Description Check if the API is running and database is connected
+// @Description Check if the API is running and database is connected, as seen by the last background database check
 // @Tags health
 // @Accept json
 // @Produce json
 // @Success 200 {object} APIResponse{data=HealthResponse}
+// @Failure 503 {object} APIResponse{data=HealthResponse}
 // @Router /health [get]
 func (h *Handlers) HealthCheckHandler(c *gin.Context) {
 	response := HealthResponse{
 		Status:    "healthy",
 		Timestamp: time.Now(),
-		Version:   "1.0.0",
+		Version:   apiVersion,
 		Database:  "connected",
 	}
 
-	c.JSON(http.StatusOK, APIResponse{
+	if h.databaseHealthy != nil && !h.databaseHealthy() {
+		response.Status = "unhealthy"
+		response.Database = "disconnected"
+		h.respondJSON(c, http.StatusServiceUnavailable, APIResponse{
+			Success: false,
+			Error:   "Database unreachable",
+			Data:    response,
+		})
+		return
+	}
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
 		Success: true,
 		Data:    response,
 	})
@@ -52,90 +138,201 @@ func (h *Handlers) HealthCheckHandler(c *gin.Context) {
 
 // ListPropertiesHandler handles listing properties with filtering and pagination
 // @Summary List properties
-// @Description Get a paginated list of properties with optional filtering
+// @Description Get a paginated list of properties with optional filtering.
+// @Description Pass cursor (empty for the first page, then meta.next_cursor) for keyset pagination instead of page.
 // @Tags properties
 // @Accept json
-// @Produce json
+// @Produce json,application/geo+json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(20)
+// @Param cursor query string false "Opaque cursor from meta.next_cursor; not supported with search"
+// @Param ids query string false "Comma-separated property IDs to restrict the listing to, at most API_MAX_BATCH_SIZE"
 // @Param city query string false "Filter by city"
 // @Param country query string false "Filter by country"
 // @Param min_stars query int false "Minimum stars" minimum(1) maximum(5)
 // @Param max_stars query int false "Maximum stars" minimum(1) maximum(5)
 // @Param min_rating query number false "Minimum rating" minimum(0) maximum(10)
 // @Param max_rating query number false "Maximum rating" minimum(0) maximum(10)
+// @Param min_price query number false "Minimum lowest nightly rate" minimum(0)
+// @Param max_price query number false "Maximum lowest nightly rate" minimum(0)
+// @Param currency query string false "ISO 4217 currency of the lowest nightly rate"
 // @Param hotel_type query string false "Filter by hotel type"
-// @Param chain query string false "Filter by chain"
-// @Param search query string false "Search in hotel name, city, country"
+// @Param chain query string false "Filter by chain, matched exactly ignoring case, as listed by /chains"
+// @Param search query string false "Search in hotel name, city, country; never fuzzy, use /search?fuzzy=true to tolerate typos"
+// @Param facility query []int false "Facility ID the property must have (repeatable, all must match)" collectionFormat(multi)
+// @Param room_amenity query []int false "Room amenity ID some room of the property must offer (repeatable, all must match)" collectionFormat(multi)
+// @Param format query string false "Response format" Enums(json, geojson) default(json)
+// @Param facets query bool false "Include the counts of matching properties per hotel type, stars and country in meta.facets; not supported with search" default(false)
 // @Success 200 {object} APIResponse{data=[]PropertyResponse,meta=Meta}
+// @Success 200 {object} GeoJSONFeatureCollection "When format=geojson"
+// @Failure 400 {object} APIResponse
 // @Router /properties [get]
 func (h *Handlers) ListPropertiesHandler(c *gin.Context) {
 	var req PropertyListRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, APIResponse{
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
 			Success: false,
 			Error:   "Invalid query parameters: " + err.Error(),
 		})
 		return
 	}
 
-	// Set defaults
-	if req.Page == 0 {
-		req.Page = 1
+	if req.Format != "" && req.Format != FormatJSON && req.Format != FormatGeoJSON {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid format: must be json or geojson",
+		})
+		return
+	}
+
+	if err := validateRange("stars", optionalBound(req.MinStars), optionalBound(req.MaxStars)); err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err := validateRange("rating", req.MinRating, req.MaxRating); err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+	if err := validateRange("price", req.MinPrice, req.MaxPrice); err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
 	}
-	if req.Limit == 0 {
-		req.Limit = 20
+	if err := validateCurrency(req.Currency); err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
 	}
 
+	req.Page, req.Limit = parsePagination(h.config, c.Query("page"), c.Query("limit"))
+
 	// Convert to storage filters
 	filters := store.PropertyFilters{
-		City:      req.City,
-		Country:   req.Country,
-		MinStars:  req.MinStars,
-		MaxStars:  req.MaxStars,
-		MinRating: req.MinRating,
-		MaxRating: req.MaxRating,
-		HotelType: req.HotelType,
-		Chain:     req.Chain,
+		City:           req.City,
+		Country:        req.Country,
+		MinStars:       req.MinStars,
+		MaxStars:       req.MaxStars,
+		MinRating:      req.MinRating,
+		MaxRating:      req.MaxRating,
+		MinPrice:       req.MinPrice,
+		MaxPrice:       req.MaxPrice,
+		Currency:       req.Currency,
+		HotelType:      req.HotelType,
+		Chain:          req.Chain,
+		FacilityIDs:    req.Facilities,
+		RoomAmenityIDs: req.RoomAmenities,
+	}
+
+	if rawIDs, ok := c.GetQuery("ids"); ok {
+		ids, err := h.parseBatchIDs(rawIDs)
+		if err != nil {
+			h.respondJSON(c, http.StatusBadRequest, APIResponse{
+				Success: false,
+				Error:   err.Error(),
+			})
+			return
+		}
+		filters.HotelIDs = ids
+	}
+
+	// Facets count the filtered properties, which a search does not use
+	if req.Facets && req.Search != "" {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "facets cannot be combined with search",
+		})
+		return
+	}
+
+	// A cursor parameter, even an empty one for the first page, switches to keyset pagination
+	_, useCursor := c.GetQuery("cursor")
+	if useCursor {
+		if req.Search != "" {
+			h.respondJSON(c, http.StatusBadRequest, APIResponse{
+				Success: false,
+				Error:   "cursor cannot be combined with search",
+			})
+			return
+		}
+
+		if req.Cursor != "" {
+			after, err := decodePropertyCursor(req.Cursor)
+			if err != nil {
+				h.respondJSON(c, http.StatusBadRequest, APIResponse{
+					Success: false,
+					Error:   "Invalid cursor",
+				})
+				return
+			}
+			filters.After = after
+		}
 	}
 
+	// Cursor pagination never skips rows, so only page-based requests are bounded
 	offset := (req.Page - 1) * req.Limit
+	if !useCursor {
+		if err := validateOffset(h.config, offset); err != nil {
+			h.respondJSON(c, http.StatusBadRequest, APIResponse{
+				Success: false,
+				Error:   err.Error(),
+			})
+			return
+		}
+	}
 
-	var properties []*cupid.Property
+	response := []PropertyResponse{}
+	var nextCursor string
 	var err error
 
 	if req.Search != "" {
-		properties, err = h.storage.SearchProperties(c.Request.Context(), req.Search, req.Limit, offset)
+		var properties []*cupid.Property
+		properties, err = h.storage.SearchProperties(c.Request.Context(), req.Search, nil, false, req.Limit, offset)
+		for _, property := range properties {
+			response = append(response, ConvertPropertyToResponse(property))
+		}
 	} else {
-		properties, err = h.storage.ListProperties(c.Request.Context(), req.Limit, offset, filters)
+		var properties []*store.PropertyWithReviewAverage
+		if useCursor {
+			// Fetch one extra row to learn whether another page follows
+			properties, err = h.storage.ListPropertiesWithReviewAverages(c.Request.Context(), req.Limit+1, 0, filters)
+			if len(properties) > req.Limit {
+				properties = properties[:req.Limit]
+				nextCursor = encodePropertyCursor(properties[len(properties)-1].Property)
+			}
+		} else {
+			properties, err = h.storage.ListPropertiesWithReviewAverages(c.Request.Context(), req.Limit, offset, filters)
+		}
+		for _, property := range properties {
+			propertyResponse := ConvertPropertyToResponse(property.Property)
+			propertyResponse.ComputedRating = property.ComputedRating
+			response = append(response, propertyResponse)
+		}
 	}
 
 	if err != nil {
-		logger.LogError("Failed to list properties", err)
-		c.JSON(http.StatusInternalServerError, APIResponse{
-			Success: false,
-			Error:   "Failed to fetch properties",
-		})
+		logError(c, "Failed to list properties", err)
+		h.respondStorageError(c, err, "Failed to fetch properties")
 		return
 	}
 
 	// Get total count for pagination
 	totalCount, err := h.storage.CountProperties(c.Request.Context(), filters)
 	if err != nil {
-		logger.LogError("Failed to count properties", err)
-		c.JSON(http.StatusInternalServerError, APIResponse{
-			Success: false,
-			Error:   "Failed to count properties",
-		})
+		logError(c, "Failed to count properties", err)
+		h.respondStorageError(c, err, "Failed to count properties")
 		return
 	}
 
-	// Convert to response format
-	var response []PropertyResponse
-	for _, property := range properties {
-		response = append(response, ConvertPropertyToResponse(property))
-	}
-
 	// Calculate pagination metadata
 	totalPages := (totalCount + req.Limit - 1) / req.Limit
 	meta := &Meta{
@@ -147,8 +344,29 @@ func (h *Handlers) ListPropertiesHandler(c *gin.Context) {
 		HasNext:    req.Page < totalPages,
 		HasPrev:    req.Page > 1,
 	}
+	if useCursor {
+		meta.Page = 0
+		meta.HasNext = nextCursor != ""
+		meta.HasPrev = req.Cursor != ""
+		meta.NextCursor = nextCursor
+	}
+
+	if req.Facets {
+		facets, err := h.storage.GetPropertyFacets(c.Request.Context(), filters)
+		if err != nil {
+			logError(c, "Failed to count property facets", err)
+			h.respondStorageError(c, err, "Failed to count property facets")
+			return
+		}
+		meta.Facets = ConvertFacetsToResponse(facets)
+	}
 
-	c.JSON(http.StatusOK, APIResponse{
+	if req.Format == FormatGeoJSON {
+		writeGeoJSON(c, h.config, http.StatusOK, ConvertPropertiesToFeatureCollection(response, meta))
+		return
+	}
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
 		Success: true,
 		Data:    response,
 		Meta:    meta,
@@ -162,103 +380,316 @@ func (h *Handlers) ListPropertiesHandler(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path int true "Property ID"
+// @Param lang query string false "Language to localize to; takes precedence over Accept-Language"
+// @Param Accept-Language header string false "Preferred languages; the name, description and important information are returned in the best matching stored translation"
+// @Param fields query string false "Comma-separated property fields to return, plus the reviews and translations sections; everything when omitted"
 // @Success 200 {object} APIResponse{data=PropertyWithDetailsResponse}
+// @Failure 400 {object} APIResponse
 // @Failure 404 {object} APIResponse
 // @Router /properties/{id} [get]
 func (h *Handlers) GetPropertyHandler(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, APIResponse{
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
 			Success: false,
 			Error:   "Invalid property ID",
 		})
 		return
 	}
 
+	fields, err := parsePropertyFields(c.Query("fields"))
+	if err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
 	propertyData, err := h.storage.GetProperty(c.Request.Context(), id)
 	if err != nil {
-		if err.Error() == "property not found" {
-			c.JSON(http.StatusNotFound, APIResponse{
+		if errors.Is(err, store.ErrPropertyNotFound) {
+			h.respondJSON(c, http.StatusNotFound, APIResponse{
 				Success: false,
 				Error:   "Property not found",
 			})
 			return
 		}
 
-		logger.LogError("Failed to get property", err, zap.Int64("property_id", id))
-		c.JSON(http.StatusInternalServerError, APIResponse{
-			Success: false,
-			Error:   "Failed to fetch property",
-		})
+		logError(c, "Failed to get property", err, zap.Int64("property_id", id))
+		h.respondStorageError(c, err, "Failed to fetch property")
 		return
 	}
 
 	// Convert to response format
 	propertyResponse := ConvertPropertyToResponse(&propertyData.Property)
+	if h.config.IncludeCheckIn {
+		propertyResponse.CheckIn = ConvertCheckInToResponse(propertyData.Property.CheckIn)
+	}
+
+	// Localize the text fields to the best matching stored translation, keeping the base language otherwise
+	c.Header("Vary", "Accept-Language")
+	language, translation := negotiateTranslation(h.requestedLanguages(c), h.config.BaseLanguage, propertyData.Translations)
+	if translation != nil {
+		applyTranslation(&propertyResponse, translation)
+	}
+	if language != "" {
+		c.Header("Content-Language", language)
+	}
 
 	// Convert reviews
-	var reviews []ReviewResponse
+	reviews := make([]ReviewResponse, 0, len(propertyData.Reviews))
 	for _, review := range propertyData.Reviews {
 		reviews = append(reviews, ConvertReviewToResponse(review))
 	}
 
-	// Convert translations
-	translations := make(map[string]TranslationResponse)
-	for lang, translation := range propertyData.Translations {
-		translations[lang] = ConvertTranslationToResponse(lang, translation)
-	}
-
 	response := PropertyWithDetailsResponse{
 		Property:     propertyResponse,
 		Reviews:      reviews,
-		Translations: translations,
+		Translations: ConvertTranslationsToResponse(propertyData.Translations),
+	}
+
+	if fields == nil {
+		h.respondJSON(c, http.StatusOK, APIResponse{
+			Success: true,
+			Data:    response,
+		})
+		return
+	}
+
+	sparse, err := selectPropertyFields(response, fields)
+	if err != nil {
+		logError(c, "Failed to select property fields", err, zap.Int64("property_id", id))
+		h.respondJSON(c, http.StatusInternalServerError, APIResponse{
+			Success: false,
+			Error:   "Failed to render response",
+		})
+		return
 	}
 
-	c.JSON(http.StatusOK, APIResponse{
+	h.respondJSON(c, http.StatusOK, APIResponse{
 		Success: true,
-		Data:    response,
+		Data:    sparse,
+	})
+}
+
+// GetPropertySummaryHandler handles getting a single property without its reviews and translations
+// @Summary Get property summary by ID
+// @Description Get the main information of a property in a single query, for lightweight uses such as link previews
+// @Tags properties
+// @Accept json
+// @Produce json
+// @Param id path int true "Property ID"
+// @Success 200 {object} APIResponse{data=PropertyResponse}
+// @Failure 400 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Router /properties/{id}/summary [get]
+func (h *Handlers) GetPropertySummaryHandler(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid property ID",
+		})
+		return
+	}
+
+	property, err := h.storage.GetPropertySummary(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, store.ErrPropertyNotFound) {
+			h.respondJSON(c, http.StatusNotFound, APIResponse{
+				Success: false,
+				Error:   "Property not found",
+			})
+			return
+		}
+
+		logError(c, "Failed to get property summary", err, zap.Int64("property_id", id))
+		h.respondStorageError(c, err, "Failed to fetch property")
+		return
+	}
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data:    ConvertPropertyToResponse(property),
 	})
 }
 
 // GetPropertyReviewsHandler handles getting reviews for a specific property
 // @Summary Get property reviews
-// @Description Get all reviews for a specific property
+// @Description Get paginated reviews for a specific property with optional type and score filtering
 // @Tags properties
 // @Accept json
 // @Produce json
 // @Param id path int true "Property ID"
-// @Success 200 {object} APIResponse{data=[]ReviewResponse}
+// @Param type query string false "Filter by review type (e.g. solo, family)"
+// @Param language query string false "Filter by review language (e.g. en)"
+// @Param country query string false "Filter by reviewer country code (e.g. gb)"
+// @Param min_score query int false "Minimum review score (0-10)"
+// @Param max_score query int false "Maximum review score (0-10)"
+// @Param sort query string false "Sort field" Enums(date, score) default(date)
+// @Param order query string false "Sort direction" Enums(asc, desc) default(desc)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} APIResponse{data=[]ReviewResponse,meta=Meta}
+// @Failure 400 {object} APIResponse
 // @Failure 404 {object} APIResponse
 // @Router /properties/{id}/reviews [get]
 func (h *Handlers) GetPropertyReviewsHandler(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, APIResponse{
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
 			Success: false,
 			Error:   "Invalid property ID",
 		})
 		return
 	}
 
-	reviews, err := h.storage.GetPropertyReviews(c.Request.Context(), id)
+	minScore, err := parseScoreParam(c.Query("min_score"))
+	if err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid min_score parameter",
+		})
+		return
+	}
+
+	maxScore, err := parseScoreParam(c.Query("max_score"))
+	if err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid max_score parameter",
+		})
+		return
+	}
+
+	if err := validateRange("score", scoreBound(minScore), scoreBound(maxScore)); err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	sortBy, ascending, err := parseReviewSort(c.Query("sort"), c.Query("order"))
 	if err != nil {
-		logger.LogError("Failed to get property reviews", err, zap.Int64("property_id", id))
-		c.JSON(http.StatusInternalServerError, APIResponse{
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid sort parameters: " + err.Error(),
+		})
+		return
+	}
+
+	page, limit := parsePagination(h.config, c.Query("page"), c.Query("limit"))
+
+	offset := (page - 1) * limit
+	if err := validateOffset(h.config, offset); err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
 			Success: false,
-			Error:   "Failed to fetch reviews",
+			Error:   err.Error(),
 		})
 		return
 	}
 
+	filters := store.ReviewFilters{
+		Type:          c.Query("type"),
+		Language:      c.Query("language"),
+		Country:       c.Query("country"),
+		MinScore:      minScore,
+		MaxScore:      maxScore,
+		SortBy:        sortBy,
+		SortAscending: ascending,
+		Limit:         limit,
+		Offset:        offset,
+	}
+
+	reviews, err := h.storage.ListPropertyReviews(c.Request.Context(), id, filters)
+	if err != nil {
+		logError(c, "Failed to get property reviews", err, zap.Int64("property_id", id))
+		h.respondStorageError(c, err, "Failed to fetch reviews")
+		return
+	}
+
+	// Get total count for pagination
+	totalCount, err := h.storage.CountPropertyReviews(c.Request.Context(), id, filters)
+	if err != nil {
+		logError(c, "Failed to count property reviews", err, zap.Int64("property_id", id))
+		h.respondStorageError(c, err, "Failed to count reviews")
+		return
+	}
+
 	// Convert to response format
-	var response []ReviewResponse
+	response := make([]ReviewResponse, 0, len(reviews))
 	for _, review := range reviews {
 		response = append(response, ConvertReviewToResponse(review))
 	}
 
-	c.JSON(http.StatusOK, APIResponse{
+	// Calculate pagination metadata
+	totalPages := (totalCount + limit - 1) / limit
+	meta := &Meta{
+		Page:       page,
+		Limit:      limit,
+		Total:      totalCount,
+		TotalItems: totalCount,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data:    response,
+		Meta:    meta,
+	})
+}
+
+// GetPropertyReviewStatsHandler handles getting review statistics for a specific property
+// @Summary Get property review statistics
+// @Description Get the average score, normalized onto the 0-10 rating scale named by scale, the review count and the score distribution (1-10) for a property
+// @Tags properties
+// @Accept json
+// @Produce json
+// @Param id path int true "Property ID"
+// @Success 200 {object} APIResponse{data=ReviewStatsResponse}
+// @Failure 400 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /properties/{id}/reviews/stats [get]
+func (h *Handlers) GetPropertyReviewStatsHandler(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid property ID",
+		})
+		return
+	}
+
+	stats, err := h.storage.GetReviewStats(c.Request.Context(), id)
+	if err != nil {
+		logError(c, "Failed to get review stats", err, zap.Int64("property_id", id))
+		h.respondStorageError(c, err, "Failed to fetch review statistics")
+		return
+	}
+
+	// Convert to response format
+	response := ReviewStatsResponse{
+		PropertyID:   id,
+		AverageScore: stats.AverageScore,
+		Scale:        stats.Scale,
+		Count:        stats.Count,
+		Distribution: make([]ScoreBucketResponse, 0, len(stats.Distribution)),
+	}
+	for _, bucket := range stats.Distribution {
+		response.Distribution = append(response.Distribution, ScoreBucketResponse{
+			Score: bucket.Score,
+			Count: bucket.Count,
+		})
+	}
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
 		Success: true,
 		Data:    response,
 	})
@@ -266,19 +697,19 @@ func (h *Handlers) GetPropertyReviewsHandler(c *gin.Context) {
 
 // GetPropertyTranslationsHandler handles getting translations for a specific property
 // @Summary Get property translations
-// @Description Get all translations for a specific property
+// @Description Get all translations for a specific property, sorted by language code
 // @Tags properties
 // @Accept json
 // @Produce json
 // @Param id path int true "Property ID"
-// @Success 200 {object} APIResponse{data=map[string]TranslationResponse}
+// @Success 200 {object} APIResponse{data=[]TranslationResponse}
 // @Failure 404 {object} APIResponse
 // @Router /properties/{id}/translations [get]
 func (h *Handlers) GetPropertyTranslationsHandler(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, APIResponse{
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
 			Success: false,
 			Error:   "Invalid property ID",
 		})
@@ -287,21 +718,15 @@ func (h *Handlers) GetPropertyTranslationsHandler(c *gin.Context) {
 
 	translations, err := h.storage.GetPropertyTranslations(c.Request.Context(), id)
 	if err != nil {
-		logger.LogError("Failed to get property translations", err, zap.Int64("property_id", id))
-		c.JSON(http.StatusInternalServerError, APIResponse{
-			Success: false,
-			Error:   "Failed to fetch translations",
-		})
+		logError(c, "Failed to get property translations", err, zap.Int64("property_id", id))
+		h.respondStorageError(c, err, "Failed to fetch translations")
 		return
 	}
 
-	// Convert to response format
-	response := make(map[string]TranslationResponse)
-	for lang, translation := range translations {
-		response[lang] = ConvertTranslationToResponse(lang, translation)
-	}
+	// Convert to response format, sorted by language code
+	response := ConvertTranslationsToResponse(translations)
 
-	c.JSON(http.StatusOK, APIResponse{
+	h.respondJSON(c, http.StatusOK, APIResponse{
 		Success: true,
 		Data:    response,
 	})
@@ -309,11 +734,15 @@ func (h *Handlers) GetPropertyTranslationsHandler(c *gin.Context) {
 
 // SearchPropertiesHandler handles searching properties
 // @Summary Search properties
-// @Description Search properties by name, city, or country
+// @Description Search properties by name, city, country, street address, state or description.
+// @Description Pass fields to restrict the search to some of these columns.
+// @Description Pass fuzzy=true to also match misspelled words, closest matches first.
 // @Tags search
 // @Accept json
 // @Produce json
 // @Param q query string true "Search query"
+// @Param fields query string false "Comma-separated fields to search: hotel_name, city, country, address, state, description"
+// @Param fuzzy query bool false "Tolerate typos with trigram similarity" default(false)
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(20)
 // @Success 200 {object} APIResponse{data=[]PropertyResponse,meta=Meta}
@@ -321,46 +750,50 @@ func (h *Handlers) GetPropertyTranslationsHandler(c *gin.Context) {
 func (h *Handlers) SearchPropertiesHandler(c *gin.Context) {
 	var req SearchRequest
 	if err := c.ShouldBindQuery(&req); err != nil {
-		c.JSON(http.StatusBadRequest, APIResponse{
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
 			Success: false,
 			Error:   "Invalid query parameters: " + err.Error(),
 		})
 		return
 	}
 
-	// Set defaults
-	if req.Page == 0 {
-		req.Page = 1
-	}
-	if req.Limit == 0 {
-		req.Limit = 20
+	fields, err := parseSearchFields(req.Fields)
+	if err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
 	}
 
-	offset := (req.Page - 1) * req.Limit
+	req.Page, req.Limit = parsePagination(h.config, c.Query("page"), c.Query("limit"))
 
-	properties, err := h.storage.SearchProperties(c.Request.Context(), req.Query, req.Limit, offset)
-	if err != nil {
-		logger.LogError("Failed to search properties", err, zap.String("query", req.Query))
-		c.JSON(http.StatusInternalServerError, APIResponse{
+	offset := (req.Page - 1) * req.Limit
+	if err := validateOffset(h.config, offset); err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
 			Success: false,
-			Error:   "Failed to search properties",
+			Error:   err.Error(),
 		})
 		return
 	}
 
+	properties, err := h.storage.SearchProperties(c.Request.Context(), req.Query, fields, req.Fuzzy, req.Limit, offset)
+	if err != nil {
+		logError(c, "Failed to search properties", err, zap.String("query", req.Query))
+		h.respondStorageError(c, err, "Failed to search properties")
+		return
+	}
+
 	// Get total count for pagination
-	totalCount, err := h.storage.CountSearchProperties(c.Request.Context(), req.Query)
+	totalCount, err := h.storage.CountSearchProperties(c.Request.Context(), req.Query, fields, req.Fuzzy)
 	if err != nil {
-		logger.LogError("Failed to count search properties", err, zap.String("query", req.Query))
-		c.JSON(http.StatusInternalServerError, APIResponse{
-			Success: false,
-			Error:   "Failed to count search results",
-		})
+		logError(c, "Failed to count search properties", err, zap.String("query", req.Query))
+		h.respondStorageError(c, err, "Failed to count search results")
 		return
 	}
 
 	// Convert to response format
-	var response []PropertyResponse
+	response := make([]PropertyResponse, 0, len(properties))
 	for _, property := range properties {
 		response = append(response, ConvertPropertyToResponse(property))
 	}
@@ -377,66 +810,806 @@ func (h *Handlers) SearchPropertiesHandler(c *gin.Context) {
 		HasPrev:    req.Page > 1,
 	}
 
-	c.JSON(http.StatusOK, APIResponse{
+	h.respondJSON(c, http.StatusOK, APIResponse{
 		Success: true,
 		Data:    response,
 		Meta:    meta,
 	})
 }
 
-// GetPropertiesByLocationHandler handles getting properties by location
-// @Summary Get properties by location
-// @Description Get properties filtered by city and/or country
-// @Tags properties
-// @Accept json
-// @Produce json
-// @Param city query string false "City name"
-// @Param country query string false "Country name"
-// @Param page query int false "Page number" default(1)
-// @Param limit query int false "Items per page" default(20)
-// @Success 200 {object} APIResponse{data=[]PropertyResponse,meta=Meta}
-// @Router /properties/location [get]
-func (h *Handlers) GetPropertiesByLocationHandler(c *gin.Context) {
-	city := c.Query("city")
-	country := c.Query("country")
-	pageStr := c.DefaultQuery("page", "1")
-	limitStr := c.DefaultQuery("limit", "20")
-
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		page = 1
+// SearchPropertiesHeadHandler reports whether a search has results without returning them
+// @Summary Check search results
+// @Description Returns the number of matching properties in the X-Total-Count header, with no body
+// @Tags search
+// @Param q query string true "Search query"
+// @Param fields query string false "Comma-separated fields to search: hotel_name, city, country, address, state, description"
+// @Param fuzzy query bool false "Tolerate typos with trigram similarity" default(false)
+// @Success 200 {string} string "Matches found"
+// @Header 200 {int} X-Total-Count "Total number of matching properties"
+// @Failure 400 {string} string "Missing query, invalid fields or invalid fuzzy"
+// @Failure 404 {string} string "No matches"
+// @Router /search [head]
+func (h *Handlers) SearchPropertiesHeadHandler(c *gin.Context) {
+	query := c.Query("q")
+	fields, err := parseSearchFields(c.Query("fields"))
+	fuzzy, fuzzyErr := strconv.ParseBool(c.DefaultQuery("fuzzy", "false"))
+	if query == "" || err != nil || fuzzyErr != nil {
+		c.Status(http.StatusBadRequest)
+		return
 	}
 
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 1 || limit > 100 {
-		limit = 20
+	totalCount, err := h.storage.CountSearchProperties(c.Request.Context(), query, fields, fuzzy)
+	if err != nil {
+		logError(c, "Failed to count search properties", err, zap.String("query", query))
+		c.Status(storageErrorStatus(c, err))
+		return
 	}
 
-	offset := (page - 1) * limit
+	c.Header(TotalCountHeader, strconv.Itoa(totalCount))
+	if totalCount == 0 {
+		c.Status(http.StatusNotFound)
+		return
+	}
 
-	properties, err := h.storage.GetPropertiesByLocation(c.Request.Context(), city, country, limit, offset)
+	c.Status(http.StatusOK)
+}
+
+// ListFacilitiesHandler handles listing every facility present in the dataset
+// @Summary List facilities
+// @Description Get all distinct facilities with the number of properties offering each
+// @Tags facilities
+// @Accept json
+// @Produce json
+// @Success 200 {object} APIResponse{data=[]FacilityResponse}
+// @Failure 500 {object} APIResponse
+// @Router /facilities [get]
+func (h *Handlers) ListFacilitiesHandler(c *gin.Context) {
+	facilities, err := h.storage.ListFacilities(c.Request.Context())
+	if err != nil {
+		logError(c, "Failed to list facilities", err)
+		h.respondStorageError(c, err, "Failed to fetch facilities")
+		return
+	}
+
+	// Convert to response format
+	response := make([]FacilityResponse, 0, len(facilities))
+	for _, facility := range facilities {
+		response = append(response, FacilityResponse{
+			FacilityID:    facility.FacilityID,
+			Name:          facility.Name,
+			PropertyCount: facility.PropertyCount,
+		})
+	}
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// ListRoomAmenitiesHandler handles listing every room amenity present in the dataset
+// @Summary List room amenities
+// @Description Get all distinct room amenities with the number of properties having rooms offering each
+// @Tags facilities
+// @Accept json
+// @Produce json
+// @Success 200 {object} APIResponse{data=[]RoomAmenityResponse}
+// @Failure 500 {object} APIResponse
+// @Router /room-amenities [get]
+func (h *Handlers) ListRoomAmenitiesHandler(c *gin.Context) {
+	amenities, err := h.storage.ListRoomAmenities(c.Request.Context())
+	if err != nil {
+		logError(c, "Failed to list room amenities", err)
+		h.respondStorageError(c, err, "Failed to fetch room amenities")
+		return
+	}
+
+	// Convert to response format
+	response := make([]RoomAmenityResponse, 0, len(amenities))
+	for _, amenity := range amenities {
+		response = append(response, RoomAmenityResponse{
+			AmenityID:     amenity.AmenityID,
+			Name:          amenity.Name,
+			PropertyCount: amenity.PropertyCount,
+		})
+	}
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// ListChainsHandler handles listing every hotel chain present in the dataset
+// @Summary List chains
+// @Description Get all distinct hotel chains with their number of properties, largest first, for the chain filter
+// @Tags properties
+// @Accept json
+// @Produce json
+// @Success 200 {object} APIResponse{data=[]ChainResponse}
+// @Failure 500 {object} APIResponse
+// @Router /chains [get]
+func (h *Handlers) ListChainsHandler(c *gin.Context) {
+	chains, err := h.storage.ListChains(c.Request.Context())
+	if err != nil {
+		logError(c, "Failed to list chains", err)
+		h.respondStorageError(c, err, "Failed to fetch chains")
+		return
+	}
+
+	// Convert to response format
+	response := make([]ChainResponse, 0, len(chains))
+	for _, chain := range chains {
+		response = append(response, ChainResponse{
+			Chain:         chain.Chain,
+			PropertyCount: chain.PropertyCount,
+		})
+	}
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// GetAverageRatingByStarsHandler handles comparing guest ratings with official star categories
+// @Summary Average rating by stars
+// @Description Get the average guest rating of rated properties in each star category
+// @Tags stats
+// @Accept json
+// @Produce json
+// @Success 200 {object} APIResponse{data=[]StarRatingResponse}
+// @Failure 500 {object} APIResponse
+// @Router /stats/rating-by-stars [get]
+func (h *Handlers) GetAverageRatingByStarsHandler(c *gin.Context) {
+	averages, err := h.storage.GetAverageRatingByStars(c.Request.Context())
+	if err != nil {
+		logError(c, "Failed to get average rating by stars", err)
+		h.respondStorageError(c, err, "Failed to fetch rating statistics")
+		return
+	}
+
+	// Convert to response format
+	response := make([]StarRatingResponse, 0, len(averages))
+	for _, average := range averages {
+		response = append(response, StarRatingResponse{
+			Stars:         average.Stars,
+			AverageRating: average.AverageRating,
+			PropertyCount: average.PropertyCount,
+		})
+	}
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// DeletePropertyHandler handles deleting a property and all its related data
+// @Summary Delete property
+// @Description Delete a property together with its reviews, translations, details and facilities
+// @Tags admin
+// @Security AdminKey
+// @Accept json
+// @Produce json
+// @Param id path int true "Property ID"
+// @Success 200 {object} APIResponse
+// @Failure 400 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /admin/properties/{id} [delete]
+func (h *Handlers) DeletePropertyHandler(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid property ID",
+		})
+		return
+	}
+
+	if err := h.storage.DeleteProperty(c.Request.Context(), id); err != nil {
+		if errors.Is(err, store.ErrPropertyNotFound) {
+			h.respondJSON(c, http.StatusNotFound, APIResponse{
+				Success: false,
+				Error:   "Property not found",
+			})
+			return
+		}
+
+		logError(c, "Failed to delete property", err, zap.Int64("property_id", id))
+		h.respondStorageError(c, err, "Failed to delete property")
+		return
+	}
+
+	logger.Info("Property deleted via API", zap.Int64("property_id", id))
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data: map[string]interface{}{
+			"message":  "Property deleted successfully",
+			"hotel_id": id,
+		},
+	})
+}
+
+// UpdatePropertyOverridesHandler handles recording manual overrides of property fields
+// @Summary Override property fields
+// @Description Override fields upstream gets wrong, such as the hotel name or description. The body maps field names
+// @Description to their value, or to null to remove the override. Overrides win over upstream values on read and survive syncs.
+// @Tags admin
+// @Security AdminKey
+// @Accept json
+// @Produce json
+// @Param id path int true "Property ID"
+// @Param overrides body map[string]string true "Field overrides"
+// @Success 200 {object} APIResponse{data=PropertyOverridesResponse}
+// @Failure 400 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /admin/properties/{id} [patch]
+func (h *Handlers) UpdatePropertyOverridesHandler(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid property ID",
+		})
+		return
+	}
+
+	var overrides map[string]*string
+	if err := c.ShouldBindJSON(&overrides); err != nil || len(overrides) == 0 {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Request body must be a non-empty object mapping field names to values",
+		})
+		return
+	}
+
+	if err := h.storage.SetPropertyOverrides(c.Request.Context(), id, overrides); err != nil {
+		switch {
+		case errors.Is(err, store.ErrInvalidOverrideField):
+			h.respondJSON(c, http.StatusBadRequest, APIResponse{
+				Success: false,
+				Error:   fmt.Sprintf("%v; overridable fields: %s", err, strings.Join(store.OverridableFields, ", ")),
+			})
+		case errors.Is(err, store.ErrPropertyNotFound):
+			h.respondJSON(c, http.StatusNotFound, APIResponse{
+				Success: false,
+				Error:   "Property not found",
+			})
+		default:
+			logError(c, "Failed to update property overrides", err, zap.Int64("property_id", id))
+			h.respondStorageError(c, err, "Failed to update property overrides")
+		}
+		return
+	}
+
+	current, err := h.storage.GetPropertyOverrides(c.Request.Context(), id)
+	if err != nil {
+		logError(c, "Failed to get property overrides", err, zap.Int64("property_id", id))
+		h.respondStorageError(c, err, "Failed to fetch property overrides")
+		return
+	}
+
+	logger.Info("Property overrides updated via API",
+		zap.Int64("property_id", id),
+		zap.Int("fields", len(overrides)),
+	)
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data: PropertyOverridesResponse{
+			HotelID:   id,
+			Overrides: current,
+		},
+	})
+}
+
+// ImportPropertyReviewsHandler handles loading reviews of a property from outside the upstream sync
+// @Summary Import property reviews
+// @Description Upsert a JSON array of reviews into a property, e.g. to backfill a historical export. Reviews
+// @Description without an ID, with a score outside 1-10 or without a valid date are rejected and reported;
+// @Description the others are stored. Stored reviews missing from the body are kept. At most API_MAX_BATCH_SIZE
+// @Description reviews are accepted per request.
+// @Tags admin
+// @Security AdminKey
+// @Accept json
+// @Produce json
+// @Param id path int true "Property ID"
+// @Param reviews body []cupid.Review true "Reviews to import"
+// @Success 200 {object} APIResponse{data=ReviewImportResponse}
+// @Failure 400 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /admin/properties/{id}/reviews/import [post]
+func (h *Handlers) ImportPropertyReviewsHandler(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid property ID",
+		})
+		return
+	}
+
+	var reviews []cupid.Review
+	if err := c.ShouldBindJSON(&reviews); err != nil || len(reviews) == 0 {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Request body must be a non-empty array of reviews",
+		})
+		return
+	}
+	if err := h.validateBatchSize("reviews", len(reviews)); err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	response := ReviewImportResponse{HotelID: id}
+	valid := make([]cupid.Review, 0, len(reviews))
+	for i, review := range reviews {
+		if err := validateImportedReview(review); err != nil {
+			response.Rejections = append(response.Rejections, ReviewRejectionResponse{
+				Index:    i,
+				ReviewID: review.ReviewID,
+				Reason:   err.Error(),
+			})
+			continue
+		}
+		valid = append(valid, review)
+	}
+	response.Rejected = len(response.Rejections)
+
+	result, err := h.storage.ImportReviews(c.Request.Context(), id, valid)
+	if err != nil {
+		if errors.Is(err, store.ErrPropertyNotFound) {
+			h.respondJSON(c, http.StatusNotFound, APIResponse{
+				Success: false,
+				Error:   "Property not found",
+				Code:    errorCodeNotFound,
+			})
+			return
+		}
+		logError(c, "Failed to import reviews", err, zap.Int64("property_id", id))
+		h.respondStorageError(c, err, "Failed to import reviews")
+		return
+	}
+	response.Inserted = result.Inserted
+	response.Updated = result.Updated
+
+	logger.Info("Reviews imported via API",
+		zap.Int64("property_id", id),
+		zap.Int("inserted", response.Inserted),
+		zap.Int("updated", response.Updated),
+		zap.Int("rejected", response.Rejected),
+	)
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// GetPropertySyncHistoryHandler handles listing the recorded syncs of a property
+// @Summary Get property sync history
+// @Description Get the most recent syncs of a property, newest first, with the parts that changed in each
+// @Tags admin
+// @Security AdminKey
+// @Accept json
+// @Produce json
+// @Param id path int true "Property ID"
+// @Param limit query int false "Maximum number of entries" default(20)
+// @Success 200 {object} APIResponse{data=[]SyncHistoryEntryResponse}
+// @Failure 400 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /admin/properties/{id}/sync-history [get]
+func (h *Handlers) GetPropertySyncHistoryHandler(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid property ID",
+		})
+		return
+	}
+
+	limit := parseLimit(h.config, c.Query("limit"), defaultPageSize)
+
+	history, err := h.storage.GetPropertySyncHistory(c.Request.Context(), id, limit)
+	if err != nil {
+		logError(c, "Failed to get property sync history", err, zap.Int64("property_id", id))
+		h.respondStorageError(c, err, "Failed to fetch sync history")
+		return
+	}
+
+	// Convert to response format
+	response := make([]SyncHistoryEntryResponse, 0, len(history))
+	for _, entry := range history {
+		response = append(response, SyncHistoryEntryResponse{
+			SyncedAt: entry.SyncedAt,
+			Changed:  len(entry.Changes) > 0,
+			Changes:  entry.Changes,
+		})
+	}
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// GetRawPropertyHandler handles getting the raw Cupid API response stored for a property
+// @Summary Get raw property response
+// @Description Get the property response exactly as the Cupid API last sent it, to diagnose mismatches between stored and upstream data.
+// @Description Responses are only captured while CUPID_CAPTURE_RAW is enabled on the fetcher.
+// @Tags admin
+// @Security AdminKey
+// @Accept json
+// @Produce json
+// @Param id path int true "Property ID"
+// @Success 200 {object} APIResponse
+// @Failure 400 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /admin/properties/{id}/raw [get]
+func (h *Handlers) GetRawPropertyHandler(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid property ID",
+		})
+		return
+	}
+
+	raw, err := h.storage.GetRawProperty(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrPropertyNotFound):
+			h.respondJSON(c, http.StatusNotFound, APIResponse{
+				Success: false,
+				Error:   "Property not found",
+				Code:    errorCodeNotFound,
+			})
+		case errors.Is(err, store.ErrRawPropertyNotCaptured):
+			h.respondJSON(c, http.StatusNotFound, APIResponse{
+				Success: false,
+				Error:   "No raw response captured for this property, enable CUPID_CAPTURE_RAW and sync it again",
+				Code:    errorCodeNotFound,
+			})
+		default:
+			logError(c, "Failed to get raw property", err, zap.Int64("property_id", id))
+			h.respondStorageError(c, err, "Failed to fetch raw property")
+		}
+		return
+	}
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data:    raw,
+	})
+}
+
+// defaultStalePropertyAge is the sync age from which a property is listed as stale when no threshold is given,
+// matching the default sync interval
+const defaultStalePropertyAge = 12 * time.Hour
+
+// GetStalePropertiesHandler handles listing properties whose last sync is older than a threshold
+// @Summary Get stale properties
+// @Description Get properties not synced within older_than, least recently synced first, so they can be re-synced early
+// @Tags admin
+// @Security AdminKey
+// @Accept json
+// @Produce json
+// @Param older_than query string false "Sync age from which a property is stale, as a Go duration" default(12h)
+// @Param limit query int false "Maximum number of properties" default(20)
+// @Success 200 {object} APIResponse{data=[]PropertyResponse}
+// @Failure 400 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /admin/properties/stale [get]
+func (h *Handlers) GetStalePropertiesHandler(c *gin.Context) {
+	olderThan := defaultStalePropertyAge
+	if raw := c.Query("older_than"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			h.respondJSON(c, http.StatusBadRequest, APIResponse{
+				Success: false,
+				Error:   "older_than must be a positive duration such as 6h",
+			})
+			return
+		}
+		olderThan = parsed
+	}
+
+	limit := parseLimit(h.config, c.Query("limit"), defaultPageSize)
+
+	properties, err := h.storage.GetStaleProperties(c.Request.Context(), olderThan, limit)
+	if err != nil {
+		logError(c, "Failed to get stale properties", err, zap.Duration("older_than", olderThan))
+		h.respondStorageError(c, err, "Failed to fetch stale properties")
+		return
+	}
+
+	response := make([]PropertyResponse, 0, len(properties))
+	for _, property := range properties {
+		response = append(response, ConvertPropertyToResponse(property))
+	}
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// PurgeOrphansHandler handles deleting child rows left without a parent property
+// @Summary Purge orphaned rows
+// @Description Delete reviews, translations, details and facilities whose property no longer exists
+// @Tags admin
+// @Security AdminKey
+// @Accept json
+// @Produce json
+// @Success 200 {object} APIResponse{data=store.OrphanPurgeResult}
+// @Failure 500 {object} APIResponse
+// @Router /admin/maintenance/purge-orphans [post]
+func (h *Handlers) PurgeOrphansHandler(c *gin.Context) {
+	result, err := h.storage.PurgeOrphans(c.Request.Context())
+	if err != nil {
+		logError(c, "Failed to purge orphaned rows", err)
+		h.respondStorageError(c, err, "Failed to purge orphaned rows")
+		return
+	}
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data:    result,
+	})
+}
+
+// defaultReviewRetentionYears is how many years of reviews are kept when pruning without a cutoff,
+// matching the reviews displayed to users
+const defaultReviewRetentionYears = 2
+
+// PruneReviewsHandler handles deleting reviews older than a cutoff date
+// @Summary Prune old reviews
+// @Description Delete the reviews of every property dated before the cutoff, two years ago by default. Reviews still returned upstream are stored again by the next sync unless SYNC_REVIEW_RETENTION_DAYS excludes them
+// @Tags admin
+// @Security AdminKey
+// @Accept json
+// @Produce json
+// @Param before query string false "Cutoff as a YYYY-MM-DD date or RFC 3339 time; reviews dated before it are deleted"
+// @Success 200 {object} APIResponse{data=ReviewPruneResponse}
+// @Failure 400 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /admin/maintenance/prune-reviews [post]
+func (h *Handlers) PruneReviewsHandler(c *gin.Context) {
+	before, err := parseTimeBound(c.Query("before"), false)
 	if err != nil {
-		logger.LogError("Failed to get properties by location", err, zap.String("city", city), zap.String("country", country))
-		c.JSON(http.StatusInternalServerError, APIResponse{
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
 			Success: false,
-			Error:   "Failed to fetch properties",
+			Error:   "before " + err.Error(),
+		})
+		return
+	}
+	if before.IsZero() {
+		before = time.Now().UTC().AddDate(-defaultReviewRetentionYears, 0, 0)
+	}
+
+	deleted, err := h.storage.DeletePropertyReviewsOlderThan(c.Request.Context(), before)
+	if err != nil {
+		logError(c, "Failed to prune old reviews", err, zap.Time("before", before))
+		h.respondStorageError(c, err, "Failed to prune old reviews")
+		return
+	}
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data: ReviewPruneResponse{
+			Before:  before.Format(time.DateOnly),
+			Deleted: deleted,
+		},
+	})
+}
+
+// GetDuplicateCoordinatesHandler handles listing properties that share the same coordinates
+// @Summary Find properties with duplicate coordinates
+// @Description List groups of properties located at exactly the same latitude and longitude, to detect duplicated hotels
+// @Tags admin
+// @Security AdminKey
+// @Accept json
+// @Produce json
+// @Success 200 {object} APIResponse{data=[]CoordinateClusterResponse}
+// @Failure 500 {object} APIResponse
+// @Router /admin/maintenance/duplicate-coordinates [get]
+func (h *Handlers) GetDuplicateCoordinatesHandler(c *gin.Context) {
+	clusters, err := h.storage.GetPropertiesWithDuplicateCoordinates(c.Request.Context())
+	if err != nil {
+		logError(c, "Failed to find duplicate coordinates", err)
+		h.respondStorageError(c, err, "Failed to find duplicate coordinates")
+		return
+	}
+
+	response := make([]CoordinateClusterResponse, 0, len(clusters))
+	for _, cluster := range clusters {
+		properties := make([]PropertyResponse, 0, len(cluster.Properties))
+		for _, property := range cluster.Properties {
+			properties = append(properties, ConvertPropertyToResponse(property))
+		}
+		response = append(response, CoordinateClusterResponse{
+			Latitude:   cluster.Latitude,
+			Longitude:  cluster.Longitude,
+			Properties: properties,
 		})
+	}
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// GetChainConflictsHandler handles listing properties with inconsistent chain names and IDs
+// @Summary Find properties with conflicting chains
+// @Description List groups of properties where one chain name maps to several chain IDs, or one chain ID to several names
+// @Tags admin
+// @Security AdminKey
+// @Accept json
+// @Produce json
+// @Success 200 {object} APIResponse{data=[]ChainConflictResponse}
+// @Failure 500 {object} APIResponse
+// @Router /admin/maintenance/chain-conflicts [get]
+func (h *Handlers) GetChainConflictsHandler(c *gin.Context) {
+	conflicts, err := h.storage.GetPropertiesWithConflictingChains(c.Request.Context())
+	if err != nil {
+		logError(c, "Failed to find conflicting chains", err)
+		h.respondStorageError(c, err, "Failed to find conflicting chains")
+		return
+	}
+
+	response := make([]ChainConflictResponse, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		properties := make([]PropertyResponse, 0, len(conflict.Properties))
+		for _, property := range conflict.Properties {
+			properties = append(properties, ConvertPropertyToResponse(property))
+		}
+		response = append(response, ChainConflictResponse{
+			Field:      conflict.Field,
+			Chain:      conflict.Chain,
+			ChainID:    conflict.ChainID,
+			Properties: properties,
+		})
+	}
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// GetPropertiesByLocationHandler handles getting properties by location
+// @Summary Get properties by location
+// @Description Get properties filtered by city and/or country
+// @Tags properties
+// @Accept json
+// @Produce json
+// @Param city query string false "City name"
+// @Param country query string false "Country name"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} APIResponse{data=[]PropertyResponse,meta=Meta}
+// @Router /properties/location [get]
+func (h *Handlers) GetPropertiesByLocationHandler(c *gin.Context) {
+	city := c.Query("city")
+	country := c.Query("country")
+	page, limit := parsePagination(h.config, c.Query("page"), c.Query("limit"))
+
+	offset := (page - 1) * limit
+	if err := validateOffset(h.config, offset); err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	properties, err := h.storage.GetPropertiesByLocation(c.Request.Context(), city, country, limit, offset)
+	if err != nil {
+		logError(c, "Failed to get properties by location", err, zap.String("city", city), zap.String("country", country))
+		h.respondStorageError(c, err, "Failed to fetch properties")
 		return
 	}
 
 	// Get total count for pagination
 	totalCount, err := h.storage.CountPropertiesByLocation(c.Request.Context(), city, country)
 	if err != nil {
-		logger.LogError("Failed to count properties by location", err, zap.String("city", city), zap.String("country", country))
-		c.JSON(http.StatusInternalServerError, APIResponse{
+		logError(c, "Failed to count properties by location", err, zap.String("city", city), zap.String("country", country))
+		h.respondStorageError(c, err, "Failed to count properties")
+		return
+	}
+
+	// Convert to response format
+	response := make([]PropertyResponse, 0, len(properties))
+	for _, property := range properties {
+		response = append(response, ConvertPropertyToResponse(property))
+	}
+
+	// Calculate pagination metadata
+	totalPages := (totalCount + limit - 1) / limit
+	meta := &Meta{
+		Page:       page,
+		Limit:      limit,
+		Total:      totalCount,
+		TotalItems: totalCount,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data:    response,
+		Meta:    meta,
+	})
+}
+
+// GetPropertiesByPostalCodeHandler handles getting properties by postal code prefix
+// @Summary Get properties by postal code prefix
+// @Description Get properties whose postal code starts with the given prefix (case-insensitive)
+// @Tags properties
+// @Accept json
+// @Produce json
+// @Param prefix query string true "Postal code prefix (2-10 letters, digits, spaces or hyphens)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} APIResponse{data=[]PropertyResponse,meta=Meta}
+// @Failure 400 {object} APIResponse
+// @Router /properties/postal [get]
+func (h *Handlers) GetPropertiesByPostalCodeHandler(c *gin.Context) {
+	prefix, err := parsePostalCodePrefix(c.Query("prefix"))
+	if err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
 			Success: false,
-			Error:   "Failed to count properties",
+			Error:   "Invalid prefix: " + err.Error(),
 		})
 		return
 	}
 
+	page, limit := parsePagination(h.config, c.Query("page"), c.Query("limit"))
+
+	offset := (page - 1) * limit
+	if err := validateOffset(h.config, offset); err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
+	}
+
+	properties, err := h.storage.GetPropertiesByPostalCodePrefix(c.Request.Context(), prefix, limit, offset)
+	if err != nil {
+		logError(c, "Failed to get properties by postal code", err, zap.String("prefix", prefix))
+		h.respondStorageError(c, err, "Failed to fetch properties")
+		return
+	}
+
+	// Get total count for pagination
+	totalCount, err := h.storage.CountPropertiesByPostalCodePrefix(c.Request.Context(), prefix)
+	if err != nil {
+		logError(c, "Failed to count properties by postal code", err, zap.String("prefix", prefix))
+		h.respondStorageError(c, err, "Failed to count properties")
+		return
+	}
+
 	// Convert to response format
-	var response []PropertyResponse
+	response := make([]PropertyResponse, 0, len(properties))
 	for _, property := range properties {
 		response = append(response, ConvertPropertyToResponse(property))
 	}
@@ -453,7 +1626,7 @@ func (h *Handlers) GetPropertiesByLocationHandler(c *gin.Context) {
 		HasPrev:    page > 1,
 	}
 
-	c.JSON(http.StatusOK, APIResponse{
+	h.respondJSON(c, http.StatusOK, APIResponse{
 		Success: true,
 		Data:    response,
 		Meta:    meta,
@@ -474,7 +1647,7 @@ func (h *Handlers) GetPropertiesByLocationHandler(c *gin.Context) {
 func (h *Handlers) GetPropertiesByRatingHandler(c *gin.Context) {
 	minRatingStr := c.Query("min_rating")
 	if minRatingStr == "" {
-		c.JSON(http.StatusBadRequest, APIResponse{
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
 			Success: false,
 			Error:   "min_rating parameter is required",
 		})
@@ -483,56 +1656,212 @@ func (h *Handlers) GetPropertiesByRatingHandler(c *gin.Context) {
 
 	minRating, err := strconv.ParseFloat(minRatingStr, 64)
 	if err != nil || minRating < 0 || minRating > 10 {
-		c.JSON(http.StatusBadRequest, APIResponse{
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
 			Success: false,
 			Error:   "Invalid min_rating parameter",
 		})
 		return
 	}
 
-	pageStr := c.DefaultQuery("page", "1")
-	limitStr := c.DefaultQuery("limit", "20")
+	page, limit := parsePagination(h.config, c.Query("page"), c.Query("limit"))
 
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		page = 1
+	offset := (page - 1) * limit
+	if err := validateOffset(h.config, offset); err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+		})
+		return
 	}
 
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 1 || limit > 100 {
-		limit = 20
+	properties, err := h.storage.GetPropertiesByRating(c.Request.Context(), minRating, limit, offset)
+	if err != nil {
+		logError(c, "Failed to get properties by rating", err, zap.Float64("min_rating", minRating))
+		h.respondStorageError(c, err, "Failed to fetch properties")
+		return
 	}
 
-	offset := (page - 1) * limit
+	// Get total count for pagination
+	totalCount, err := h.storage.CountPropertiesByRating(c.Request.Context(), minRating)
+	if err != nil {
+		logError(c, "Failed to count properties by rating", err, zap.Float64("min_rating", minRating))
+		h.respondStorageError(c, err, "Failed to count properties")
+		return
+	}
 
-	properties, err := h.storage.GetPropertiesByRating(c.Request.Context(), minRating, limit, offset)
+	// Convert to response format
+	response := make([]PropertyResponse, 0, len(properties))
+	for _, property := range properties {
+		response = append(response, ConvertPropertyToResponse(property))
+	}
+
+	// Calculate pagination metadata
+	totalPages := (totalCount + limit - 1) / limit
+	meta := &Meta{
+		Page:       page,
+		Limit:      limit,
+		Total:      totalCount,
+		TotalItems: totalCount,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data:    response,
+		Meta:    meta,
+	})
+}
+
+// GetTopRatedByCityHandler handles listing the best rated property of each city
+// @Summary Get top rated property per city
+// @Description Get the best rated property of each city, best first; ties are broken by review count then hotel ID
+// @Tags properties
+// @Accept json
+// @Produce json
+// @Param limit query int false "Maximum number of cities" default(20)
+// @Success 200 {object} APIResponse{data=[]PropertyResponse}
+// @Failure 500 {object} APIResponse
+// @Router /properties/top-by-city [get]
+func (h *Handlers) GetTopRatedByCityHandler(c *gin.Context) {
+	limit := parseLimit(h.config, c.Query("limit"), defaultPageSize)
+
+	properties, err := h.storage.GetTopRatedByCity(c.Request.Context(), limit)
 	if err != nil {
-		logger.LogError("Failed to get properties by rating", err, zap.Float64("min_rating", minRating))
-		c.JSON(http.StatusInternalServerError, APIResponse{
+		logError(c, "Failed to get top rated properties by city", err)
+		h.respondStorageError(c, err, "Failed to fetch properties")
+		return
+	}
+
+	response := make([]PropertyResponse, 0, len(properties))
+	for _, property := range properties {
+		response = append(response, ConvertPropertyToResponse(property))
+	}
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+const (
+	// defaultNearbyRadiusKm is the radius searched around a property when none is given
+	defaultNearbyRadiusKm = 5.0
+	// maxNearbyRadiusKm caps the radius, since every located property is a candidate
+	maxNearbyRadiusKm = 100.0
+)
+
+// GetNearbyPropertiesHandler handles listing the properties near a stored property
+// @Summary Get nearby properties
+// @Description Get the other properties within radius kilometers of a property, nearest first, with their distance in distance_km
+// @Tags properties
+// @Accept json
+// @Produce json
+// @Param id path int true "Property ID"
+// @Param radius query number false "Search radius in kilometers, up to 100" default(5)
+// @Param limit query int false "Maximum number of properties" default(20)
+// @Success 200 {object} APIResponse{data=[]PropertyResponse}
+// @Failure 400 {object} APIResponse
+// @Failure 404 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /properties/{id}/nearby [get]
+func (h *Handlers) GetNearbyPropertiesHandler(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
 			Success: false,
-			Error:   "Failed to fetch properties",
+			Error:   "Invalid property ID",
 		})
 		return
 	}
 
-	// Get total count for pagination
-	totalCount, err := h.storage.CountPropertiesByRating(c.Request.Context(), minRating)
+	radiusKm := defaultNearbyRadiusKm
+	if raw := c.Query("radius"); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil || parsed <= 0 || parsed > maxNearbyRadiusKm {
+			h.respondJSON(c, http.StatusBadRequest, APIResponse{
+				Success: false,
+				Error:   fmt.Sprintf("radius must be a number of kilometers above 0 and up to %g", maxNearbyRadiusKm),
+			})
+			return
+		}
+		radiusKm = parsed
+	}
+
+	limit := parseLimit(h.config, c.Query("limit"), defaultPageSize)
+
+	properties, err := h.storage.GetNearbyProperties(c.Request.Context(), id, radiusKm, limit)
 	if err != nil {
-		logger.LogError("Failed to count properties by rating", err, zap.Float64("min_rating", minRating))
-		c.JSON(http.StatusInternalServerError, APIResponse{
+		if errors.Is(err, store.ErrPropertyNotFound) {
+			h.respondJSON(c, http.StatusNotFound, APIResponse{
+				Success: false,
+				Error:   "Property not found",
+			})
+			return
+		}
+		logError(c, "Failed to get nearby properties", err, zap.Int64("property_id", id))
+		h.respondStorageError(c, err, "Failed to fetch nearby properties")
+		return
+	}
+
+	response := make([]PropertyResponse, 0, len(properties))
+	for _, property := range properties {
+		propertyResponse := ConvertPropertyToResponse(property.Property)
+		propertyResponse.DistanceKm = &property.DistanceKm
+		response = append(response, propertyResponse)
+	}
+
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// GetBestPropertiesHandler handles listing the best properties by quality score
+// @Summary Get best properties
+// @Description Get properties ranked by a quality score balancing their rating with the number of reviews behind it
+// @Tags properties
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} APIResponse{data=[]PropertyResponse,meta=Meta}
+// @Router /properties/best [get]
+func (h *Handlers) GetBestPropertiesHandler(c *gin.Context) {
+	page, limit := parsePagination(h.config, c.Query("page"), c.Query("limit"))
+
+	offset := (page - 1) * limit
+	if err := validateOffset(h.config, offset); err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
 			Success: false,
-			Error:   "Failed to count properties",
+			Error:   err.Error(),
 		})
 		return
 	}
 
-	// Convert to response format
-	var response []PropertyResponse
+	properties, err := h.storage.GetPropertiesByQualityScore(c.Request.Context(), limit, offset)
+	if err != nil {
+		logError(c, "Failed to get properties by quality score", err)
+		h.respondStorageError(c, err, "Failed to fetch properties")
+		return
+	}
+
+	// Get total count for pagination
+	totalCount, err := h.storage.CountProperties(c.Request.Context(), store.PropertyFilters{})
+	if err != nil {
+		logError(c, "Failed to count properties", err)
+		h.respondStorageError(c, err, "Failed to count properties")
+		return
+	}
+
+	response := make([]PropertyResponse, 0, len(properties))
 	for _, property := range properties {
-		response = append(response, ConvertPropertyToResponse(property))
+		propertyResponse := ConvertPropertyToResponse(property.Property)
+		propertyResponse.QualityScore = &property.QualityScore
+		response = append(response, propertyResponse)
 	}
 
-	// Calculate pagination metadata
 	totalPages := (totalCount + limit - 1) / limit
 	meta := &Meta{
 		Page:       page,
@@ -544,9 +1873,41 @@ func (h *Handlers) GetPropertiesByRatingHandler(c *gin.Context) {
 		HasPrev:    page > 1,
 	}
 
-	c.JSON(http.StatusOK, APIResponse{
+	h.respondJSON(c, http.StatusOK, APIResponse{
 		Success: true,
 		Data:    response,
 		Meta:    meta,
 	})
 }
+
+// MethodNotAllowedHandler answers requests whose path exists but not for the request method.
+// Gin sets the Allow header listing the supported methods before calling it.
+func (h *Handlers) MethodNotAllowedHandler(c *gin.Context) {
+	h.respondJSON(c, http.StatusMethodNotAllowed, APIResponse{
+		Success: false,
+		Error:   "Method not allowed",
+	})
+}
+
+// RootHandler describes the API and where to find its documentation
+func (h *Handlers) RootHandler(c *gin.Context) {
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data: RootResponse{
+			Name:     h.config.ServiceName,
+			Version:  apiVersion,
+			BasePath: "/api/v1",
+			Docs:     "/docs/index.html",
+			Health:   "/api/v1/health",
+		},
+	})
+}
+
+// NotFoundHandler answers requests to unknown paths with the JSON error envelope instead of Gin's plain text 404
+func (h *Handlers) NotFoundHandler(c *gin.Context) {
+	h.respondJSON(c, http.StatusNotFound, APIResponse{
+		Success: false,
+		Error:   "not found",
+		Code:    errorCodeNotFound,
+	})
+}
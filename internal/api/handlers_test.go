@@ -2,10 +2,15 @@ package api
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/barimehdi77/cupid-api/internal/cupid"
 	"github.com/barimehdi77/cupid-api/internal/logger"
@@ -13,6 +18,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockStorage implements the store.Storage interface for testing
@@ -88,16 +94,16 @@ func (m *MockStorage) GetTranslationByLanguage(ctx context.Context, hotelID int6
 	return args.Get(0).(*cupid.Property), args.Error(1)
 }
 
-func (m *MockStorage) SearchProperties(ctx context.Context, query string, limit, offset int) ([]*cupid.Property, error) {
-	args := m.Called(ctx, query, limit, offset)
+func (m *MockStorage) SearchProperties(ctx context.Context, query string, fields []string, fuzzy bool, limit, offset int) ([]*cupid.Property, error) {
+	args := m.Called(ctx, query, fields, fuzzy, limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*cupid.Property), args.Error(1)
 }
 
-func (m *MockStorage) CountSearchProperties(ctx context.Context, query string) (int, error) {
-	args := m.Called(ctx, query)
+func (m *MockStorage) CountSearchProperties(ctx context.Context, query string, fields []string, fuzzy bool) (int, error) {
+	args := m.Called(ctx, query, fields, fuzzy)
 	return args.Int(0), args.Error(1)
 }
 
@@ -127,6 +133,291 @@ func (m *MockStorage) CountPropertiesByRating(ctx context.Context, minRating flo
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockStorage) GetPropertiesWithOldestReviews(ctx context.Context, limit int) ([]*store.PropertyReviewAge, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.PropertyReviewAge), args.Error(1)
+}
+
+func (m *MockStorage) ListPropertyReviews(ctx context.Context, hotelID int64, filters store.ReviewFilters) ([]cupid.Review, error) {
+	args := m.Called(ctx, hotelID, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]cupid.Review), args.Error(1)
+}
+
+func (m *MockStorage) CountPropertyReviews(ctx context.Context, hotelID int64, filters store.ReviewFilters) (int, error) {
+	args := m.Called(ctx, hotelID, filters)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStorage) ListFacilities(ctx context.Context) ([]store.FacilitySummary, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]store.FacilitySummary), args.Error(1)
+}
+
+func (m *MockStorage) ListPropertiesWithReviewAverages(ctx context.Context, limit, offset int, filters store.PropertyFilters) ([]*store.PropertyWithReviewAverage, error) {
+	args := m.Called(ctx, limit, offset, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.PropertyWithReviewAverage), args.Error(1)
+}
+
+func (m *MockStorage) GetReviewStats(ctx context.Context, hotelID int64) (*store.ReviewStats, error) {
+	args := m.Called(ctx, hotelID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.ReviewStats), args.Error(1)
+}
+
+func (m *MockStorage) PurgeOrphans(ctx context.Context) (*store.OrphanPurgeResult, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.OrphanPurgeResult), args.Error(1)
+}
+
+func (m *MockStorage) GetPropertiesByPostalCodePrefix(ctx context.Context, prefix string, limit, offset int) ([]*cupid.Property, error) {
+	args := m.Called(ctx, prefix, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*cupid.Property), args.Error(1)
+}
+
+func (m *MockStorage) CountPropertiesByPostalCodePrefix(ctx context.Context, prefix string) (int, error) {
+	args := m.Called(ctx, prefix)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStorage) StorePropertiesBatch(ctx context.Context, properties []*cupid.PropertyData) (map[int64]error, error) {
+	args := m.Called(ctx, properties)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]error), args.Error(1)
+}
+
+func (m *MockStorage) RecordPropertySync(ctx context.Context, hotelID int64, changes []string) error {
+	args := m.Called(ctx, hotelID, changes)
+	return args.Error(0)
+}
+
+func (m *MockStorage) GetPropertySyncHistory(ctx context.Context, hotelID int64, limit int) ([]store.PropertySyncEntry, error) {
+	args := m.Called(ctx, hotelID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]store.PropertySyncEntry), args.Error(1)
+}
+
+func (m *MockStorage) CreateSyncLog(ctx context.Context, entry *store.SyncLogRecord) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockStorage) UpdateSyncLog(ctx context.Context, entry *store.SyncLogRecord) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockStorage) ListSyncLogs(ctx context.Context, filters store.SyncLogFilters) ([]store.SyncLogRecord, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]store.SyncLogRecord), args.Error(1)
+}
+
+func (m *MockStorage) GetPropertySummary(ctx context.Context, hotelID int64) (*cupid.Property, error) {
+	args := m.Called(ctx, hotelID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cupid.Property), args.Error(1)
+}
+
+func (m *MockStorage) GetPropertiesWithDuplicateCoordinates(ctx context.Context) ([]store.CoordinateCluster, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]store.CoordinateCluster), args.Error(1)
+}
+
+func (m *MockStorage) GetAverageRatingByStars(ctx context.Context) ([]store.StarRatingAverage, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]store.StarRatingAverage), args.Error(1)
+}
+
+func (m *MockStorage) GetPropertiesWithStaleTranslations(ctx context.Context, olderThan time.Time, limit int) ([]*store.PropertyTranslationAge, error) {
+	args := m.Called(ctx, olderThan, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.PropertyTranslationAge), args.Error(1)
+}
+
+func (m *MockStorage) TryAcquireSyncLock(ctx context.Context) (func(), bool, error) {
+	args := m.Called(ctx)
+	release, _ := args.Get(0).(func())
+	return release, args.Bool(1), args.Error(2)
+}
+
+func (m *MockStorage) MarkPropertySynced(ctx context.Context, hotelID int64, raw json.RawMessage) error {
+	args := m.Called(ctx, hotelID, raw)
+	return args.Error(0)
+}
+
+func (m *MockStorage) GetPropertiesByQualityScore(ctx context.Context, limit, offset int) ([]*store.PropertyWithQualityScore, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.PropertyWithQualityScore), args.Error(1)
+}
+
+func (m *MockStorage) GetStaleProperties(ctx context.Context, olderThan time.Duration, limit int) ([]*cupid.Property, error) {
+	args := m.Called(ctx, olderThan, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*cupid.Property), args.Error(1)
+}
+
+func (m *MockStorage) SetPropertyOverrides(ctx context.Context, hotelID int64, overrides map[string]*string) error {
+	args := m.Called(ctx, hotelID, overrides)
+	return args.Error(0)
+}
+
+func (m *MockStorage) GetPropertyOverrides(ctx context.Context, hotelID int64) (map[string]string, error) {
+	args := m.Called(ctx, hotelID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (m *MockStorage) GetTranslationCounts(ctx context.Context, hotelIDs []int64) (map[int64]int, error) {
+	args := m.Called(ctx, hotelIDs)
+	counts, _ := args.Get(0).(map[int64]int)
+	return counts, args.Error(1)
+}
+
+func (m *MockStorage) GetPropertiesWithConflictingChains(ctx context.Context) ([]store.ChainConflict, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]store.ChainConflict), args.Error(1)
+}
+
+func (m *MockStorage) ListRoomAmenities(ctx context.Context) ([]store.RoomAmenitySummary, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]store.RoomAmenitySummary), args.Error(1)
+}
+
+func (m *MockStorage) GetPropertiesByCountries(ctx context.Context, countries []string) ([]*cupid.Property, error) {
+	args := m.Called(ctx, countries)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*cupid.Property), args.Error(1)
+}
+
+func (m *MockStorage) GetRawProperty(ctx context.Context, hotelID int64) (json.RawMessage, error) {
+	args := m.Called(ctx, hotelID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(json.RawMessage), args.Error(1)
+}
+
+func (m *MockStorage) GetTopRatedByCity(ctx context.Context, limit int) ([]*cupid.Property, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*cupid.Property), args.Error(1)
+}
+
+func (m *MockStorage) ListChains(ctx context.Context) ([]store.ChainSummary, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]store.ChainSummary), args.Error(1)
+}
+
+func (m *MockStorage) GetPropertyFacets(ctx context.Context, filters store.PropertyFilters) (*store.PropertyFacets, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.PropertyFacets), args.Error(1)
+}
+
+func (m *MockStorage) ImportReviews(ctx context.Context, hotelID int64, reviews []cupid.Review) (*store.ReviewImportResult, error) {
+	args := m.Called(ctx, hotelID, reviews)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.ReviewImportResult), args.Error(1)
+}
+
+func (m *MockStorage) DeletePropertyReviewsOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	args := m.Called(ctx, cutoff)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStorage) GetNearbyProperties(ctx context.Context, hotelID int64, radiusKm float64, limit int) ([]*store.PropertyWithDistance, error) {
+	args := m.Called(ctx, hotelID, radiusKm, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.PropertyWithDistance), args.Error(1)
+}
+
+func (m *MockStorage) CountSyncLogs(ctx context.Context, filters store.SyncLogFilters) (int, error) {
+	args := m.Called(ctx, filters)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStorage) CompareStoredPropertyDetails(ctx context.Context, propertyData *cupid.PropertyData) ([]string, error) {
+	args := m.Called(ctx, propertyData)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockStorage) EachSyncLog(ctx context.Context, filters store.SyncLogFilters, fn func(store.SyncLogRecord) error) error {
+	args := m.Called(ctx, filters, fn)
+	if records, ok := args.Get(0).([]store.SyncLogRecord); ok {
+		for _, record := range records {
+			if err := fn(record); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
 // Test data fixtures
 func createTestProperty() *cupid.Property {
 	return &cupid.Property{
@@ -179,6 +470,15 @@ func createTestPropertyData() *cupid.PropertyData {
 	}
 }
 
+// Helper functions for creating pointers
+func intPtr(i int) *int {
+	return &i
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
 func setupTestRouter(handlers *Handlers) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
@@ -192,11 +492,32 @@ func setupTestRouter(handlers *Handlers) *gin.Engine {
 		v1.GET("/health", handlers.HealthCheckHandler)
 		v1.GET("/properties", handlers.ListPropertiesHandler)
 		v1.GET("/properties/:id", handlers.GetPropertyHandler)
+		v1.GET("/properties/:id/summary", handlers.GetPropertySummaryHandler)
 		v1.GET("/properties/:id/reviews", handlers.GetPropertyReviewsHandler)
+		v1.GET("/properties/:id/reviews/stats", handlers.GetPropertyReviewStatsHandler)
 		v1.GET("/properties/:id/translations", handlers.GetPropertyTranslationsHandler)
+		v1.GET("/properties/:id/nearby", handlers.GetNearbyPropertiesHandler)
 		v1.GET("/properties/location", handlers.GetPropertiesByLocationHandler)
+		v1.GET("/properties/postal", handlers.GetPropertiesByPostalCodeHandler)
 		v1.GET("/properties/rating", handlers.GetPropertiesByRatingHandler)
+		v1.GET("/properties/best", handlers.GetBestPropertiesHandler)
+		v1.GET("/properties/top-by-city", handlers.GetTopRatedByCityHandler)
 		v1.GET("/search", handlers.SearchPropertiesHandler)
+		v1.HEAD("/search", handlers.SearchPropertiesHeadHandler)
+		v1.GET("/facilities", handlers.ListFacilitiesHandler)
+		v1.GET("/room-amenities", handlers.ListRoomAmenitiesHandler)
+		v1.GET("/chains", handlers.ListChainsHandler)
+		v1.GET("/stats/rating-by-stars", handlers.GetAverageRatingByStarsHandler)
+		v1.DELETE("/admin/properties/:id", handlers.DeletePropertyHandler)
+		v1.PATCH("/admin/properties/:id", handlers.UpdatePropertyOverridesHandler)
+		v1.GET("/admin/properties/:id/sync-history", handlers.GetPropertySyncHistoryHandler)
+		v1.GET("/admin/properties/:id/raw", handlers.GetRawPropertyHandler)
+		v1.POST("/admin/properties/:id/reviews/import", handlers.ImportPropertyReviewsHandler)
+		v1.GET("/admin/properties/stale", handlers.GetStalePropertiesHandler)
+		v1.POST("/admin/maintenance/purge-orphans", handlers.PurgeOrphansHandler)
+		v1.POST("/admin/maintenance/prune-reviews", handlers.PruneReviewsHandler)
+		v1.GET("/admin/maintenance/duplicate-coordinates", handlers.GetDuplicateCoordinatesHandler)
+		v1.GET("/admin/maintenance/chain-conflicts", handlers.GetChainConflictsHandler)
 	}
 
 	return router
@@ -232,6 +553,31 @@ func TestHealthCheckHandler(t *testing.T) {
 	assert.Equal(t, "connected", healthData["database"])
 }
 
+// Test HealthCheckHandler - Database unreachable
+func TestHealthCheckHandler_DatabaseDown(t *testing.T) {
+	// Arrange
+	handlers := NewHandlers(new(MockStorage))
+	handlers.SetDatabaseHealth(func() bool { return false })
+	router := setupTestRouter(handlers)
+
+	req, _ := http.NewRequest("GET", "/api/v1/health", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var response APIResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.False(t, response.Success)
+	healthData, ok := response.Data.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, "unhealthy", healthData["status"])
+	assert.Equal(t, "disconnected", healthData["database"])
+}
+
 // Test ListPropertiesHandler - Success Case
 func TestListPropertiesHandler_Success(t *testing.T) {
 	// Arrange
@@ -239,10 +585,13 @@ func TestListPropertiesHandler_Success(t *testing.T) {
 	handlers := NewHandlers(mockStorage)
 	router := setupTestRouter(handlers)
 
-	testProperties := []*cupid.Property{createTestProperty()}
+	computedRating := 8.5
+	testProperties := []*store.PropertyWithReviewAverage{
+		{Property: createTestProperty(), ComputedRating: &computedRating},
+	}
 	testFilters := store.PropertyFilters{}
 
-	mockStorage.On("ListProperties", mock.Anything, 20, 0, testFilters).Return(testProperties, nil)
+	mockStorage.On("ListPropertiesWithReviewAverages", mock.Anything, 20, 0, testFilters).Return(testProperties, nil)
 	mockStorage.On("CountProperties", mock.Anything, testFilters).Return(1, nil)
 
 	req, _ := http.NewRequest("GET", "/api/v1/properties?limit=20&page=1", nil)
@@ -274,47 +623,2787 @@ func TestListPropertiesHandler_Success(t *testing.T) {
 	properties, ok := response.Data.([]interface{})
 	assert.True(t, ok)
 	assert.Len(t, properties, 1)
+	assert.Equal(t, computedRating, properties[0].(map[string]interface{})["computed_rating"])
+
+	mockStorage.AssertExpectations(t)
+}
+
+// Test ListPropertiesHandler - GeoJSON Format
+func TestListPropertiesHandler_GeoJSON(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	property := createTestProperty()
+	testProperties := []*store.PropertyWithReviewAverage{{Property: property}}
+	testFilters := store.PropertyFilters{}
+
+	mockStorage.On("ListPropertiesWithReviewAverages", mock.Anything, 20, 0, testFilters).Return(testProperties, nil)
+	mockStorage.On("CountProperties", mock.Anything, testFilters).Return(1, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/properties?format=geojson", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/geo+json", w.Header().Get("Content-Type"))
+
+	var collection GeoJSONFeatureCollection
+	err := json.Unmarshal(w.Body.Bytes(), &collection)
+	assert.NoError(t, err)
+	assert.Equal(t, "FeatureCollection", collection.Type)
+	assert.Len(t, collection.Features, 1)
+	assert.Equal(t, [2]float64{property.Longitude, property.Latitude}, collection.Features[0].Geometry.Coordinates)
+	assert.Equal(t, property.HotelID, collection.Features[0].Properties.HotelID)
+	assert.Equal(t, 1, collection.Meta.Total)
+
+	mockStorage.AssertExpectations(t)
+}
+
+// Test ListPropertiesHandler - Facets
+func TestListPropertiesHandler_Facets(t *testing.T) {
+	t.Run("IncludedWhenRequested", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		testProperties := []*store.PropertyWithReviewAverage{{Property: createTestProperty()}}
+		testFilters := store.PropertyFilters{City: "Paris"}
+
+		mockStorage.On("ListPropertiesWithReviewAverages", mock.Anything, 20, 0, testFilters).Return(testProperties, nil)
+		mockStorage.On("CountProperties", mock.Anything, testFilters).Return(150, nil)
+		mockStorage.On("GetPropertyFacets", mock.Anything, testFilters).Return(&store.PropertyFacets{
+			HotelTypes: []store.FacetCount{{Value: "Hotels", Count: 120}, {Value: "Apartments", Count: 30}},
+			Stars:      []store.FacetCount{{Value: "4", Count: 150}},
+		}, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties?city=Paris&facets=true", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response APIResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.NotNil(t, response.Meta.Facets)
+		assert.Equal(t, []FacetCountResponse{{Value: "Hotels", Count: 120}, {Value: "Apartments", Count: 30}}, response.Meta.Facets.HotelTypes)
+		assert.Equal(t, []FacetCountResponse{{Value: "4", Count: 150}}, response.Meta.Facets.Stars)
+		assert.Empty(t, response.Meta.Facets.Countries)
+		assert.Contains(t, w.Body.String(), `"countries":[]`)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("OmittedByDefault", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		testFilters := store.PropertyFilters{}
+		mockStorage.On("ListPropertiesWithReviewAverages", mock.Anything, 20, 0, testFilters).Return([]*store.PropertyWithReviewAverage{}, nil)
+		mockStorage.On("CountProperties", mock.Anything, testFilters).Return(0, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), `"facets"`)
+		mockStorage.AssertNotCalled(t, "GetPropertyFacets", mock.Anything, mock.Anything)
+	})
+
+	t.Run("StorageError", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		testFilters := store.PropertyFilters{}
+		mockStorage.On("ListPropertiesWithReviewAverages", mock.Anything, 20, 0, testFilters).Return([]*store.PropertyWithReviewAverage{}, nil)
+		mockStorage.On("CountProperties", mock.Anything, testFilters).Return(0, nil)
+		mockStorage.On("GetPropertyFacets", mock.Anything, testFilters).Return(nil, errors.New("database error"))
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties?facets=true", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "Failed to count property facets")
+		mockStorage.AssertExpectations(t)
+	})
+}
+
+// Test ListPropertiesHandler - Invalid Format
+func TestListPropertiesHandler_InvalidFormat(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	req, _ := http.NewRequest("GET", "/api/v1/properties?format=kml", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockStorage.AssertNotCalled(t, "ListPropertiesWithReviewAverages", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test ListPropertiesHandler - Facility Filter
+func TestListPropertiesHandler_FacilityFilter(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	testProperties := []*store.PropertyWithReviewAverage{{Property: createTestProperty()}}
+	testFilters := store.PropertyFilters{FacilityIDs: []int{5, 47}}
+
+	mockStorage.On("ListPropertiesWithReviewAverages", mock.Anything, 20, 0, testFilters).Return(testProperties, nil)
+	mockStorage.On("CountProperties", mock.Anything, testFilters).Return(1, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/properties?facility=5&facility=47", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockStorage.AssertExpectations(t)
+}
+
+// Test ListPropertiesHandler - ids restricts the listing to the given properties
+func TestListPropertiesHandler_IDs(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		router := setupTestRouter(NewHandlers(mockStorage))
+
+		testProperties := []*store.PropertyWithReviewAverage{{Property: createTestProperty()}}
+		testFilters := store.PropertyFilters{HotelIDs: []int64{12345, 67890}}
+
+		mockStorage.On("ListPropertiesWithReviewAverages", mock.Anything, 20, 0, testFilters).Return(testProperties, nil)
+		mockStorage.On("CountProperties", mock.Anything, testFilters).Return(1, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties?ids=12345,67890", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		router := setupTestRouter(NewHandlers(mockStorage))
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties?ids=12345,abc", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockStorage.AssertNotCalled(t, "ListPropertiesWithReviewAverages", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+// Test batch endpoints - every endpoint taking a list rejects batches over Config.MaxBatchSize with 400
+func TestBatchEndpoints_MaxBatchSize(t *testing.T) {
+	reviews := `[
+		{"review_id": 1, "average_score": 8, "date": "2021-06-01"},
+		{"review_id": 2, "average_score": 8, "date": "2021-06-02"},
+		{"review_id": 3, "average_score": 8, "date": "2021-06-03"}
+	]`
+
+	tests := []struct {
+		name          string
+		method        string
+		url           string
+		body          string
+		expectedError string
+	}{
+		{name: "ListByIDs", method: "GET", url: "/api/v1/properties?ids=" + joinIDs(3), expectedError: "too many IDs: maximum is 2, got 3"},
+		{name: "ImportReviews", method: "POST", url: "/api/v1/admin/properties/12345/reviews/import", body: reviews, expectedError: "too many reviews: maximum is 2, got 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockStorage := new(MockStorage)
+			config := DefaultConfig()
+			config.MaxBatchSize = 2
+			router := setupTestRouter(NewHandlersWithConfig(mockStorage, config))
+
+			req, _ := http.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response APIResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedError, response.Error)
+			assert.Empty(t, mockStorage.Calls)
+		})
+	}
+}
+
+// Test ListPropertiesHandler - Room Amenity Filter
+func TestListPropertiesHandler_RoomAmenityFilter(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	testProperties := []*store.PropertyWithReviewAverage{{Property: createTestProperty()}}
+	testFilters := store.PropertyFilters{RoomAmenityIDs: []int{12, 30}}
+
+	mockStorage.On("ListPropertiesWithReviewAverages", mock.Anything, 20, 0, testFilters).Return(testProperties, nil)
+	mockStorage.On("CountProperties", mock.Anything, testFilters).Return(1, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/properties?room_amenity=12&room_amenity=30", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockStorage.AssertExpectations(t)
+}
+
+// Test ListPropertiesHandler - Price Filters
+func TestListPropertiesHandler_PriceFilters(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	testProperties := []*store.PropertyWithReviewAverage{{Property: createTestProperty()}}
+	testFilters := store.PropertyFilters{MinPrice: float64Ptr(50), MaxPrice: float64Ptr(150), Currency: "EUR"}
+
+	mockStorage.On("ListPropertiesWithReviewAverages", mock.Anything, 20, 0, testFilters).Return(testProperties, nil)
+	mockStorage.On("CountProperties", mock.Anything, testFilters).Return(1, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/properties?min_price=50&max_price=150&currency=EUR", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockStorage.AssertExpectations(t)
+}
+
+// Test ListPropertiesHandler - Invalid Currency
+func TestListPropertiesHandler_InvalidCurrency(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	req, _ := http.NewRequest("GET", "/api/v1/properties?currency=euro", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "currency must be a three-letter ISO 4217 code", response.Error)
+	mockStorage.AssertNotCalled(t, "ListPropertiesWithReviewAverages", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test ListPropertiesHandler - Zero bounds are passed on instead of being treated as unset
+func TestListPropertiesHandler_ZeroBounds(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	testFilters := store.PropertyFilters{MinRating: float64Ptr(0), MaxRating: float64Ptr(0)}
+
+	mockStorage.On("ListPropertiesWithReviewAverages", mock.Anything, 20, 0, testFilters).Return([]*store.PropertyWithReviewAverage{}, nil)
+	mockStorage.On("CountProperties", mock.Anything, testFilters).Return(0, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/properties?min_rating=0&max_rating=0", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockStorage.AssertExpectations(t)
+}
+
+// Test list handlers - Empty results are serialized as empty arrays rather than null
+func TestListHandlers_EmptyResultsAreArrays(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		arrange func(m *MockStorage)
+		field   func(data map[string]json.RawMessage) json.RawMessage
+	}{
+		{
+			name: "List properties",
+			url:  "/api/v1/properties",
+			arrange: func(m *MockStorage) {
+				m.On("ListPropertiesWithReviewAverages", mock.Anything, 20, 0, store.PropertyFilters{}).Return(nil, nil)
+				m.On("CountProperties", mock.Anything, store.PropertyFilters{}).Return(0, nil)
+			},
+		},
+		{
+			name: "Search",
+			url:  "/api/v1/search?q=nowhere",
+			arrange: func(m *MockStorage) {
+				m.On("SearchProperties", mock.Anything, "nowhere", []string(nil), false, 20, 0).Return(nil, nil)
+				m.On("CountSearchProperties", mock.Anything, "nowhere", []string(nil), false).Return(0, nil)
+			},
+		},
+		{
+			name: "Location",
+			url:  "/api/v1/properties/location?city=Nowhere",
+			arrange: func(m *MockStorage) {
+				m.On("GetPropertiesByLocation", mock.Anything, "Nowhere", "", 20, 0).Return(nil, nil)
+				m.On("CountPropertiesByLocation", mock.Anything, "Nowhere", "").Return(0, nil)
+			},
+		},
+		{
+			name: "Rating",
+			url:  "/api/v1/properties/rating?min_rating=9.9",
+			arrange: func(m *MockStorage) {
+				m.On("GetPropertiesByRating", mock.Anything, 9.9, 20, 0).Return(nil, nil)
+				m.On("CountPropertiesByRating", mock.Anything, 9.9).Return(0, nil)
+			},
+		},
+		{
+			name: "Reviews",
+			url:  "/api/v1/properties/12345/reviews",
+			arrange: func(m *MockStorage) {
+				filters := store.ReviewFilters{Limit: 20, Offset: 0}
+				m.On("ListPropertyReviews", mock.Anything, int64(12345), filters).Return(nil, nil)
+				m.On("CountPropertyReviews", mock.Anything, int64(12345), filters).Return(0, nil)
+			},
+		},
+		{
+			name: "Property reviews and translations",
+			url:  "/api/v1/properties/12345",
+			arrange: func(m *MockStorage) {
+				m.On("GetProperty", mock.Anything, int64(12345)).Return(&cupid.PropertyData{Property: *createTestProperty()}, nil)
+			},
+			field: func(data map[string]json.RawMessage) json.RawMessage {
+				return json.RawMessage(string(data["reviews"]) + string(data["translations"]))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockStorage := new(MockStorage)
+			router := setupTestRouter(NewHandlers(mockStorage))
+			tt.arrange(mockStorage)
+
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var response struct {
+				Data json.RawMessage `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			if tt.field == nil {
+				assert.JSONEq(t, `[]`, string(response.Data))
+			} else {
+				var data map[string]json.RawMessage
+				require.NoError(t, json.Unmarshal(response.Data, &data))
+				assert.Equal(t, `[][]`, string(tt.field(data)))
+			}
+			mockStorage.AssertExpectations(t)
+		})
+	}
+}
+
+// Test paginated handlers - Oversized and negative limits are clamped instead of rejected
+func TestPaginatedHandlers_ClampLimit(t *testing.T) {
+	t.Run("List properties", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		mockStorage.On("ListPropertiesWithReviewAverages", mock.Anything, 100, 0, store.PropertyFilters{}).Return([]*store.PropertyWithReviewAverage{}, nil)
+		mockStorage.On("CountProperties", mock.Anything, store.PropertyFilters{}).Return(0, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties?limit=5000&page=-3", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response APIResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.NotNil(t, response.Meta)
+		assert.Equal(t, 1, response.Meta.Page)
+		assert.Equal(t, 100, response.Meta.Limit)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		mockStorage.On("SearchProperties", mock.Anything, "paris", []string(nil), false, 1, 0).Return([]*cupid.Property{}, nil)
+		mockStorage.On("CountSearchProperties", mock.Anything, "paris", []string(nil), false).Return(0, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/search?q=paris&limit=-5", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("Configured maximum", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		config := DefaultConfig()
+		config.MaxPageSize = 50
+		handlers := NewHandlersWithConfig(mockStorage, config)
+		router := setupTestRouter(handlers)
+
+		mockStorage.On("GetPropertiesByLocation", mock.Anything, "Paris", "", 50, 0).Return([]*cupid.Property{}, nil)
+		mockStorage.On("CountPropertiesByLocation", mock.Anything, "Paris", "").Return(0, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties/location?city=Paris&limit=5000", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockStorage.AssertExpectations(t)
+	})
+}
+
+// Test ListPropertiesHandler - Database Error
+func TestListPropertiesHandler_DatabaseError(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	testFilters := store.PropertyFilters{}
+
+	mockStorage.On("ListPropertiesWithReviewAverages", mock.Anything, 20, 0, testFilters).Return(nil, assert.AnError)
+
+	req, _ := http.NewRequest("GET", "/api/v1/properties", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	var response APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.False(t, response.Success)
+	assert.Equal(t, "Failed to fetch properties", response.Error)
+
+	mockStorage.AssertExpectations(t)
+}
+
+// Test ListPropertiesHandler - Invalid Query Parameters
+func TestListPropertiesHandler_InvalidQueryParams(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	req, _ := http.NewRequest("GET", "/api/v1/properties?limit=invalid", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.False(t, response.Success)
+	assert.Contains(t, response.Error, "Invalid query parameters")
+}
+
+// Test ListPropertiesHandler - Inverted Ranges
+func TestListPropertiesHandler_InvertedRanges(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		expectedError string
+	}{
+		{"Stars", "min_stars=5&max_stars=3", "min_stars cannot be greater than max_stars"},
+		{"Rating", "min_rating=8.5&max_rating=7", "min_rating cannot be greater than max_rating"},
+		{"Price", "min_price=200&max_price=100", "min_price cannot be greater than max_price"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockStorage := new(MockStorage)
+			handlers := NewHandlers(mockStorage)
+			router := setupTestRouter(handlers)
+
+			req, _ := http.NewRequest("GET", "/api/v1/properties?"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response APIResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.False(t, response.Success)
+			assert.Equal(t, tt.expectedError, response.Error)
+			mockStorage.AssertNotCalled(t, "ListPropertiesWithReviewAverages", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// Test ListPropertiesHandler - Cursor Pagination
+func TestListPropertiesHandler_Cursor(t *testing.T) {
+	t.Run("First page returns next cursor", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		first := createTestProperty()
+		second := createTestProperty()
+		second.HotelID, second.Rating, second.ReviewCount = 222, 9.1, 40
+		extra := createTestProperty()
+		extra.HotelID = 333
+		testProperties := []*store.PropertyWithReviewAverage{{Property: first}, {Property: second}, {Property: extra}}
+
+		mockStorage.On("ListPropertiesWithReviewAverages", mock.Anything, 3, 0, store.PropertyFilters{}).Return(testProperties, nil)
+		mockStorage.On("CountProperties", mock.Anything, store.PropertyFilters{}).Return(5, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties?cursor=&limit=2", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response APIResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Len(t, response.Data, 2)
+		require.NotNil(t, response.Meta)
+		assert.True(t, response.Meta.HasNext)
+		assert.False(t, response.Meta.HasPrev)
+		assert.Equal(t, 5, response.Meta.Total)
+
+		after, err := decodePropertyCursor(response.Meta.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, store.PropertyCursor{Rating: 9.1, ReviewCount: 40, HotelID: 222}, *after)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("Last page has no next cursor", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		cursor := encodePropertyCursor(&cupid.Property{HotelID: 222, Rating: 9.1, ReviewCount: 40})
+		filters := store.PropertyFilters{
+			City:  "London",
+			After: &store.PropertyCursor{Rating: 9.1, ReviewCount: 40, HotelID: 222},
+		}
+		testProperties := []*store.PropertyWithReviewAverage{{Property: createTestProperty()}}
+
+		mockStorage.On("ListPropertiesWithReviewAverages", mock.Anything, 3, 0, filters).Return(testProperties, nil)
+		mockStorage.On("CountProperties", mock.Anything, filters).Return(3, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties?city=London&limit=2&cursor="+cursor, nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response APIResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Len(t, response.Data, 1)
+		require.NotNil(t, response.Meta)
+		assert.False(t, response.Meta.HasNext)
+		assert.True(t, response.Meta.HasPrev)
+		assert.Empty(t, response.Meta.NextCursor)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("Offset pagination has no cursor", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		mockStorage.On("ListPropertiesWithReviewAverages", mock.Anything, 1, 0, store.PropertyFilters{}).
+			Return([]*store.PropertyWithReviewAverage{{Property: createTestProperty()}}, nil)
+		mockStorage.On("CountProperties", mock.Anything, store.PropertyFilters{}).Return(2, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties?limit=1", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), "next_cursor")
+		mockStorage.AssertExpectations(t)
+	})
+
+	invalidTests := []struct {
+		name          string
+		query         string
+		expectedError string
+	}{
+		{"Invalid cursor", "cursor=not-a-cursor", "Invalid cursor"},
+		{"Cursor with search", "cursor=&search=paris", "cursor cannot be combined with search"},
+		{"Facets with search", "facets=true&search=paris", "facets cannot be combined with search"},
+	}
+
+	for _, tt := range invalidTests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockStorage := new(MockStorage)
+			handlers := NewHandlers(mockStorage)
+			router := setupTestRouter(handlers)
+
+			req, _ := http.NewRequest("GET", "/api/v1/properties?"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response APIResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedError, response.Error)
+			mockStorage.AssertNotCalled(t, "ListPropertiesWithReviewAverages", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// Test GetPropertyHandler - Success Case
+func TestGetPropertyHandler_Success(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	testPropertyData := createTestPropertyData()
+
+	mockStorage.On("GetProperty", mock.Anything, int64(12345)).Return(testPropertyData, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/properties/12345", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.NotNil(t, response.Data)
+
+	// Verify property with details structure
+	propertyData, ok := response.Data.(map[string]interface{})
+	assert.True(t, ok)
+	assert.NotNil(t, propertyData["property"])
+	assert.NotNil(t, propertyData["reviews"])
+	assert.NotNil(t, propertyData["translations"])
+
+	mockStorage.AssertExpectations(t)
+}
+
+// Test GetPropertyHandler - Sparse fieldsets
+func TestGetPropertyHandler_Fields(t *testing.T) {
+	t.Run("SelectedFieldsOnly", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		mockStorage.On("GetProperty", mock.Anything, int64(12345)).Return(createTestPropertyData(), nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties/12345?fields=hotel_name,rating,main_image_th", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data map[string]map[string]interface{} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Data, 1)
+		property := response.Data["property"]
+		assert.Len(t, property, 4)
+		assert.Equal(t, float64(12345), property["hotel_id"])
+		assert.Equal(t, "Test Hotel", property["hotel_name"])
+		assert.Contains(t, property, "rating")
+		assert.Contains(t, property, "main_image_th")
+
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("Sections", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		mockStorage.On("GetProperty", mock.Anything, int64(12345)).Return(createTestPropertyData(), nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties/12345?fields=reviews", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data map[string]json.RawMessage `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Contains(t, response.Data, "reviews")
+		assert.NotContains(t, response.Data, "translations")
+		assert.JSONEq(t, `{"hotel_id": 12345}`, string(response.Data["property"]))
+
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("InvalidField", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties/12345?fields=hotel_name,password", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `invalid field \"password\"`)
+		mockStorage.AssertNotCalled(t, "GetProperty", mock.Anything, mock.Anything)
+	})
+}
+
+// Test GetPropertyHandler - Check-in times follow Config.IncludeCheckIn
+func TestGetPropertyHandler_CheckIn(t *testing.T) {
+	for _, include := range []bool{true, false} {
+		t.Run(fmt.Sprintf("IncludeCheckIn=%t", include), func(t *testing.T) {
+			// Arrange
+			mockStorage := new(MockStorage)
+			config := DefaultConfig()
+			config.IncludeCheckIn = include
+			router := setupTestRouter(NewHandlersWithConfig(mockStorage, config))
+
+			testPropertyData := createTestPropertyData()
+			testPropertyData.Property.CheckIn = cupid.CheckIn{CheckInStart: "15:00", CheckInEnd: "23:00", Checkout: "11:00"}
+			mockStorage.On("GetProperty", mock.Anything, int64(12345)).Return(testPropertyData, nil)
+
+			req, _ := http.NewRequest("GET", "/api/v1/properties/12345", nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var response struct {
+				Data PropertyWithDetailsResponse `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			if include {
+				require.NotNil(t, response.Data.Property.CheckIn)
+				assert.Equal(t, "15:00", response.Data.Property.CheckIn.CheckInStart)
+				assert.Equal(t, "11:00", response.Data.Property.CheckIn.Checkout)
+			} else {
+				assert.Nil(t, response.Data.Property.CheckIn)
+			}
+		})
+	}
+}
+
+// Test GetPropertyHandler - Accept-Language selects a stored translation
+func TestGetPropertyHandler_AcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name             string
+		acceptLanguage   string
+		expectedName     string
+		expectedLanguage string
+	}{
+		{name: "ExactMatch", acceptLanguage: "fr", expectedName: "Hôtel de Test", expectedLanguage: "fr"},
+		{name: "RegionFallsBackToPrimary", acceptLanguage: "fr-CA, en;q=0.8", expectedName: "Hôtel de Test", expectedLanguage: "fr"},
+		{name: "PreferenceOrder", acceptLanguage: "de;q=0.5, es;q=0.9, fr;q=0.7", expectedName: "Hotel de Prueba", expectedLanguage: "es"},
+		{name: "BaseLanguagePreferred", acceptLanguage: "en, fr;q=0.8", expectedName: "Test Hotel", expectedLanguage: "en"},
+		{name: "BaseLanguageRegion", acceptLanguage: "en-GB, fr;q=0.8", expectedName: "Test Hotel", expectedLanguage: "en"},
+		{name: "UnknownLanguage", acceptLanguage: "ja", expectedName: "Test Hotel"},
+		{name: "NoHeader", expectedName: "Test Hotel"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockStorage := new(MockStorage)
+			router := setupTestRouter(NewHandlers(mockStorage))
+
+			testPropertyData := createTestPropertyData()
+			testPropertyData.Translations["fr"].Description = "Un hôtel de test"
+			testPropertyData.Translations["es"] = &cupid.Property{HotelID: 12345, HotelName: "Hotel de Prueba"}
+			mockStorage.On("GetProperty", mock.Anything, int64(12345)).Return(testPropertyData, nil)
+
+			req, _ := http.NewRequest("GET", "/api/v1/properties/12345", nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "Accept-Language", w.Header().Get("Vary"))
+			assert.Equal(t, tt.expectedLanguage, w.Header().Get("Content-Language"))
+
+			var response struct {
+				Data PropertyWithDetailsResponse `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedName, response.Data.Property.HotelName)
+			assert.Len(t, response.Data.Translations, 2)
+
+			switch tt.expectedLanguage {
+			case "fr":
+				assert.Equal(t, "Un hôtel de test", response.Data.Property.Description)
+			case "es":
+				// Fields missing from the translation keep the base language
+				assert.Equal(t, testPropertyData.Property.Description, response.Data.Property.Description)
+			}
+		})
+	}
+}
+
+// Test GetPropertyHandler - Config.DefaultLanguage applies only when the request names no language
+func TestGetPropertyHandler_DefaultLanguage(t *testing.T) {
+	tests := []struct {
+		name             string
+		defaultLanguage  string
+		url              string
+		acceptLanguage   string
+		expectedName     string
+		expectedLanguage string
+	}{
+		{name: "NoLanguageRequested", defaultLanguage: "fr", url: "/api/v1/properties/12345", expectedName: "Hôtel de Test", expectedLanguage: "fr"},
+		{name: "AcceptLanguageWins", defaultLanguage: "fr", url: "/api/v1/properties/12345", acceptLanguage: "es", expectedName: "Hotel de Prueba", expectedLanguage: "es"},
+		{name: "LangParamWins", defaultLanguage: "fr", url: "/api/v1/properties/12345?lang=es", acceptLanguage: "fr", expectedName: "Hotel de Prueba", expectedLanguage: "es"},
+		{name: "LangParamBaseLanguage", defaultLanguage: "fr", url: "/api/v1/properties/12345?lang=en", expectedName: "Test Hotel", expectedLanguage: "en"},
+		{name: "UnmatchedRequestKeepsBase", defaultLanguage: "fr", url: "/api/v1/properties/12345?lang=ja", expectedName: "Test Hotel"},
+		{name: "NoDefault", url: "/api/v1/properties/12345", expectedName: "Test Hotel"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockStorage := new(MockStorage)
+			config := DefaultConfig()
+			config.DefaultLanguage = tt.defaultLanguage
+			router := setupTestRouter(NewHandlersWithConfig(mockStorage, config))
+
+			testPropertyData := createTestPropertyData()
+			testPropertyData.Translations["es"] = &cupid.Property{HotelID: 12345, HotelName: "Hotel de Prueba"}
+			mockStorage.On("GetProperty", mock.Anything, int64(12345)).Return(testPropertyData, nil)
+
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expectedLanguage, w.Header().Get("Content-Language"))
+
+			var response struct {
+				Data PropertyWithDetailsResponse `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedName, response.Data.Property.HotelName)
+		})
+	}
+}
+
+// Test GetPropertyHandler - Property Not Found
+func TestGetPropertyHandler_NotFound(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	mockStorage.On("GetProperty", mock.Anything, int64(99999)).Return(nil, assert.AnError)
+
+	req, _ := http.NewRequest("GET", "/api/v1/properties/99999", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	var response APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.False(t, response.Success)
+	assert.Equal(t, "Failed to fetch property", response.Error)
+
+	mockStorage.AssertExpectations(t)
+}
+
+// Test GetPropertyHandler - Invalid Property ID
+func TestGetPropertyHandler_InvalidID(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	req, _ := http.NewRequest("GET", "/api/v1/properties/invalid", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.False(t, response.Success)
+	assert.Equal(t, "Invalid property ID", response.Error)
+}
+
+// Test paginated list handlers - Offset beyond the configured maximum
+func TestPaginatedHandlers_MaxOffset(t *testing.T) {
+	urls := []string{
+		"/api/v1/properties?page=1000&limit=100",
+		"/api/v1/search?q=paris&page=1000&limit=100",
+		"/api/v1/properties/location?city=Paris&page=1000&limit=100",
+		"/api/v1/properties/postal?prefix=75&page=1000&limit=100",
+		"/api/v1/properties/rating?min_rating=4&page=1000&limit=100",
+		"/api/v1/properties/12345/reviews?page=1000&limit=100",
+	}
+
+	for _, url := range urls {
+		t.Run(url, func(t *testing.T) {
+			// Arrange
+			mockStorage := new(MockStorage)
+			router := setupTestRouter(NewHandlers(mockStorage))
+
+			req, _ := http.NewRequest("GET", url, nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response APIResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.False(t, response.Success)
+			assert.Equal(t, "offset 99900 exceeds the maximum of 10000: narrow the filters or use cursor pagination", response.Error)
+			assert.Empty(t, mockStorage.Calls)
+		})
+	}
+
+	t.Run("WithinConfiguredMaximum", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		config := DefaultConfig()
+		config.MaxOffset = 0
+		router := setupTestRouter(NewHandlersWithConfig(mockStorage, config))
+
+		mockStorage.On("SearchProperties", mock.Anything, "paris", []string(nil), false, 100, 99900).Return([]*cupid.Property{}, nil)
+		mockStorage.On("CountSearchProperties", mock.Anything, "paris", []string(nil), false).Return(0, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/search?q=paris&page=1000&limit=100", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("CursorPaginationIgnoresPage", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		router := setupTestRouter(NewHandlers(mockStorage))
+
+		mockStorage.On("ListPropertiesWithReviewAverages", mock.Anything, 101, 0, mock.Anything).Return([]*store.PropertyWithReviewAverage{}, nil)
+		mockStorage.On("CountProperties", mock.Anything, mock.Anything).Return(0, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties?cursor=&page=1000&limit=100", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockStorage.AssertExpectations(t)
+	})
+}
+
+// Test NotFoundHandler - Unknown paths get the JSON error envelope
+func TestNotFoundHandler(t *testing.T) {
+	for _, path := range []string{"/unknown", "/api/v1/unknown", "/api/v1/properties/12345/unknown"} {
+		t.Run(path, func(t *testing.T) {
+			// Arrange
+			handlers := NewHandlers(new(MockStorage))
+			router := setupTestRouter(handlers)
+			router.NoRoute(handlers.NotFoundHandler)
+
+			req, _ := http.NewRequest("GET", path, nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusNotFound, w.Code)
+			assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+
+			var response APIResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.False(t, response.Success)
+			assert.Equal(t, "not found", response.Error)
+			assert.Equal(t, "NOT_FOUND", response.Code)
+		})
+	}
+}
+
+// Test RootHandler
+func TestRootHandler(t *testing.T) {
+	// Arrange
+	config := DefaultConfig()
+	config.ServiceName = "Test API"
+	handlers := NewHandlersWithConfig(new(MockStorage), config)
+	router := setupTestRouter(handlers)
+	router.GET("/", handlers.RootHandler)
+
+	req, _ := http.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Success bool         `json:"success"`
+		Data    RootResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(t, response.Success)
+	assert.Equal(t, "Test API", response.Data.Name)
+	assert.Equal(t, apiVersion, response.Data.Version)
+	assert.Equal(t, "/api/v1", response.Data.BasePath)
+}
+
+// Test MethodNotAllowedHandler
+func TestMethodNotAllowedHandler(t *testing.T) {
+	t.Run("WrongMethodGets405WithAllowHeader", func(t *testing.T) {
+		// Arrange
+		handlers := NewHandlers(new(MockStorage))
+		router := setupTestRouter(handlers)
+		router.HandleMethodNotAllowed = true
+		router.NoMethod(handlers.MethodNotAllowedHandler)
+
+		req, _ := http.NewRequest("POST", "/api/v1/properties/12345", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+		assert.Equal(t, "GET", w.Header().Get("Allow"))
+
+		var response APIResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.False(t, response.Success)
+		assert.Equal(t, "Method not allowed", response.Error)
+	})
+
+	t.Run("AllowListsEveryMethod", func(t *testing.T) {
+		// Arrange
+		handlers := NewHandlers(new(MockStorage))
+		router := setupTestRouter(handlers)
+		router.HandleMethodNotAllowed = true
+		router.NoMethod(handlers.MethodNotAllowedHandler)
+
+		req, _ := http.NewRequest("PUT", "/api/v1/search", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+		assert.ElementsMatch(t, []string{"GET", "HEAD"}, strings.Split(w.Header().Get("Allow"), ", "))
+	})
+
+	t.Run("UnknownPathStays404", func(t *testing.T) {
+		// Arrange
+		handlers := NewHandlers(new(MockStorage))
+		router := setupTestRouter(handlers)
+		router.HandleMethodNotAllowed = true
+		router.NoMethod(handlers.MethodNotAllowedHandler)
+
+		req, _ := http.NewRequest("POST", "/api/v1/unknown", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Empty(t, w.Header().Get("Allow"))
+	})
+}
+
+// Test GetPropertySummaryHandler - Success Case
+func TestGetPropertySummaryHandler_Success(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	testProperty := createTestProperty()
+	mockStorage.On("GetPropertySummary", mock.Anything, int64(12345)).Return(testProperty, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/properties/12345/summary", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+
+	data, ok := response.Data.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, float64(testProperty.HotelID), data["hotel_id"])
+	assert.Equal(t, testProperty.HotelName, data["hotel_name"])
+	assert.NotContains(t, data, "property")
+	assert.NotContains(t, data, "reviews")
+	assert.NotContains(t, data, "translations")
+
+	// Only the main property is loaded, in a single storage call
+	mockStorage.AssertExpectations(t)
+	assert.Len(t, mockStorage.Calls, 1)
+	mockStorage.AssertNotCalled(t, "GetProperty", mock.Anything, mock.Anything)
+	mockStorage.AssertNotCalled(t, "GetPropertyReviews", mock.Anything, mock.Anything)
+	mockStorage.AssertNotCalled(t, "GetPropertyTranslations", mock.Anything, mock.Anything)
+}
+
+// Test GetPropertySummaryHandler - Error Cases
+func TestGetPropertySummaryHandler_Errors(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		router := setupTestRouter(NewHandlers(mockStorage))
+		mockStorage.On("GetPropertySummary", mock.Anything, int64(99999)).Return(nil, store.ErrPropertyNotFound)
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties/99999/summary", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), "Property not found")
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		router := setupTestRouter(NewHandlers(mockStorage))
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties/invalid/summary", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid property ID")
+		mockStorage.AssertNotCalled(t, "GetPropertySummary", mock.Anything, mock.Anything)
+	})
+}
+
+// Test SearchPropertiesHandler - Success Case
+func TestSearchPropertiesHandler_Success(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	testProperties := []*cupid.Property{createTestProperty()}
+	searchQuery := "London"
+
+	mockStorage.On("SearchProperties", mock.Anything, searchQuery, []string(nil), false, 20, 0).Return(testProperties, nil)
+	mockStorage.On("CountSearchProperties", mock.Anything, searchQuery, []string(nil), false).Return(1, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/search?q=London&limit=20&page=1", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.NotNil(t, response.Data)
+	assert.NotNil(t, response.Meta)
+
+	// Verify search results
+	properties, ok := response.Data.([]interface{})
+	assert.True(t, ok)
+	assert.Len(t, properties, 1)
+
+	mockStorage.AssertExpectations(t)
+}
+
+// Test SearchPropertiesHandler - Fields
+func TestSearchPropertiesHandler_Fields(t *testing.T) {
+	t.Run("RestrictsSearchedFields", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		fields := []string{"address", "description"}
+		mockStorage.On("SearchProperties", mock.Anything, "beach", fields, false, 20, 0).Return([]*cupid.Property{createTestProperty()}, nil)
+		mockStorage.On("CountSearchProperties", mock.Anything, "beach", fields, false).Return(1, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/search?q=beach&fields=address,description", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("UnsearchableField", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		req, _ := http.NewRequest("GET", "/api/v1/search?q=beach&fields=phone", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockStorage.AssertNotCalled(t, "SearchProperties", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+// Test SearchPropertiesHandler - Fuzzy
+func TestSearchPropertiesHandler_Fuzzy(t *testing.T) {
+	t.Run("PassesFuzzyToStorage", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		mockStorage.On("SearchProperties", mock.Anything, "hiltn", []string(nil), true, 20, 0).Return([]*cupid.Property{createTestProperty()}, nil)
+		mockStorage.On("CountSearchProperties", mock.Anything, "hiltn", []string(nil), true).Return(1, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/search?q=hiltn&fuzzy=true", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("HeadPassesFuzzyToStorage", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		mockStorage.On("CountSearchProperties", mock.Anything, "hiltn", []string(nil), true).Return(3, nil)
+
+		req, _ := http.NewRequest("HEAD", "/api/v1/search?q=hiltn&fuzzy=true", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "3", w.Header().Get(TotalCountHeader))
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("InvalidFuzzy", func(t *testing.T) {
+		for _, method := range []string{"GET", "HEAD"} {
+			// Arrange
+			mockStorage := new(MockStorage)
+			handlers := NewHandlers(mockStorage)
+			router := setupTestRouter(handlers)
+
+			req, _ := http.NewRequest(method, "/api/v1/search?q=hiltn&fuzzy=maybe", nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code, method)
+			mockStorage.AssertNotCalled(t, "CountSearchProperties", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+}
+
+// Test SearchPropertiesHandler - Missing Query Parameter
+func TestSearchPropertiesHandler_MissingQuery(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	req, _ := http.NewRequest("GET", "/api/v1/search", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.False(t, response.Success)
+	assert.Contains(t, response.Error, "Invalid query parameters")
+}
+
+// Test SearchPropertiesHeadHandler - Results Found
+func TestSearchPropertiesHeadHandler_Results(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	mockStorage.On("CountSearchProperties", mock.Anything, "London", []string(nil), false).Return(42, nil)
+
+	req, _ := http.NewRequest("HEAD", "/api/v1/search?q=London", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "42", w.Header().Get(TotalCountHeader))
+	assert.Empty(t, w.Body.String())
+	mockStorage.AssertNotCalled(t, "SearchProperties", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockStorage.AssertExpectations(t)
+}
+
+// Test SearchPropertiesHeadHandler - No Results
+func TestSearchPropertiesHeadHandler_NoResults(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	mockStorage.On("CountSearchProperties", mock.Anything, "Atlantis", []string(nil), false).Return(0, nil)
+
+	req, _ := http.NewRequest("HEAD", "/api/v1/search?q=Atlantis", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "0", w.Header().Get(TotalCountHeader))
+	assert.Empty(t, w.Body.String())
+	mockStorage.AssertExpectations(t)
+}
+
+// Test SearchPropertiesHeadHandler - Missing Query
+func TestSearchPropertiesHeadHandler_MissingQuery(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	req, _ := http.NewRequest("HEAD", "/api/v1/search", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockStorage.AssertNotCalled(t, "CountSearchProperties", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test ListFacilitiesHandler - Success Case
+func TestListFacilitiesHandler_Success(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	testFacilities := []store.FacilitySummary{
+		{FacilityID: 47, Name: "WiFi", PropertyCount: 120},
+		{FacilityID: 5, Name: "Pool", PropertyCount: 30},
+	}
+	mockStorage.On("ListFacilities", mock.Anything).Return(testFacilities, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/facilities", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+
+	facilities, ok := response.Data.([]interface{})
+	assert.True(t, ok)
+	assert.Len(t, facilities, 2)
+	first := facilities[0].(map[string]interface{})
+	assert.Equal(t, "WiFi", first["name"])
+	assert.Equal(t, float64(120), first["property_count"])
+
+	mockStorage.AssertExpectations(t)
+}
+
+// Test ListFacilitiesHandler - Database Error
+func TestListFacilitiesHandler_DatabaseError(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	mockStorage.On("ListFacilities", mock.Anything).Return(nil, assert.AnError)
+
+	req, _ := http.NewRequest("GET", "/api/v1/facilities", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	mockStorage.AssertExpectations(t)
+}
+
+// Test ListRoomAmenitiesHandler
+func TestListRoomAmenitiesHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		router := setupTestRouter(NewHandlers(mockStorage))
+
+		mockStorage.On("ListRoomAmenities", mock.Anything).Return([]store.RoomAmenitySummary{
+			{AmenityID: 30, Name: "Minibar", PropertyCount: 80},
+			{AmenityID: 12, Name: "Balcony", PropertyCount: 25},
+		}, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/room-amenities", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Success bool                  `json:"success"`
+			Data    []RoomAmenityResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.True(t, response.Success)
+		require.Len(t, response.Data, 2)
+		assert.Equal(t, RoomAmenityResponse{AmenityID: 12, Name: "Balcony", PropertyCount: 25}, response.Data[1])
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("DatabaseError", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		router := setupTestRouter(NewHandlers(mockStorage))
+
+		mockStorage.On("ListRoomAmenities", mock.Anything).Return(nil, assert.AnError)
+
+		req, _ := http.NewRequest("GET", "/api/v1/room-amenities", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "Failed to fetch room amenities")
+		mockStorage.AssertExpectations(t)
+	})
+}
+
+// Test ListChainsHandler
+func TestListChainsHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		router := setupTestRouter(NewHandlers(mockStorage))
+
+		mockStorage.On("ListChains", mock.Anything).Return([]store.ChainSummary{
+			{Chain: "Accor", PropertyCount: 42},
+			{Chain: "Hilton", PropertyCount: 7},
+		}, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/chains", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Success bool            `json:"success"`
+			Data    []ChainResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.True(t, response.Success)
+		require.Len(t, response.Data, 2)
+		assert.Equal(t, ChainResponse{Chain: "Hilton", PropertyCount: 7}, response.Data[1])
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("DatabaseError", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		router := setupTestRouter(NewHandlers(mockStorage))
+
+		mockStorage.On("ListChains", mock.Anything).Return(nil, assert.AnError)
+
+		req, _ := http.NewRequest("GET", "/api/v1/chains", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "Failed to fetch chains")
+		mockStorage.AssertExpectations(t)
+	})
+}
+
+// Test GetAverageRatingByStarsHandler
+func TestGetAverageRatingByStarsHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		router := setupTestRouter(NewHandlers(mockStorage))
+
+		mockStorage.On("GetAverageRatingByStars", mock.Anything).Return([]store.StarRatingAverage{
+			{Stars: 3, AverageRating: 7.5, PropertyCount: 2},
+			{Stars: 5, AverageRating: 9.0, PropertyCount: 3},
+		}, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/stats/rating-by-stars", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response APIResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.True(t, response.Success)
+
+		averages, ok := response.Data.([]interface{})
+		require.True(t, ok)
+		require.Len(t, averages, 2)
+		first := averages[0].(map[string]interface{})
+		assert.Equal(t, float64(3), first["stars"])
+		assert.Equal(t, 7.5, first["average_rating"])
+		assert.Equal(t, float64(2), first["property_count"])
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("DatabaseError", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		router := setupTestRouter(NewHandlers(mockStorage))
+
+		mockStorage.On("GetAverageRatingByStars", mock.Anything).Return(nil, assert.AnError)
+
+		req, _ := http.NewRequest("GET", "/api/v1/stats/rating-by-stars", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		mockStorage.AssertExpectations(t)
+	})
+}
+
+// Test DeletePropertyHandler
+func TestDeletePropertyHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		storageErr   error
+		callsStorage bool
+		expectedCode int
+	}{
+		{name: "Deleted", path: "/api/v1/admin/properties/12345", callsStorage: true, expectedCode: http.StatusOK},
+		{name: "Not found", path: "/api/v1/admin/properties/12345", storageErr: store.ErrPropertyNotFound, callsStorage: true, expectedCode: http.StatusNotFound},
+		{name: "Database error", path: "/api/v1/admin/properties/12345", storageErr: assert.AnError, callsStorage: true, expectedCode: http.StatusInternalServerError},
+		{name: "Invalid ID", path: "/api/v1/admin/properties/abc", expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockStorage := new(MockStorage)
+			handlers := NewHandlers(mockStorage)
+			router := setupTestRouter(handlers)
+
+			if tt.callsStorage {
+				mockStorage.On("DeleteProperty", mock.Anything, int64(12345)).Return(tt.storageErr)
+			}
+
+			req, _ := http.NewRequest("DELETE", tt.path, nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			var response APIResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode == http.StatusOK, response.Success)
+
+			if tt.callsStorage {
+				mockStorage.AssertExpectations(t)
+			} else {
+				mockStorage.AssertNotCalled(t, "DeleteProperty", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+// Test GetRawPropertyHandler
+func TestGetRawPropertyHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		raw          json.RawMessage
+		storageErr   error
+		callsStorage bool
+		expectedCode int
+	}{
+		{name: "Captured", path: "/api/v1/admin/properties/12345/raw", raw: json.RawMessage(`{"hotel_id":12345,"stars":4}`), callsStorage: true, expectedCode: http.StatusOK},
+		{name: "Not captured", path: "/api/v1/admin/properties/12345/raw", storageErr: store.ErrRawPropertyNotCaptured, callsStorage: true, expectedCode: http.StatusNotFound},
+		{name: "Not found", path: "/api/v1/admin/properties/12345/raw", storageErr: store.ErrPropertyNotFound, callsStorage: true, expectedCode: http.StatusNotFound},
+		{name: "Database error", path: "/api/v1/admin/properties/12345/raw", storageErr: assert.AnError, callsStorage: true, expectedCode: http.StatusInternalServerError},
+		{name: "Invalid ID", path: "/api/v1/admin/properties/abc/raw", expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockStorage := new(MockStorage)
+			handlers := NewHandlers(mockStorage)
+			router := setupTestRouter(handlers)
+
+			if tt.callsStorage {
+				if tt.storageErr != nil {
+					mockStorage.On("GetRawProperty", mock.Anything, int64(12345)).Return(nil, tt.storageErr)
+				} else {
+					mockStorage.On("GetRawProperty", mock.Anything, int64(12345)).Return(tt.raw, nil)
+				}
+			}
+
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			var response struct {
+				Success bool            `json:"success"`
+				Data    json.RawMessage `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCode == http.StatusOK, response.Success)
+			if tt.raw != nil {
+				assert.JSONEq(t, string(tt.raw), string(response.Data))
+			}
+
+			if tt.callsStorage {
+				mockStorage.AssertExpectations(t)
+			} else {
+				mockStorage.AssertNotCalled(t, "GetRawProperty", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+// Test UpdatePropertyOverridesHandler
+func TestUpdatePropertyOverridesHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		body         string
+		storageErr   error
+		callsStorage bool
+		expectedCode int
+	}{
+		{name: "Updated", path: "/api/v1/admin/properties/12345", body: `{"hotel_name":"Corrected Name","description":null}`, callsStorage: true, expectedCode: http.StatusOK},
+		{name: "Invalid field", path: "/api/v1/admin/properties/12345", body: `{"rating":"10"}`, storageErr: fmt.Errorf("%w: rating", store.ErrInvalidOverrideField), callsStorage: true, expectedCode: http.StatusBadRequest},
+		{name: "Not found", path: "/api/v1/admin/properties/12345", body: `{"hotel_name":"Corrected Name"}`, storageErr: store.ErrPropertyNotFound, callsStorage: true, expectedCode: http.StatusNotFound},
+		{name: "Database error", path: "/api/v1/admin/properties/12345", body: `{"hotel_name":"Corrected Name"}`, storageErr: assert.AnError, callsStorage: true, expectedCode: http.StatusInternalServerError},
+		{name: "Empty body", path: "/api/v1/admin/properties/12345", body: `{}`, expectedCode: http.StatusBadRequest},
+		{name: "Malformed body", path: "/api/v1/admin/properties/12345", body: `["hotel_name"]`, expectedCode: http.StatusBadRequest},
+		{name: "Invalid ID", path: "/api/v1/admin/properties/abc", body: `{"hotel_name":"Corrected Name"}`, expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockStorage := new(MockStorage)
+			handlers := NewHandlers(mockStorage)
+			router := setupTestRouter(handlers)
+
+			if tt.callsStorage {
+				mockStorage.On("SetPropertyOverrides", mock.Anything, int64(12345), mock.Anything).Return(tt.storageErr)
+			}
+			if tt.expectedCode == http.StatusOK {
+				mockStorage.On("GetPropertyOverrides", mock.Anything, int64(12345)).
+					Return(map[string]string{"hotel_name": "Corrected Name"}, nil)
+			}
+
+			req, _ := http.NewRequest("PATCH", tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			var response APIResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode == http.StatusOK, response.Success)
+
+			if tt.callsStorage {
+				mockStorage.AssertExpectations(t)
+			} else {
+				mockStorage.AssertNotCalled(t, "SetPropertyOverrides", mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tt.expectedCode == http.StatusOK {
+				data := response.Data.(map[string]interface{})
+				assert.Equal(t, float64(12345), data["hotel_id"])
+				assert.Equal(t, map[string]interface{}{"hotel_name": "Corrected Name"}, data["overrides"])
+			}
+		})
+	}
+
+	t.Run("Passes null values as removals", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		mockStorage.On("SetPropertyOverrides", mock.Anything, int64(12345), mock.MatchedBy(func(overrides map[string]*string) bool {
+			return len(overrides) == 2 && overrides["description"] == nil && *overrides["hotel_name"] == "Corrected Name"
+		})).Return(nil)
+		mockStorage.On("GetPropertyOverrides", mock.Anything, int64(12345)).Return(map[string]string{}, nil)
+
+		req, _ := http.NewRequest("PATCH", "/api/v1/admin/properties/12345", strings.NewReader(`{"hotel_name":"Corrected Name","description":null}`))
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockStorage.AssertExpectations(t)
+	})
+}
+
+// Test storageErrorStatus
+func TestStorageErrorStatus(t *testing.T) {
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		err          error
+		expectedCode int
+	}{
+		{"Deadline exceeded", context.Background(), fmt.Errorf("query failed: %w", context.DeadlineExceeded), http.StatusGatewayTimeout},
+		{"Request deadline passed", expired, errors.New("pq: canceling statement due to user request"), http.StatusGatewayTimeout},
+		{"No rows", context.Background(), fmt.Errorf("scan failed: %w", sql.ErrNoRows), http.StatusNotFound},
+		{"Other error", context.Background(), assert.AnError, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request, _ = http.NewRequestWithContext(tt.ctx, "GET", "/", nil)
+
+			// Act
+			code := storageErrorStatus(c, tt.err)
+
+			// Assert
+			assert.Equal(t, tt.expectedCode, code)
+		})
+	}
+}
+
+// Test GetPropertySyncHistoryHandler
+func TestGetPropertySyncHistoryHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		syncedAt := time.Date(2025, 9, 11, 12, 0, 0, 0, time.UTC)
+		history := []store.PropertySyncEntry{
+			{SyncedAt: syncedAt, Changes: []string{"property", "reviews"}},
+			{SyncedAt: syncedAt.Add(-12 * time.Hour), Changes: []string{}},
+		}
+		mockStorage.On("GetPropertySyncHistory", mock.Anything, int64(12345), 5).Return(history, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/admin/properties/12345/sync-history?limit=5", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Success bool                       `json:"success"`
+			Data    []SyncHistoryEntryResponse `json:"data"`
+		}
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.True(t, response.Success)
+		require.Len(t, response.Data, 2)
+		assert.True(t, response.Data[0].Changed)
+		assert.Equal(t, []string{"property", "reviews"}, response.Data[0].Changes)
+		assert.True(t, response.Data[0].SyncedAt.Equal(syncedAt))
+		assert.False(t, response.Data[1].Changed)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("Oversized limit is clamped", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		mockStorage.On("GetPropertySyncHistory", mock.Anything, int64(12345), 100).Return([]store.PropertySyncEntry(nil), nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/admin/properties/12345/sync-history?limit=500", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"success":true,"data":[]}`, w.Body.String())
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		req, _ := http.NewRequest("GET", "/api/v1/admin/properties/abc/sync-history", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockStorage.AssertNotCalled(t, "GetPropertySyncHistory", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Database error", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		mockStorage.On("GetPropertySyncHistory", mock.Anything, int64(12345), 20).Return(nil, assert.AnError)
+
+		req, _ := http.NewRequest("GET", "/api/v1/admin/properties/12345/sync-history", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		mockStorage.AssertExpectations(t)
+	})
+}
+
+// TestGetStalePropertiesHandler tests listing properties not synced within a threshold
+func TestGetStalePropertiesHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		lastSyncedAt := time.Date(2025, 9, 11, 6, 0, 0, 0, time.UTC)
+		property := createTestProperty()
+		property.LastSyncedAt = &lastSyncedAt
+		mockStorage.On("GetStaleProperties", mock.Anything, 6*time.Hour, 5).Return([]*cupid.Property{property}, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/admin/properties/stale?older_than=6h&limit=5", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Success bool               `json:"success"`
+			Data    []PropertyResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+		require.NotNil(t, response.Data[0].LastSyncedAt)
+		assert.True(t, response.Data[0].LastSyncedAt.Equal(lastSyncedAt))
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("Default threshold", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		mockStorage.On("GetStaleProperties", mock.Anything, 12*time.Hour, 20).Return([]*cupid.Property{}, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/admin/properties/stale", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"success":true,"data":[]}`, w.Body.String())
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("Invalid threshold", func(t *testing.T) {
+		for _, olderThan := range []string{"abc", "-1h", "0s"} {
+			// Arrange
+			mockStorage := new(MockStorage)
+			handlers := NewHandlers(mockStorage)
+			router := setupTestRouter(handlers)
+
+			req, _ := http.NewRequest("GET", "/api/v1/admin/properties/stale?older_than="+olderThan, nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code, olderThan)
+			mockStorage.AssertNotCalled(t, "GetStaleProperties", mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+}
+
+// Test PurgeOrphansHandler - Success Case
+func TestPurgeOrphansHandler_Success(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	result := &store.OrphanPurgeResult{Reviews: 3, Translations: 1}
+	mockStorage.On("PurgeOrphans", mock.Anything).Return(result, nil)
+
+	req, _ := http.NewRequest("POST", "/api/v1/admin/maintenance/purge-orphans", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+
+	counts, ok := response.Data.(map[string]interface{})
+	assert.True(t, ok)
+	assert.Equal(t, float64(3), counts["reviews"])
+	assert.Equal(t, float64(1), counts["translations"])
+	assert.Equal(t, float64(0), counts["property_facilities"])
+
+	mockStorage.AssertExpectations(t)
+}
+
+// Test PurgeOrphansHandler - Database Error
+func TestPurgeOrphansHandler_DatabaseError(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	mockStorage.On("PurgeOrphans", mock.Anything).Return(nil, assert.AnError)
+
+	req, _ := http.NewRequest("POST", "/api/v1/admin/maintenance/purge-orphans", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	mockStorage.AssertExpectations(t)
+}
+
+// Test PruneReviewsHandler - Success Case
+func TestPruneReviewsHandler_Success(t *testing.T) {
+	t.Run("ExplicitCutoff", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		before := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockStorage.On("DeletePropertyReviewsOlderThan", mock.Anything, before).Return(int64(12), nil)
+
+		req, _ := http.NewRequest("POST", "/api/v1/admin/maintenance/prune-reviews?before=2024-01-01", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response APIResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.True(t, response.Success)
+
+		data, ok := response.Data.(map[string]interface{})
+		assert.True(t, ok)
+		assert.Equal(t, "2024-01-01", data["before"])
+		assert.Equal(t, float64(12), data["deleted"])
+
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("DefaultsToTwoYearsAgo", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		expected := time.Now().UTC().AddDate(-2, 0, 0)
+		mockStorage.On("DeletePropertyReviewsOlderThan", mock.Anything, mock.MatchedBy(func(cutoff time.Time) bool {
+			return cutoff.Sub(expected).Abs() < time.Minute
+		})).Return(int64(0), nil)
+
+		req, _ := http.NewRequest("POST", "/api/v1/admin/maintenance/prune-reviews", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockStorage.AssertExpectations(t)
+	})
+}
+
+// Test PruneReviewsHandler - Invalid Cutoff
+func TestPruneReviewsHandler_InvalidBefore(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	req, _ := http.NewRequest("POST", "/api/v1/admin/maintenance/prune-reviews?before=last-year", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "before must be an RFC 3339 time or a YYYY-MM-DD date")
+	mockStorage.AssertNotCalled(t, "DeletePropertyReviewsOlderThan", mock.Anything, mock.Anything)
+}
+
+// Test PruneReviewsHandler - Database Error
+func TestPruneReviewsHandler_DatabaseError(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	mockStorage.On("DeletePropertyReviewsOlderThan", mock.Anything, mock.Anything).Return(int64(0), assert.AnError)
+
+	req, _ := http.NewRequest("POST", "/api/v1/admin/maintenance/prune-reviews?before=2024-01-01", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	mockStorage.AssertExpectations(t)
+}
+
+// Test GetDuplicateCoordinatesHandler - Success Case
+func TestGetDuplicateCoordinatesHandler_Success(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	clusters := []store.CoordinateCluster{{
+		Latitude:  48.8566,
+		Longitude: 2.3522,
+		Properties: []*cupid.Property{
+			{HotelID: 1, HotelName: "Hotel Paris"},
+			{HotelID: 2, HotelName: "Hotel Paris (copy)"},
+		},
+	}}
+	mockStorage.On("GetPropertiesWithDuplicateCoordinates", mock.Anything).Return(clusters, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/admin/maintenance/duplicate-coordinates", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Success bool                        `json:"success"`
+		Data    []CoordinateClusterResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(t, response.Success)
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, 48.8566, response.Data[0].Latitude)
+	require.Len(t, response.Data[0].Properties, 2)
+	assert.Equal(t, int64(2), response.Data[0].Properties[1].HotelID)
+
+	mockStorage.AssertExpectations(t)
+}
+
+// Test GetDuplicateCoordinatesHandler - Database Error
+func TestGetDuplicateCoordinatesHandler_DatabaseError(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	mockStorage.On("GetPropertiesWithDuplicateCoordinates", mock.Anything).Return(nil, assert.AnError)
+
+	req, _ := http.NewRequest("GET", "/api/v1/admin/maintenance/duplicate-coordinates", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "Failed to find duplicate coordinates")
+	mockStorage.AssertExpectations(t)
+}
+
+// Test GetChainConflictsHandler - Success Case
+func TestGetChainConflictsHandler_Success(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	conflicts := []store.ChainConflict{{
+		Field:   store.ChainConflictID,
+		ChainID: 20,
+		Properties: []*cupid.Property{
+			{HotelID: 2, Chain: "Hilton", ChainID: 20},
+			{HotelID: 3, Chain: "Hilton Hotels", ChainID: 20},
+		},
+	}}
+	mockStorage.On("GetPropertiesWithConflictingChains", mock.Anything).Return(conflicts, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/admin/maintenance/chain-conflicts", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Success bool                    `json:"success"`
+		Data    []ChainConflictResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.True(t, response.Success)
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, "chain_id", response.Data[0].Field)
+	assert.Equal(t, 20, response.Data[0].ChainID)
+	require.Len(t, response.Data[0].Properties, 2)
+	assert.Equal(t, "Hilton Hotels", response.Data[0].Properties[1].Chain)
+
+	mockStorage.AssertExpectations(t)
+}
+
+// Test GetChainConflictsHandler - Database Error
+func TestGetChainConflictsHandler_DatabaseError(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	mockStorage.On("GetPropertiesWithConflictingChains", mock.Anything).Return(nil, assert.AnError)
+
+	req, _ := http.NewRequest("GET", "/api/v1/admin/maintenance/chain-conflicts", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "Failed to find conflicting chains")
+	mockStorage.AssertExpectations(t)
+}
+
+// Test GetPropertiesByRatingHandler - Success Case
+func TestGetPropertiesByRatingHandler_Success(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	testProperties := []*cupid.Property{createTestProperty()}
+	minRating := 9.0
+
+	mockStorage.On("GetPropertiesByRating", mock.Anything, minRating, 20, 0).Return(testProperties, nil)
+	mockStorage.On("CountPropertiesByRating", mock.Anything, minRating).Return(1, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/properties/rating?min_rating=9.0&limit=20&page=1", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response APIResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.NotNil(t, response.Data)
+	assert.NotNil(t, response.Meta)
 
 	mockStorage.AssertExpectations(t)
 }
 
-// Test ListPropertiesHandler - Database Error
-func TestListPropertiesHandler_DatabaseError(t *testing.T) {
+// Test GetBestPropertiesHandler - properties are returned in quality score order with their score
+func TestGetBestPropertiesHandler(t *testing.T) {
 	// Arrange
 	mockStorage := new(MockStorage)
 	handlers := NewHandlers(mockStorage)
 	router := setupTestRouter(handlers)
 
-	testFilters := store.PropertyFilters{}
-
-	mockStorage.On("ListProperties", mock.Anything, 20, 0, testFilters).Return(nil, assert.AnError)
+	first, second := createTestProperty(), createTestProperty()
+	second.HotelID = 67890
+	mockStorage.On("GetPropertiesByQualityScore", mock.Anything, 2, 2).Return([]*store.PropertyWithQualityScore{
+		{Property: first, QualityScore: 55.9},
+		{Property: second, QualityScore: 13.5},
+	}, nil)
+	mockStorage.On("CountProperties", mock.Anything, store.PropertyFilters{}).Return(5, nil)
 
-	req, _ := http.NewRequest("GET", "/api/v1/properties", nil)
+	req, _ := http.NewRequest("GET", "/api/v1/properties/best?page=2&limit=2", nil)
 	w := httptest.NewRecorder()
 
 	// Act
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, http.StatusOK, w.Code)
 
-	var response APIResponse
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	assert.NoError(t, err)
-	assert.False(t, response.Success)
-	assert.Equal(t, "Failed to fetch properties", response.Error)
+	var response struct {
+		Data []PropertyResponse `json:"data"`
+		Meta Meta               `json:"meta"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Data, 2)
+	assert.Equal(t, first.HotelID, response.Data[0].HotelID)
+	require.NotNil(t, response.Data[0].QualityScore)
+	assert.Equal(t, 55.9, *response.Data[0].QualityScore)
+	assert.Equal(t, second.HotelID, response.Data[1].HotelID)
+	assert.Equal(t, 3, response.Meta.TotalPages)
+	assert.True(t, response.Meta.HasNext)
 
 	mockStorage.AssertExpectations(t)
 }
 
-// Test ListPropertiesHandler - Invalid Query Parameters
-func TestListPropertiesHandler_InvalidQueryParams(t *testing.T) {
+// Test ImportPropertyReviewsHandler - valid reviews are stored and invalid ones reported
+func TestImportPropertyReviewsHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		router := setupTestRouter(NewHandlers(mockStorage))
+
+		valid := []cupid.Review{
+			{ReviewID: 1, AverageScore: 8, Name: "John", Date: "2021-06-01"},
+			{ReviewID: 3, AverageScore: 10, Name: "Ana", Date: "2021-06-03 10:30:00"},
+		}
+		mockStorage.On("ImportReviews", mock.Anything, int64(12345), valid).
+			Return(&store.ReviewImportResult{Inserted: 1, Updated: 1}, nil)
+
+		body := `[
+			{"review_id": 1, "average_score": 8, "name": "John", "date": "2021-06-01"},
+			{"review_id": 2, "average_score": 11, "name": "Max", "date": "2021-06-02"},
+			{"review_id": 3, "average_score": 10, "name": "Ana", "date": "2021-06-03 10:30:00"},
+			{"review_id": 0, "average_score": 7, "date": "2021-06-04"},
+			{"review_id": 5, "average_score": 7, "date": "last summer"}
+		]`
+		req, _ := http.NewRequest("POST", "/api/v1/admin/properties/12345/reviews/import", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data ReviewImportResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, int64(12345), response.Data.HotelID)
+		assert.Equal(t, 1, response.Data.Inserted)
+		assert.Equal(t, 1, response.Data.Updated)
+		assert.Equal(t, 3, response.Data.Rejected)
+		assert.Equal(t, []ReviewRejectionResponse{
+			{Index: 1, ReviewID: 2, Reason: "average_score 11 outside 1-10"},
+			{Index: 3, ReviewID: 0, Reason: "review_id must be positive"},
+			{Index: 4, ReviewID: 5, Reason: `date "last summer" is not a recognized date`},
+		}, response.Data.Rejections)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("InvalidBody", func(t *testing.T) {
+		for _, body := range []string{`[]`, `{"review_id": 1}`, `not json`} {
+			// Arrange
+			mockStorage := new(MockStorage)
+			router := setupTestRouter(NewHandlers(mockStorage))
+
+			req, _ := http.NewRequest("POST", "/api/v1/admin/properties/12345/reviews/import", strings.NewReader(body))
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code, body)
+			mockStorage.AssertNotCalled(t, "ImportReviews", mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("PropertyNotFound", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		router := setupTestRouter(NewHandlers(mockStorage))
+
+		mockStorage.On("ImportReviews", mock.Anything, int64(99999), mock.Anything).Return(nil, store.ErrPropertyNotFound)
+
+		body := `[{"review_id": 1, "average_score": 8, "date": "2021-06-01"}]`
+		req, _ := http.NewRequest("POST", "/api/v1/admin/properties/99999/reviews/import", strings.NewReader(body))
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+
+		var response APIResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, errorCodeNotFound, response.Code)
+		mockStorage.AssertExpectations(t)
+	})
+}
+
+// Test GetTopRatedByCityHandler - the best property of each city is returned in storage order
+func TestGetTopRatedByCityHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		paris, lyon := createTestProperty(), createTestProperty()
+		lyon.HotelID = 67890
+		lyon.Address.City = "Lyon"
+		mockStorage.On("GetTopRatedByCity", mock.Anything, 5).Return([]*cupid.Property{paris, lyon}, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties/top-by-city?limit=5", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data []PropertyResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 2)
+		assert.Equal(t, paris.HotelID, response.Data[0].HotelID)
+		assert.Equal(t, lyon.HotelID, response.Data[1].HotelID)
+
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("StorageError", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		mockStorage.On("GetTopRatedByCity", mock.Anything, defaultPageSize).Return(nil, errors.New("database error"))
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties/top-by-city", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		mockStorage.AssertExpectations(t)
+	})
+}
+
+// Test GetNearbyPropertiesHandler
+func TestGetNearbyPropertiesHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		neighbor := createTestProperty()
+		neighbor.HotelID = 67890
+		nearby := []*store.PropertyWithDistance{{Property: neighbor, DistanceKm: 1.25}}
+		mockStorage.On("GetNearbyProperties", mock.Anything, int64(12345), 2.5, 10).Return(nearby, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties/12345/nearby?radius=2.5&limit=10", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data []PropertyResponse `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 1)
+		assert.Equal(t, int64(67890), response.Data[0].HotelID)
+		require.NotNil(t, response.Data[0].DistanceKm)
+		assert.Equal(t, 1.25, *response.Data[0].DistanceKm)
+
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("DefaultRadius", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		mockStorage.On("GetNearbyProperties", mock.Anything, int64(12345), defaultNearbyRadiusKm, defaultPageSize).
+			Return([]*store.PropertyWithDistance{}, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties/12345/nearby", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("InvalidRadius", func(t *testing.T) {
+		for _, radius := range []string{"abc", "0", "-1", "150"} {
+			// Arrange
+			mockStorage := new(MockStorage)
+			handlers := NewHandlers(mockStorage)
+			router := setupTestRouter(handlers)
+
+			req, _ := http.NewRequest("GET", "/api/v1/properties/12345/nearby?radius="+radius, nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code, radius)
+			mockStorage.AssertNotCalled(t, "GetNearbyProperties", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties/abc/nearby", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		mockStorage.On("GetNearbyProperties", mock.Anything, int64(99999), defaultNearbyRadiusKm, defaultPageSize).
+			Return(nil, store.ErrPropertyNotFound)
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties/99999/nearby", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("StorageError", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := setupTestRouter(handlers)
+
+		mockStorage.On("GetNearbyProperties", mock.Anything, int64(12345), defaultNearbyRadiusKm, defaultPageSize).
+			Return(nil, errors.New("database error"))
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties/12345/nearby", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		mockStorage.AssertExpectations(t)
+	})
+}
+
+// Test GetPropertiesByRatingHandler - Missing Rating Parameter
+func TestGetPropertiesByRatingHandler_MissingRating(t *testing.T) {
 	// Arrange
 	mockStorage := new(MockStorage)
 	handlers := NewHandlers(mockStorage)
 	router := setupTestRouter(handlers)
 
-	req, _ := http.NewRequest("GET", "/api/v1/properties?limit=invalid", nil)
+	req, _ := http.NewRequest("GET", "/api/v1/properties/rating", nil)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -327,109 +3416,156 @@ func TestListPropertiesHandler_InvalidQueryParams(t *testing.T) {
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
 	assert.False(t, response.Success)
-	assert.Contains(t, response.Error, "Invalid query parameters")
+	assert.Equal(t, "min_rating parameter is required", response.Error)
 }
 
-// Test GetPropertyHandler - Success Case
-func TestGetPropertyHandler_Success(t *testing.T) {
+// Test GetPropertiesByRatingHandler - Invalid Rating Parameter
+func TestGetPropertiesByRatingHandler_InvalidRating(t *testing.T) {
 	// Arrange
 	mockStorage := new(MockStorage)
 	handlers := NewHandlers(mockStorage)
 	router := setupTestRouter(handlers)
 
-	testPropertyData := createTestPropertyData()
-
-	mockStorage.On("GetProperty", mock.Anything, int64(12345)).Return(testPropertyData, nil)
-
-	req, _ := http.NewRequest("GET", "/api/v1/properties/12345", nil)
+	req, _ := http.NewRequest("GET", "/api/v1/properties/rating?min_rating=invalid", nil)
 	w := httptest.NewRecorder()
 
 	// Act
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 
 	var response APIResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.True(t, response.Success)
-	assert.NotNil(t, response.Data)
-
-	// Verify property with details structure
-	propertyData, ok := response.Data.(map[string]interface{})
-	assert.True(t, ok)
-	assert.NotNil(t, propertyData["property"])
-	assert.NotNil(t, propertyData["reviews"])
-	assert.NotNil(t, propertyData["translations"])
-
-	mockStorage.AssertExpectations(t)
+	assert.False(t, response.Success)
+	assert.Equal(t, "Invalid min_rating parameter", response.Error)
 }
 
-// Test GetPropertyHandler - Property Not Found
-func TestGetPropertyHandler_NotFound(t *testing.T) {
+// Test GetPropertiesByLocationHandler - Success Case
+func TestGetPropertiesByLocationHandler_Success(t *testing.T) {
 	// Arrange
 	mockStorage := new(MockStorage)
 	handlers := NewHandlers(mockStorage)
 	router := setupTestRouter(handlers)
 
-	mockStorage.On("GetProperty", mock.Anything, int64(99999)).Return(nil, assert.AnError)
+	testProperties := []*cupid.Property{createTestProperty()}
+	city := "London"
+	country := "gb"
 
-	req, _ := http.NewRequest("GET", "/api/v1/properties/99999", nil)
+	mockStorage.On("GetPropertiesByLocation", mock.Anything, city, country, 20, 0).Return(testProperties, nil)
+	mockStorage.On("CountPropertiesByLocation", mock.Anything, city, country).Return(1, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/properties/location?city=London&country=gb&limit=20&page=1", nil)
 	w := httptest.NewRecorder()
 
 	// Act
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, http.StatusOK, w.Code)
 
 	var response APIResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.False(t, response.Success)
-	assert.Equal(t, "Failed to fetch property", response.Error)
+	assert.True(t, response.Success)
+	assert.NotNil(t, response.Data)
+	assert.NotNil(t, response.Meta)
 
 	mockStorage.AssertExpectations(t)
 }
 
-// Test GetPropertyHandler - Invalid Property ID
-func TestGetPropertyHandler_InvalidID(t *testing.T) {
+// Test GetPropertiesByPostalCodeHandler - Success Case
+func TestGetPropertiesByPostalCodeHandler_Success(t *testing.T) {
 	// Arrange
 	mockStorage := new(MockStorage)
 	handlers := NewHandlers(mockStorage)
 	router := setupTestRouter(handlers)
 
-	req, _ := http.NewRequest("GET", "/api/v1/properties/invalid", nil)
+	testProperties := []*cupid.Property{createTestProperty()}
+
+	mockStorage.On("GetPropertiesByPostalCodePrefix", mock.Anything, "SW1", 10, 10).Return(testProperties, nil)
+	mockStorage.On("CountPropertiesByPostalCodePrefix", mock.Anything, "SW1").Return(11, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/properties/postal?prefix=SW1&page=2&limit=10", nil)
 	w := httptest.NewRecorder()
 
 	// Act
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, http.StatusOK, w.Code)
 
 	var response APIResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.False(t, response.Success)
-	assert.Equal(t, "Invalid property ID", response.Error)
+	assert.True(t, response.Success)
+	assert.NotNil(t, response.Meta)
+	assert.Equal(t, 11, response.Meta.TotalItems)
+	assert.Equal(t, 2, response.Meta.TotalPages)
+	assert.False(t, response.Meta.HasNext)
+
+	mockStorage.AssertExpectations(t)
 }
 
-// Test SearchPropertiesHandler - Success Case
-func TestSearchPropertiesHandler_Success(t *testing.T) {
+// Test GetPropertiesByPostalCodeHandler - Invalid Prefix
+func TestGetPropertiesByPostalCodeHandler_InvalidPrefix(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "Missing", query: ""},
+		{name: "Too short", query: "prefix=S"},
+		{name: "Too long", query: "prefix=SW1A1AA12345"},
+		{name: "Wildcard", query: "prefix=S%25"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockStorage := new(MockStorage)
+			handlers := NewHandlers(mockStorage)
+			router := setupTestRouter(handlers)
+
+			req, _ := http.NewRequest("GET", "/api/v1/properties/postal?"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockStorage.AssertNotCalled(t, "GetPropertiesByPostalCodePrefix", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// Test GetPropertyReviewsHandler - Success Case
+func TestGetPropertyReviewsHandler_Success(t *testing.T) {
 	// Arrange
 	mockStorage := new(MockStorage)
 	handlers := NewHandlers(mockStorage)
 	router := setupTestRouter(handlers)
 
-	testProperties := []*cupid.Property{createTestProperty()}
-	searchQuery := "London"
+	testReviews := []cupid.Review{
+		{
+			ReviewID:     1,
+			AverageScore: 9,
+			Country:      "GB",
+			Name:         "John Doe",
+			Headline:     "Great hotel!",
+			Pros:         "Clean, comfortable",
+			Cons:         "No complaints",
+			Date:         "2024-01-15",
+			Language:     "en",
+		},
+	}
 
-	mockStorage.On("SearchProperties", mock.Anything, searchQuery, 20, 0).Return(testProperties, nil)
-	mockStorage.On("CountSearchProperties", mock.Anything, searchQuery).Return(1, nil)
+	filters := store.ReviewFilters{Limit: 20, Offset: 0}
+	mockStorage.On("ListPropertyReviews", mock.Anything, int64(12345), filters).Return(testReviews, nil)
+	mockStorage.On("CountPropertyReviews", mock.Anything, int64(12345), filters).Return(1, nil)
 
-	req, _ := http.NewRequest("GET", "/api/v1/search?q=London&limit=20&page=1", nil)
+	req, _ := http.NewRequest("GET", "/api/v1/properties/12345/reviews", nil)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -443,53 +3579,74 @@ func TestSearchPropertiesHandler_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, response.Success)
 	assert.NotNil(t, response.Data)
-	assert.NotNil(t, response.Meta)
 
-	// Verify search results
-	properties, ok := response.Data.([]interface{})
+	// Verify reviews data
+	reviews, ok := response.Data.([]interface{})
 	assert.True(t, ok)
-	assert.Len(t, properties, 1)
+	assert.Len(t, reviews, 1)
 
 	mockStorage.AssertExpectations(t)
 }
 
-// Test SearchPropertiesHandler - Missing Query Parameter
-func TestSearchPropertiesHandler_MissingQuery(t *testing.T) {
+// Test GetPropertyReviewsHandler - Type Filter
+func TestGetPropertyReviewsHandler_TypeFilter(t *testing.T) {
 	// Arrange
 	mockStorage := new(MockStorage)
 	handlers := NewHandlers(mockStorage)
 	router := setupTestRouter(handlers)
 
-	req, _ := http.NewRequest("GET", "/api/v1/search", nil)
+	testReviews := []cupid.Review{
+		{
+			ReviewID:     2,
+			AverageScore: 8,
+			Type:         "family",
+			Name:         "Jane Doe",
+		},
+	}
+
+	filters := store.ReviewFilters{Type: "family", Limit: 20, Offset: 0}
+	mockStorage.On("ListPropertyReviews", mock.Anything, int64(12345), filters).Return(testReviews, nil)
+	mockStorage.On("CountPropertyReviews", mock.Anything, int64(12345), filters).Return(1, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/properties/12345/reviews?type=family", nil)
 	w := httptest.NewRecorder()
 
 	// Act
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, http.StatusOK, w.Code)
 
 	var response APIResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.False(t, response.Success)
-	assert.Contains(t, response.Error, "Invalid query parameters")
+	assert.True(t, response.Success)
+
+	reviews, ok := response.Data.([]interface{})
+	assert.True(t, ok)
+	assert.Len(t, reviews, 1)
+	assert.Equal(t, "family", reviews[0].(map[string]interface{})["type"])
+
+	mockStorage.AssertExpectations(t)
 }
 
-// Test GetPropertiesByRatingHandler - Success Case
-func TestGetPropertiesByRatingHandler_Success(t *testing.T) {
+// Test GetPropertyReviewsHandler - Score Filter With Pagination
+func TestGetPropertyReviewsHandler_ScoreFilterPaginated(t *testing.T) {
 	// Arrange
 	mockStorage := new(MockStorage)
 	handlers := NewHandlers(mockStorage)
 	router := setupTestRouter(handlers)
 
-	testProperties := []*cupid.Property{createTestProperty()}
-	minRating := 9.0
+	testReviews := []cupid.Review{
+		{ReviewID: 3, AverageScore: 8, Name: "Jane Doe"},
+		{ReviewID: 4, AverageScore: 7, Name: "John Smith"},
+	}
 
-	mockStorage.On("GetPropertiesByRating", mock.Anything, minRating, 20, 0).Return(testProperties, nil)
-	mockStorage.On("CountPropertiesByRating", mock.Anything, minRating).Return(1, nil)
+	filters := store.ReviewFilters{MinScore: 7, MaxScore: 9, Limit: 2, Offset: 2}
+	mockStorage.On("ListPropertyReviews", mock.Anything, int64(12345), filters).Return(testReviews, nil)
+	mockStorage.On("CountPropertyReviews", mock.Anything, int64(12345), filters).Return(5, nil)
 
-	req, _ := http.NewRequest("GET", "/api/v1/properties/rating?min_rating=9.0&limit=20&page=1", nil)
+	req, _ := http.NewRequest("GET", "/api/v1/properties/12345/reviews?min_score=7&max_score=9&page=2&limit=2", nil)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -502,73 +3659,141 @@ func TestGetPropertiesByRatingHandler_Success(t *testing.T) {
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
 	assert.True(t, response.Success)
-	assert.NotNil(t, response.Data)
+
+	reviews, ok := response.Data.([]interface{})
+	assert.True(t, ok)
+	assert.Len(t, reviews, 2)
+
 	assert.NotNil(t, response.Meta)
+	assert.Equal(t, 2, response.Meta.Page)
+	assert.Equal(t, 5, response.Meta.TotalItems)
+	assert.Equal(t, 3, response.Meta.TotalPages)
+	assert.True(t, response.Meta.HasNext)
+	assert.True(t, response.Meta.HasPrev)
 
 	mockStorage.AssertExpectations(t)
 }
 
-// Test GetPropertiesByRatingHandler - Missing Rating Parameter
-func TestGetPropertiesByRatingHandler_MissingRating(t *testing.T) {
+// Test GetPropertyReviewsHandler - Language And Country Filters
+func TestGetPropertyReviewsHandler_LanguageCountryFilter(t *testing.T) {
 	// Arrange
 	mockStorage := new(MockStorage)
 	handlers := NewHandlers(mockStorage)
 	router := setupTestRouter(handlers)
 
-	req, _ := http.NewRequest("GET", "/api/v1/properties/rating", nil)
+	testReviews := []cupid.Review{
+		{ReviewID: 5, AverageScore: 9, Language: "en", Country: "gb", Name: "Jane Doe"},
+	}
+
+	filters := store.ReviewFilters{Language: "en", Country: "gb", MinScore: 8, Limit: 20, Offset: 0}
+	mockStorage.On("ListPropertyReviews", mock.Anything, int64(12345), filters).Return(testReviews, nil)
+	mockStorage.On("CountPropertyReviews", mock.Anything, int64(12345), filters).Return(1, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/properties/12345/reviews?language=en&country=gb&min_score=8", nil)
 	w := httptest.NewRecorder()
 
 	// Act
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, http.StatusOK, w.Code)
 
 	var response APIResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.False(t, response.Success)
-	assert.Equal(t, "min_rating parameter is required", response.Error)
+	assert.True(t, response.Success)
+	assert.NotNil(t, response.Meta)
+	assert.Equal(t, 1, response.Meta.TotalItems)
+
+	mockStorage.AssertExpectations(t)
 }
 
-// Test GetPropertiesByRatingHandler - Invalid Rating Parameter
-func TestGetPropertiesByRatingHandler_InvalidRating(t *testing.T) {
+// Test GetPropertyReviewsHandler - Sort Parameters
+func TestGetPropertyReviewsHandler_Sort(t *testing.T) {
 	// Arrange
 	mockStorage := new(MockStorage)
 	handlers := NewHandlers(mockStorage)
 	router := setupTestRouter(handlers)
 
-	req, _ := http.NewRequest("GET", "/api/v1/properties/rating?min_rating=invalid", nil)
+	testReviews := []cupid.Review{
+		{ReviewID: 2, AverageScore: 6, Name: "Jane Doe"},
+		{ReviewID: 1, AverageScore: 9, Name: "John Doe"},
+	}
+
+	filters := store.ReviewFilters{SortBy: store.ReviewSortScore, SortAscending: true, Limit: 20, Offset: 0}
+	mockStorage.On("ListPropertyReviews", mock.Anything, int64(12345), filters).Return(testReviews, nil)
+	mockStorage.On("CountPropertyReviews", mock.Anything, int64(12345), filters).Return(2, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/properties/12345/reviews?sort=score&order=asc", nil)
 	w := httptest.NewRecorder()
 
 	// Act
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, http.StatusOK, w.Code)
 
 	var response APIResponse
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.False(t, response.Success)
-	assert.Equal(t, "Invalid min_rating parameter", response.Error)
+	reviews := response.Data.([]interface{})
+	assert.Equal(t, float64(2), reviews[0].(map[string]interface{})["review_id"])
+	assert.Equal(t, float64(1), reviews[1].(map[string]interface{})["review_id"])
+
+	mockStorage.AssertExpectations(t)
 }
 
-// Test GetPropertiesByLocationHandler - Success Case
-func TestGetPropertiesByLocationHandler_Success(t *testing.T) {
+// Test GetPropertyReviewsHandler - Invalid Score And Sort Filters
+func TestGetPropertyReviewsHandler_InvalidScore(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "Non-numeric min_score", query: "min_score=abc"},
+		{name: "max_score out of range", query: "max_score=11"},
+		{name: "min_score greater than max_score", query: "min_score=8&max_score=5"},
+		{name: "Unknown sort field", query: "sort=name"},
+		{name: "Unknown sort order", query: "order=sideways"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockStorage := new(MockStorage)
+			handlers := NewHandlers(mockStorage)
+			router := setupTestRouter(handlers)
+
+			req, _ := http.NewRequest("GET", "/api/v1/properties/12345/reviews?"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockStorage.AssertNotCalled(t, "ListPropertyReviews", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// Test GetPropertyReviewStatsHandler - Success Case
+func TestGetPropertyReviewStatsHandler_Success(t *testing.T) {
 	// Arrange
 	mockStorage := new(MockStorage)
 	handlers := NewHandlers(mockStorage)
 	router := setupTestRouter(handlers)
 
-	testProperties := []*cupid.Property{createTestProperty()}
-	city := "London"
-	country := "gb"
+	distribution := make([]store.ScoreBucket, 10)
+	for i := range distribution {
+		distribution[i].Score = i + 1
+	}
+	distribution[7].Count = 1
+	distribution[8].Count = 2
+	stats := &store.ReviewStats{AverageScore: 26.0 / 3, Scale: store.RatingScale, Count: 3, Distribution: distribution}
 
-	mockStorage.On("GetPropertiesByLocation", mock.Anything, city, country, 20, 0).Return(testProperties, nil)
-	mockStorage.On("CountPropertiesByLocation", mock.Anything, city, country).Return(1, nil)
+	mockStorage.On("GetReviewStats", mock.Anything, int64(12345)).Return(stats, nil)
 
-	req, _ := http.NewRequest("GET", "/api/v1/properties/location?city=London&country=gb&limit=20&page=1", nil)
+	req, _ := http.NewRequest("GET", "/api/v1/properties/12345/reviews/stats", nil)
 	w := httptest.NewRecorder()
 
 	// Act
@@ -577,60 +3802,39 @@ func TestGetPropertiesByLocationHandler_Success(t *testing.T) {
 	// Assert
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	var response APIResponse
+	var response struct {
+		Success bool                `json:"success"`
+		Data    ReviewStatsResponse `json:"data"`
+	}
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
 	assert.True(t, response.Success)
-	assert.NotNil(t, response.Data)
-	assert.NotNil(t, response.Meta)
+	assert.Equal(t, int64(12345), response.Data.PropertyID)
+	assert.Equal(t, 3, response.Data.Count)
+	assert.InDelta(t, 8.6667, response.Data.AverageScore, 0.001)
+	assert.Equal(t, "0-10", response.Data.Scale)
+	assert.Len(t, response.Data.Distribution, 10)
+	assert.Equal(t, ScoreBucketResponse{Score: 9, Count: 2}, response.Data.Distribution[8])
 
 	mockStorage.AssertExpectations(t)
 }
 
-// Test GetPropertyReviewsHandler - Success Case
-func TestGetPropertyReviewsHandler_Success(t *testing.T) {
+// Test GetPropertyReviewStatsHandler - Invalid ID
+func TestGetPropertyReviewStatsHandler_InvalidID(t *testing.T) {
 	// Arrange
 	mockStorage := new(MockStorage)
 	handlers := NewHandlers(mockStorage)
 	router := setupTestRouter(handlers)
 
-	testReviews := []cupid.Review{
-		{
-			ReviewID:     1,
-			AverageScore: 9,
-			Country:      "GB",
-			Name:         "John Doe",
-			Headline:     "Great hotel!",
-			Pros:         "Clean, comfortable",
-			Cons:         "No complaints",
-			Date:         "2024-01-15",
-			Language:     "en",
-		},
-	}
-
-	mockStorage.On("GetPropertyReviews", mock.Anything, int64(12345)).Return(testReviews, nil)
-
-	req, _ := http.NewRequest("GET", "/api/v1/properties/12345/reviews", nil)
+	req, _ := http.NewRequest("GET", "/api/v1/properties/abc/reviews/stats", nil)
 	w := httptest.NewRecorder()
 
 	// Act
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusOK, w.Code)
-
-	var response APIResponse
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	assert.NoError(t, err)
-	assert.True(t, response.Success)
-	assert.NotNil(t, response.Data)
-
-	// Verify reviews data
-	reviews, ok := response.Data.([]interface{})
-	assert.True(t, ok)
-	assert.Len(t, reviews, 1)
-
-	mockStorage.AssertExpectations(t)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockStorage.AssertNotCalled(t, "GetReviewStats", mock.Anything, mock.Anything)
 }
 
 // Test GetPropertyTranslationsHandler - Success Case
@@ -669,9 +3873,53 @@ func TestGetPropertyTranslationsHandler_Success(t *testing.T) {
 	assert.NotNil(t, response.Data)
 
 	// Verify translations data
-	translations, ok := response.Data.(map[string]interface{})
+	translations, ok := response.Data.([]interface{})
 	assert.True(t, ok)
-	assert.Contains(t, translations, "fr")
+	assert.Len(t, translations, 1)
+	assert.Equal(t, "fr", translations[0].(map[string]interface{})["language"])
 
 	mockStorage.AssertExpectations(t)
 }
+
+// Test GetPropertyTranslationsHandler - Deterministic Ordering
+func TestGetPropertyTranslationsHandler_SortedByLanguage(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	handlers := NewHandlers(mockStorage)
+	router := setupTestRouter(handlers)
+
+	testTranslations := map[string]*cupid.Property{
+		"fr": {HotelName: "Hôtel de Test"},
+		"es": {HotelName: "Hotel de Prueba"},
+		"de": {HotelName: "Testhotel"},
+		"it": {HotelName: "Albergo di Prova"},
+	}
+	mockStorage.On("GetPropertyTranslations", mock.Anything, int64(12345)).Return(testTranslations, nil)
+
+	var firstBody string
+	for i := 0; i < 5; i++ {
+		req, _ := http.NewRequest("GET", "/api/v1/properties/12345/translations", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		if i == 0 {
+			firstBody = w.Body.String()
+
+			var response APIResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			translations := response.Data.([]interface{})
+			var languages []string
+			for _, translation := range translations {
+				languages = append(languages, translation.(map[string]interface{})["language"].(string))
+			}
+			assert.Equal(t, []string{"de", "es", "fr", "it"}, languages)
+			continue
+		}
+		assert.Equal(t, firstBody, w.Body.String())
+	}
+}
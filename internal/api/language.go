@@ -0,0 +1,106 @@
+package api
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/barimehdi77/cupid-api/internal/cupid"
+	"github.com/gin-gonic/gin"
+)
+
+// languagePreference is one language range of an Accept-Language header with its quality
+type languagePreference struct {
+	tag     string
+	quality float64
+}
+
+// parseAcceptLanguage parses an Accept-Language header into lowercase language ranges, most preferred first.
+// Ranges with a zero or malformed quality, and the "*" wildcard, are dropped; ties keep the header order.
+func parseAcceptLanguage(header string) []string {
+	var preferences []languagePreference
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				parsed = 0
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		preferences = append(preferences, languagePreference{tag: tag, quality: quality})
+	}
+
+	sort.SliceStable(preferences, func(i, j int) bool {
+		return preferences[i].quality > preferences[j].quality
+	})
+
+	tags := make([]string, len(preferences))
+	for i, preference := range preferences {
+		tags[i] = preference.tag
+	}
+	return tags
+}
+
+// requestedLanguages returns the language ranges a request asks for, in Accept-Language syntax.
+// The lang query parameter wins over the Accept-Language header; a request naming neither falls back to
+// Config.DefaultLanguage.
+func (h *Handlers) requestedLanguages(c *gin.Context) string {
+	if lang := strings.TrimSpace(c.Query("lang")); lang != "" {
+		return lang
+	}
+	if header := c.GetHeader("Accept-Language"); strings.TrimSpace(header) != "" {
+		return header
+	}
+	return h.config.DefaultLanguage
+}
+
+// negotiateTranslation picks the stored translation best matching an Accept-Language header.
+// A language range matches a translation stored under the same tag or, failing that, under its primary
+// subtag, so "fr-CA" falls back to "fr". A range matching baseLanguage ends the negotiation with baseLanguage
+// and a nil translation, since the stored property is already in that language. It returns an empty language
+// when nothing matches.
+func negotiateTranslation(header, baseLanguage string, translations map[string]*cupid.Property) (string, *cupid.Property) {
+	for _, tag := range parseAcceptLanguage(header) {
+		primary, _, _ := strings.Cut(tag, "-")
+		for _, candidate := range []string{tag, primary} {
+			if baseLanguage != "" && candidate == baseLanguage {
+				return baseLanguage, nil
+			}
+			if translation, ok := translations[candidate]; ok && translation != nil {
+				return candidate, translation
+			}
+		}
+	}
+	return "", nil
+}
+
+// applyTranslation replaces the localized text fields of response with the non-empty fields of translation
+func applyTranslation(response *PropertyResponse, translation *cupid.Property) {
+	if translation.HotelName != "" {
+		response.HotelName = translation.HotelName
+	}
+	if translation.Description != "" {
+		response.Description = translation.Description
+	}
+	if translation.MarkdownDescription != "" {
+		response.MarkdownDescription = translation.MarkdownDescription
+	}
+	if translation.ImportantInfo != "" {
+		response.ImportantInfo = translation.ImportantInfo
+	}
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test parseAcceptLanguage
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected []string
+	}{
+		{name: "Empty", header: "", expected: []string{}},
+		{name: "Single", header: "FR-ca", expected: []string{"fr-ca"}},
+		{name: "SortedByQuality", header: "en;q=0.5, fr, de;q=0.8", expected: []string{"fr", "de", "en"}},
+		{name: "TiesKeepHeaderOrder", header: "es;q=0.7, it;q=0.7", expected: []string{"es", "it"}},
+		{name: "DropsZeroWildcardAndMalformed", header: "*, en;q=0, de;q=abc, fr ; q = 0.3", expected: []string{"fr"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseAcceptLanguage(tt.header))
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"time"
+
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"github.com/barimehdi77/cupid-api/internal/store"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// AdminKeyHeader is the request header carrying the admin API key
+const AdminKeyHeader = "X-Admin-Key"
+
+// AdminAuthMiddleware rejects requests whose X-Admin-Key header doesn't match the configured key.
+// An empty configured key rejects every request so admin routes are never left open by accident.
+func AdminAuthMiddleware(apiKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		providedKey := c.GetHeader(AdminKeyHeader)
+		if apiKey == "" || subtle.ConstantTimeCompare([]byte(providedKey), []byte(apiKey)) != 1 {
+			logger.Warn("Unauthorized admin request",
+				zap.String("method", c.Request.Method),
+				zap.String("path", c.Request.URL.Path),
+				zap.String("ip", c.ClientIP()),
+			)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, APIResponse{
+				Success: false,
+				Error:   "Unauthorized",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// SkippedRowsHeader is the response header reporting how many stored rows were left out of partial results
+const SkippedRowsHeader = "X-Skipped-Rows"
+
+// SkippedRowsMiddleware counts the rows the storage skips while serving a request, which JSON responses report
+// in the X-Skipped-Rows header and meta.skipped_rows so clients know the results are partial
+func SkippedRowsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(store.WithSkippedRows(c.Request.Context()))
+		c.Next()
+	}
+}
+
+// RequestTimeoutMiddleware sets a deadline on the request context so storage queries cannot hang a request
+// until the client disconnects. A timeout of zero or less leaves the request context untouched.
+func RequestTimeoutMiddleware(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// headResponseWriter drops the body written to a response, keeping its status and headers
+type headResponseWriter struct {
+	gin.ResponseWriter
+}
+
+func (w *headResponseWriter) Write(data []byte) (int, error) {
+	w.ResponseWriter.WriteHeaderNow()
+	return len(data), nil
+}
+
+func (w *headResponseWriter) WriteString(s string) (int, error) {
+	w.ResponseWriter.WriteHeaderNow()
+	return len(s), nil
+}
+
+// HeadMiddleware serves a HEAD request with the GET handler of a route, discarding the body it writes,
+// so the status and headers, Content-Length included, are the ones a GET would get
+func HeadMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer = &headResponseWriter{ResponseWriter: c.Writer}
+		c.Next()
+	}
+}
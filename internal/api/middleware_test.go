@@ -0,0 +1,253 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/barimehdi77/cupid-api/internal/database"
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"github.com/barimehdi77/cupid-api/internal/store"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAdminRouter(apiKey string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logger.InitLogger()
+
+	router := gin.New()
+	admin := router.Group("/api/v1/admin", AdminAuthMiddleware(apiKey))
+	admin.POST("/sync", func(c *gin.Context) {
+		c.JSON(http.StatusOK, APIResponse{Success: true})
+	})
+	return router
+}
+
+// Test AdminAuthMiddleware
+func TestAdminAuthMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		configuredKey  string
+		providedKey    string
+		expectedStatus int
+	}{
+		{"ValidKey", "secret", "secret", http.StatusOK},
+		{"WrongKey", "secret", "wrong", http.StatusUnauthorized},
+		{"MissingKey", "secret", "", http.StatusUnauthorized},
+		{"NoKeyConfigured", "", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			router := setupAdminRouter(tt.configuredKey)
+			req, _ := http.NewRequest("POST", "/api/v1/admin/sync", nil)
+			if tt.providedKey != "" {
+				req.Header.Set(AdminKeyHeader, tt.providedKey)
+			}
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var response APIResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.False(t, response.Success)
+				assert.Equal(t, "Unauthorized", response.Error)
+			} else {
+				assert.True(t, response.Success)
+			}
+		})
+	}
+}
+
+// Test RequestTimeoutMiddleware
+func TestRequestTimeoutMiddleware(t *testing.T) {
+	t.Run("SetsDeadline", func(t *testing.T) {
+		// Arrange
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		var deadline time.Time
+		var hasDeadline bool
+		router.GET("/test", RequestTimeoutMiddleware(time.Second), func(c *gin.Context) {
+			deadline, hasDeadline = c.Request.Context().Deadline()
+			c.Status(http.StatusOK)
+		})
+		req, _ := http.NewRequest("GET", "/test", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		start := time.Now()
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.True(t, hasDeadline)
+		assert.WithinDuration(t, start.Add(time.Second), deadline, 500*time.Millisecond)
+	})
+
+	t.Run("ZeroDisablesDeadline", func(t *testing.T) {
+		// Arrange
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		hasDeadline := true
+		router.GET("/test", RequestTimeoutMiddleware(0), func(c *gin.Context) {
+			_, hasDeadline = c.Request.Context().Deadline()
+			c.Status(http.StatusOK)
+		})
+		req, _ := http.NewRequest("GET", "/test", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.False(t, hasDeadline)
+	})
+
+	t.Run("ExpiredQueryReturnsGatewayTimeout", func(t *testing.T) {
+		// Arrange
+		gin.SetMode(gin.TestMode)
+		logger.InitLogger()
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := gin.New()
+		router.GET("/api/v1/facilities", RequestTimeoutMiddleware(10*time.Millisecond), handlers.ListFacilitiesHandler)
+
+		mockStorage.On("ListFacilities", mock.Anything).Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+		}).Return(nil, errors.New("pq: canceling statement due to user request"))
+
+		req, _ := http.NewRequest("GET", "/api/v1/facilities", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusGatewayTimeout, w.Code)
+
+		var response APIResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.False(t, response.Success)
+		assert.Equal(t, "Request timed out", response.Error)
+		mockStorage.AssertExpectations(t)
+	})
+}
+
+// Test HeadMiddleware
+func TestHeadMiddleware(t *testing.T) {
+	t.Run("MatchesGetWithoutBody", func(t *testing.T) {
+		// Arrange
+		gin.SetMode(gin.TestMode)
+		logger.InitLogger()
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := gin.New()
+		router.GET("/api/v1/properties/:id/summary", handlers.GetPropertySummaryHandler)
+		router.HEAD("/api/v1/properties/:id/summary", HeadMiddleware(), handlers.GetPropertySummaryHandler)
+
+		// A fixed body keeps the GET and HEAD responses the same length
+		mockStorage.On("GetPropertySummary", mock.Anything, int64(12345)).Return(createTestProperty(), nil)
+
+		getReq, _ := http.NewRequest("GET", "/api/v1/properties/12345/summary", nil)
+		getW := httptest.NewRecorder()
+		headReq, _ := http.NewRequest("HEAD", "/api/v1/properties/12345/summary", nil)
+		headW := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(getW, getReq)
+		router.ServeHTTP(headW, headReq)
+
+		// Assert
+		require.Equal(t, http.StatusOK, getW.Code)
+		assert.Equal(t, http.StatusOK, headW.Code)
+		assert.Empty(t, headW.Body.String())
+		assert.Equal(t, getW.Header().Get("Content-Type"), headW.Header().Get("Content-Type"))
+		assert.Equal(t, strconv.Itoa(getW.Body.Len()), headW.Header().Get("Content-Length"))
+	})
+
+	t.Run("KeepsErrorStatus", func(t *testing.T) {
+		// Arrange
+		gin.SetMode(gin.TestMode)
+		logger.InitLogger()
+		mockStorage := new(MockStorage)
+		handlers := NewHandlers(mockStorage)
+		router := gin.New()
+		router.HEAD("/api/v1/properties/:id", HeadMiddleware(), handlers.GetPropertyHandler)
+
+		mockStorage.On("GetProperty", mock.Anything, int64(404)).Return(nil, store.ErrPropertyNotFound)
+
+		req, _ := http.NewRequest("HEAD", "/api/v1/properties/404", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.NotEmpty(t, w.Header().Get("Content-Length"))
+		mockStorage.AssertExpectations(t)
+	})
+}
+
+// Test SkippedRowsMiddleware - rows skipped by the storage are reported in the header and meta
+func TestSkippedRowsMiddleware(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	logger.InitLogger()
+
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	storage := store.NewStorageWithConfig(&database.DB{DB: db}, &store.Config{SkipBadRows: true})
+
+	reviewColumns := []string{"review_id", "average_score", "country", "type", "name", "date", "headline", "language", "pros", "cons", "source", "imported"}
+	sqlMock.ExpectQuery(`FROM reviews`).WillReturnRows(sqlmock.NewRows(reviewColumns).
+		AddRow(1, 9, "US", "family", "John Doe", "2024-01-15", "Great stay", "en", "Pool", "None", "booking.com", false).
+		AddRow(2, "not-a-score", "FR", "solo", "Marie", "2024-01-10", "Fine", "fr", "Location", "Noise", "booking.com", false))
+	sqlMock.ExpectQuery(`FROM reviews`).WillReturnRows(sqlmock.NewRows(reviewColumns).
+		AddRow(1, 9, "US", "family", "John Doe", "2024-01-15", "Great stay", "en", "Pool", "None", "booking.com", false))
+
+	handlers := NewHandlers(storage)
+	router := gin.New()
+	router.Use(SkippedRowsMiddleware())
+	router.GET("/reviews", func(c *gin.Context) {
+		reviews, err := storage.GetPropertyReviews(c.Request.Context(), 12345)
+		require.NoError(t, err)
+		handlers.respondJSON(c, http.StatusOK, APIResponse{Success: true, Data: reviews, Meta: &Meta{Page: 1, Limit: 20}})
+	})
+
+	// Act
+	partial := httptest.NewRecorder()
+	router.ServeHTTP(partial, httptest.NewRequest("GET", "/reviews", nil))
+	complete := httptest.NewRecorder()
+	router.ServeHTTP(complete, httptest.NewRequest("GET", "/reviews", nil))
+
+	// Assert
+	assert.Equal(t, "1", partial.Header().Get(SkippedRowsHeader))
+	var response APIResponse
+	require.NoError(t, json.Unmarshal(partial.Body.Bytes(), &response))
+	require.NotNil(t, response.Meta)
+	assert.Equal(t, 1, response.Meta.SkippedRows)
+
+	assert.Empty(t, complete.Header().Get(SkippedRowsHeader))
+	assert.NotContains(t, complete.Body.String(), "skipped_rows")
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
@@ -1,16 +1,20 @@
 package api
 
 import (
+	"sort"
 	"time"
 
 	"github.com/barimehdi77/cupid-api/internal/cupid"
+	"github.com/barimehdi77/cupid-api/internal/store"
 )
 
-// APIResponse represents a standard API response structure
+// APIResponse represents a standard API response structure.
+// Code is a machine-readable error code for errors clients are expected to branch on.
 type APIResponse struct {
 	Success bool        `json:"success"`
 	Data    interface{} `json:"data,omitempty"`
 	Error   string      `json:"error,omitempty"`
+	Code    string      `json:"code,omitempty"`
 	Meta    *Meta       `json:"meta,omitempty"`
 }
 
@@ -23,41 +27,103 @@ type Meta struct {
 	TotalPages int  `json:"total_pages"`
 	HasNext    bool `json:"has_next"`
 	HasPrev    bool `json:"has_prev"`
+	// NextCursor is set when listing with cursor pagination and another page follows
+	NextCursor string `json:"next_cursor,omitempty"`
+	// Facets is set when listing properties with facets=true
+	Facets *PropertyFacetsResponse `json:"facets,omitempty"`
+	// SkippedRows is the number of stored rows left out of the results because they failed to read
+	// (STORE_SKIP_BAD_ROWS), also reported in the X-Skipped-Rows header
+	SkippedRows int `json:"skipped_rows,omitempty"`
+}
+
+// FacetCountResponse represents the number of matching properties having a facet value
+type FacetCountResponse struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// PropertyFacetsResponse represents the counts of matching properties per hotel type, stars and country
+type PropertyFacetsResponse struct {
+	HotelTypes []FacetCountResponse `json:"hotel_types"`
+	Stars      []FacetCountResponse `json:"stars"`
+	Countries  []FacetCountResponse `json:"countries"`
+}
+
+// ConvertFacetsToResponse converts storage facet counts to the API response format
+func ConvertFacetsToResponse(facets *store.PropertyFacets) *PropertyFacetsResponse {
+	convert := func(counts []store.FacetCount) []FacetCountResponse {
+		response := make([]FacetCountResponse, 0, len(counts))
+		for _, count := range counts {
+			response = append(response, FacetCountResponse{Value: count.Value, Count: count.Count})
+		}
+		return response
+	}
+
+	return &PropertyFacetsResponse{
+		HotelTypes: convert(facets.HotelTypes),
+		Stars:      convert(facets.Stars),
+		Countries:  convert(facets.Countries),
+	}
 }
 
 // PropertyListRequest represents query parameters for listing properties
 type PropertyListRequest struct {
-	Page      int     `form:"page"`
-	Limit     int     `form:"limit"`
-	City      string  `form:"city"`
-	Country   string  `form:"country"`
-	MinStars  int     `form:"min_stars"`
-	MaxStars  int     `form:"max_stars"`
-	MinRating float64 `form:"min_rating"`
-	MaxRating float64 `form:"max_rating"`
-	HotelType string  `form:"hotel_type"`
-	Chain     string  `form:"chain"`
-	Search    string  `form:"search"`
+	Page          int      `form:"page"`
+	Limit         int      `form:"limit"`
+	City          string   `form:"city"`
+	Country       string   `form:"country"`
+	MinStars      *int     `form:"min_stars"`
+	MaxStars      *int     `form:"max_stars"`
+	MinRating     *float64 `form:"min_rating"`
+	MaxRating     *float64 `form:"max_rating"`
+	MinPrice      *float64 `form:"min_price"`
+	MaxPrice      *float64 `form:"max_price"`
+	Currency      string   `form:"currency"`
+	HotelType     string   `form:"hotel_type"`
+	Chain         string   `form:"chain"`
+	Search        string   `form:"search"`
+	Facilities    []int    `form:"facility"`
+	RoomAmenities []int    `form:"room_amenity"`
+	Format        string   `form:"format"`
+	Cursor        string   `form:"cursor"`
+	Facets        bool     `form:"facets"`
 }
 
 // PropertyResponse represents a property in API responses
 type PropertyResponse struct {
-	HotelID     int64                    `json:"hotel_id"`
-	CupidID     int64                    `json:"cupid_id"`
-	HotelName   string                   `json:"hotel_name"`
-	HotelType   string                   `json:"hotel_type"`
-	Chain       string                   `json:"chain"`
-	Latitude    float64                  `json:"latitude"`
-	Longitude   float64                  `json:"longitude"`
-	Stars       int                      `json:"stars"`
-	Rating      float64                  `json:"rating"`
-	ReviewCount int                      `json:"review_count"`
-	AirportCode string                   `json:"airport_code"`
-	Address     AddressResponse          `json:"address"`
-	MainImageTh string                   `json:"main_image_th"`
-	CreatedAt   time.Time                `json:"created_at"`
-	UpdatedAt   time.Time                `json:"updated_at"`
-	Details     *PropertyDetailsResponse `json:"details,omitempty"`
+	HotelID             int64                    `json:"hotel_id"`
+	CupidID             int64                    `json:"cupid_id"`
+	HotelName           string                   `json:"hotel_name"`
+	HotelType           string                   `json:"hotel_type"`
+	Chain               string                   `json:"chain"`
+	Latitude            float64                  `json:"latitude"`
+	Longitude           float64                  `json:"longitude"`
+	Stars               int                      `json:"stars"`
+	Rating              float64                  `json:"rating"`
+	ComputedRating      *float64                 `json:"computed_rating,omitempty"`
+	QualityScore        *float64                 `json:"quality_score,omitempty"`
+	DistanceKm          *float64                 `json:"distance_km,omitempty"`
+	ReviewCount         int                      `json:"review_count"`
+	AirportCode         string                   `json:"airport_code"`
+	Address             AddressResponse          `json:"address"`
+	MainImageTh         string                   `json:"main_image_th"`
+	Description         string                   `json:"description"`
+	MarkdownDescription string                   `json:"markdown_description"`
+	ImportantInfo       string                   `json:"important_info"`
+	Phone               string                   `json:"phone"`
+	Fax                 string                   `json:"fax"`
+	Email               string                   `json:"email"`
+	CheckIn             *CheckInResponse         `json:"checkin,omitempty"`
+	Parking             *string                  `json:"parking,omitempty"`
+	GroupRoomMin        *int                     `json:"group_room_min,omitempty"`
+	ChildAllowed        *bool                    `json:"child_allowed,omitempty"`
+	PetsAllowed         *bool                    `json:"pets_allowed,omitempty"`
+	MinPrice            *float64                 `json:"min_price,omitempty"`
+	Currency            string                   `json:"currency,omitempty"`
+	CreatedAt           time.Time                `json:"created_at"`
+	UpdatedAt           time.Time                `json:"updated_at"`
+	LastSyncedAt        *time.Time               `json:"last_synced_at,omitempty"`
+	Details             *PropertyDetailsResponse `json:"details,omitempty"`
 }
 
 // AddressResponse represents address information in API responses
@@ -69,6 +135,15 @@ type AddressResponse struct {
 	PostalCode string `json:"postal_code"`
 }
 
+// CheckInResponse represents the check-in and check-out times of a property in API responses
+type CheckInResponse struct {
+	CheckInStart        string   `json:"checkin_start"`
+	CheckInEnd          string   `json:"checkin_end"`
+	Checkout            string   `json:"checkout"`
+	SpecialInstructions string   `json:"special_instructions,omitempty"`
+	Instructions        []string `json:"instructions,omitempty"`
+}
+
 // PropertyDetailsResponse represents complex property details
 type PropertyDetailsResponse struct {
 	Address     interface{} `json:"address,omitempty"`
@@ -81,6 +156,33 @@ type PropertyDetailsResponse struct {
 	Metadata    interface{} `json:"metadata,omitempty"`
 }
 
+// FacilityResponse represents a facility in API responses
+type FacilityResponse struct {
+	FacilityID    int    `json:"facility_id"`
+	Name          string `json:"name"`
+	PropertyCount int    `json:"property_count"`
+}
+
+// RoomAmenityResponse represents a room amenity with the number of properties having rooms offering it
+type RoomAmenityResponse struct {
+	AmenityID     int    `json:"amenity_id"`
+	Name          string `json:"name"`
+	PropertyCount int    `json:"property_count"`
+}
+
+// ChainResponse represents a hotel chain with its number of properties in API responses
+type ChainResponse struct {
+	Chain         string `json:"chain"`
+	PropertyCount int    `json:"property_count"`
+}
+
+// StarRatingResponse represents the average guest rating of a star category in API responses
+type StarRatingResponse struct {
+	Stars         int     `json:"stars"`
+	AverageRating float64 `json:"average_rating"`
+	PropertyCount int     `json:"property_count"`
+}
+
 // ReviewResponse represents a review in API responses
 type ReviewResponse struct {
 	ID           int64     `json:"id"`
@@ -98,6 +200,73 @@ type ReviewResponse struct {
 	CreatedAt    time.Time `json:"created_at"`
 }
 
+// ReviewStatsResponse represents review statistics for a property in API responses
+type ReviewStatsResponse struct {
+	PropertyID   int64                 `json:"property_id"`
+	AverageScore float64               `json:"average_score"`
+	Scale        string                `json:"scale"`
+	Count        int                   `json:"count"`
+	Distribution []ScoreBucketResponse `json:"distribution"`
+}
+
+// ScoreBucketResponse represents the number of reviews with a given score
+type ScoreBucketResponse struct {
+	Score int `json:"score"`
+	Count int `json:"count"`
+}
+
+// SyncHistoryEntryResponse represents one recorded sync of a property in API responses
+type SyncHistoryEntryResponse struct {
+	SyncedAt time.Time `json:"synced_at"`
+	Changed  bool      `json:"changed"`
+	Changes  []string  `json:"changes"`
+}
+
+// PropertyOverridesResponse represents the manual field overrides of a property in API responses
+type PropertyOverridesResponse struct {
+	HotelID   int64             `json:"hotel_id"`
+	Overrides map[string]string `json:"overrides"`
+}
+
+// ReviewImportResponse represents the outcome of a review import in API responses
+type ReviewImportResponse struct {
+	HotelID    int64                     `json:"hotel_id"`
+	Inserted   int                       `json:"inserted"`
+	Updated    int                       `json:"updated"`
+	Rejected   int                       `json:"rejected"`
+	Rejections []ReviewRejectionResponse `json:"rejections,omitempty"`
+}
+
+// ReviewPruneResponse represents the outcome of deleting old reviews in API responses
+type ReviewPruneResponse struct {
+	// Before is the date reviews were deleted before
+	Before  string `json:"before"`
+	Deleted int64  `json:"deleted"`
+}
+
+// ReviewRejectionResponse represents an imported review that failed validation and was not stored
+type ReviewRejectionResponse struct {
+	// Index is the position of the review in the request body
+	Index    int    `json:"index"`
+	ReviewID int64  `json:"review_id"`
+	Reason   string `json:"reason"`
+}
+
+// CoordinateClusterResponse represents properties sharing the same coordinates in API responses
+type CoordinateClusterResponse struct {
+	Latitude   float64            `json:"latitude"`
+	Longitude  float64            `json:"longitude"`
+	Properties []PropertyResponse `json:"properties"`
+}
+
+// ChainConflictResponse represents properties with inconsistent chain data in API responses
+type ChainConflictResponse struct {
+	Field      string             `json:"field"`
+	Chain      string             `json:"chain,omitempty"`
+	ChainID    int                `json:"chain_id,omitempty"`
+	Properties []PropertyResponse `json:"properties"`
+}
+
 // TranslationResponse represents a translation in API responses
 type TranslationResponse struct {
 	Language            string    `json:"language"`
@@ -111,16 +280,18 @@ type TranslationResponse struct {
 
 // PropertyWithDetailsResponse represents a complete property with all details
 type PropertyWithDetailsResponse struct {
-	Property     PropertyResponse               `json:"property"`
-	Reviews      []ReviewResponse               `json:"reviews"`
-	Translations map[string]TranslationResponse `json:"translations"`
+	Property     PropertyResponse      `json:"property"`
+	Reviews      []ReviewResponse      `json:"reviews"`
+	Translations []TranslationResponse `json:"translations"`
 }
 
 // SearchRequest represents search query parameters
 type SearchRequest struct {
-	Query string `form:"q" binding:"required"`
-	Page  int    `form:"page"`
-	Limit int    `form:"limit"`
+	Query  string `form:"q" binding:"required"`
+	Fields string `form:"fields"`
+	Fuzzy  bool   `form:"fuzzy"`
+	Page   int    `form:"page"`
+	Limit  int    `form:"limit"`
 }
 
 // ReviewListRequest represents query parameters for listing reviews
@@ -141,6 +312,15 @@ type HealthResponse struct {
 	Database  string    `json:"database"`
 }
 
+// RootResponse describes the API at its root path
+type RootResponse struct {
+	Name     string `json:"name"`
+	Version  string `json:"version"`
+	BasePath string `json:"base_path"`
+	Docs     string `json:"docs"`
+	Health   string `json:"health"`
+}
+
 // ConvertPropertyToResponse converts a cupid.Property to PropertyResponse
 func ConvertPropertyToResponse(property *cupid.Property) PropertyResponse {
 	if property == nil {
@@ -166,10 +346,45 @@ func ConvertPropertyToResponse(property *cupid.Property) PropertyResponse {
 			Country:    property.Address.Country,
 			PostalCode: property.Address.PostalCode,
 		},
-		MainImageTh: property.MainImageTh,
+		MainImageTh:         property.MainImageTh,
+		Description:         property.Description,
+		MarkdownDescription: property.MarkdownDescription,
+		ImportantInfo:       property.ImportantInfo,
+		Phone:               property.Phone,
+		Fax:                 property.Fax,
+		Email:               property.Email,
+		Parking:             property.Parking,
+		GroupRoomMin:        property.GroupRoomMin,
+		ChildAllowed:        property.ChildAllowed,
+		PetsAllowed:         property.PetsAllowed,
+		MinPrice:            property.MinPrice,
+		Currency:            property.Currency,
+		LastSyncedAt:        property.LastSyncedAt,
 	}
 }
 
+// ConvertCheckInToResponse converts a cupid.CheckIn to CheckInResponse.
+// Returns nil when upstream provided no check-in information, so the field is left out of responses.
+func ConvertCheckInToResponse(checkIn cupid.CheckIn) *CheckInResponse {
+	response := &CheckInResponse{
+		CheckInStart:        checkIn.CheckInStart,
+		CheckInEnd:          checkIn.CheckInEnd,
+		Checkout:            checkIn.Checkout,
+		SpecialInstructions: checkIn.SpecialInstructions,
+	}
+	for _, instruction := range checkIn.Instructions {
+		if instruction.Instruction != "" {
+			response.Instructions = append(response.Instructions, instruction.Instruction)
+		}
+	}
+
+	if response.CheckInStart == "" && response.CheckInEnd == "" && response.Checkout == "" &&
+		response.SpecialInstructions == "" && len(response.Instructions) == 0 {
+		return nil
+	}
+	return response
+}
+
 // ConvertReviewToResponse converts a cupid.Review to ReviewResponse
 func ConvertReviewToResponse(review cupid.Review) ReviewResponse {
 	return ReviewResponse{
@@ -203,3 +418,19 @@ func ConvertTranslationToResponse(language string, translation *cupid.Property)
 		ImportantInfo:       translation.ImportantInfo,
 	}
 }
+
+// ConvertTranslationsToResponse converts translations keyed by language into a slice sorted by language code,
+// so responses have a stable order
+func ConvertTranslationsToResponse(translations map[string]*cupid.Property) []TranslationResponse {
+	languages := make([]string, 0, len(translations))
+	for language := range translations {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+
+	response := make([]TranslationResponse, 0, len(languages))
+	for _, language := range languages {
+		response = append(response, ConvertTranslationToResponse(language, translations[language]))
+	}
+	return response
+}
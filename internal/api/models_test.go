@@ -1,15 +1,20 @@
 package api
 
 import (
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/barimehdi77/cupid-api/internal/cupid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Test ConvertPropertyToResponse
 func TestConvertPropertyToResponse(t *testing.T) {
 	// Arrange
+	lastSyncedAt := time.Date(2025, 9, 12, 6, 0, 0, 0, time.UTC)
+	minPrice := 129.0
 	property := &cupid.Property{
 		HotelID:     12345,
 		CupidID:     12345,
@@ -28,7 +33,16 @@ func TestConvertPropertyToResponse(t *testing.T) {
 			Country:    "gb",
 			PostalCode: "SW1A 1AA",
 		},
-		MainImageTh: "https://example.com/image.jpg",
+		MainImageTh:         "https://example.com/image.jpg",
+		Description:         "A test hotel",
+		MarkdownDescription: "A **test** hotel",
+		ImportantInfo:       "No smoking",
+		Phone:               "+44 20 1234 5678",
+		Fax:                 "+44 20 1234 5679",
+		Email:               "info@example.com",
+		MinPrice:            &minPrice,
+		Currency:            "GBP",
+		LastSyncedAt:        &lastSyncedAt,
 	}
 
 	// Act
@@ -46,6 +60,15 @@ func TestConvertPropertyToResponse(t *testing.T) {
 	assert.Equal(t, property.Rating, response.Rating)
 	assert.Equal(t, property.ReviewCount, response.ReviewCount)
 	assert.Equal(t, property.MainImageTh, response.MainImageTh)
+	assert.Equal(t, property.Description, response.Description)
+	assert.Equal(t, property.MarkdownDescription, response.MarkdownDescription)
+	assert.Equal(t, property.ImportantInfo, response.ImportantInfo)
+	assert.Equal(t, property.Phone, response.Phone)
+	assert.Equal(t, property.Fax, response.Fax)
+	assert.Equal(t, property.Email, response.Email)
+	assert.Equal(t, property.MinPrice, response.MinPrice)
+	assert.Equal(t, property.Currency, response.Currency)
+	assert.Equal(t, property.LastSyncedAt, response.LastSyncedAt)
 	// Note: CreatedAt and UpdatedAt are not part of the Property model
 
 	// Verify address conversion
@@ -79,6 +102,95 @@ func TestConvertPropertyToResponse_NilProperty(t *testing.T) {
 	// Note: CreatedAt and UpdatedAt are not part of the Property model
 }
 
+// Test ConvertPropertyToResponse - Nullable policy fields
+func TestConvertPropertyToResponse_NullableFields(t *testing.T) {
+	t.Run("Present", func(t *testing.T) {
+		// Arrange
+		parking := "Free parking"
+		groupRoomMin := 5
+		childAllowed := true
+		petsAllowed := false
+		property := &cupid.Property{
+			HotelID:      12345,
+			Parking:      &parking,
+			GroupRoomMin: &groupRoomMin,
+			ChildAllowed: &childAllowed,
+			PetsAllowed:  &petsAllowed,
+		}
+
+		// Act
+		response := ConvertPropertyToResponse(property)
+		body, err := json.Marshal(response)
+
+		// Assert
+		require.NoError(t, err)
+		require.NotNil(t, response.Parking)
+		assert.Equal(t, "Free parking", *response.Parking)
+		require.NotNil(t, response.GroupRoomMin)
+		assert.Equal(t, 5, *response.GroupRoomMin)
+		require.NotNil(t, response.ChildAllowed)
+		assert.True(t, *response.ChildAllowed)
+		require.NotNil(t, response.PetsAllowed)
+		assert.False(t, *response.PetsAllowed)
+		assert.Contains(t, string(body), `"pets_allowed":false`)
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		// Arrange
+		property := &cupid.Property{HotelID: 12345}
+
+		// Act
+		response := ConvertPropertyToResponse(property)
+		body, err := json.Marshal(response)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Nil(t, response.Parking)
+		assert.Nil(t, response.GroupRoomMin)
+		assert.Nil(t, response.ChildAllowed)
+		assert.Nil(t, response.PetsAllowed)
+		for _, key := range []string{"parking", "group_room_min", "child_allowed", "pets_allowed"} {
+			assert.NotContains(t, string(body), `"`+key+`"`)
+		}
+	})
+}
+
+// Test ConvertCheckInToResponse
+func TestConvertCheckInToResponse(t *testing.T) {
+	t.Run("Present", func(t *testing.T) {
+		// Arrange
+		checkIn := cupid.CheckIn{
+			CheckInStart:        "15:00",
+			CheckInEnd:          "23:00",
+			Checkout:            "11:00",
+			SpecialInstructions: "Key box at the entrance",
+			Instructions: []cupid.Instruction{
+				{ID: 1, Instruction: "Photo ID required"},
+				{ID: 2},
+			},
+		}
+
+		// Act
+		response := ConvertCheckInToResponse(checkIn)
+
+		// Assert
+		require.NotNil(t, response)
+		assert.Equal(t, "15:00", response.CheckInStart)
+		assert.Equal(t, "23:00", response.CheckInEnd)
+		assert.Equal(t, "11:00", response.Checkout)
+		assert.Equal(t, "Key box at the entrance", response.SpecialInstructions)
+		assert.Equal(t, []string{"Photo ID required"}, response.Instructions)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		// Act
+		response := ConvertCheckInToResponse(cupid.CheckIn{Instructions: []cupid.Instruction{{ID: 1}}})
+
+		// Assert
+		assert.Nil(t, response)
+	})
+}
+
 // Test ConvertReviewToResponse
 func TestConvertReviewToResponse(t *testing.T) {
 	// Arrange
@@ -181,6 +293,27 @@ func TestConvertTranslationToResponse_EmptyLanguage(t *testing.T) {
 	assert.Equal(t, translation.HotelName, response.HotelName)
 }
 
+// Test ConvertTranslationsToResponse sorts by language code
+func TestConvertTranslationsToResponse(t *testing.T) {
+	// Arrange
+	translations := map[string]*cupid.Property{
+		"fr": {HotelName: "Hôtel de Test"},
+		"de": {HotelName: "Testhotel"},
+		"es": {HotelName: "Hotel de Prueba"},
+	}
+
+	// Act
+	response := ConvertTranslationsToResponse(translations)
+
+	// Assert
+	assert.Len(t, response, 3)
+	assert.Equal(t, "de", response[0].Language)
+	assert.Equal(t, "es", response[1].Language)
+	assert.Equal(t, "fr", response[2].Language)
+	assert.Equal(t, "Hôtel de Test", response[2].HotelName)
+	assert.NotNil(t, ConvertTranslationsToResponse(nil))
+}
+
 // Test PropertyListRequest validation
 func TestPropertyListRequest_Validation(t *testing.T) {
 	tests := []struct {
@@ -195,10 +328,10 @@ func TestPropertyListRequest_Validation(t *testing.T) {
 				Limit:     20,
 				City:      "London",
 				Country:   "gb",
-				MinStars:  3,
-				MaxStars:  5,
-				MinRating: 7.0,
-				MaxRating: 10.0,
+				MinStars:  intPtr(3),
+				MaxStars:  intPtr(5),
+				MinRating: float64Ptr(7.0),
+				MaxRating: float64Ptr(10.0),
 				HotelType: "Hotels",
 				Chain:     "Test Chain",
 				Search:    "test",
@@ -234,8 +367,8 @@ func TestPropertyListRequest_Validation(t *testing.T) {
 			request: PropertyListRequest{
 				Page:     1,
 				Limit:    20,
-				MinStars: 6, // Should be max 5
-				MaxStars: 5,
+				MinStars: intPtr(6), // Should be max 5
+				MaxStars: intPtr(5),
 			},
 			expectError: true,
 		},
@@ -244,8 +377,8 @@ func TestPropertyListRequest_Validation(t *testing.T) {
 			request: PropertyListRequest{
 				Page:      1,
 				Limit:     20,
-				MinRating: 11.0, // Should be max 10
-				MaxRating: 10.0,
+				MinRating: float64Ptr(11.0), // Should be max 10
+				MaxRating: float64Ptr(10.0),
 			},
 			expectError: true,
 		},
@@ -433,17 +566,15 @@ func validatePropertyListRequest(req PropertyListRequest) error {
 	if req.Limit < 1 || req.Limit > 100 {
 		return assert.AnError
 	}
-	if req.MinStars < 0 || req.MinStars > 5 {
-		return assert.AnError
+	for _, stars := range []*int{req.MinStars, req.MaxStars} {
+		if stars != nil && (*stars < 0 || *stars > 5) {
+			return assert.AnError
+		}
 	}
-	if req.MaxStars < 0 || req.MaxStars > 5 {
-		return assert.AnError
-	}
-	if req.MinRating < 0 || req.MinRating > 10 {
-		return assert.AnError
-	}
-	if req.MaxRating < 0 || req.MaxRating > 10 {
-		return assert.AnError
+	for _, rating := range []*float64{req.MinRating, req.MaxRating} {
+		if rating != nil && (*rating < 0 || *rating > 10) {
+			return assert.AnError
+		}
 	}
 	return nil
 }
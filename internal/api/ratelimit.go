@@ -0,0 +1,106 @@
+package api
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyHeader is the request header carrying a client API key with its own rate limit
+const APIKeyHeader = "X-API-Key"
+
+// rateLimitWindow tracks the requests of one client in the current fixed window
+type rateLimitWindow struct {
+	start time.Time
+	count int
+}
+
+// rateLimiter counts requests per client in fixed windows
+type rateLimiter struct {
+	mu        sync.Mutex
+	window    time.Duration
+	clients   map[string]*rateLimitWindow
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// newRateLimiter creates a rate limiter with the given window length
+func newRateLimiter(window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		window:  window,
+		clients: make(map[string]*rateLimitWindow),
+		now:     time.Now,
+	}
+}
+
+// allow records a request from client and reports whether it is within limit.
+// When it is not, the time until the window resets is returned.
+func (l *rateLimiter) allow(client string, limit int) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	current, ok := l.clients[client]
+	if !ok || now.Sub(current.start) >= l.window {
+		current = &rateLimitWindow{start: now}
+		l.clients[client] = current
+	}
+
+	if current.count >= limit {
+		return false, current.start.Add(l.window).Sub(now)
+	}
+	current.count++
+	return true, 0
+}
+
+// sweep drops the clients whose window has expired, at most once per window, so idle clients do not accumulate
+func (l *rateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.window {
+		return
+	}
+	for client, current := range l.clients {
+		if now.Sub(current.start) >= l.window {
+			delete(l.clients, client)
+		}
+	}
+	l.lastSweep = now
+}
+
+// RateLimitMiddleware limits the number of requests each client may make per Config.RateLimitWindow.
+// Requests carrying an API key listed in Config.RateLimitKeys are counted per key against that key's limit;
+// every other request, including those with an unknown key, is counted per client IP against Config.RateLimit.
+// A RateLimit of zero or less disables the limit.
+func RateLimitMiddleware(config *Config) gin.HandlerFunc {
+	if config.RateLimit <= 0 || config.RateLimitWindow <= 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	limiter := newRateLimiter(config.RateLimitWindow)
+	return func(c *gin.Context) {
+		client, limit := "ip:"+c.ClientIP(), config.RateLimit
+		if key := c.GetHeader(APIKeyHeader); key != "" {
+			if keyLimit, ok := config.RateLimitKeys[key]; ok {
+				client, limit = "key:"+key, keyLimit
+			}
+		}
+
+		allowed, retryAfter := limiter.allow(client, limit)
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, APIResponse{
+				Success: false,
+				Error:   "Rate limit exceeded, retry later",
+				Code:    errorCodeRateLimited,
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupRateLimitedRouter(config *Config) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	_ = router.SetTrustedProxies(config.TrustedProxies)
+	router.GET("/api/v1/properties", RateLimitMiddleware(config), func(c *gin.Context) {
+		c.JSON(http.StatusOK, APIResponse{Success: true})
+	})
+	return router
+}
+
+// sendRequests sends count requests with the given API key and returns their status codes
+func sendRequests(router *gin.Engine, count int, apiKey string) []int {
+	codes := make([]int, 0, count)
+	for range count {
+		req, _ := http.NewRequest("GET", "/api/v1/properties", nil)
+		if apiKey != "" {
+			req.Header.Set(APIKeyHeader, apiKey)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		codes = append(codes, w.Code)
+	}
+	return codes
+}
+
+// countStatus counts the occurrences of status in codes
+func countStatus(codes []int, status int) int {
+	count := 0
+	for _, code := range codes {
+		if code == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Test RateLimitMiddleware
+func TestRateLimitMiddleware(t *testing.T) {
+	t.Run("PrivilegedKeyGetsHigherLimit", func(t *testing.T) {
+		// Arrange
+		config := DefaultConfig()
+		config.RateLimit = 3
+		config.RateLimitKeys = map[string]int{"integration-key": 10}
+		router := setupRateLimitedRouter(config)
+
+		// Act
+		anonymous := sendRequests(router, 12, "")
+		privileged := sendRequests(router, 12, "integration-key")
+
+		// Assert
+		assert.Equal(t, 3, countStatus(anonymous, http.StatusOK))
+		assert.Equal(t, 10, countStatus(privileged, http.StatusOK))
+		assert.Equal(t, 2, countStatus(privileged, http.StatusTooManyRequests))
+	})
+
+	t.Run("UnknownKeyFallsBackToIP", func(t *testing.T) {
+		// Arrange
+		config := DefaultConfig()
+		config.RateLimit = 2
+		config.RateLimitKeys = map[string]int{"integration-key": 10}
+		router := setupRateLimitedRouter(config)
+
+		// Act
+		anonymous := sendRequests(router, 1, "")
+		unknown := sendRequests(router, 3, "made-up-key")
+
+		// Assert
+		assert.Equal(t, []int{http.StatusOK}, anonymous)
+		assert.Equal(t, []int{http.StatusOK, http.StatusTooManyRequests, http.StatusTooManyRequests}, unknown)
+	})
+
+	t.Run("RejectedRequest", func(t *testing.T) {
+		// Arrange
+		config := DefaultConfig()
+		config.RateLimit = 1
+		router := setupRateLimitedRouter(config)
+		sendRequests(router, 1, "")
+
+		req, _ := http.NewRequest("GET", "/api/v1/properties", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "60", w.Header().Get("Retry-After"))
+
+		var response APIResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.False(t, response.Success)
+		assert.Equal(t, errorCodeRateLimited, response.Code)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		// Arrange
+		router := setupRateLimitedRouter(DefaultConfig())
+
+		// Act
+		codes := sendRequests(router, 50, "")
+
+		// Assert
+		assert.Equal(t, 50, countStatus(codes, http.StatusOK))
+	})
+
+	t.Run("SpoofedForwardedForIgnored", func(t *testing.T) {
+		// Arrange
+		config := DefaultConfig()
+		config.RateLimit = 2
+		router := setupRateLimitedRouter(config)
+
+		// Act
+		codes := make([]int, 0, 5)
+		for i := range 5 {
+			req, _ := http.NewRequest("GET", "/api/v1/properties", nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+			req.Header.Set("X-Forwarded-For", fmt.Sprintf("203.0.113.%d", i+1))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			codes = append(codes, w.Code)
+		}
+
+		// Assert
+		assert.Equal(t, 2, countStatus(codes, http.StatusOK))
+		assert.Equal(t, 3, countStatus(codes, http.StatusTooManyRequests))
+	})
+
+	t.Run("TrustedProxyForwardedFor", func(t *testing.T) {
+		// Arrange
+		config := DefaultConfig()
+		config.RateLimit = 1
+		config.TrustedProxies = []string{"192.0.2.1"}
+		router := setupRateLimitedRouter(config)
+
+		// Act
+		codes := make([]int, 0, 3)
+		for i := range 3 {
+			req, _ := http.NewRequest("GET", "/api/v1/properties", nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+			req.Header.Set("X-Forwarded-For", fmt.Sprintf("203.0.113.%d", i+1))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			codes = append(codes, w.Code)
+		}
+
+		// Assert
+		assert.Equal(t, 3, countStatus(codes, http.StatusOK))
+	})
+}
+
+// Test rateLimiter window reset
+func TestRateLimiter_WindowReset(t *testing.T) {
+	// Arrange
+	now := time.Date(2025, 9, 14, 12, 0, 0, 0, time.UTC)
+	limiter := newRateLimiter(time.Minute)
+	limiter.now = func() time.Time { return now }
+
+	// Act & Assert
+	allowed, _ := limiter.allow("ip:10.0.0.1", 1)
+	assert.True(t, allowed)
+
+	now = now.Add(20 * time.Second)
+	allowed, retryAfter := limiter.allow("ip:10.0.0.1", 1)
+	assert.False(t, allowed)
+	assert.Equal(t, 40*time.Second, retryAfter)
+
+	now = now.Add(40 * time.Second)
+	allowed, _ = limiter.allow("ip:10.0.0.1", 1)
+	assert.True(t, allowed)
+	assert.Len(t, limiter.clients, 1)
+}
+
+// Test parseRateLimitKeys
+func TestParseRateLimitKeys(t *testing.T) {
+	keys := parseRateLimitKeys(" key-a:1000, key-b : 50,no-limit,:5,key-c:0,key-d:abc,key:with:colon:7")
+
+	assert.Equal(t, map[string]int{"key-a": 1000, "key-b": 50, "key:with:colon": 7}, keys)
+	assert.Empty(t, parseRateLimitKeys(""))
+}
+
+// Test parseTrustedProxies
+func TestParseTrustedProxies(t *testing.T) {
+	proxies := parseTrustedProxies(" 10.0.0.1, ,192.168.0.0/16,")
+
+	assert.Equal(t, []string{"10.0.0.1", "192.168.0.0/16"}, proxies)
+	assert.Empty(t, parseTrustedProxies(""))
+}
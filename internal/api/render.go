@@ -0,0 +1,156 @@
+package api
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/barimehdi77/cupid-api/internal/store"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// errResponseTooLarge is logged when a buffered response exceeds Config.MaxResponseBytes
+var errResponseTooLarge = errors.New("response too large")
+
+// Response formats with a configurable Content-Type
+const (
+	FormatJSON    = "json"
+	FormatXML     = "xml"
+	FormatCSV     = "csv"
+	FormatGeoJSON = "geojson"
+)
+
+// ContentType returns the configured Content-Type header value for a response format
+func (cfg *Config) ContentType(format string) string {
+	switch format {
+	case FormatXML:
+		return cfg.XMLContentType
+	case FormatCSV:
+		return cfg.CSVContentType
+	case FormatGeoJSON:
+		return cfg.GeoJSONContentType
+	default:
+		return cfg.JSONContentType
+	}
+}
+
+// writeJSON writes obj as JSON with the configured Content-Type.
+// Rows skipped by the storage while serving the request are reported, see SkippedRowsMiddleware.
+func writeJSON(c *gin.Context, config *Config, code int, obj interface{}) {
+	skipped := 0
+	if c.Request != nil {
+		skipped = store.SkippedRows(c.Request.Context())
+	}
+	if skipped > 0 {
+		c.Header(SkippedRowsHeader, strconv.Itoa(skipped))
+	}
+
+	if response, ok := obj.(APIResponse); ok && skipped > 0 && response.Meta != nil {
+		response.Meta.SkippedRows = skipped
+	}
+
+	body := newLimitedBuffer(config.MaxResponseBytes)
+	err := json.NewEncoder(body).Encode(obj)
+	writeBuffered(c, config, code, config.ContentType(FormatJSON), body.Bytes(), err)
+}
+
+// writeGeoJSON writes a FeatureCollection with the configured GeoJSON Content-Type
+func writeGeoJSON(c *gin.Context, config *Config, code int, collection GeoJSONFeatureCollection) {
+	body := newLimitedBuffer(config.MaxResponseBytes)
+	err := json.NewEncoder(body).Encode(collection)
+	writeBuffered(c, config, code, config.ContentType(FormatGeoJSON), body.Bytes(), err)
+}
+
+// writeXML writes obj as XML with the configured Content-Type
+func writeXML(c *gin.Context, config *Config, code int, obj interface{}) {
+	body := newLimitedBuffer(config.MaxResponseBytes)
+	err := xml.NewEncoder(body).Encode(obj)
+	writeBuffered(c, config, code, config.ContentType(FormatXML), body.Bytes(), err)
+}
+
+// limitedBuffer is an in-memory response body whose writes fail with errResponseTooLarge once it would hold more
+// than limit bytes, so rendering an oversized response stops there; a limit of 0 disables the check
+type limitedBuffer struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+// newLimitedBuffer creates a response body holding at most limit bytes
+func newLimitedBuffer(limit int) *limitedBuffer {
+	return &limitedBuffer{limit: limit}
+}
+
+// Write appends p, or fails without writing anything when the body would exceed the limit
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.limit > 0 && b.buf.Len()+len(p) > b.limit {
+		return 0, fmt.Errorf("%w: maximum is %d bytes", errResponseTooLarge, b.limit)
+	}
+	return b.buf.Write(p)
+}
+
+// Bytes returns the body written so far
+func (b *limitedBuffer) Bytes() []byte {
+	return b.buf.Bytes()
+}
+
+// writeBuffered writes a body rendered in memory, unless it failed to render, in which case the failure is
+// logged and a small 500 error is sent instead.
+// Every format is rendered into a limitedBuffer so a huge batch or export cannot pin a multi-hundred-MB body in
+// memory while it is sent; a body that outgrows Config.MaxResponseBytes arrives here as an errResponseTooLarge
+// error. Content-Length is always set, since the body is known upfront, so HEAD responses and large bodies
+// report it instead of being chunked.
+func writeBuffered(c *gin.Context, config *Config, code int, contentType string, body []byte, err error) {
+	if err != nil {
+		logError(c, "Failed to write response", err, zap.String("path", c.FullPath()), zap.Int("status", code))
+
+		message, errorCode := "Failed to render response", ""
+		if errors.Is(err, errResponseTooLarge) {
+			message, errorCode = "Response too large, narrow the request", errorCodeResponseTooLarge
+		}
+		fallback, _ := json.Marshal(APIResponse{Success: false, Error: message, Code: errorCode})
+		c.Abort()
+		c.Header("Content-Length", strconv.Itoa(len(fallback)))
+		c.Data(http.StatusInternalServerError, config.ContentType(FormatJSON), fallback)
+		return
+	}
+
+	c.Header("Content-Length", strconv.Itoa(len(body)))
+	c.Data(code, contentType, body)
+}
+
+// writeCSV writes the records produced by write as CSV with the configured Content-Type.
+// write is called with a csv.Writer encoding into a limitedBuffer, so it can stream records from a database
+// cursor and stops with an errResponseTooLarge error once the export outgrows Config.MaxResponseBytes; oversized
+// exports get the same 500 error as other responses. Any other error from write is returned without writing a
+// response, for the caller to report.
+func writeCSV(c *gin.Context, config *Config, code int, write func(*csv.Writer) error) error {
+	body := newLimitedBuffer(config.MaxResponseBytes)
+	writer := csv.NewWriter(body)
+	err := write(writer)
+	if err == nil {
+		writer.Flush()
+		err = writer.Error()
+	}
+	if err != nil && !errors.Is(err, errResponseTooLarge) {
+		return err
+	}
+
+	writeBuffered(c, config, code, config.ContentType(FormatCSV), body.Bytes(), err)
+	return nil
+}
+
+// respondJSON writes obj as JSON using the handlers' configured Content-Type
+func (h *Handlers) respondJSON(c *gin.Context, code int, obj interface{}) {
+	writeJSON(c, h.config, code, obj)
+}
+
+// respondJSON writes obj as JSON using the sync handlers' configured Content-Type
+func (h *SyncHandlers) respondJSON(c *gin.Context, code int, obj interface{}) {
+	writeJSON(c, h.config, code, obj)
+}
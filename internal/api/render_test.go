@@ -0,0 +1,274 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"github.com/barimehdi77/cupid-api/internal/store"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// Test response content types for each format
+func TestResponseContentTypes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	customConfig := DefaultConfig()
+	customConfig.JSONContentType = "application/json"
+	customConfig.XMLContentType = "text/xml; charset=iso-8859-1"
+	customConfig.CSVContentType = "text/csv"
+	customConfig.GeoJSONContentType = "application/json"
+
+	tests := []struct {
+		name     string
+		config   *Config
+		write    func(c *gin.Context, config *Config)
+		expected string
+	}{
+		{
+			name:     "Default JSON",
+			config:   DefaultConfig(),
+			write:    func(c *gin.Context, config *Config) { writeJSON(c, config, http.StatusOK, APIResponse{Success: true}) },
+			expected: "application/json; charset=utf-8",
+		},
+		{
+			name:     "Custom JSON",
+			config:   customConfig,
+			write:    func(c *gin.Context, config *Config) { writeJSON(c, config, http.StatusOK, APIResponse{Success: true}) },
+			expected: "application/json",
+		},
+		{
+			name:     "Default XML",
+			config:   DefaultConfig(),
+			write:    func(c *gin.Context, config *Config) { writeXML(c, config, http.StatusOK, gin.H{"success": true}) },
+			expected: "application/xml; charset=utf-8",
+		},
+		{
+			name:     "Custom XML",
+			config:   customConfig,
+			write:    func(c *gin.Context, config *Config) { writeXML(c, config, http.StatusOK, gin.H{"success": true}) },
+			expected: "text/xml; charset=iso-8859-1",
+		},
+		{
+			name:   "Default CSV",
+			config: DefaultConfig(),
+			write: func(c *gin.Context, config *Config) {
+				require.NoError(t, writeCSV(c, config, http.StatusOK, writeRecords([][]string{{"id", "name"}, {"1", "Hotel"}})))
+			},
+			expected: "text/csv; charset=utf-8",
+		},
+		{
+			name:   "Custom CSV",
+			config: customConfig,
+			write: func(c *gin.Context, config *Config) {
+				require.NoError(t, writeCSV(c, config, http.StatusOK, writeRecords([][]string{{"id", "name"}, {"1", "Hotel"}})))
+			},
+			expected: "text/csv",
+		},
+		{
+			name:   "Default GeoJSON",
+			config: DefaultConfig(),
+			write: func(c *gin.Context, config *Config) {
+				writeGeoJSON(c, config, http.StatusOK, ConvertPropertiesToFeatureCollection(nil, nil))
+			},
+			expected: "application/geo+json",
+		},
+		{
+			name:   "Custom GeoJSON",
+			config: customConfig,
+			write: func(c *gin.Context, config *Config) {
+				writeGeoJSON(c, config, http.StatusOK, ConvertPropertiesToFeatureCollection(nil, nil))
+			},
+			expected: "application/json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			// Act
+			tt.write(c, tt.config)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expected, w.Header().Get("Content-Type"))
+			assert.NotEmpty(t, w.Body.String())
+		})
+	}
+}
+
+// Test handlers use the configured JSON content type
+func TestHandlers_ConfiguredJSONContentType(t *testing.T) {
+	// Arrange
+	config := DefaultConfig()
+	config.JSONContentType = "application/json"
+	mockStorage := new(MockStorage)
+	handlers := NewHandlersWithConfig(mockStorage, config)
+	router := setupTestRouter(handlers)
+
+	mockStorage.On("ListFacilities", mock.Anything).Return([]store.FacilitySummary{}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/facilities", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+}
+
+// Test buffered responses larger than Config.MaxResponseBytes are replaced by an error
+func TestMaxResponseBytes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger.InitLogger()
+	oversized := APIResponse{Success: true, Data: strings.Repeat("x", 2048)}
+
+	tests := []struct {
+		name  string
+		write func(c *gin.Context, config *Config)
+	}{
+		{name: "JSON", write: func(c *gin.Context, config *Config) { writeJSON(c, config, http.StatusOK, oversized) }},
+		{name: "XML", write: func(c *gin.Context, config *Config) {
+			writeXML(c, config, http.StatusOK, gin.H{"data": strings.Repeat("x", 2048)})
+		}},
+		{name: "GeoJSON", write: func(c *gin.Context, config *Config) {
+			properties := make([]PropertyResponse, 50)
+			writeGeoJSON(c, config, http.StatusOK, ConvertPropertiesToFeatureCollection(properties, nil))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			config := DefaultConfig()
+			config.MaxResponseBytes = 1024
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/api/v1/properties", nil)
+
+			// Act
+			tt.write(c, config)
+
+			// Assert
+			assert.Equal(t, http.StatusInternalServerError, w.Code)
+			assert.Equal(t, config.ContentType(FormatJSON), w.Header().Get("Content-Type"))
+			assert.Less(t, w.Body.Len(), 1024)
+
+			var response APIResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.False(t, response.Success)
+			assert.Equal(t, errorCodeResponseTooLarge, response.Code)
+			assert.True(t, c.IsAborted())
+		})
+	}
+
+	t.Run("WithinLimit", func(t *testing.T) {
+		// Arrange
+		config := DefaultConfig()
+		config.MaxResponseBytes = 4096
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		// Act
+		writeJSON(c, config, http.StatusOK, oversized)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), strings.Repeat("x", 2048))
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		// Arrange
+		config := DefaultConfig()
+		config.MaxResponseBytes = 1024
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/api/v1/admin/sync/logs/export", nil)
+
+		// Act
+		err := writeCSV(c, config, http.StatusOK, writeRecords([][]string{{"data"}, {strings.Repeat("x", 2048)}}))
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, config.ContentType(FormatJSON), w.Header().Get("Content-Type"))
+		assert.NotContains(t, w.Body.String(), "xxx")
+	})
+
+	t.Run("CSVStopsAtLimit", func(t *testing.T) {
+		// Arrange
+		config := DefaultConfig()
+		config.MaxResponseBytes = 64 << 10
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/api/v1/admin/sync/logs/export", nil)
+		written := 0
+
+		// Act
+		err := writeCSV(c, config, http.StatusOK, func(writer *csv.Writer) error {
+			for range 10000 {
+				if err := writer.Write([]string{strings.Repeat("x", 64)}); err != nil {
+					return err
+				}
+				written++
+			}
+			return nil
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Less(t, written, 10000)
+
+		var response APIResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, errorCodeResponseTooLarge, response.Code)
+	})
+
+	t.Run("CSVWriteError", func(t *testing.T) {
+		// Arrange
+		config := DefaultConfig()
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		// Act
+		err := writeCSV(c, config, http.StatusOK, func(writer *csv.Writer) error { return assert.AnError })
+
+		// Assert
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.False(t, c.Writer.Written())
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		// Arrange
+		config := DefaultConfig()
+		config.MaxResponseBytes = 0
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+
+		// Act
+		writeJSON(c, config, http.StatusOK, oversized)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
+
+// writeRecords returns a writeCSV callback writing records
+func writeRecords(records [][]string) func(*csv.Writer) error {
+	return func(writer *csv.Writer) error {
+		return writer.WriteAll(records)
+	}
+}
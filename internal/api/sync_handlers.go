@@ -1,11 +1,16 @@
 package api
 
 import (
+	"context"
+	"encoding/csv"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/barimehdi77/cupid-api/internal/logger"
+	"github.com/barimehdi77/cupid-api/internal/store"
 	"github.com/barimehdi77/cupid-api/internal/sync"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -14,12 +19,23 @@ import (
 // SyncHandlers contains sync-related API handlers
 type SyncHandlers struct {
 	syncService *sync.SyncService
+	config      *Config
 }
 
-// NewSyncHandlers creates a new sync handlers instance
+// NewSyncHandlers creates a new sync handlers instance with the default configuration
 func NewSyncHandlers(syncService *sync.SyncService) *SyncHandlers {
+	return NewSyncHandlersWithConfig(syncService, DefaultConfig())
+}
+
+// NewSyncHandlersWithConfig creates a new sync handlers instance with the given configuration
+func NewSyncHandlersWithConfig(syncService *sync.SyncService, config *Config) *SyncHandlers {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
 	return &SyncHandlers{
 		syncService: syncService,
+		config:      config,
 	}
 }
 
@@ -27,45 +43,104 @@ func NewSyncHandlers(syncService *sync.SyncService) *SyncHandlers {
 // @Summary Trigger manual synchronization
 // @Description Manually trigger a synchronization operation
 // @Tags admin
+// @Security AdminKey
 // @Accept json
 // @Produce json
 // @Success 200 {object} APIResponse{data=SyncResult}
+// @Failure 409 {object} APIResponse
 // @Failure 500 {object} APIResponse
 // @Router /admin/sync [post]
 func (h *SyncHandlers) TriggerSyncHandler(c *gin.Context) {
 	logger.Info("Manual sync triggered via API")
 
-	// Trigger sync in background
-	go func() {
-		ctx := c.Request.Context()
-		result, err := h.syncService.SyncNow(ctx)
-		if err != nil {
-			logger.LogError("Manual sync failed", err)
-		} else {
-			logger.LogSuccess("Manual sync completed",
-				zap.String("sync_id", result.SyncID),
-				zap.Int("total_properties", result.TotalProperties),
-				zap.Int("updated_properties", result.UpdatedProperties),
-				zap.Duration("duration", result.Duration),
-			)
-		}
-	}()
+	// The sync outlives the request, so it must not be cancelled when the response is written
+	syncID, err := h.syncService.TriggerSync(context.WithoutCancel(c.Request.Context()))
+	if errors.Is(err, sync.ErrSyncInProgress) {
+		h.respondJSON(c, http.StatusConflict, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Code:    errorCodeSyncInProgress,
+			Data: map[string]interface{}{
+				"sync_id": syncID,
+			},
+		})
+		return
+	}
 
-	c.JSON(http.StatusOK, APIResponse{
+	h.respondJSON(c, http.StatusOK, APIResponse{
 		Success: true,
 		Data: map[string]interface{}{
 			"status":             "running",
 			"message":            "Synchronization started in background",
 			"estimated_duration": "5-10 minutes",
 			"triggered_at":       time.Now(),
+			"status_url":         "/api/v1/admin/sync/status",
+		},
+	})
+}
+
+// SyncCountryHandler handles targeted resync requests for the properties of one or more countries
+// @Summary Resync the properties of a country
+// @Description Re-fetch from the Cupid API and update only the stored properties whose country matches, for a market that reported stale data.
+// @Description The sync runs in the background; its progress is reported by the sync status and its result by the sync logs under the returned sync_id.
+// @Tags admin
+// @Security AdminKey
+// @Accept json
+// @Produce json
+// @Param country query string true "Country code, or a comma-separated list of them (e.g. fr or fr,es)"
+// @Success 202 {object} APIResponse
+// @Failure 400 {object} APIResponse
+// @Failure 409 {object} APIResponse
+// @Router /admin/sync/country [post]
+func (h *SyncHandlers) SyncCountryHandler(c *gin.Context) {
+	var countries []string
+	for _, country := range strings.Split(c.Query("country"), ",") {
+		if country = strings.TrimSpace(country); country != "" {
+			countries = append(countries, country)
+		}
+	}
+	if len(countries) == 0 {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "country is required",
+		})
+		return
+	}
+
+	logger.Info("Country sync triggered via API", zap.Strings("countries", countries))
+
+	// The sync outlives the request, so it must not be cancelled when the response is written
+	syncID, err := h.syncService.TriggerCountrySync(context.WithoutCancel(c.Request.Context()), countries)
+	if errors.Is(err, sync.ErrSyncInProgress) {
+		h.respondJSON(c, http.StatusConflict, APIResponse{
+			Success: false,
+			Error:   err.Error(),
+			Code:    errorCodeSyncInProgress,
+			Data: map[string]interface{}{
+				"sync_id": syncID,
+			},
+		})
+		return
+	}
+
+	h.respondJSON(c, http.StatusAccepted, APIResponse{
+		Success: true,
+		Data: map[string]interface{}{
+			"status":       "running",
+			"message":      "Country synchronization started in background",
+			"sync_id":      syncID,
+			"countries":    countries,
+			"triggered_at": time.Now(),
+			"status_url":   "/api/v1/admin/sync/status",
 		},
 	})
 }
 
 // GetSyncStatusHandler handles sync status requests
 // @Summary Get sync status
-// @Description Get the current status of the synchronization service
+// @Description Get the current status of the synchronization service and the per-property changes of the last run
 // @Tags admin
+// @Security AdminKey
 // @Accept json
 // @Produce json
 // @Success 200 {object} APIResponse{data=SyncStatus}
@@ -73,16 +148,33 @@ func (h *SyncHandlers) TriggerSyncHandler(c *gin.Context) {
 func (h *SyncHandlers) GetSyncStatusHandler(c *gin.Context) {
 	status := h.syncService.GetStatus()
 
-	c.JSON(http.StatusOK, APIResponse{
+	h.respondJSON(c, http.StatusOK, APIResponse{
 		Success: true,
 		Data:    status,
 	})
 }
 
+// GetSyncScheduleHandler handles sync schedule requests
+// @Summary Get sync schedule
+// @Description Get the effective automatic synchronization schedule; the state is disabled, stopped or scheduled, and next_run is only set when scheduled
+// @Tags admin
+// @Security AdminKey
+// @Accept json
+// @Produce json
+// @Success 200 {object} APIResponse{data=ScheduleInfo}
+// @Router /admin/sync/schedule [get]
+func (h *SyncHandlers) GetSyncScheduleHandler(c *gin.Context) {
+	h.respondJSON(c, http.StatusOK, APIResponse{
+		Success: true,
+		Data:    h.syncService.GetSchedule(),
+	})
+}
+
 // StopSyncHandler handles sync stop requests
 // @Summary Stop sync service
 // @Description Stop the automatic synchronization service
 // @Tags admin
+// @Security AdminKey
 // @Accept json
 // @Produce json
 // @Success 200 {object} APIResponse
@@ -93,15 +185,15 @@ func (h *SyncHandlers) StopSyncHandler(c *gin.Context) {
 
 	err := h.syncService.Stop()
 	if err != nil {
-		logger.LogError("Failed to stop sync service", err)
-		c.JSON(http.StatusInternalServerError, APIResponse{
+		logError(c, "Failed to stop sync service", err)
+		h.respondJSON(c, http.StatusInternalServerError, APIResponse{
 			Success: false,
 			Error:   "Failed to stop sync service",
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, APIResponse{
+	h.respondJSON(c, http.StatusOK, APIResponse{
 		Success: true,
 		Data: map[string]interface{}{
 			"message":    "Sync service stopped successfully",
@@ -115,6 +207,7 @@ func (h *SyncHandlers) StopSyncHandler(c *gin.Context) {
 // @Summary Start sync service
 // @Description Start the automatic synchronization service
 // @Tags admin
+// @Security AdminKey
 // @Accept json
 // @Produce json
 // @Param interval query string false "Sync interval (e.g., 12h, 24h)" default(12h)
@@ -125,7 +218,7 @@ func (h *SyncHandlers) StartSyncHandler(c *gin.Context) {
 	intervalStr := c.DefaultQuery("interval", "12h")
 	interval, err := time.ParseDuration(intervalStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, APIResponse{
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
 			Success: false,
 			Error:   "Invalid interval format. Use format like '12h' or '24h'",
 		})
@@ -139,15 +232,15 @@ func (h *SyncHandlers) StartSyncHandler(c *gin.Context) {
 	ctx := c.Request.Context()
 	err = h.syncService.Start(ctx)
 	if err != nil {
-		logger.LogError("Failed to start sync service", err)
-		c.JSON(http.StatusInternalServerError, APIResponse{
+		logError(c, "Failed to start sync service", err)
+		h.respondJSON(c, http.StatusInternalServerError, APIResponse{
 			Success: false,
 			Error:   "Failed to start sync service",
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, APIResponse{
+	h.respondJSON(c, http.StatusOK, APIResponse{
 		Success: true,
 		Data: map[string]interface{}{
 			"message":    "Sync service started successfully",
@@ -159,57 +252,182 @@ func (h *SyncHandlers) StartSyncHandler(c *gin.Context) {
 	})
 }
 
+// defaultSyncLogsLimit is the number of sync logs returned when no limit is given
+const defaultSyncLogsLimit = 10
+
 // GetSyncLogsHandler handles sync logs requests
 // @Summary Get sync logs
-// @Description Get synchronization operation logs
+// @Description Get persisted synchronization runs, most recent first
 // @Tags admin
+// @Security AdminKey
 // @Accept json
 // @Produce json
 // @Param limit query int false "Number of logs to return" default(10)
 // @Param offset query int false "Number of logs to skip" default(0)
-// @Success 200 {object} APIResponse{data=[]SyncLog}
+// @Success 200 {object} APIResponse{data=[]sync.SyncLog,meta=Meta}
+// @Failure 400 {object} APIResponse
+// @Failure 500 {object} APIResponse
 // @Router /admin/sync/logs [get]
 func (h *SyncHandlers) GetSyncLogsHandler(c *gin.Context) {
-	limitStr := c.DefaultQuery("limit", "10")
+	limit := parseLimit(h.config, c.Query("limit"), defaultSyncLogsLimit)
 	offsetStr := c.DefaultQuery("offset", "0")
 
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 1 || limit > 100 {
-		c.JSON(http.StatusBadRequest, APIResponse{
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil || offset < 0 {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
 			Success: false,
-			Error:   "Invalid limit. Must be between 1 and 100",
+			Error:   "Invalid offset. Must be >= 0",
+		})
+		return
+	}
+	if err := validateOffset(h.config, offset); err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   err.Error(),
 		})
 		return
 	}
 
-	offset, err := strconv.Atoi(offsetStr)
-	if err != nil || offset < 0 {
-		c.JSON(http.StatusBadRequest, APIResponse{
+	filters := store.SyncLogFilters{Limit: limit, Offset: offset, NewestFirst: true}
+	logs, err := h.syncService.ListSyncLogs(c.Request.Context(), filters)
+	if err != nil {
+		logError(c, "Failed to list sync logs", err)
+		h.respondJSON(c, storageErrorStatus(c, err), APIResponse{
 			Success: false,
-			Error:   "Invalid offset. Must be >= 0",
+			Error:   "Failed to fetch sync logs",
 		})
 		return
 	}
 
-	// For now, return empty logs since we haven't implemented the storage layer
-	// This would be implemented to fetch from sync_logs table
-	logs := []sync.SyncLog{}
+	totalCount, err := h.syncService.CountSyncLogs(c.Request.Context(), filters)
+	if err != nil {
+		logError(c, "Failed to count sync logs", err)
+		h.respondJSON(c, storageErrorStatus(c, err), APIResponse{
+			Success: false,
+			Error:   "Failed to fetch sync logs",
+		})
+		return
+	}
 
-	c.JSON(http.StatusOK, APIResponse{
+	page := (offset / limit) + 1
+	totalPages := (totalCount + limit - 1) / limit
+	h.respondJSON(c, http.StatusOK, APIResponse{
 		Success: true,
 		Data:    logs,
 		Meta: &Meta{
-			Page:  (offset / limit) + 1,
-			Limit: limit,
-			Total: len(logs),
+			Page:       page,
+			Limit:      limit,
+			Total:      totalCount,
+			TotalItems: totalCount,
+			TotalPages: totalPages,
+			HasNext:    offset+len(logs) < totalCount,
+			HasPrev:    offset > 0,
 		},
 	})
 }
 
+// syncLogCSVHeader lists the columns of exported sync logs
+var syncLogCSVHeader = []string{
+	"sync_id", "sync_type", "status", "started_at", "completed_at",
+	"total_properties", "updated_properties", "failed_properties", "duration_seconds", "error_message",
+}
+
+// ExportSyncLogsHandler handles exporting persisted sync logs
+// @Summary Export sync logs
+// @Description Export persisted synchronization runs, oldest first, optionally limited to runs started between from and to
+// @Tags admin
+// @Security AdminKey
+// @Produce text/csv
+// @Param format query string false "Export format" Enums(csv) default(csv)
+// @Param from query string false "Only runs started at or after this time (RFC 3339 or YYYY-MM-DD)"
+// @Param to query string false "Only runs started before this time (RFC 3339, or YYYY-MM-DD to include the whole day)"
+// @Success 200 {string} string "CSV export"
+// @Failure 400 {object} APIResponse
+// @Failure 500 {object} APIResponse
+// @Router /admin/sync/logs/export [get]
+func (h *SyncHandlers) ExportSyncLogsHandler(c *gin.Context) {
+	if format := c.DefaultQuery("format", FormatCSV); format != FormatCSV {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid format: must be csv",
+		})
+		return
+	}
+
+	from, err := parseTimeBound(c.Query("from"), false)
+	if err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid from parameter: " + err.Error(),
+		})
+		return
+	}
+
+	to, err := parseTimeBound(c.Query("to"), true)
+	if err != nil {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "Invalid to parameter: " + err.Error(),
+		})
+		return
+	}
+
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
+			Success: false,
+			Error:   "from must be before to",
+		})
+		return
+	}
+
+	// Runs are encoded as they are read from the database cursor instead of being loaded first
+	filters := store.SyncLogFilters{From: from, To: to}
+	c.Header("Content-Disposition", `attachment; filename="sync_logs.csv"`)
+	err = writeCSV(c, h.config, http.StatusOK, func(writer *csv.Writer) error {
+		if err := writer.Write(syncLogCSVHeader); err != nil {
+			return err
+		}
+		return h.syncService.EachSyncLog(c.Request.Context(), filters, func(entry sync.SyncLog) error {
+			return writer.Write(syncLogCSVRecord(entry))
+		})
+	})
+	if err != nil {
+		logError(c, "Failed to list sync logs", err)
+		c.Header("Content-Disposition", "")
+		h.respondJSON(c, storageErrorStatus(c, err), APIResponse{
+			Success: false,
+			Error:   "Failed to fetch sync logs",
+		})
+	}
+}
+
+// syncLogCSVRecord formats a synchronization run as a row of the sync log export
+func syncLogCSVRecord(entry sync.SyncLog) []string {
+	completedAt, duration := "", ""
+	if entry.CompletedAt != nil {
+		completedAt = entry.CompletedAt.UTC().Format(time.RFC3339)
+		duration = strconv.FormatFloat(entry.CompletedAt.Sub(entry.StartedAt).Seconds(), 'f', 3, 64)
+	}
+
+	return []string{
+		entry.SyncID,
+		entry.SyncType,
+		entry.Status,
+		entry.StartedAt.UTC().Format(time.RFC3339),
+		completedAt,
+		strconv.Itoa(entry.TotalProperties),
+		strconv.Itoa(entry.UpdatedProperties),
+		strconv.Itoa(entry.FailedProperties),
+		duration,
+		entry.ErrorMessage,
+	}
+}
+
 // GetSyncSettingsHandler handles sync settings requests
 // @Summary Get sync settings
 // @Description Get current synchronization settings
 // @Tags admin
+// @Security AdminKey
 // @Accept json
 // @Produce json
 // @Success 200 {object} APIResponse{data=[]SyncSettings}
@@ -244,7 +462,7 @@ func (h *SyncHandlers) GetSyncSettingsHandler(c *gin.Context) {
 		},
 	}
 
-	c.JSON(http.StatusOK, APIResponse{
+	h.respondJSON(c, http.StatusOK, APIResponse{
 		Success: true,
 		Data:    settings,
 	})
@@ -254,6 +472,7 @@ func (h *SyncHandlers) GetSyncSettingsHandler(c *gin.Context) {
 // @Summary Update sync settings
 // @Description Update synchronization settings
 // @Tags admin
+// @Security AdminKey
 // @Accept json
 // @Produce json
 // @Param settings body []SyncSettings true "Sync settings to update"
@@ -263,7 +482,7 @@ func (h *SyncHandlers) GetSyncSettingsHandler(c *gin.Context) {
 func (h *SyncHandlers) UpdateSyncSettingsHandler(c *gin.Context) {
 	var settings []sync.SyncSettings
 	if err := c.ShouldBindJSON(&settings); err != nil {
-		c.JSON(http.StatusBadRequest, APIResponse{
+		h.respondJSON(c, http.StatusBadRequest, APIResponse{
 			Success: false,
 			Error:   "Invalid request body",
 		})
@@ -276,7 +495,7 @@ func (h *SyncHandlers) UpdateSyncSettingsHandler(c *gin.Context) {
 		zap.Int("settings_count", len(settings)),
 	)
 
-	c.JSON(http.StatusOK, APIResponse{
+	h.respondJSON(c, http.StatusOK, APIResponse{
 		Success: true,
 		Data: map[string]interface{}{
 			"message":    "Sync settings updated successfully",
@@ -290,6 +509,7 @@ func (h *SyncHandlers) UpdateSyncSettingsHandler(c *gin.Context) {
 // @Summary Get sync health
 // @Description Get the health status of the synchronization service
 // @Tags admin
+// @Security AdminKey
 // @Accept json
 // @Produce json
 // @Success 200 {object} APIResponse{data=map[string]interface{}}
@@ -314,7 +534,7 @@ func (h *SyncHandlers) GetSyncHealthHandler(c *gin.Context) {
 		health["status"] = "unhealthy"
 	}
 
-	c.JSON(http.StatusOK, APIResponse{
+	h.respondJSON(c, http.StatusOK, APIResponse{
 		Success: true,
 		Data:    health,
 	})
@@ -0,0 +1,405 @@
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/barimehdi77/cupid-api/internal/cupid"
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"github.com/barimehdi77/cupid-api/internal/store"
+	"github.com/barimehdi77/cupid-api/internal/sync"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSyncTestRouter creates a router with the sync handlers backed by mockStorage
+func setupSyncTestRouter(mockStorage *MockStorage) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logger.InitLogger()
+
+	handlers := NewSyncHandlers(sync.NewSyncService(nil, mockStorage, nil))
+	router := gin.New()
+	router.GET("/api/v1/admin/sync/logs", handlers.GetSyncLogsHandler)
+	router.GET("/api/v1/admin/sync/logs/export", handlers.ExportSyncLogsHandler)
+	return router
+}
+
+// getSyncSchedule serves GET /api/v1/admin/sync/schedule for syncService and decodes the returned schedule
+func getSyncSchedule(t *testing.T, syncService *sync.SyncService) sync.ScheduleInfo {
+	t.Helper()
+
+	router := gin.New()
+	router.GET("/api/v1/admin/sync/schedule", NewSyncHandlers(syncService).GetSyncScheduleHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/admin/sync/schedule", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Success bool              `json:"success"`
+		Data    sync.ScheduleInfo `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.True(t, response.Success)
+	return response.Data
+}
+
+// Test GetSyncScheduleHandler - Disabled and not yet started automatic sync
+func TestGetSyncScheduleHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger.InitLogger()
+
+	t.Run("Auto sync disabled", func(t *testing.T) {
+		// Arrange
+		config := sync.DefaultConfig()
+		config.EnableAuto = false
+		syncService := sync.NewSyncService(nil, new(MockStorage), config)
+		require.NoError(t, syncService.Start(context.Background()))
+
+		// Act
+		schedule := getSyncSchedule(t, syncService)
+
+		// Assert
+		assert.False(t, schedule.AutoSyncEnabled)
+		assert.Equal(t, sync.ScheduleStateDisabled, schedule.State)
+		assert.Nil(t, schedule.NextRun)
+		assert.Empty(t, schedule.NextRunIn)
+		assert.Equal(t, sync.ScheduleStateDisabled, syncService.GetStatus().ScheduleState)
+	})
+
+	t.Run("Scheduler not started", func(t *testing.T) {
+		// Arrange
+		syncService := sync.NewSyncService(nil, new(MockStorage), nil)
+
+		// Act
+		schedule := getSyncSchedule(t, syncService)
+
+		// Assert
+		assert.True(t, schedule.AutoSyncEnabled)
+		assert.Equal(t, sync.ScheduleStateStopped, schedule.State)
+		assert.Nil(t, schedule.NextRun)
+	})
+}
+
+// blockingCupidService is a sync.CupidService whose FetchAllProperties blocks until release is closed
+type blockingCupidService struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingCupidService) FetchAllProperties(ctx context.Context) ([]*cupid.PropertyData, error) {
+	close(b.started)
+	<-b.release
+	return nil, nil
+}
+
+func (b *blockingCupidService) FetchProperty(ctx context.Context, propertyID int64) (*cupid.PropertyData, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (b *blockingCupidService) FetchProperties(ctx context.Context, propertyIDs []int64) ([]*cupid.PropertyData, error) {
+	return nil, errors.New("not implemented")
+}
+
+// Test TriggerSyncHandler - A second trigger during a running sync gets 409 with the running sync ID
+func TestTriggerSyncHandler_Conflict(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	logger.InitLogger()
+
+	mockStorage := new(MockStorage)
+	mockStorage.On("CreateSyncLog", mock.Anything, mock.Anything).Return(nil)
+	mockStorage.On("UpdateSyncLog", mock.Anything, mock.Anything).Return(nil)
+	cupidService := &blockingCupidService{started: make(chan struct{}), release: make(chan struct{})}
+	syncService := sync.NewSyncService(cupidService, mockStorage, nil)
+
+	handlers := NewSyncHandlers(syncService)
+	router := gin.New()
+	router.POST("/api/v1/admin/sync", handlers.TriggerSyncHandler)
+
+	// Act
+	first := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/v1/admin/sync", nil)
+	router.ServeHTTP(first, req)
+	<-cupidService.started
+
+	second := httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/api/v1/admin/sync", nil)
+	router.ServeHTTP(second, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusConflict, second.Code)
+
+	var response APIResponse
+	require.NoError(t, json.Unmarshal(second.Body.Bytes(), &response))
+	assert.False(t, response.Success)
+	assert.Equal(t, "SYNC_IN_PROGRESS", response.Code)
+	data, ok := response.Data.(map[string]interface{})
+	require.True(t, ok)
+	assert.Equal(t, syncService.CurrentSyncID(), data["sync_id"])
+	assert.NotEmpty(t, data["sync_id"])
+
+	close(cupidService.release)
+	assert.Eventually(t, func() bool {
+		return syncService.CurrentSyncID() == ""
+	}, time.Second, 10*time.Millisecond)
+}
+
+// Test SyncCountryHandler - Country validation and a background scoped sync
+func TestSyncCountryHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger.InitLogger()
+
+	t.Run("MissingCountry", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		router := gin.New()
+		router.POST("/api/v1/admin/sync/country", NewSyncHandlers(sync.NewSyncService(nil, mockStorage, nil)).SyncCountryHandler)
+
+		// Act
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/admin/sync/country?country=%20,", nil)
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockStorage.AssertNotCalled(t, "GetPropertiesByCountries", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		mockStorage.On("CreateSyncLog", mock.Anything, mock.Anything).Return(nil)
+		mockStorage.On("GetPropertiesByCountries", mock.Anything, []string{"fr", "es"}).Return([]*cupid.Property{}, nil)
+		completed := make(chan struct{})
+		mockStorage.On("UpdateSyncLog", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+			close(completed)
+		}).Return(nil)
+		syncService := sync.NewSyncService(nil, mockStorage, nil)
+		router := gin.New()
+		router.POST("/api/v1/admin/sync/country", NewSyncHandlers(syncService).SyncCountryHandler)
+
+		// Act
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/v1/admin/sync/country?country=fr,%20es", nil)
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		var response struct {
+			Success bool `json:"success"`
+			Data    struct {
+				Status string `json:"status"`
+				SyncID string `json:"sync_id"`
+			} `json:"data"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.True(t, response.Success)
+		assert.Equal(t, "running", response.Data.Status)
+		assert.True(t, strings.HasPrefix(response.Data.SyncID, "sync_country_"))
+		select {
+		case <-completed:
+		case <-time.After(time.Second):
+			t.Fatal("country sync did not complete")
+		}
+		mockStorage.AssertExpectations(t)
+	})
+}
+
+// Test GetSyncLogsHandler - persisted sync runs are listed most recent first with pagination metadata
+func TestGetSyncLogsHandler(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		router := setupSyncTestRouter(mockStorage)
+
+		startedAt := time.Date(2025, 9, 10, 12, 0, 0, 0, time.UTC)
+		filters := store.SyncLogFilters{Limit: 2, Offset: 2, NewestFirst: true}
+		mockStorage.On("ListSyncLogs", mock.Anything, filters).Return([]store.SyncLogRecord{
+			{SyncID: "sync_20250910_120000", SyncType: "manual", Status: "failed", StartedAt: startedAt, ErrorMessage: "upstream unavailable"},
+			{SyncID: "sync_20250910_060000", SyncType: "scheduled", Status: "completed", StartedAt: startedAt.Add(-6 * time.Hour), TotalProperties: 120},
+		}, nil)
+		mockStorage.On("CountSyncLogs", mock.Anything, filters).Return(5, nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/admin/sync/logs?limit=2&offset=2", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Data []sync.SyncLog `json:"data"`
+			Meta Meta           `json:"meta"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Data, 2)
+		assert.Equal(t, "sync_20250910_120000", response.Data[0].SyncID)
+		assert.Equal(t, "upstream unavailable", response.Data[0].ErrorMessage)
+		assert.Equal(t, 120, response.Data[1].TotalProperties)
+		assert.Equal(t, Meta{Page: 2, Limit: 2, Total: 5, TotalItems: 5, TotalPages: 3, HasNext: true, HasPrev: true}, response.Meta)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("InvalidOffset", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		router := setupSyncTestRouter(mockStorage)
+
+		req, _ := http.NewRequest("GET", "/api/v1/admin/sync/logs?offset=-1", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockStorage.AssertNotCalled(t, "ListSyncLogs", mock.Anything, mock.Anything)
+	})
+
+	t.Run("StorageError", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		router := setupSyncTestRouter(mockStorage)
+
+		mockStorage.On("ListSyncLogs", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+		req, _ := http.NewRequest("GET", "/api/v1/admin/sync/logs", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		mockStorage.AssertExpectations(t)
+	})
+}
+
+// Test ExportSyncLogsHandler - CSV export
+func TestExportSyncLogsHandler_CSV(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	router := setupSyncTestRouter(mockStorage)
+
+	startedAt := time.Date(2025, 9, 10, 6, 0, 0, 0, time.UTC)
+	completedAt := startedAt.Add(90 * time.Second)
+	filters := store.SyncLogFilters{
+		From: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 9, 11, 0, 0, 0, 0, time.UTC),
+	}
+	mockStorage.On("EachSyncLog", mock.Anything, filters, mock.Anything).Return([]store.SyncLogRecord{
+		{
+			SyncID:            "sync_20250910_060000",
+			SyncType:          "scheduled",
+			Status:            "completed",
+			StartedAt:         startedAt,
+			CompletedAt:       &completedAt,
+			TotalProperties:   120,
+			UpdatedProperties: 7,
+			FailedProperties:  1,
+		},
+		{
+			SyncID:       "sync_20250910_120000",
+			SyncType:     "manual",
+			Status:       "failed",
+			StartedAt:    startedAt.Add(6 * time.Hour),
+			ErrorMessage: "failed to fetch properties, upstream unavailable",
+		},
+	}, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/admin/sync/logs/export?format=csv&from=2025-09-01&to=2025-09-10", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, DefaultConfig().CSVContentType, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "sync_logs.csv")
+
+	records, err := csv.NewReader(strings.NewReader(w.Body.String())).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{
+		"sync_id", "sync_type", "status", "started_at", "completed_at",
+		"total_properties", "updated_properties", "failed_properties", "duration_seconds", "error_message",
+	}, records[0])
+	assert.Equal(t, []string{
+		"sync_20250910_060000", "scheduled", "completed", "2025-09-10T06:00:00Z", "2025-09-10T06:01:30Z",
+		"120", "7", "1", "90.000", "",
+	}, records[1])
+	assert.Equal(t, "", records[2][4])
+	assert.Equal(t, "failed to fetch properties, upstream unavailable", records[2][9])
+	mockStorage.AssertExpectations(t)
+}
+
+// Test ExportSyncLogsHandler - Invalid parameters
+func TestExportSyncLogsHandler_InvalidParams(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		expectedError string
+	}{
+		{"Unsupported format", "format=xml", "Invalid format: must be csv"},
+		{"Invalid from", "from=yesterday", "Invalid from parameter: must be an RFC 3339 time or a YYYY-MM-DD date"},
+		{"Invalid to", "to=10/09/2025", "Invalid to parameter: must be an RFC 3339 time or a YYYY-MM-DD date"},
+		{"Inverted range", "from=2025-09-10&to=2025-09-01", "from must be before to"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockStorage := new(MockStorage)
+			router := setupSyncTestRouter(mockStorage)
+
+			req, _ := http.NewRequest("GET", "/api/v1/admin/sync/logs/export?"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response APIResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedError, response.Error)
+			mockStorage.AssertNotCalled(t, "EachSyncLog", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// Test ExportSyncLogsHandler - Database error
+func TestExportSyncLogsHandler_DatabaseError(t *testing.T) {
+	// Arrange
+	mockStorage := new(MockStorage)
+	router := setupSyncTestRouter(mockStorage)
+	mockStorage.On("EachSyncLog", mock.Anything, store.SyncLogFilters{}, mock.Anything).Return(nil, assert.AnError)
+
+	req, _ := http.NewRequest("GET", "/api/v1/admin/sync/logs/export", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+	mockStorage.AssertExpectations(t)
+}
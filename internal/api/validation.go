@@ -0,0 +1,247 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/barimehdi77/cupid-api/internal/cupid"
+	"github.com/barimehdi77/cupid-api/internal/store"
+)
+
+// parseBatchIDs parses a comma-separated list of property IDs and enforces the shared maximum batch size.
+// Every endpoint accepting a list of IDs should go through this so the limit is applied uniformly.
+func (h *Handlers) parseBatchIDs(raw string) ([]int64, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, fmt.Errorf("at least one ID is required")
+	}
+
+	parts := strings.Split(raw, ",")
+	if err := h.validateBatchSize("IDs", len(parts)); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, 0, len(parts))
+	for _, part := range parts {
+		id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ID %q", part)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// validateBatchSize rejects batches larger than the configured maximum; kind names the batch items in the error
+func (h *Handlers) validateBatchSize(kind string, size int) error {
+	if size > h.config.MaxBatchSize {
+		return fmt.Errorf("too many %s: maximum is %d, got %d", kind, h.config.MaxBatchSize, size)
+	}
+	return nil
+}
+
+// defaultPageSize is the limit of paginated listings when no limit is given
+const defaultPageSize = 20
+
+// parsePagination parses the page and limit query parameters of a paginated listing.
+// Every paginated handler goes through it so the same defaults and maximum page size apply everywhere.
+func parsePagination(config *Config, rawPage, rawLimit string) (int, int) {
+	return parsePage(rawPage), parseLimit(config, rawLimit, defaultPageSize)
+}
+
+// parsePage parses a page query parameter; a missing or malformed value gives page 1 and lower values are clamped to 1
+func parsePage(raw string) int {
+	page, err := strconv.Atoi(raw)
+	if err != nil {
+		return 1
+	}
+	return max(page, 1)
+}
+
+// parseLimit parses a limit query parameter and clamps it between 1 and Config.MaxPageSize.
+// A missing or malformed value gives defaultLimit; out of range values are clamped rather than rejected.
+func parseLimit(config *Config, raw string, defaultLimit int) int {
+	limit, err := strconv.Atoi(raw)
+	if err != nil {
+		limit = defaultLimit
+	}
+	if config.MaxPageSize > 0 {
+		limit = min(limit, config.MaxPageSize)
+	}
+	return max(limit, 1)
+}
+
+// validateOffset rejects pagination offsets beyond the configured maximum.
+// Every paginated list handler checks its offset here so deep OFFSET scans never reach the database.
+func validateOffset(config *Config, offset int) error {
+	if config.MaxOffset > 0 && offset > config.MaxOffset {
+		return fmt.Errorf("offset %d exceeds the maximum of %d: narrow the filters or use cursor pagination", offset, config.MaxOffset)
+	}
+	return nil
+}
+
+// validateRange rejects a range whose lower bound is above its upper bound.
+// A nil bound is not applied, so it never conflicts with the other one.
+func validateRange(name string, lower, upper *float64) error {
+	if lower == nil || upper == nil {
+		return nil
+	}
+	if *lower > *upper {
+		return fmt.Errorf("min_%[1]s cannot be greater than max_%[1]s", name)
+	}
+	return nil
+}
+
+// optionalBound converts an optional query parameter bound to the form checked by validateRange
+func optionalBound[T int | float64](bound *T) *float64 {
+	if bound == nil {
+		return nil
+	}
+	value := float64(*bound)
+	return &value
+}
+
+// scoreBound converts a score parsed by parseScoreParam, where 0 means not applied, for validateRange
+func scoreBound(score int) *float64 {
+	if score == 0 {
+		return nil
+	}
+	return optionalBound(&score)
+}
+
+// Postal code prefix length bounds; a single character would match most of the dataset
+const (
+	minPostalPrefixLength = 2
+	maxPostalPrefixLength = 10
+)
+
+// parsePostalCodePrefix validates a postal code prefix.
+// Only letters, digits, spaces and hyphens are accepted so the value is never interpreted as a LIKE pattern.
+func parsePostalCodePrefix(raw string) (string, error) {
+	prefix := strings.TrimSpace(raw)
+	if len(prefix) < minPostalPrefixLength || len(prefix) > maxPostalPrefixLength {
+		return "", fmt.Errorf("prefix must be between %d and %d characters", minPostalPrefixLength, maxPostalPrefixLength)
+	}
+
+	for _, r := range prefix {
+		isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		isDigit := r >= '0' && r <= '9'
+		if !isLetter && !isDigit && r != ' ' && r != '-' {
+			return "", fmt.Errorf("prefix may only contain letters, digits, spaces and hyphens")
+		}
+	}
+
+	return prefix, nil
+}
+
+// validateCurrency rejects a currency filter that is not a three-letter ISO 4217 code.
+// An empty value means the filter is not applied.
+func validateCurrency(currency string) error {
+	if currency == "" {
+		return nil
+	}
+	if len(currency) != 3 {
+		return fmt.Errorf("currency must be a three-letter ISO 4217 code")
+	}
+	for _, r := range currency {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return fmt.Errorf("currency must be a three-letter ISO 4217 code")
+		}
+	}
+	return nil
+}
+
+// parseSearchFields parses the comma-separated fields a search is restricted to.
+// Only store.SearchableFields are accepted, since searching an unindexed column would scan the whole table.
+// An empty value returns nil, meaning every searchable field.
+func parseSearchFields(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	for _, part := range strings.Split(raw, ",") {
+		field := strings.ToLower(strings.TrimSpace(part))
+		if !slices.Contains(store.SearchableFields, field) {
+			return nil, fmt.Errorf("invalid search field %q: must be one of %s", part, strings.Join(store.SearchableFields, ", "))
+		}
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
+
+// parseScoreParam parses an optional review score query parameter.
+// An empty value returns 0, meaning the bound is not applied.
+func parseScoreParam(raw string) (int, error) {
+	if raw == "" {
+		return 0, nil
+	}
+
+	score, err := strconv.Atoi(raw)
+	if err != nil || score < 0 || score > 10 {
+		return 0, fmt.Errorf("score must be an integer between 0 and 10")
+	}
+
+	return score, nil
+}
+
+// parseReviewSort parses the optional sort and order query parameters of review listings.
+// Empty values leave the store default of date, newest first.
+func parseReviewSort(sortBy, order string) (string, bool, error) {
+	switch sortBy {
+	case "", store.ReviewSortDate, store.ReviewSortScore:
+	default:
+		return "", false, fmt.Errorf("sort must be one of: date, score")
+	}
+
+	switch order {
+	case "", "desc":
+		return sortBy, false, nil
+	case "asc":
+		return sortBy, true, nil
+	default:
+		return "", false, fmt.Errorf("order must be asc or desc")
+	}
+}
+
+// parseTimeBound parses an optional time range bound given as RFC 3339 or as a YYYY-MM-DD date in UTC.
+// A date-only upper bound is moved to the start of the next day so the whole day is included.
+// An empty value returns the zero time, meaning the bound is not applied.
+func parseTimeBound(raw string, upper bool) (time.Time, error) {
+	if raw == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+
+	day, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("must be an RFC 3339 time or a YYYY-MM-DD date")
+	}
+	if upper {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day, nil
+}
+
+// validateImportedReview checks that a review from an import can be stored: it needs an ID, a score
+// within the 1-10 range of the reviews table and a date
+func validateImportedReview(review cupid.Review) error {
+	if review.ReviewID <= 0 {
+		return fmt.Errorf("review_id must be positive")
+	}
+	if review.AverageScore < 1 || review.AverageScore > 10 {
+		return fmt.Errorf("average_score %d outside 1-10", review.AverageScore)
+	}
+	if _, ok := cupid.ParseReviewDate(review.Date); !ok {
+		return fmt.Errorf("date %q is not a recognized date", review.Date)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// joinIDs builds a comma-separated list of count sequential IDs
+func joinIDs(count int) string {
+	ids := make([]string, count)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("%d", i+1)
+	}
+	return strings.Join(ids, ",")
+}
+
+// Test parseBatchIDs
+func TestParseBatchIDs(t *testing.T) {
+	handlers := NewHandlersWithConfig(new(MockStorage), &Config{MaxBatchSize: 3})
+
+	t.Run("WithinLimit", func(t *testing.T) {
+		ids, err := handlers.parseBatchIDs("1, 2,3")
+
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 2, 3}, ids)
+	})
+
+	t.Run("OverLimit", func(t *testing.T) {
+		ids, err := handlers.parseBatchIDs(joinIDs(4))
+
+		assert.Nil(t, ids)
+		assert.EqualError(t, err, "too many IDs: maximum is 3, got 4")
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		_, err := handlers.parseBatchIDs("")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		_, err := handlers.parseBatchIDs("1,abc")
+
+		assert.EqualError(t, err, `invalid ID "abc"`)
+	})
+}
+
+// Test that the batch limit comes from the shared configuration
+func TestValidateBatchSize_UsesConfiguredLimit(t *testing.T) {
+	defaultHandlers := NewHandlers(new(MockStorage))
+	assert.NoError(t, defaultHandlers.validateBatchSize("IDs", DefaultConfig().MaxBatchSize))
+	assert.Error(t, defaultHandlers.validateBatchSize("IDs", DefaultConfig().MaxBatchSize+1))
+
+	customHandlers := NewHandlersWithConfig(new(MockStorage), &Config{MaxBatchSize: 10})
+	assert.NoError(t, customHandlers.validateBatchSize("IDs", 10))
+	assert.Error(t, customHandlers.validateBatchSize("IDs", 11))
+}
+
+// Test validateOffset
+func TestValidateOffset(t *testing.T) {
+	config := &Config{MaxOffset: 100}
+	assert.NoError(t, validateOffset(config, 100))
+	assert.EqualError(t, validateOffset(config, 101), "offset 101 exceeds the maximum of 100: narrow the filters or use cursor pagination")
+
+	// A zero maximum disables the check
+	assert.NoError(t, validateOffset(&Config{}, 1_000_000))
+}
+
+// Test validateCurrency
+func TestValidateCurrency(t *testing.T) {
+	assert.NoError(t, validateCurrency(""))
+	assert.NoError(t, validateCurrency("EUR"))
+	assert.NoError(t, validateCurrency("usd"))
+	assert.Error(t, validateCurrency("EU"))
+	assert.Error(t, validateCurrency("EU1"))
+	assert.Error(t, validateCurrency("EURO"))
+}
+
+// Test parseSearchFields
+func TestParseSearchFields(t *testing.T) {
+	fields, err := parseSearchFields("")
+	require.NoError(t, err)
+	assert.Nil(t, fields)
+
+	fields, err = parseSearchFields(" Address,description, address")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"address", "description"}, fields)
+
+	_, err = parseSearchFields("hotel_name,important_info")
+	assert.EqualError(t, err, `invalid search field "important_info": must be one of hotel_name, city, country, address, state, description`)
+}
+
+// Test parseReviewSort
+func TestParseReviewSort(t *testing.T) {
+	tests := []struct {
+		name      string
+		sortBy    string
+		order     string
+		expected  string
+		ascending bool
+		wantErr   bool
+	}{
+		{name: "Defaults", expected: "", ascending: false},
+		{name: "Score descending", sortBy: "score", order: "desc", expected: "score", ascending: false},
+		{name: "Date ascending", sortBy: "date", order: "asc", expected: "date", ascending: true},
+		{name: "Unknown field", sortBy: "name", wantErr: true},
+		{name: "Unknown order", sortBy: "score", order: "up", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sortBy, ascending, err := parseReviewSort(tt.sortBy, tt.order)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, sortBy)
+			assert.Equal(t, tt.ascending, ascending)
+		})
+	}
+}
+
+// Test parsePagination
+func TestParsePagination(t *testing.T) {
+	config := DefaultConfig()
+
+	tests := []struct {
+		name          string
+		page          string
+		limit         string
+		expectedPage  int
+		expectedLimit int
+	}{
+		{"Defaults", "", "", 1, 20},
+		{"Valid", "3", "50", 3, 50},
+		{"Malformed", "abc", "xyz", 1, 20},
+		{"Negative", "-2", "-10", 1, 1},
+		{"Zero", "0", "0", 1, 1},
+		{"HugeLimit", "1", "5000", 1, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, limit := parsePagination(config, tt.page, tt.limit)
+
+			assert.Equal(t, tt.expectedPage, page)
+			assert.Equal(t, tt.expectedLimit, limit)
+		})
+	}
+
+	t.Run("NoMaximum", func(t *testing.T) {
+		assert.Equal(t, 5000, parseLimit(&Config{}, "5000", defaultPageSize))
+	})
+}
+
+// Test validateRange
+func TestValidateRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		lower    *float64
+		upper    *float64
+		expected string
+	}{
+		{"Ordered", float64Ptr(3), float64Ptr(5), ""},
+		{"Equal", float64Ptr(4), float64Ptr(4), ""},
+		{"NoLowerBound", nil, float64Ptr(5), ""},
+		{"NoUpperBound", float64Ptr(5), nil, ""},
+		{"Inverted", float64Ptr(5), float64Ptr(3), "min_stars cannot be greater than max_stars"},
+		{"ZeroUpperBound", float64Ptr(5), float64Ptr(0), "min_stars cannot be greater than max_stars"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRange("stars", tt.lower, tt.upper)
+
+			if tt.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expected)
+			}
+		})
+	}
+}
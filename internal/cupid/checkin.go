@@ -0,0 +1,98 @@
+package cupid
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"go.uber.org/zap"
+)
+
+var (
+	// clockTimePattern matches times such as "15:00", "3:00 PM", "3pm", "15h30", "15.30" or "15:00:00"
+	clockTimePattern = regexp.MustCompile(`^(\d{1,2})(?:[:h.](\d{2})(?::\d{2})?)?\s*(am|pm)?$`)
+	// compactTimePattern matches 24-hour times without a separator, such as "1500"
+	compactTimePattern = regexp.MustCompile(`^(\d{2})(\d{2})$`)
+)
+
+// normalizeCheckInTime converts an upstream check-in or check-out time to the canonical 24-hour HH:MM form.
+// An empty value stays empty; ok is false when the value is not a recognizable time.
+func normalizeCheckInTime(value string) (normalized string, ok bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	value = strings.NewReplacer("a.m.", "am", "p.m.", "pm").Replace(value)
+
+	switch value {
+	case "":
+		return "", true
+	case "noon":
+		return "12:00", true
+	case "midnight":
+		return "00:00", true
+	}
+
+	var hourText, minuteText, meridiem string
+	if match := clockTimePattern.FindStringSubmatch(value); match != nil {
+		hourText, minuteText, meridiem = match[1], match[2], match[3]
+	} else if match := compactTimePattern.FindStringSubmatch(value); match != nil {
+		hourText, minuteText = match[1], match[2]
+	} else {
+		return "", false
+	}
+
+	// A bare number is only a time with a meridiem or a separator, e.g. "3pm" but not "3"
+	if minuteText == "" && meridiem == "" {
+		return "", false
+	}
+
+	hour, _ := strconv.Atoi(hourText)
+	minute := 0
+	if minuteText != "" {
+		minute, _ = strconv.Atoi(minuteText)
+	}
+	if minute > 59 {
+		return "", false
+	}
+
+	switch meridiem {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return "", false
+		}
+		hour %= 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	default:
+		if hour > 23 {
+			return "", false
+		}
+	}
+
+	return fmt.Sprintf("%02d:%02d", hour, minute), true
+}
+
+// normalizeCheckIn rewrites the check-in and check-out times of checkIn to HH:MM.
+// Malformed times are kept as received and logged, so no upstream information is lost.
+func normalizeCheckIn(propertyID int64, checkIn *CheckIn) {
+	for _, field := range []struct {
+		name  string
+		value *string
+	}{
+		{"checkin_start", &checkIn.CheckInStart},
+		{"checkin_end", &checkIn.CheckInEnd},
+		{"checkout", &checkIn.Checkout},
+	} {
+		normalized, ok := normalizeCheckInTime(*field.value)
+		if !ok {
+			logger.Warn("Malformed check-in time, keeping it as received",
+				zap.Int64("property_id", propertyID),
+				zap.String("field", field.name),
+				zap.String("value", *field.value),
+			)
+			continue
+		}
+		*field.value = normalized
+	}
+}
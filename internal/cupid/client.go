@@ -1,14 +1,23 @@
 package cupid
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/barimehdi77/cupid-api/internal/env"
 	"github.com/barimehdi77/cupid-api/internal/logger"
+	"github.com/barimehdi77/cupid-api/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -18,31 +27,183 @@ type Client struct {
 	version    string
 	apiKey     string
 	httpClient *http.Client
+	maxRetries int
+	retryDelay time.Duration
+	// allowAnonymous lets CheckAPIKey accept an empty API key, for testing against unauthenticated endpoints
+	allowAnonymous bool
+	// maxReviews caps the number of reviews requested per property; 0 requests every review
+	maxReviews int
+	// Per-call timeouts overriding the httpClient timeout; 0 keeps the httpClient timeout
+	propertyTimeout     time.Duration
+	reviewsTimeout      time.Duration
+	translationsTimeout time.Duration
+	// translationLanguages are the languages whose translations are fetched for each property
+	translationLanguages []string
+	// maxTranslationLanguages caps the languages fetched per property and call, rotating through
+	// translationLanguages across calls; 0 fetches every language each time
+	maxTranslationLanguages int
+	// translationRotation holds, per property, the index in translationLanguages of the next language to fetch
+	translationRotation map[int64]int
+	rotationMu          sync.Mutex
+	// strictDecoding rejects property responses carrying fields Property does not know,
+	// so upstream schema changes are noticed instead of silently dropped
+	strictDecoding bool
+	// captureRaw keeps the raw property response in PropertyData.RawProperty, for debugging upstream data issues
+	captureRaw bool
+	// normalizeCheckIn rewrites check-in and check-out times to HH:MM, as upstream formats vary between properties
+	normalizeCheckIn bool
+}
+
+// ErrMissingAPIKey is returned by CheckAPIKey when no API key is configured
+var ErrMissingAPIKey = errors.New("CUPID_API_KEY is not set: set it, or set CUPID_ALLOW_ANONYMOUS=true to send unauthenticated requests")
+
+// APIError is returned when the Cupid API answers with an error status
+type APIError struct {
+	StatusCode int
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API error: status %d", e.StatusCode)
+}
+
+// IsFatalError reports whether err means no further request can succeed, such as a rejected API key.
+// Fatal errors abort bulk fetches instead of repeating the same failure for every property.
+func IsFatalError(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden
+}
+
+// ValidationError is returned when a property response decodes but holds values that cannot be right,
+// such as another hotel's ID or an out-of-range rating. Such a property fails instead of being stored.
+type ValidationError struct {
+	PropertyID int64
+	Problems   []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid property %d: %s", e.PropertyID, strings.Join(e.Problems, "; "))
+}
+
+// validateProperty checks the fields of a fetched property that the rest of the pipeline relies on
+func validateProperty(propertyID int64, property *Property) error {
+	var problems []string
+	if property.HotelID != propertyID {
+		problems = append(problems, fmt.Sprintf("hotel_id %d does not match the requested ID", property.HotelID))
+	}
+	if property.Stars < 1 || property.Stars > 5 {
+		problems = append(problems, fmt.Sprintf("stars %d outside 1-5", property.Stars))
+	}
+	if property.Rating < 0 || property.Rating > 10 {
+		problems = append(problems, fmt.Sprintf("rating %g outside 0-10", property.Rating))
+	}
+	if len(problems) > 0 {
+		return &ValidationError{PropertyID: propertyID, Problems: problems}
+	}
+	return nil
 }
 
 // NewClient creates a new Cupid API client
+// The base HTTP timeout comes from CUPID_HTTP_TIMEOUT and can be overridden per call, e.g. reviews of
+// large hotels may need longer than property details
 func NewClient() *Client {
+	timeout := env.GetEnvDuration("CUPID_HTTP_TIMEOUT", 30*time.Second)
+
 	return &Client{
 		baseURL: env.GetEnvString("CUPID_API_BASE_URL", "https://api.cupid.com"),
 		version: env.GetEnvString("CUPID_API_VERSION", "v1"),
 		apiKey:  env.GetEnvString("CUPID_API_KEY", ""),
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: timeout,
 		},
+		maxRetries:              env.GetEnvInt("CUPID_API_RETRY_ATTEMPTS", 3),
+		retryDelay:              env.GetEnvDuration("CUPID_API_RETRY_DELAY", 500*time.Millisecond),
+		allowAnonymous:          env.GetEnvBool("CUPID_ALLOW_ANONYMOUS", false),
+		maxReviews:              env.GetEnvInt("CUPID_API_MAX_REVIEWS", 1000),
+		propertyTimeout:         env.GetEnvDuration("CUPID_HTTP_TIMEOUT_PROPERTY", timeout),
+		reviewsTimeout:          env.GetEnvDuration("CUPID_HTTP_TIMEOUT_REVIEWS", timeout),
+		translationsTimeout:     env.GetEnvDuration("CUPID_HTTP_TIMEOUT_TRANSLATIONS", timeout),
+		translationLanguages:    parseLanguages(env.GetEnvString("CUPID_TRANSLATION_LANGUAGES", "fr,es")),
+		maxTranslationLanguages: env.GetEnvInt("CUPID_MAX_TRANSLATION_LANGUAGES", 0),
+		strictDecoding:          env.GetEnvBool("CUPID_STRICT_DECODING", false),
+		captureRaw:              env.GetEnvBool("CUPID_CAPTURE_RAW", false),
+		normalizeCheckIn:        env.GetEnvBool("CUPID_NORMALIZE_CHECKIN", true),
+	}
+}
+
+// CheckAPIKey returns ErrMissingAPIKey when the client has no API key and anonymous requests are not allowed.
+// Binaries calling the Cupid API check it at startup, since unauthenticated requests are otherwise sent and rejected.
+func (c *Client) CheckAPIKey() error {
+	if c.apiKey == "" && !c.allowAnonymous {
+		return ErrMissingAPIKey
 	}
+	return nil
+}
+
+// parseLanguages parses a comma-separated list of language codes, dropping blanks and duplicates
+func parseLanguages(raw string) []string {
+	var languages []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(raw, ",") {
+		lang := strings.ToLower(strings.TrimSpace(part))
+		if lang == "" || seen[lang] {
+			continue
+		}
+		seen[lang] = true
+		languages = append(languages, lang)
+	}
+	return languages
 }
 
 // doRequest performs HTTP request with retry logic
-func (c *Client) doRequest(ctx context.Context, method, endpoint string) (*http.Response, error) {
-	url := fmt.Sprintf("%s%s", c.baseURL, endpoint)
-	logger.Debug("Making API request",
-		zap.String("method", method),
-		zap.String("url", url),
+// Transport errors, 429 and 5xx responses are retried with exponential backoff
+// The whole call, retries included, is recorded as a single client span
+// timeout bounds each attempt, including reading the body; 0 keeps the httpClient timeout
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, timeout time.Duration) (resp *http.Response, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "cupid "+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(method),
+			attribute.String("cupid.endpoint", endpoint),
+		),
 	)
+	defer func() { tracing.EndSpan(span, err) }()
+
+	delay := c.retryDelay
+	for attempt := 0; ; attempt++ {
+		resp, retryable, err := c.attemptRequest(ctx, method, endpoint, timeout)
+		if err == nil || !retryable || attempt >= c.maxRetries {
+			return resp, err
+		}
+
+		// Only the endpoint and error are logged, never request headers (they carry the API key)
+		logger.Debug("Retrying API request",
+			zap.String("method", method),
+			zap.String("endpoint", endpoint),
+			zap.Int("attempt", attempt+1),
+			zap.Int("max_retries", c.maxRetries),
+			zap.Duration("delay", delay),
+			zap.Error(err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("request cancelled while retrying: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// attemptRequest performs a single HTTP request and reports whether a failure can be retried
+func (c *Client) attemptRequest(ctx context.Context, method, endpoint string, timeout time.Duration) (*http.Response, bool, error) {
+	url := fmt.Sprintf("%s%s", c.baseURL, endpoint)
 
 	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, false, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Add headers
@@ -51,38 +212,81 @@ func (c *Client) doRequest(ctx context.Context, method, endpoint string) (*http.
 	if c.apiKey != "" {
 		req.Header.Set("x-api-key", c.apiKey)
 	}
+	tracing.InjectHeaders(ctx, req.Header)
 
 	logger.Debug("Making API request",
 		zap.String("method", method),
 		zap.String("url", url),
 	)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.httpClientFor(timeout).Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return nil, ctx.Err() == nil, fmt.Errorf("request failed: %w", err)
 	}
 
 	if resp.StatusCode >= 400 {
 		defer resp.Body.Close()
-		return nil, fmt.Errorf("API error: status %d", resp.StatusCode)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return nil, retryable, &APIError{StatusCode: resp.StatusCode}
 	}
 
-	return resp, nil
+	return resp, false, nil
+}
+
+// httpClientFor returns the HTTP client to use for a call with the given timeout.
+// Clients share their transport, so an override keeps the connection pool.
+func (c *Client) httpClientFor(timeout time.Duration) *http.Client {
+	if timeout <= 0 || timeout == c.httpClient.Timeout {
+		return c.httpClient
+	}
+	client := *c.httpClient
+	client.Timeout = timeout
+	return &client
 }
 
-// GetProperty fetches a single property by ID
+// GetProperty fetches a single property by ID.
+// The response is validated after decoding and a *ValidationError is returned when it is implausible.
+// Check-in and check-out times are normalized to HH:MM unless CUPID_NORMALIZE_CHECKIN is false.
 func (c *Client) GetProperty(ctx context.Context, propertyID int64) (*Property, error) {
+	property, _, err := c.getProperty(ctx, propertyID)
+	return property, err
+}
+
+// getProperty fetches a single property by ID along with the raw response body, which is only kept when
+// captureRaw is set and is nil otherwise
+func (c *Client) getProperty(ctx context.Context, propertyID int64) (*Property, json.RawMessage, error) {
 	endpoint := fmt.Sprintf("/%s/property/%d", c.version, propertyID)
 
-	resp, err := c.doRequest(ctx, "GET", endpoint)
+	resp, err := c.doRequest(ctx, "GET", endpoint, c.propertyTimeout)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch property %d: %w", propertyID, err)
+		return nil, nil, fmt.Errorf("failed to fetch property %d: %w", propertyID, err)
 	}
 	defer resp.Body.Close()
 
+	var body io.Reader = resp.Body
+	var raw bytes.Buffer
+	if c.captureRaw {
+		body = io.TeeReader(resp.Body, &raw)
+	}
+
 	var property Property
-	if err := json.NewDecoder(resp.Body).Decode(&property); err != nil {
-		return nil, fmt.Errorf("failed to decode property response: %w", err)
+	decoder := json.NewDecoder(body)
+	if c.strictDecoding {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(&property); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode property response: %w", err)
+	}
+	if err := validateProperty(propertyID, &property); err != nil {
+		logger.Warn("Rejected invalid property response",
+			zap.Int64("property_id", propertyID),
+			zap.Error(err),
+		)
+		return nil, nil, err
+	}
+	if c.normalizeCheckIn {
+		normalizeCheckIn(propertyID, &property.CheckIn)
 	}
 
 	logger.Info("Fetched property successfully",
@@ -90,14 +294,33 @@ func (c *Client) GetProperty(ctx context.Context, propertyID int64) (*Property,
 		zap.String("name", property.HotelName),
 	)
 
-	return &property, nil
+	if !c.captureRaw {
+		return &property, nil, nil
+	}
+	return &property, json.RawMessage(bytes.TrimSpace(raw.Bytes())), nil
+}
+
+// MaxReviews returns the maximum number of reviews requested per property, 0 when every review is requested
+func (c *Client) MaxReviews() int {
+	return c.maxReviews
 }
 
-// GetPropertyReviews fetches reviews for a property
+// GetPropertyReviews fetches reviews for a property.
+// The reviews endpoint has no offset parameter, so reviews cannot be paged; instead the requested count is
+// capped at the configured maximum to keep a single response bounded for hotels with thousands of reviews.
 func (c *Client) GetPropertyReviews(ctx context.Context, propertyID int64, reviewCount int) ([]Review, error) {
+	if c.maxReviews > 0 && reviewCount > c.maxReviews {
+		logger.Debug("Capping requested review count",
+			zap.Int64("property_id", propertyID),
+			zap.Int("review_count", reviewCount),
+			zap.Int("max_reviews", c.maxReviews),
+		)
+		reviewCount = c.maxReviews
+	}
+
 	endpoint := fmt.Sprintf("/%s/property/reviews/%d/%d", c.version, propertyID, reviewCount)
 
-	resp, err := c.doRequest(ctx, "GET", endpoint)
+	resp, err := c.doRequest(ctx, "GET", endpoint, c.reviewsTimeout)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch reviews for property %d: %w", propertyID, err)
 	}
@@ -120,7 +343,7 @@ func (c *Client) GetPropertyReviews(ctx context.Context, propertyID int64, revie
 func (c *Client) GetPropertyTranslations(ctx context.Context, propertyID int64, language string) (*Property, error) {
 	endpoint := fmt.Sprintf("/%s/property/%d/lang/%s", c.version, propertyID, language)
 
-	resp, err := c.doRequest(ctx, "GET", endpoint)
+	resp, err := c.doRequest(ctx, "GET", endpoint, c.translationsTimeout)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch translations for property %d in %s: %w", propertyID, language, err)
 	}
@@ -145,34 +368,36 @@ func (c *Client) FetchAllPropertyData(ctx context.Context, propertyID int64) (*P
 		zap.Int64("property_id", propertyID),
 	)
 
-	// Fetch property details
-	property, err := c.GetProperty(ctx, propertyID)
+	propertyData, err := c.FetchPropertyDataWithoutReviews(ctx, propertyID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch property details: %w", err)
+		return nil, err
 	}
+	fetchReviews(ctx, c, propertyID, propertyData)
 
-	// Fetch reviews using the review count from the property
-	var reviews []Review
-	if property.ReviewCount > 0 {
-		reviews, err = c.GetPropertyReviews(ctx, propertyID, property.ReviewCount)
-		if err != nil {
-			logger.Warn("Failed to fetch reviews, continuing without them",
-				zap.Int64("property_id", propertyID),
-				zap.Int("review_count", property.ReviewCount),
-				zap.Error(err),
-			)
-			reviews = []Review{} // Continue without reviews
-		}
-	} else {
-		logger.Debug("No reviews available for property",
-			zap.Int64("property_id", propertyID),
-		)
-		reviews = []Review{}
+	logger.LogSuccess("Complete property data fetched",
+		zap.Int64("property_id", propertyID),
+		zap.Int("review_count", len(propertyData.Reviews)),
+		zap.Int("translation_count", len(propertyData.Translations)),
+		zap.Int("warning_count", len(propertyData.FetchWarnings)),
+	)
+
+	return propertyData, nil
+}
+
+// FetchPropertyDataWithoutReviews fetches the details and translations of a property, leaving Reviews nil.
+// It lets callers fetch the reviews, the slowest part for large hotels, separately with fetchReviews.
+func (c *Client) FetchPropertyDataWithoutReviews(ctx context.Context, propertyID int64) (*PropertyData, error) {
+	// Fetch property details
+	property, raw, err := c.getProperty(ctx, propertyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch property details: %w", err)
 	}
 
-	// Fetch translations (French and Spanish)
+	// Fetch translations, at most maxTranslationLanguages of them per call
+	languages, skipped := c.nextTranslationLanguages(propertyID)
 	translations := make(map[string]*Property)
-	for _, lang := range []string{"fr", "es"} {
+	var warnings []string
+	for _, lang := range languages {
 		translation, err := c.GetPropertyTranslations(ctx, propertyID, lang)
 		if err != nil {
 			logger.Warn("Failed to fetch translation, continuing without it",
@@ -180,22 +405,107 @@ func (c *Client) FetchAllPropertyData(ctx context.Context, propertyID int64) (*P
 				zap.String("language", lang),
 				zap.Error(err),
 			)
+			warnings = append(warnings, fmt.Sprintf("%s translation fetch failed: %v", lang, err))
 			continue
 		}
 		translations[lang] = translation
 	}
 
-	propertyData := &PropertyData{
-		Property:     *property,
-		Reviews:      reviews,
-		Translations: translations,
+	return &PropertyData{
+		Property:            *property,
+		Translations:        translations,
+		SkippedTranslations: skipped,
+		FetchWarnings:       warnings,
+		RawProperty:         raw,
+	}, nil
+}
+
+// fetchReviews fetches the reviews of propertyData given its review count and stores them in Reviews.
+// A failed fetch leaves Reviews empty and is recorded in FetchWarnings.
+// Reviews are optional: a failed fetch is logged and an empty slice returned so the property is still stored.
+// ReviewsComplete is set unless the fetch failed or was capped at the fetcher's MaxReviews.
+func fetchReviews(ctx context.Context, fetcher PropertyFetcher, propertyID int64, propertyData *PropertyData) {
+	reviewCount := propertyData.Property.ReviewCount
+	if reviewCount <= 0 {
+		logger.Debug("No reviews available for property",
+			zap.Int64("property_id", propertyID),
+		)
+		propertyData.Reviews = []Review{}
+		propertyData.ReviewsComplete = true
+		return
+	}
+
+	reviews, err := fetcher.GetPropertyReviews(ctx, propertyID, reviewCount)
+	if err != nil {
+		logger.Warn("Failed to fetch reviews, continuing without them",
+			zap.Int64("property_id", propertyID),
+			zap.Int("review_count", reviewCount),
+			zap.Error(err),
+		)
+		propertyData.Reviews = []Review{} // Continue without reviews
+		propertyData.FetchWarnings = append(propertyData.FetchWarnings, fmt.Sprintf("reviews fetch failed: %v", err))
+		return
 	}
 
-	logger.LogSuccess("Complete property data fetched",
-		zap.Int64("property_id", propertyID),
-		zap.Int("review_count", len(reviews)),
-		zap.Int("translation_count", len(translations)),
-	)
+	// Upstream occasionally repeats a review; dedupe here so the sync comparator sees what storage keeps
+	unique := DedupeReviews(reviews)
+	if dropped := len(reviews) - len(unique); dropped > 0 {
+		logger.Warn("Dropped duplicate reviews from upstream response",
+			zap.Int64("property_id", propertyID),
+			zap.Int("duplicates", dropped),
+		)
+	}
+	propertyData.Reviews = unique
+	propertyData.ReviewsComplete = fetcher.MaxReviews() <= 0 || reviewCount <= fetcher.MaxReviews()
+}
 
-	return propertyData, nil
+// DedupeReviews keeps one review per review ID, the last occurrence being the latest version upstream sent.
+// Kept reviews stay in their original order; reviews is returned as is when it holds no duplicate.
+func DedupeReviews(reviews []Review) []Review {
+	latest := make(map[int64]int, len(reviews))
+	for i, review := range reviews {
+		latest[review.ReviewID] = i
+	}
+	if len(latest) == len(reviews) {
+		return reviews
+	}
+
+	unique := make([]Review, 0, len(latest))
+	for i, review := range reviews {
+		if latest[review.ReviewID] == i {
+			unique = append(unique, review)
+		}
+	}
+	return unique
+}
+
+// nextTranslationLanguages returns the translation languages to fetch for a property in this call, along with
+// the configured languages left out by maxTranslationLanguages.
+// Each call starts where the previous one for the same property stopped, so every language is fetched
+// once every ceil(len(translationLanguages) / maxTranslationLanguages) calls.
+func (c *Client) nextTranslationLanguages(propertyID int64) ([]string, []string) {
+	languages := c.translationLanguages
+	if c.maxTranslationLanguages <= 0 || len(languages) <= c.maxTranslationLanguages {
+		return languages, nil
+	}
+
+	c.rotationMu.Lock()
+	if c.translationRotation == nil {
+		c.translationRotation = make(map[int64]int)
+	}
+	start := c.translationRotation[propertyID]
+	c.translationRotation[propertyID] = (start + c.maxTranslationLanguages) % len(languages)
+	c.rotationMu.Unlock()
+
+	fetch := make([]string, 0, c.maxTranslationLanguages)
+	skipped := make([]string, 0, len(languages)-c.maxTranslationLanguages)
+	for i := range languages {
+		lang := languages[(start+i)%len(languages)]
+		if i < c.maxTranslationLanguages {
+			fetch = append(fetch, lang)
+		} else {
+			skipped = append(skipped, lang)
+		}
+	}
+	return fetch, skipped
 }
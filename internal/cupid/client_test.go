@@ -0,0 +1,654 @@
+package cupid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+const testAPIKey = "secret-test-key"
+
+// setupObservedLogger replaces the global logger with one that records entries in memory
+func setupObservedLogger(t *testing.T) *observer.ObservedLogs {
+	core, logs := observer.New(zapcore.DebugLevel)
+	previous := logger.Logger
+	logger.Logger = zap.New(core)
+	t.Cleanup(func() { logger.Logger = previous })
+	return logs
+}
+
+// newTestClient creates a client pointing at the given test server
+func newTestClient(baseURL string, maxRetries int) *Client {
+	return &Client{
+		baseURL:    baseURL,
+		version:    "v3.0",
+		apiKey:     testAPIKey,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+		maxRetries: maxRetries,
+		retryDelay: time.Millisecond,
+	}
+}
+
+// TestClient_DoRequestRetries tests retry behaviour and its log entries
+func TestClient_DoRequestRetries(t *testing.T) {
+	t.Run("retries server errors and logs each attempt", func(t *testing.T) {
+		// Arrange
+		logs := setupObservedLogger(t)
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, testAPIKey, r.Header.Get("x-api-key"))
+			if atomic.AddInt32(&calls, 1) <= 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+		client := newTestClient(server.URL, 3)
+
+		// Act
+		resp, err := client.doRequest(context.Background(), http.MethodGet, "/v3.0/property/1", 0)
+
+		// Assert
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+		retries := logs.FilterMessage("🔍 Retrying API request").All()
+		require.Len(t, retries, 2)
+		for i, entry := range retries {
+			assert.Equal(t, zapcore.DebugLevel, entry.Level)
+			fields := entry.ContextMap()
+			assert.Equal(t, int64(i+1), fields["attempt"])
+			assert.Equal(t, time.Millisecond<<i, fields["delay"])
+			assert.Contains(t, fields["error"], "status 503")
+		}
+		for _, entry := range logs.All() {
+			for _, value := range entry.ContextMap() {
+				if s, ok := value.(string); ok {
+					assert.False(t, strings.Contains(s, testAPIKey), "log entry leaked the API key")
+				}
+			}
+		}
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		// Arrange
+		logs := setupObservedLogger(t)
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+		client := newTestClient(server.URL, 2)
+
+		// Act
+		_, err := client.doRequest(context.Background(), http.MethodGet, "/v3.0/property/1", 0)
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+		assert.Equal(t, 2, logs.FilterMessage("🔍 Retrying API request").Len())
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		// Arrange
+		logs := setupObservedLogger(t)
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+		client := newTestClient(server.URL, 3)
+
+		// Act
+		_, err := client.doRequest(context.Background(), http.MethodGet, "/v3.0/property/1", 0)
+
+		// Assert
+		require.Error(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		assert.Equal(t, 0, logs.FilterMessage("🔍 Retrying API request").Len())
+	})
+}
+
+// TestClient_GetPropertyReviews tests that the requested review count is capped at the configured maximum
+func TestClient_GetPropertyReviews(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxReviews  int
+		reviewCount int
+		wantPath    string
+	}{
+		{"BelowMax", 100, 40, "/v3.0/property/reviews/1/40"},
+		{"AboveMax", 100, 5000, "/v3.0/property/reviews/1/100"},
+		{"NoMax", 0, 5000, "/v3.0/property/reviews/1/5000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			setupObservedLogger(t)
+			var path string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				path = r.URL.Path
+				w.Write([]byte(`[{"review_id": 1, "average_score": 8}]`))
+			}))
+			defer server.Close()
+			client := newTestClient(server.URL, 0)
+			client.maxReviews = tt.maxReviews
+
+			// Act
+			reviews, err := client.GetPropertyReviews(context.Background(), 1, tt.reviewCount)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPath, path)
+			require.Len(t, reviews, 1)
+			assert.Equal(t, int64(1), reviews[0].ReviewID)
+		})
+	}
+}
+
+// TestClient_TranslationLanguageCap tests that each fetch requests at most the capped number of languages
+// and that successive fetches of a property rotate through every configured language
+func TestClient_TranslationLanguageCap(t *testing.T) {
+	// Arrange
+	setupObservedLogger(t)
+	var mu sync.Mutex
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if lang, ok := strings.CutPrefix(r.URL.Path, "/v3.0/property/1/lang/"); ok {
+			mu.Lock()
+			requested = append(requested, lang)
+			mu.Unlock()
+			w.Write([]byte(`{"data": {"hotel_name": "Translated ` + lang + `"}}`))
+			return
+		}
+		w.Write([]byte(`{"hotel_id": 1, "stars": 4, "rating": 8.5, "review_count": 0}`))
+	}))
+	defer server.Close()
+	client := newTestClient(server.URL, 0)
+	client.translationLanguages = []string{"fr", "es", "de", "it", "nl"}
+	client.maxTranslationLanguages = 2
+
+	covered := make(map[string]bool)
+	for run := 0; run < 3; run++ {
+		requested = nil
+
+		// Act
+		data, err := client.FetchAllPropertyData(context.Background(), 1)
+
+		// Assert
+		require.NoError(t, err)
+		assert.LessOrEqual(t, len(requested), 2, "run %d", run)
+		assert.Len(t, data.Translations, len(requested))
+		assert.ElementsMatch(t, client.translationLanguages, append(append([]string{}, requested...), data.SkippedTranslations...))
+		for _, lang := range requested {
+			covered[lang] = true
+		}
+	}
+
+	assert.Len(t, covered, len(client.translationLanguages), "three runs cover every language")
+}
+
+// TestClient_FetchWarnings tests that failed review and translation fetches are recorded as fetch warnings
+// while the rest of the property data is still returned
+func TestClient_FetchWarnings(t *testing.T) {
+	// Arrange
+	setupObservedLogger(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3.0/property/1":
+			w.Write([]byte(`{"hotel_id": 1, "stars": 4, "rating": 8.5, "review_count": 5}`))
+		case "/v3.0/property/1/lang/es":
+			w.Write([]byte(`{"data": {"hotel_name": "Hotel Traducido"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := newTestClient(server.URL, 0)
+	client.translationLanguages = []string{"fr", "es"}
+
+	// Act
+	data, err := client.FetchAllPropertyData(context.Background(), 1)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, data.Reviews)
+	assert.False(t, data.ReviewsComplete)
+	assert.Contains(t, data.Translations, "es")
+	require.Len(t, data.FetchWarnings, 2)
+	assert.True(t, strings.HasPrefix(data.FetchWarnings[0], "fr translation fetch failed: "), data.FetchWarnings[0])
+	assert.True(t, strings.HasPrefix(data.FetchWarnings[1], "reviews fetch failed: "), data.FetchWarnings[1])
+}
+
+// TestClient_DuplicateReviews tests that a review repeated in the upstream response is kept once, in its last version
+func TestClient_DuplicateReviews(t *testing.T) {
+	// Arrange
+	logs := setupObservedLogger(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3.0/property/1":
+			w.Write([]byte(`{"hotel_id": 1, "stars": 4, "rating": 8.5, "review_count": 3}`))
+		case "/v3.0/property/reviews/1/3":
+			w.Write([]byte(`[
+				{"review_id": 10, "average_score": 6, "headline": "First version"},
+				{"review_id": 11, "average_score": 9},
+				{"review_id": 10, "average_score": 7, "headline": "Edited"}
+			]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	client := newTestClient(server.URL, 0)
+
+	// Act
+	data, err := client.FetchAllPropertyData(context.Background(), 1)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []Review{
+		{ReviewID: 11, AverageScore: 9},
+		{ReviewID: 10, AverageScore: 7, Headline: "Edited"},
+	}, data.Reviews)
+	warnings := logs.FilterMessage("⚠️  Dropped duplicate reviews from upstream response").All()
+	require.Len(t, warnings, 1)
+	assert.Equal(t, int64(1), warnings[0].ContextMap()["duplicates"])
+}
+
+// TestDedupeReviews tests keeping the last occurrence of each review ID
+func TestDedupeReviews(t *testing.T) {
+	t.Run("NoDuplicates", func(t *testing.T) {
+		// Arrange
+		reviews := []Review{{ReviewID: 1}, {ReviewID: 2}}
+
+		// Act & Assert
+		assert.Equal(t, reviews, DedupeReviews(reviews))
+		assert.Empty(t, DedupeReviews(nil))
+	})
+
+	t.Run("KeepsLastOccurrence", func(t *testing.T) {
+		// Arrange
+		reviews := []Review{{ReviewID: 1, Name: "old"}, {ReviewID: 2}, {ReviewID: 1, Name: "new"}, {ReviewID: 3}}
+
+		// Act
+		unique := DedupeReviews(reviews)
+
+		// Assert
+		assert.Equal(t, []Review{{ReviewID: 2}, {ReviewID: 1, Name: "new"}, {ReviewID: 3}}, unique)
+	})
+}
+
+// TestClient_GetPropertyValidation tests that implausible property responses are rejected with a
+// ValidationError and that strict decoding rejects unknown fields
+func TestClient_GetPropertyValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		strict   bool
+		problems []string
+		errText  string
+	}{
+		{name: "Valid", body: `{"hotel_id": 1, "stars": 4, "rating": 8.5}`},
+		{name: "MismatchedID", body: `{"hotel_id": 2, "stars": 4, "rating": 8.5}`, problems: []string{"hotel_id 2 does not match the requested ID"}},
+		{name: "StarsOutOfRange", body: `{"hotel_id": 1, "stars": 0, "rating": 8.5}`, problems: []string{"stars 0 outside 1-5"}},
+		{name: "RatingOutOfRange", body: `{"hotel_id": 1, "stars": 4, "rating": 85}`, problems: []string{"rating 85 outside 0-10"}},
+		{name: "UnknownFieldLenient", body: `{"hotel_id": 1, "stars": 4, "rating": 8.5, "new_field": true}`},
+		{name: "UnknownFieldStrict", body: `{"hotel_id": 1, "stars": 4, "rating": 8.5, "new_field": true}`, strict: true, errText: "unknown field"},
+		{name: "WrongType", body: `{"hotel_id": 1, "stars": "four", "rating": 8.5}`, errText: "failed to decode property response"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			setupObservedLogger(t)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+			client := newTestClient(server.URL, 0)
+			client.strictDecoding = tt.strict
+
+			// Act
+			property, err := client.GetProperty(context.Background(), 1)
+
+			// Assert
+			switch {
+			case tt.problems != nil:
+				var validationErr *ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, int64(1), validationErr.PropertyID)
+				assert.Equal(t, tt.problems, validationErr.Problems)
+				assert.Nil(t, property)
+			case tt.errText != "":
+				assert.ErrorContains(t, err, tt.errText)
+				assert.Nil(t, property)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, int64(1), property.HotelID)
+			}
+		})
+	}
+}
+
+// TestNormalizeCheckInTime tests converting upstream time formats to HH:MM
+func TestNormalizeCheckInTime(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		ok       bool
+	}{
+		{value: "", expected: "", ok: true},
+		{value: "15:00", expected: "15:00", ok: true},
+		{value: "9:30", expected: "09:30", ok: true},
+		{value: "15:00:00", expected: "15:00", ok: true},
+		{value: "3:00 PM", expected: "15:00", ok: true},
+		{value: "3pm", expected: "15:00", ok: true},
+		{value: "11 a.m.", expected: "11:00", ok: true},
+		{value: "12 AM", expected: "00:00", ok: true},
+		{value: "12:30 pm", expected: "12:30", ok: true},
+		{value: "15h30", expected: "15:30", ok: true},
+		{value: "15.30", expected: "15:30", ok: true},
+		{value: "1500", expected: "15:00", ok: true},
+		{value: " Noon ", expected: "12:00", ok: true},
+		{value: "midnight", expected: "00:00", ok: true},
+		{value: "3", ok: false},
+		{value: "25:00", ok: false},
+		{value: "14:75", ok: false},
+		{value: "13 pm", ok: false},
+		{value: "anytime", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			// Act
+			normalized, ok := normalizeCheckInTime(tt.value)
+
+			// Assert
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, normalized)
+		})
+	}
+}
+
+// TestParseReviewDate tests that upstream review dates in the known formats parse to their UTC day
+func TestParseReviewDate(t *testing.T) {
+	jan5 := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value    string
+		expected time.Time
+		ok       bool
+	}{
+		{value: "2024-01-05", expected: jan5, ok: true},
+		{value: "2024-1-5", expected: jan5, ok: true},
+		{value: "2024-01-05 18:45:00", expected: jan5, ok: true},
+		{value: "2024-01-05T18:45:00", expected: jan5, ok: true},
+		{value: "2024-01-05T23:30:00-02:00", expected: jan5, ok: true},
+		{value: "2024/01/05", expected: jan5, ok: true},
+		{value: "January 5, 2024", expected: jan5, ok: true},
+		{value: "5 Jan 2024", expected: jan5, ok: true},
+		{value: " 2024-01-05 ", expected: jan5, ok: true},
+		{value: "", ok: false},
+		{value: "05/01/2024", ok: false},
+		{value: "2024-13-01", ok: false},
+		{value: "last summer", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			// Act
+			date, ok := ParseReviewDate(tt.value)
+
+			// Assert
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, date)
+		})
+	}
+}
+
+// TestClient_NormalizeCheckIn tests that fetched check-in times are normalized and malformed ones kept and logged
+func TestClient_NormalizeCheckIn(t *testing.T) {
+	body := `{"hotel_id": 1, "stars": 4, "rating": 8.5,
+		"checkin": {"checkin_start": "3 PM", "checkin_end": "anytime", "checkout": "11:00:00"}}`
+
+	for _, normalize := range []bool{false, true} {
+		t.Run(fmt.Sprintf("normalize=%t", normalize), func(t *testing.T) {
+			// Arrange
+			logs := setupObservedLogger(t)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(body))
+			}))
+			defer server.Close()
+			client := newTestClient(server.URL, 0)
+			client.normalizeCheckIn = normalize
+
+			// Act
+			property, err := client.GetProperty(context.Background(), 1)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, "anytime", property.CheckIn.CheckInEnd)
+			warnings := logs.FilterMessage("⚠️  Malformed check-in time, keeping it as received")
+			if !normalize {
+				assert.Equal(t, "3 PM", property.CheckIn.CheckInStart)
+				assert.Equal(t, "11:00:00", property.CheckIn.Checkout)
+				assert.Zero(t, warnings.Len())
+				return
+			}
+			assert.Equal(t, "15:00", property.CheckIn.CheckInStart)
+			assert.Equal(t, "11:00", property.CheckIn.Checkout)
+			require.Equal(t, 1, warnings.Len())
+			assert.Equal(t, "checkin_end", warnings.All()[0].ContextMap()["field"])
+		})
+	}
+}
+
+// TestClient_CaptureRaw tests that the raw property response is kept only when capturing is enabled
+func TestClient_CaptureRaw(t *testing.T) {
+	body := `{"hotel_id": 1, "stars": 4, "rating": 8.5, "review_count": 0, "upstream_only": "kept"}`
+
+	for _, capture := range []bool{false, true} {
+		t.Run(fmt.Sprintf("capture=%t", capture), func(t *testing.T) {
+			// Arrange
+			setupObservedLogger(t)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(body + "\n"))
+			}))
+			defer server.Close()
+			client := newTestClient(server.URL, 0)
+			client.captureRaw = capture
+
+			// Act
+			data, err := client.FetchAllPropertyData(context.Background(), 1)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), data.Property.HotelID)
+			if capture {
+				assert.Equal(t, body, string(data.RawProperty))
+			} else {
+				assert.Nil(t, data.RawProperty)
+			}
+		})
+	}
+}
+
+// TestParseLanguages tests parsing of the configured translation languages
+func TestParseLanguages(t *testing.T) {
+	assert.Equal(t, []string{"fr", "es", "de"}, parseLanguages(" fr, ES ,,de,fr"))
+	assert.Nil(t, parseLanguages(""))
+}
+
+// TestNewClient_Timeouts tests the base HTTP timeout and its per-call overrides from the environment
+func TestNewClient_Timeouts(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		// Arrange
+		t.Setenv("CUPID_HTTP_TIMEOUT", "")
+		t.Setenv("CUPID_HTTP_TIMEOUT_REVIEWS", "")
+
+		// Act
+		client := NewClient()
+
+		// Assert
+		assert.Equal(t, 30*time.Second, client.httpClient.Timeout)
+		assert.Equal(t, 30*time.Second, client.propertyTimeout)
+		assert.Equal(t, 30*time.Second, client.reviewsTimeout)
+		assert.Equal(t, 30*time.Second, client.translationsTimeout)
+	})
+
+	t.Run("Overrides", func(t *testing.T) {
+		// Arrange
+		t.Setenv("CUPID_HTTP_TIMEOUT", "45s")
+		t.Setenv("CUPID_HTTP_TIMEOUT_REVIEWS", "2m")
+
+		// Act
+		client := NewClient()
+
+		// Assert
+		assert.Equal(t, 45*time.Second, client.httpClient.Timeout)
+		assert.Equal(t, 45*time.Second, client.propertyTimeout)
+		assert.Equal(t, 2*time.Minute, client.reviewsTimeout)
+		assert.Equal(t, 45*time.Second, client.translationsTimeout)
+	})
+}
+
+// TestClient_CheckAPIKey tests the startup check rejecting a missing API key
+func TestClient_CheckAPIKey(t *testing.T) {
+	tests := []struct {
+		name           string
+		apiKey         string
+		allowAnonymous string
+		expected       error
+	}{
+		{name: "KeySet", apiKey: "secret", expected: nil},
+		{name: "KeyMissing", apiKey: "", expected: ErrMissingAPIKey},
+		{name: "KeyMissingAnonymousAllowed", apiKey: "", allowAnonymous: "true", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			t.Setenv("CUPID_API_KEY", tt.apiKey)
+			t.Setenv("CUPID_ALLOW_ANONYMOUS", tt.allowAnonymous)
+
+			// Act
+			err := NewClient().CheckAPIKey()
+
+			// Assert
+			assert.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+// TestClient_PerCallTimeout tests that a per-call timeout overrides the HTTP client timeout
+func TestClient_PerCallTimeout(t *testing.T) {
+	// Arrange
+	setupObservedLogger(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		if strings.Contains(r.URL.Path, "/reviews/") {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Write([]byte(`{"hotel_id": 1, "stars": 4, "rating": 8.5}`))
+	}))
+	defer server.Close()
+	client := newTestClient(server.URL, 0)
+	client.httpClient.Timeout = 20 * time.Millisecond
+	client.reviewsTimeout = time.Second
+
+	// Act
+	_, propertyErr := client.GetProperty(context.Background(), 1)
+	_, reviewsErr := client.GetPropertyReviews(context.Background(), 1, 10)
+
+	// Assert
+	assert.ErrorContains(t, propertyErr, "Timeout")
+	assert.NoError(t, reviewsErr)
+	assert.Equal(t, 20*time.Millisecond, client.httpClient.Timeout)
+}
+
+// TestClient_DoRequestSpan tests that a request is recorded as one client span and propagates the trace
+func TestClient_DoRequestSpan(t *testing.T) {
+	// Arrange
+	setupObservedLogger(t)
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider := otel.GetTracerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	var traceparents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparents = append(traceparents, r.Header.Get("traceparent"))
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+	client := newTestClient(server.URL, 1)
+
+	// Act
+	_, err := client.doRequest(context.Background(), http.MethodGet, "/v3.0/property/1", 0)
+
+	// Assert
+	require.Error(t, err)
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "cupid GET", span.Name())
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind())
+	assert.Equal(t, codes.Error, span.Status().Code)
+
+	require.Len(t, traceparents, 2)
+	for _, traceparent := range traceparents {
+		assert.Contains(t, traceparent, span.SpanContext().TraceID().String())
+	}
+}
+
+// TestIsFatalError tests which API errors abort bulk fetches
+func TestIsFatalError(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		fatal bool
+	}{
+		{"Unauthorized", &APIError{StatusCode: http.StatusUnauthorized}, true},
+		{"Forbidden", &APIError{StatusCode: http.StatusForbidden}, true},
+		{"WrappedUnauthorized", fmt.Errorf("failed to fetch property 1: %w", &APIError{StatusCode: http.StatusUnauthorized}), true},
+		{"NotFound", &APIError{StatusCode: http.StatusNotFound}, false},
+		{"ServerError", &APIError{StatusCode: http.StatusInternalServerError}, false},
+		{"Transport", errors.New("connection refused"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.fatal, IsFatalError(tt.err))
+		})
+	}
+}
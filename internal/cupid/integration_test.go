@@ -174,7 +174,7 @@ func TestCupidServiceIntegration(t *testing.T) {
 	}
 	defer logger.Sync()
 
-	service := NewService()
+	service := NewService(NewClient())
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
 
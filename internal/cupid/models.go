@@ -1,6 +1,7 @@
 package cupid
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -37,6 +38,9 @@ type Property struct {
 	Policies            []Policy   `json:"policies"`
 	Rooms               []Room     `json:"rooms"`
 	Reviews             *[]Review  `json:"reviews"`
+	MinPrice            *float64   `json:"min_price,omitempty"`
+	Currency            string     `json:"currency,omitempty"`
+	LastSyncedAt        *time.Time `json:"last_synced_at,omitempty"`
 }
 
 // Address represents the hotel address
@@ -108,6 +112,8 @@ type Room struct {
 	RoomAmenities  []RoomAmenity `json:"room_amenities"`
 	Photos         []Photo       `json:"photos"`
 	Views          []RoomView    `json:"views"`
+	MinNightlyRate *float64      `json:"min_nightly_rate,omitempty"`
+	Currency       string        `json:"currency,omitempty"`
 }
 
 // BedType represents bed type information
@@ -158,6 +164,8 @@ type Review struct {
 	Pros         string `json:"pros"`
 	Cons         string `json:"cons"`
 	Source       string `json:"source"`
+	// Imported marks a stored review that came from a review import rather than a sync; syncs never remove it
+	Imported bool `json:"-"`
 }
 
 // TranslationResponse represents the translation API response
@@ -179,9 +187,23 @@ type PropertyData struct {
 	Property     Property             `json:"property"`
 	Reviews      []Review             `json:"reviews"`
 	Translations map[string]*Property `json:"translations"`
-}
-
-// PropertyIDs contains all the property IDs from the assignment
+	// SkippedTranslations lists the configured languages that were not fetched this time because of
+	// CUPID_MAX_TRANSLATION_LANGUAGES; their stored translations are still valid and should be kept
+	SkippedTranslations []string `json:"-"`
+	// Overrides holds the manual field overrides of a stored property, keyed by column name
+	Overrides map[string]string `json:"-"`
+	// FetchWarnings lists the parts of the property that could not be fetched, such as
+	// "reviews fetch failed: ..." or "fr translation fetch failed: ...", so incomplete data can be spotted
+	FetchWarnings []string `json:"fetch_warnings,omitempty"`
+	// RawProperty is the property response exactly as the Cupid API sent it, set when CUPID_CAPTURE_RAW is enabled
+	RawProperty json.RawMessage `json:"-"`
+	// ReviewsComplete reports that Reviews holds every upstream review of the property: the fetch succeeded and
+	// was not capped by CUPID_API_MAX_REVIEWS. Only then are stored reviews missing from Reviews removed.
+	ReviewsComplete bool `json:"-"`
+}
+
+// PropertyIDs contains all the property IDs from the assignment.
+// It is the default roster, used when neither PROPERTY_IDS_FILE nor PROPERTY_IDS is set (see LoadPropertyIDs).
 var PropertyIDs = []int64{
 	1641879, 317597, 1202743, 1037179, 1154868, 1270324, 1305326, 1617655,
 	1975211, 2017823, 1503950, 1033299, 378772, 1563003, 1085875, 828917,
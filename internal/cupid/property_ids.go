@@ -0,0 +1,118 @@
+package cupid
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/barimehdi77/cupid-api/internal/env"
+)
+
+// LoadPropertyIDs returns the property IDs to fetch, so the hotel roster can change without a redeploy.
+// PROPERTY_IDS_FILE names a file holding either a JSON array of IDs or IDs separated by commas or newlines,
+// as in a single-column CSV with an optional header; lines starting with # are ignored.
+// Otherwise PROPERTY_IDS holds a comma-separated list. Without either, the embedded PropertyIDs are returned.
+// Duplicates are dropped keeping the first occurrence; a configured source that is unreadable, malformed
+// or empty is an error rather than a silent fallback.
+func LoadPropertyIDs() ([]int64, error) {
+	if path := env.GetEnvString("PROPERTY_IDS_FILE", ""); path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read property IDs file: %w", err)
+		}
+		ids, err := parsePropertyIDsFile(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("invalid property IDs file %s: %w", path, err)
+		}
+		return ids, nil
+	}
+
+	if raw := env.GetEnvString("PROPERTY_IDS", ""); raw != "" {
+		ids, err := ParsePropertyIDs(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid PROPERTY_IDS: %w", err)
+		}
+		return ids, nil
+	}
+
+	return PropertyIDs, nil
+}
+
+// parsePropertyIDsFile parses the content of PROPERTY_IDS_FILE like ParsePropertyIDs, except that the file may be a
+// single-column CSV: a first non-comment line holding a single non-numeric column is a header and skipped.
+func parsePropertyIDsFile(content string) ([]int64, error) {
+	if strings.HasPrefix(strings.TrimSpace(content), "[") {
+		return ParsePropertyIDs(content)
+	}
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if fields := splitPropertyIDs(line); len(fields) == 1 {
+			if _, err := strconv.ParseInt(fields[0], 10, 64); err != nil {
+				lines[i] = ""
+			}
+		}
+		break
+	}
+	return ParsePropertyIDs(strings.Join(lines, "\n"))
+}
+
+// ParsePropertyIDs parses a JSON array of IDs, or IDs separated by commas, whitespace or newlines; lines starting
+// with # are ignored. Duplicates are dropped keeping the first occurrence; anything that is not a positive ID and
+// an empty list are errors.
+func ParsePropertyIDs(content string) ([]int64, error) {
+	content = strings.TrimSpace(content)
+
+	var ids []int64
+	if strings.HasPrefix(content, "[") {
+		if err := json.Unmarshal([]byte(content), &ids); err != nil {
+			return nil, fmt.Errorf("malformed JSON array: %w", err)
+		}
+	} else {
+		for _, line := range strings.Split(content, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			for _, field := range splitPropertyIDs(line) {
+				id, err := strconv.ParseInt(field, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid property ID %q", field)
+				}
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	return dedupePropertyIDs(ids)
+}
+
+// splitPropertyIDs splits a line of IDs separated by commas or whitespace
+func splitPropertyIDs(line string) []string {
+	return strings.FieldsFunc(line, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })
+}
+
+// dedupePropertyIDs rejects non-positive IDs and drops duplicates, keeping the first occurrence
+func dedupePropertyIDs(ids []int64) ([]int64, error) {
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, fmt.Errorf("invalid property ID %d", id)
+		}
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, fmt.Errorf("no property IDs")
+	}
+	return unique, nil
+}
@@ -0,0 +1,39 @@
+package cupid
+
+import (
+	"strings"
+	"time"
+)
+
+// reviewDateLayouts are the review date formats seen upstream, tried in order. Slash-separated dates are
+// only accepted year first, since day-first and month-first dates cannot be told apart.
+var reviewDateLayouts = []string{
+	time.DateOnly,
+	time.DateTime,
+	time.RFC3339,
+	"2006-1-2",
+	"2006-1-2 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006/1/2",
+	"January 2, 2006",
+	"Jan 2, 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+}
+
+// ParseReviewDate parses an upstream review date into its calendar day, at midnight UTC; a time of day is dropped.
+// ok is false when the value is empty or not a recognizable date.
+func ParseReviewDate(value string) (date time.Time, ok bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	for _, layout := range reviewDateLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			year, month, day := parsed.Date()
+			return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), true
+		}
+	}
+	return time.Time{}, false
+}
@@ -6,22 +6,68 @@ import (
 	"sync"
 	"time"
 
+	"github.com/barimehdi77/cupid-api/internal/env"
 	"github.com/barimehdi77/cupid-api/internal/logger"
 	"go.uber.org/zap"
 )
 
+// PropertyFetcher fetches property data from the Cupid API.
+// It is implemented by *Client and lets the service be tested without real HTTP calls.
+type PropertyFetcher interface {
+	GetProperty(ctx context.Context, propertyID int64) (*Property, error)
+	GetPropertyReviews(ctx context.Context, propertyID int64, reviewCount int) ([]Review, error)
+	MaxReviews() int
+	GetPropertyTranslations(ctx context.Context, propertyID int64, language string) (*Property, error)
+	FetchAllPropertyData(ctx context.Context, propertyID int64) (*PropertyData, error)
+	FetchPropertyDataWithoutReviews(ctx context.Context, propertyID int64) (*PropertyData, error)
+}
+
 // Service handles batch operations and business logic
 type Service struct {
-	client *Client
+	client PropertyFetcher
+	// cancelOnFatal stops the remaining fetches of FetchAllProperties on the first fatal error
+	cancelOnFatal bool
+	// concurrency caps the properties FetchAllProperties fetches at once, review fetches included
+	concurrency int
+	// reviewConcurrency reserves that many of the concurrency slots for review fetches, so property slots are freed
+	// while the reviews of large hotels download; 0 fetches reviews within the property slot
+	reviewConcurrency int
+	// propertyIDs are the properties FetchAllProperties fetches, loaded by LoadPropertyIDs
+	propertyIDs []int64
 }
 
-// NewService creates a new Cupid service
-func NewService() *Service {
+// NewService creates a new Cupid service fetching through client.
+// A nil client falls back to a Client configured from the environment.
+// The properties to fetch come from LoadPropertyIDs; if the configured source cannot be loaded, the error is
+// logged and the embedded PropertyIDs are used, so callers wanting to fail fast should call LoadPropertyIDs first.
+func NewService(client PropertyFetcher) *Service {
+	if client == nil {
+		client = NewClient()
+	}
+
+	concurrency := max(env.GetEnvInt("CUPID_FETCH_CONCURRENCY", 5), 1)
+	reviewConcurrency := min(max(env.GetEnvInt("CUPID_REVIEW_FETCH_CONCURRENCY", 0), 0), concurrency-1)
+
+	propertyIDs, err := LoadPropertyIDs()
+	if err != nil {
+		logger.LogError("Failed to load property IDs, using the embedded list", err)
+		propertyIDs = PropertyIDs
+	}
+
 	return &Service{
-		client: NewClient(),
+		client:            client,
+		cancelOnFatal:     env.GetEnvBool("CUPID_CANCEL_ON_FATAL", true),
+		concurrency:       concurrency,
+		reviewConcurrency: reviewConcurrency,
+		propertyIDs:       propertyIDs,
 	}
 }
 
+// PropertyIDs returns the properties FetchAllProperties fetches
+func (s *Service) PropertyIDs() []int64 {
+	return s.propertyIDs
+}
+
 // fetchResult represents the aggregated results from concurrent property fetching operations.
 // It contains all successfully fetched properties, any errors that occurred during fetching,
 // and the total duration of the operation for performance tracking.
@@ -32,9 +78,44 @@ type fetchResult struct {
 	fetchErrors []error
 	// duration represents the total time taken for the entire fetch operation
 	duration time.Duration
+	// fatalErr is the fatal error that cancelled the remaining fetches, if any
+	fatalErr error
+}
+
+// fetchAbort cancels the workers of a bulk fetch and remembers the fatal error that caused it
+type fetchAbort struct {
+	once   sync.Once
+	cancel context.CancelFunc
+	err    error
+}
+
+// trigger records err and cancels the remaining workers; only the first call has any effect
+func (a *fetchAbort) trigger(err error) {
+	a.once.Do(func() {
+		a.err = err
+		a.cancel()
+	})
+}
+
+// fetchSlots bounds the concurrent Cupid API requests of a bulk fetch.
+// A worker holds a property slot while fetching a property. When reviews is set, the property slot only covers
+// the details and translations, and the reviews are fetched afterwards in a review slot.
+// Each slot carries at most one request at a time, so the requests in flight never exceed the total number of slots.
+type fetchSlots struct {
+	properties chan struct{}
+	reviews    chan struct{}
+}
+
+// newFetchSlots creates the slots of a bulk fetch, splitting the configured concurrency between properties and reviews
+func (s *Service) newFetchSlots() *fetchSlots {
+	slots := &fetchSlots{properties: make(chan struct{}, s.concurrency-s.reviewConcurrency)}
+	if s.reviewConcurrency > 0 {
+		slots.reviews = make(chan struct{}, s.reviewConcurrency)
+	}
+	return slots
 }
 
-// FetchAllProperties fetches all properties from the predefined PropertyIDs list using concurrent processing.
+// FetchAllProperties fetches all properties of the loaded property ID list (see LoadPropertyIDs) using concurrent processing.
 // This is the main entry point for bulk property data retrieval.
 //
 // The function orchestrates the entire fetching process by:
@@ -48,59 +129,76 @@ type fetchResult struct {
 //
 // Returns:
 //   - []*PropertyData: Slice of successfully fetched property data
-//   - error: Always returns nil (errors are logged but don't fail the operation)
+//   - error: The fatal error that aborted the operation, nil otherwise
 //
 // Note: Individual property fetch failures are logged but don't cause the entire operation to fail.
 // This ensures maximum data retrieval even when some properties are unavailable.
+// A fatal error (see IsFatalError), such as a rejected API key, cancels the remaining fetches
+// and is returned, unless CUPID_CANCEL_ON_FATAL is false.
 func (s *Service) FetchAllProperties(ctx context.Context) ([]*PropertyData, error) {
-	s.logFetchStart()
+	return s.FetchProperties(ctx, s.propertyIDs)
+}
+
+// FetchProperties fetches the given properties with the same bounded concurrency and error handling as
+// FetchAllProperties, for syncs scoped to part of the roster.
+func (s *Service) FetchProperties(ctx context.Context, propertyIDs []int64) ([]*PropertyData, error) {
+	s.logFetchStart(len(propertyIDs))
 
 	start := time.Now()
-	result := s.processConcurrentFetches(ctx)
+	result := s.processConcurrentFetches(ctx, propertyIDs)
 	result.duration = time.Since(start)
 
 	s.logFetchResults(result)
 	s.logFetchErrors(result.fetchErrors)
 
+	if result.fatalErr != nil {
+		return result.properties, fmt.Errorf("property fetching aborted: %w", result.fatalErr)
+	}
 	return result.properties, nil
 }
 
 // logFetchStart logs the initiation of the property fetching operation.
 // This provides visibility into when bulk fetching begins and how many properties
 // are being processed, which is useful for monitoring and debugging.
-func (s *Service) logFetchStart() {
+func (s *Service) logFetchStart(total int) {
 	logger.LogStartup("Property data fetching",
-		zap.Int("total_properties", len(PropertyIDs)),
+		zap.Int("total_properties", total),
 	)
 }
 
-// processConcurrentFetches orchestrates the concurrent fetching of all properties.
+// processConcurrentFetches orchestrates the concurrent fetching of the given properties.
 // This function sets up the necessary concurrency infrastructure including:
 //   - Result and error channels for goroutine communication
 //   - WaitGroup for synchronization
-//   - Semaphore for rate limiting (max 5 concurrent requests)
+//   - Property and review slots limiting the concurrent requests (see fetchSlots)
 //
 // The function launches worker goroutines for each property ID and then
 // collects all results before returning them in an aggregated format.
 //
 // Parameters:
 //   - ctx: Context for cancellation and timeout control
+//   - propertyIDs: The properties to fetch
 //
 // Returns:
 //   - *fetchResult: Aggregated results containing properties, errors, and metadata
-func (s *Service) processConcurrentFetches(ctx context.Context) *fetchResult {
+func (s *Service) processConcurrentFetches(ctx context.Context, propertyIDs []int64) *fetchResult {
+	// Shared cancellation so a fatal error stops the workers that have not fetched yet
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	abort := &fetchAbort{cancel: cancel}
+
 	// Channel for results
-	results := make(chan *PropertyData, len(PropertyIDs))
-	errors := make(chan error, len(PropertyIDs))
+	results := make(chan *PropertyData, len(propertyIDs))
+	errors := make(chan error, len(propertyIDs))
 
 	// WaitGroup for concurrency
 	var wg sync.WaitGroup
 
-	// Semaphore to limit concurrent requests (avoid rate limiting)
-	semaphore := make(chan struct{}, 5) // Max 5 concurrent requests
+	// Slots to limit concurrent requests (avoid rate limiting)
+	slots := s.newFetchSlots()
 
 	// Launch worker goroutines
-	s.launchWorkerGoroutines(ctx, &wg, semaphore, results, errors)
+	s.launchWorkerGoroutines(ctx, propertyIDs, &wg, slots, abort, results, errors)
 
 	// Close channels when done
 	go func() {
@@ -110,29 +208,33 @@ func (s *Service) processConcurrentFetches(ctx context.Context) *fetchResult {
 	}()
 
 	// Collect and return results
-	return s.collectFetchResults(results, errors)
+	result := s.collectFetchResults(results, errors)
+	result.fatalErr = abort.err
+	return result
 }
 
 // launchWorkerGoroutines creates and starts a worker goroutine for each property ID.
 // Each goroutine will independently fetch one property's data while respecting
-// the concurrency limits imposed by the semaphore.
+// the concurrency limits imposed by the fetch slots.
 //
 // Parameters:
 //   - ctx: Context for cancellation and timeout control
+//   - propertyIDs: The properties to fetch, one worker each
 //   - wg: WaitGroup to track completion of all workers
-//   - semaphore: Channel used as a semaphore to limit concurrent requests
+//   - slots: Property and review slots limiting concurrent requests
+//   - abort: Cancels the remaining workers on a fatal error
 //   - results: Channel for sending successfully fetched property data
 //   - errors: Channel for sending any errors that occur during fetching
-func (s *Service) launchWorkerGoroutines(ctx context.Context, wg *sync.WaitGroup, semaphore chan struct{}, results chan *PropertyData, errors chan error) {
-	for _, propertyID := range PropertyIDs {
+func (s *Service) launchWorkerGoroutines(ctx context.Context, propertyIDs []int64, wg *sync.WaitGroup, slots *fetchSlots, abort *fetchAbort, results chan *PropertyData, errors chan error) {
+	for _, propertyID := range propertyIDs {
 		wg.Add(1)
-		go s.fetchPropertyWorker(ctx, propertyID, wg, semaphore, results, errors)
+		go s.fetchPropertyWorker(ctx, propertyID, wg, slots, abort, results, errors)
 	}
 }
 
 // fetchPropertyWorker is the worker function that fetches data for a single property.
 // This function runs in its own goroutine and handles:
-//   - Semaphore acquisition for rate limiting
+//   - Slot acquisition for rate limiting
 //   - Rate limiting delay to avoid overwhelming the external API
 //   - Actual property data fetching via the client
 //   - Error handling and logging
@@ -142,24 +244,47 @@ func (s *Service) launchWorkerGoroutines(ctx context.Context, wg *sync.WaitGroup
 //   - ctx: Context for cancellation and timeout control
 //   - propertyID: The unique identifier of the property to fetch
 //   - wg: WaitGroup to signal completion
-//   - semaphore: Channel used as a semaphore to limit concurrent requests
+//   - slots: Property and review slots limiting concurrent requests
+//   - abort: Cancels the remaining workers on a fatal error
 //   - results: Channel for sending successfully fetched property data
 //   - errors: Channel for sending any errors that occur during fetching
 //
 // The function implements a "fail-fast" approach where individual errors don't
 // block other workers, ensuring maximum throughput even with partial failures.
-func (s *Service) fetchPropertyWorker(ctx context.Context, propertyID int64, wg *sync.WaitGroup, semaphore chan struct{}, results chan *PropertyData, errors chan error) {
+// Once ctx is cancelled, workers that have not fetched yet return without making a request.
+func (s *Service) fetchPropertyWorker(ctx context.Context, propertyID int64, wg *sync.WaitGroup, slots *fetchSlots, abort *fetchAbort, results chan *PropertyData, errors chan error) {
 	defer wg.Done()
 
-	// Acquire semaphore
-	semaphore <- struct{}{}
-	defer func() { <-semaphore }()
+	// Acquire a property slot
+	select {
+	case slots.properties <- struct{}{}:
+	case <-ctx.Done():
+		errors <- fmt.Errorf("property %d: %w", propertyID, ctx.Err())
+		return
+	}
 
 	// Add small delay to avoid rate limiting
-	time.Sleep(100 * time.Millisecond)
+	select {
+	case <-time.After(100 * time.Millisecond):
+	case <-ctx.Done():
+		<-slots.properties
+		errors <- fmt.Errorf("property %d: %w", propertyID, ctx.Err())
+		return
+	}
+
+	var propertyData *PropertyData
+	var err error
+	if slots.reviews == nil {
+		propertyData, err = s.client.FetchAllPropertyData(ctx, propertyID)
+	} else {
+		propertyData, err = s.client.FetchPropertyDataWithoutReviews(ctx, propertyID)
+	}
+	<-slots.properties
 
-	propertyData, err := s.client.FetchAllPropertyData(ctx, propertyID)
 	if err != nil {
+		if s.cancelOnFatal && IsFatalError(err) {
+			abort.trigger(err)
+		}
 		logger.LogError("Property fetch failed", err,
 			zap.Int64("property_id", propertyID),
 		)
@@ -167,6 +292,18 @@ func (s *Service) fetchPropertyWorker(ctx context.Context, propertyID int64, wg
 		return
 	}
 
+	if slots.reviews != nil {
+		// Reviews are fetched in a review slot, leaving the property slot to the next property
+		select {
+		case slots.reviews <- struct{}{}:
+		case <-ctx.Done():
+			errors <- fmt.Errorf("property %d: %w", propertyID, ctx.Err())
+			return
+		}
+		fetchReviews(ctx, s.client, propertyID, propertyData)
+		<-slots.reviews
+	}
+
 	results <- propertyData
 }
 
@@ -0,0 +1,368 @@
+package cupid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFetcher is an in-memory PropertyFetcher returning canned property data
+type fakeFetcher struct {
+	// failing lists the property IDs whose fetch returns an error
+	failing map[int64]bool
+	// unauthorized makes every fetch fail with a 401 API error
+	unauthorized bool
+
+	// reviewDelay is how long each review fetch takes
+	reviewDelay time.Duration
+	// maxReviews is returned by MaxReviews
+	maxReviews int
+
+	mu                 sync.Mutex
+	fetched            []int64
+	inFlight           int32
+	maxInFlight        int32
+	reviewsInFlight    int32
+	maxReviewsInFlight int32
+}
+
+// trackInFlight counts a request in flight and records the highest count seen; the returned func ends the request
+func trackInFlight(inFlight, maxInFlight *int32) func() {
+	current := atomic.AddInt32(inFlight, 1)
+	for {
+		seen := atomic.LoadInt32(maxInFlight)
+		if current <= seen || atomic.CompareAndSwapInt32(maxInFlight, seen, current) {
+			break
+		}
+	}
+	return func() { atomic.AddInt32(inFlight, -1) }
+}
+
+func (f *fakeFetcher) GetProperty(ctx context.Context, propertyID int64) (*Property, error) {
+	return &Property{HotelID: propertyID}, nil
+}
+
+func (f *fakeFetcher) GetPropertyReviews(ctx context.Context, propertyID int64, reviewCount int) ([]Review, error) {
+	defer trackInFlight(&f.inFlight, &f.maxInFlight)()
+	defer trackInFlight(&f.reviewsInFlight, &f.maxReviewsInFlight)()
+
+	time.Sleep(f.reviewDelay)
+	reviews := make([]Review, reviewCount)
+	for i := range reviews {
+		reviews[i].ReviewID = int64(i + 1)
+	}
+	return reviews, nil
+}
+
+func (f *fakeFetcher) MaxReviews() int {
+	return f.maxReviews
+}
+
+func (f *fakeFetcher) GetPropertyTranslations(ctx context.Context, propertyID int64, language string) (*Property, error) {
+	return &Property{HotelID: propertyID}, nil
+}
+
+func (f *fakeFetcher) FetchAllPropertyData(ctx context.Context, propertyID int64) (*PropertyData, error) {
+	defer trackInFlight(&f.inFlight, &f.maxInFlight)()
+
+	f.mu.Lock()
+	f.fetched = append(f.fetched, propertyID)
+	f.mu.Unlock()
+
+	if f.unauthorized {
+		return nil, fmt.Errorf("failed to fetch property details: %w", &APIError{StatusCode: http.StatusUnauthorized})
+	}
+	if f.failing[propertyID] {
+		return nil, errors.New("upstream unavailable")
+	}
+	return &PropertyData{Property: Property{HotelID: propertyID}}, nil
+}
+
+func (f *fakeFetcher) FetchPropertyDataWithoutReviews(ctx context.Context, propertyID int64) (*PropertyData, error) {
+	defer trackInFlight(&f.inFlight, &f.maxInFlight)()
+
+	f.mu.Lock()
+	f.fetched = append(f.fetched, propertyID)
+	f.mu.Unlock()
+
+	return &PropertyData{Property: Property{HotelID: propertyID, ReviewCount: 3}}, nil
+}
+
+// TestNewService tests that the client is injected and defaults to the HTTP client
+func TestNewService(t *testing.T) {
+	t.Run("UsesGivenFetcher", func(t *testing.T) {
+		// Arrange
+		fetcher := &fakeFetcher{}
+
+		// Act
+		service := NewService(fetcher)
+
+		// Assert
+		assert.Same(t, fetcher, service.client)
+	})
+
+	t.Run("DefaultsToClient", func(t *testing.T) {
+		// Act
+		service := NewService(nil)
+
+		// Assert
+		assert.IsType(t, &Client{}, service.client)
+	})
+}
+
+// TestService_FetchAllProperties tests concurrent fetching and error aggregation with a fake fetcher
+func TestService_FetchAllProperties(t *testing.T) {
+	// Arrange
+	setupObservedLogger(t)
+	failing := map[int64]bool{PropertyIDs[0]: true, PropertyIDs[3]: true}
+	fetcher := &fakeFetcher{failing: failing}
+	service := NewService(fetcher)
+
+	// Act
+	properties, err := service.FetchAllProperties(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, fetcher.fetched, len(PropertyIDs))
+	assert.ElementsMatch(t, PropertyIDs, fetcher.fetched)
+	assert.LessOrEqual(t, atomic.LoadInt32(&fetcher.maxInFlight), int32(5))
+
+	require.Len(t, properties, len(PropertyIDs)-len(failing))
+	for _, property := range properties {
+		assert.False(t, failing[property.Property.HotelID])
+	}
+}
+
+// TestService_FetchAllProperties_ConfiguredIDs tests that the loaded property IDs replace the embedded list
+func TestService_FetchAllProperties_ConfiguredIDs(t *testing.T) {
+	// Arrange
+	setupObservedLogger(t)
+	t.Setenv("PROPERTY_IDS", "101, 202,303")
+	fetcher := &fakeFetcher{}
+	service := NewService(fetcher)
+
+	// Act
+	properties, err := service.FetchAllProperties(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []int64{101, 202, 303}, service.PropertyIDs())
+	assert.ElementsMatch(t, []int64{101, 202, 303}, fetcher.fetched)
+	assert.Len(t, properties, 3)
+}
+
+// TestService_FetchProperties tests that a scoped fetch only requests the given properties
+func TestService_FetchProperties(t *testing.T) {
+	// Arrange
+	setupObservedLogger(t)
+	fetcher := &fakeFetcher{failing: map[int64]bool{PropertyIDs[1]: true}}
+	service := NewService(fetcher)
+	ids := []int64{PropertyIDs[0], PropertyIDs[1], PropertyIDs[2]}
+
+	// Act
+	properties, err := service.FetchProperties(context.Background(), ids)
+
+	// Assert
+	require.NoError(t, err)
+	assert.ElementsMatch(t, ids, fetcher.fetched)
+	assert.Len(t, properties, 2)
+}
+
+// TestLoadPropertyIDs tests the property ID sources and their precedence
+func TestLoadPropertyIDs(t *testing.T) {
+	writeFile := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "property_ids")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	tests := []struct {
+		name        string
+		file        string
+		env         string
+		expected    []int64
+		expectedErr string
+	}{
+		{name: "Embedded", expected: PropertyIDs},
+		{name: "Env", env: "3, 1,2,1", expected: []int64{3, 1, 2}},
+		{name: "JSONFile", file: "[42, 7, 42]", env: "1", expected: []int64{42, 7}},
+		{name: "CSVFile", file: "hotel_id\n# onboarding batch\n1641879\n317597,1202743\n", expected: []int64{1641879, 317597, 1202743}},
+		{name: "CSVFileHeaderAfterComment", file: "# roster\nhotel_id\n42\n", expected: []int64{42}},
+		{name: "MalformedEnv", env: "1,two", expectedErr: `invalid property ID "two"`},
+		{name: "NonNumericFirstEnvID", env: "abc,1,2", expectedErr: `invalid property ID "abc"`},
+		{name: "NonNumericFirstFileID", file: "abc,1,2\n", expectedErr: `invalid property ID "abc"`},
+		{name: "HeaderNotFirstInFile", file: "1\nhotel_id\n2\n", expectedErr: `invalid property ID "hotel_id"`},
+		{name: "MalformedJSON", file: "[1, 2", expectedErr: "malformed JSON array"},
+		{name: "NonPositiveID", env: "5,-1", expectedErr: "invalid property ID -1"},
+		{name: "EmptyFile", file: "# nothing yet\n", expectedErr: "no property IDs"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			t.Setenv("PROPERTY_IDS", tt.env)
+			t.Setenv("PROPERTY_IDS_FILE", "")
+			if tt.file != "" {
+				t.Setenv("PROPERTY_IDS_FILE", writeFile(t, tt.file))
+			}
+
+			// Act
+			ids, err := LoadPropertyIDs()
+
+			// Assert
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				assert.Nil(t, ids)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+
+	t.Run("MissingFile", func(t *testing.T) {
+		// Arrange
+		t.Setenv("PROPERTY_IDS_FILE", filepath.Join(t.TempDir(), "missing.json"))
+
+		// Act
+		ids, err := LoadPropertyIDs()
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read property IDs file")
+		assert.Nil(t, ids)
+	})
+}
+
+// TestService_FetchAllProperties_ReviewConcurrency tests that reviews fetched in their own slots keep the
+// property and review requests in flight within the configured total
+func TestService_FetchAllProperties_ReviewConcurrency(t *testing.T) {
+	// Arrange
+	setupObservedLogger(t)
+	t.Setenv("CUPID_FETCH_CONCURRENCY", "6")
+	t.Setenv("CUPID_REVIEW_FETCH_CONCURRENCY", "2")
+	fetcher := &fakeFetcher{reviewDelay: 50 * time.Millisecond}
+	service := NewService(fetcher)
+
+	// Act
+	properties, err := service.FetchAllProperties(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, properties, len(PropertyIDs))
+	for _, property := range properties {
+		assert.Len(t, property.Reviews, 3)
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&fetcher.maxInFlight), int32(6))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetcher.maxReviewsInFlight))
+}
+
+// TestFetchReviews_Complete tests that reviews are only reported complete when the fetch was not capped
+func TestFetchReviews_Complete(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxReviews   int
+		reviewCount  int
+		wantComplete bool
+	}{
+		{"NoReviews", 2, 0, true},
+		{"NoMax", 0, 3, true},
+		{"BelowMax", 3, 3, true},
+		{"Capped", 2, 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			setupObservedLogger(t)
+			fetcher := &fakeFetcher{maxReviews: tt.maxReviews}
+			propertyData := &PropertyData{Property: Property{HotelID: 1, ReviewCount: tt.reviewCount}}
+
+			// Act
+			fetchReviews(context.Background(), fetcher, 1, propertyData)
+
+			// Assert
+			assert.Equal(t, tt.wantComplete, propertyData.ReviewsComplete)
+		})
+	}
+}
+
+// TestNewService_Concurrency tests that the review slots are carved out of the total concurrency
+func TestNewService_Concurrency(t *testing.T) {
+	tests := []struct {
+		name              string
+		concurrency       string
+		reviewConcurrency string
+		expectedTotal     int
+		expectedReviews   int
+	}{
+		{name: "Defaults", expectedTotal: 5, expectedReviews: 0},
+		{name: "ReviewSlots", concurrency: "8", reviewConcurrency: "3", expectedTotal: 8, expectedReviews: 3},
+		{name: "KeepsOnePropertySlot", concurrency: "4", reviewConcurrency: "10", expectedTotal: 4, expectedReviews: 3},
+		{name: "InvalidValues", concurrency: "0", reviewConcurrency: "-1", expectedTotal: 1, expectedReviews: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			t.Setenv("CUPID_FETCH_CONCURRENCY", tt.concurrency)
+			t.Setenv("CUPID_REVIEW_FETCH_CONCURRENCY", tt.reviewConcurrency)
+
+			// Act
+			service := NewService(&fakeFetcher{})
+
+			// Assert
+			assert.Equal(t, tt.expectedTotal, service.concurrency)
+			assert.Equal(t, tt.expectedReviews, service.reviewConcurrency)
+		})
+	}
+}
+
+// TestService_FetchAllProperties_FatalError tests that a 401 cancels the workers that have not fetched yet
+func TestService_FetchAllProperties_FatalError(t *testing.T) {
+	// Arrange
+	setupObservedLogger(t)
+	fetcher := &fakeFetcher{unauthorized: true}
+	service := NewService(fetcher)
+
+	// Act
+	start := time.Now()
+	properties, err := service.FetchAllProperties(context.Background())
+	elapsed := time.Since(start)
+
+	// Assert
+	require.Error(t, err)
+	assert.True(t, IsFatalError(err))
+	assert.ErrorContains(t, err, "API error: status 401")
+	assert.Empty(t, properties)
+	// Only the first wave of workers, bounded by the semaphore, reached the API
+	assert.LessOrEqual(t, len(fetcher.fetched), 5)
+	assert.Less(t, elapsed, time.Second)
+}
+
+// TestService_FetchProperty tests that a single fetch error is returned to the caller
+func TestService_FetchProperty(t *testing.T) {
+	// Arrange
+	fetcher := &fakeFetcher{failing: map[int64]bool{2: true}}
+	service := NewService(fetcher)
+
+	// Act
+	property, err := service.FetchProperty(context.Background(), 1)
+	_, failErr := service.FetchProperty(context.Background(), 2)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), property.Property.HotelID)
+	assert.ErrorContains(t, failErr, "upstream unavailable")
+}
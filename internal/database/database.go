@@ -2,15 +2,23 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/barimehdi77/cupid-api/internal/env"
+	"github.com/barimehdi77/cupid-api/internal/tracing"
 	_ "github.com/lib/pq"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// DB wraps the connection pool.
+// Its query methods shadow the ones of sql.DB so every statement runs in its own trace span.
 type DB struct {
 	*sql.DB
+	health healthState
 }
 
 func NewDB() (*DB, error) {
@@ -30,14 +38,52 @@ func NewDB() (*DB, error) {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
+	// Recycle connections periodically so ones left stale by a failover are not reused forever
+	maxIdleConns := env.GetEnvInt("DB_MAX_IDLE_CONNS", 2)
+	db.SetMaxIdleConns(maxIdleConns)
+	db.SetConnMaxLifetime(env.GetEnvDuration("DB_CONN_MAX_LIFETIME", 30*time.Minute))
+	db.SetConnMaxIdleTime(env.GetEnvDuration("DB_CONN_MAX_IDLE_TIME", 5*time.Minute))
+
 	if err := db.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &DB{DB: db}, nil
+	return &DB{DB: db, health: healthState{maxIdleConns: maxIdleConns}}, nil
 }
 
 // Add helper methods if needed
 func (db *DB) Close() error {
 	return db.DB.Close()
 }
+
+// QueryContext runs a query returning rows inside a span
+func (db *DB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	rows, err := db.DB.QueryContext(ctx, query, args...)
+	tracing.EndSpan(span, err)
+	return rows, err
+}
+
+// QueryRowContext runs a query returning at most one row inside a span
+func (db *DB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, span := startQuerySpan(ctx, query)
+	row := db.DB.QueryRowContext(ctx, query, args...)
+	tracing.EndSpan(span, row.Err())
+	return row
+}
+
+// ExecContext runs a statement without returned rows inside a span
+func (db *DB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, span := startQuerySpan(ctx, query)
+	result, err := db.DB.ExecContext(ctx, query, args...)
+	tracing.EndSpan(span, err)
+	return result, err
+}
+
+// startQuerySpan starts the span of a single database statement
+func startQuerySpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	return tracing.StartSpan(ctx, "db.query",
+		semconv.DBSystemPostgreSQL,
+		semconv.DBQueryText(query),
+	)
+}
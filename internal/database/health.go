@@ -0,0 +1,78 @@
+package database
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/barimehdi77/cupid-api/internal/env"
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"go.uber.org/zap"
+)
+
+// healthState tracks the outcome of the background connection checks.
+// Its zero value is healthy, as a pool starts out right after a successful ping.
+type healthState struct {
+	down atomic.Bool
+	// maxIdleConns is restored after the idle connections are dropped on a failed check
+	maxIdleConns int
+}
+
+// StartHealthCheck pings the database every DB_HEALTH_CHECK_INTERVAL until ctx is done, logging when the
+// connection is lost and when it comes back. A failed ping drops the idle connections of the pool, so once the
+// database is reachable again queries get fresh connections instead of failing on ones the network blip or
+// failover broke. An interval of zero or less disables the check.
+func (db *DB) StartHealthCheck(ctx context.Context) {
+	interval := env.GetEnvDuration("DB_HEALTH_CHECK_INTERVAL", 30*time.Second)
+	if interval <= 0 {
+		return
+	}
+
+	logger.Info("Starting database health check", zap.Duration("interval", interval))
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				db.checkHealth(ctx, interval)
+			}
+		}
+	}()
+}
+
+// Healthy reports whether the last connection check succeeded; it is true until a check fails
+func (db *DB) Healthy() bool {
+	return !db.health.down.Load()
+}
+
+// checkHealth pings the database once, bounded by timeout, and records and logs any change of state
+func (db *DB) checkHealth(ctx context.Context, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := db.PingContext(ctx)
+	wasHealthy := !db.health.down.Swap(err != nil)
+
+	switch {
+	case err != nil && wasHealthy:
+		logger.LogError("Database connection lost", err)
+		db.dropIdleConns()
+	case err != nil:
+		logger.Warn("Database still unreachable", zap.Error(err))
+		db.dropIdleConns()
+	case !wasHealthy:
+		logger.LogSuccess("Database connection restored",
+			zap.Int("open_connections", db.Stats().OpenConnections),
+		)
+	}
+}
+
+// dropIdleConns closes the idle connections of the pool, which may be broken, keeping the configured idle limit
+func (db *DB) dropIdleConns() {
+	db.SetMaxIdleConns(0)
+	db.SetMaxIdleConns(db.health.maxIdleConns)
+}
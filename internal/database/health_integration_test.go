@@ -0,0 +1,47 @@
+//go:build integration
+
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDB_HealthCheckRecovery tests against a real PostgreSQL database (configured through the DB_* variables)
+// that queries recover after the server drops the pool's connection, as it would during a failover.
+// Run with: go test -tags integration ./internal/database/
+func TestDB_HealthCheckRecovery(t *testing.T) {
+	// Arrange
+	require.NoError(t, logger.InitLogger())
+	db, err := NewDB()
+	if err != nil {
+		t.Skipf("Database not reachable: %v", err)
+	}
+	defer db.Close()
+	admin, err := NewDB()
+	require.NoError(t, err)
+	defer admin.Close()
+
+	// A single connection makes the terminated backend the one the next query would reuse
+	db.SetMaxOpenConns(1)
+	ctx := context.Background()
+	var pid int
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT pg_backend_pid()").Scan(&pid))
+
+	// Act
+	var terminated bool
+	require.NoError(t, admin.QueryRowContext(ctx, "SELECT pg_terminate_backend($1)", pid).Scan(&terminated))
+	require.True(t, terminated)
+	db.checkHealth(ctx, 5*time.Second)
+
+	// Assert
+	var newPID int
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT pg_backend_pid()").Scan(&newPID))
+	assert.NotEqual(t, pid, newPID, "the dropped connection is replaced")
+	assert.True(t, db.Healthy())
+}
@@ -0,0 +1,47 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// TestDB_CheckHealth tests that a failed ping marks the connection unhealthy until a later ping succeeds
+func TestDB_CheckHealth(t *testing.T) {
+	// Arrange
+	logger.Logger = zap.NewNop()
+	// Reopening the DSN hands the pool the same mock connection back after its idle connections are dropped
+	mockDB, mock, err := sqlmock.NewWithDSN("health_check", sqlmock.MonitorPingsOption(true))
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+	conn, err := sql.Open("sqlmock", "health_check")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	db := &DB{DB: conn}
+
+	mock.ExpectPing().WillReturnError(errors.New("connection reset by peer"))
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+	mock.ExpectPing()
+
+	// Act & Assert
+	assert.True(t, db.Healthy(), "a new pool is healthy")
+
+	db.checkHealth(context.Background(), time.Second)
+	assert.False(t, db.Healthy(), "lost")
+
+	db.checkHealth(context.Background(), time.Second)
+	assert.False(t, db.Healthy(), "still unreachable")
+
+	db.checkHealth(context.Background(), time.Second)
+	assert.True(t, db.Healthy(), "restored")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"github.com/pressly/goose/v3"
+	"go.uber.org/zap"
+)
+
+// newMigrationProvider creates a goose provider for the given migrations.
+// Versions are tracked in goose's default goose_db_version table, so the goose CLI and the
+// embedded runner can be used interchangeably on the same database.
+func (db *DB) newMigrationProvider(migrations fs.FS) (*goose.Provider, error) {
+	provider, err := goose.NewProvider(goose.DialectPostgres, db.DB, migrations)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+	return provider, nil
+}
+
+// MigrateUp applies all pending migrations
+func (db *DB) MigrateUp(ctx context.Context, migrations fs.FS) error {
+	provider, err := db.newMigrationProvider(migrations)
+	if err != nil {
+		return err
+	}
+
+	results, err := provider.Up(ctx)
+	for _, result := range results {
+		logMigrationResult(result)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply migrations: %w", err)
+	}
+
+	version, err := provider.GetDBVersion(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read migration version: %w", err)
+	}
+	logger.LogSuccess("Database migrations applied",
+		zap.Int("applied", len(results)),
+		zap.Int64("version", version),
+	)
+	return nil
+}
+
+// MigrateDown rolls back the most recently applied migration
+func (db *DB) MigrateDown(ctx context.Context, migrations fs.FS) error {
+	provider, err := db.newMigrationProvider(migrations)
+	if err != nil {
+		return err
+	}
+
+	result, err := provider.Down(ctx)
+	if result != nil {
+		logMigrationResult(result)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to roll back migration: %w", err)
+	}
+	return nil
+}
+
+// MigrationStatus logs the state of every known migration
+func (db *DB) MigrationStatus(ctx context.Context, migrations fs.FS) error {
+	provider, err := db.newMigrationProvider(migrations)
+	if err != nil {
+		return err
+	}
+
+	statuses, err := provider.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read migration status: %w", err)
+	}
+
+	for _, status := range statuses {
+		fields := []zap.Field{
+			zap.Int64("version", status.Source.Version),
+			zap.String("file", status.Source.Path),
+			zap.String("state", string(status.State)),
+		}
+		if !status.AppliedAt.IsZero() {
+			fields = append(fields, zap.Time("applied_at", status.AppliedAt))
+		}
+		logger.Info("Migration status", fields...)
+	}
+	return nil
+}
+
+// logMigrationResult logs the outcome of a single migration
+func logMigrationResult(result *goose.MigrationResult) {
+	fields := []zap.Field{
+		zap.Int64("version", result.Source.Version),
+		zap.String("file", result.Source.Path),
+		zap.String("direction", result.Direction),
+		zap.Duration("duration", result.Duration),
+	}
+	if result.Error != nil {
+		logger.LogError("Migration failed", result.Error, fields...)
+		return
+	}
+	logger.Info("Migration applied", fields...)
+}
@@ -3,6 +3,7 @@ package env
 import (
 	"os"
 	"strconv"
+	"time"
 )
 
 func GetEnvString(key string, defaultValue string) string {
@@ -18,3 +19,39 @@ func GetEnvInt(key string, defaultValue int) int {
 	port, _ := strconv.Atoi(env)
 	return port
 }
+
+func GetEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	env := os.Getenv(key)
+	if env == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(env)
+	if err != nil {
+		return defaultValue
+	}
+	return duration
+}
+
+func GetEnvFloat(key string, defaultValue float64) float64 {
+	env := os.Getenv(key)
+	if env == "" {
+		return defaultValue
+	}
+	value, err := strconv.ParseFloat(env, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func GetEnvBool(key string, defaultValue bool) bool {
+	env := os.Getenv(key)
+	if env == "" {
+		return defaultValue
+	}
+	value, err := strconv.ParseBool(env)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
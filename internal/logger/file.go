@@ -0,0 +1,52 @@
+package logger
+
+import (
+	"github.com/barimehdi77/cupid-api/internal/env"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// FileConfig controls optional log file output with size-based rotation
+type FileConfig struct {
+	Path       string
+	MaxSizeMB  int
+	MaxBackups int
+	MaxAgeDays int
+}
+
+// FileConfigFromEnv reads the file logging configuration from environment variables.
+// File output is disabled when LOG_FILE is empty.
+func FileConfigFromEnv() FileConfig {
+	return FileConfig{
+		Path:       env.GetEnvString("LOG_FILE", ""),
+		MaxSizeMB:  env.GetEnvInt("LOG_MAX_SIZE_MB", 100),
+		MaxBackups: env.GetEnvInt("LOG_MAX_BACKUPS", 5),
+		MaxAgeDays: env.GetEnvInt("LOG_MAX_AGE_DAYS", 30),
+	}
+}
+
+// Enabled reports whether logs should also be written to a file
+func (fc FileConfig) Enabled() bool {
+	return fc.Path != ""
+}
+
+// createFileCore creates a JSON core writing to a rotating log file.
+// JSON is used regardless of environment so the file stays free of color codes and easy to ship.
+func createFileCore(fc FileConfig, level zapcore.Level) zapcore.Core {
+	writer := &lumberjack.Logger{
+		Filename:   fc.Path,
+		MaxSize:    fc.MaxSizeMB,
+		MaxBackups: fc.MaxBackups,
+		MaxAge:     fc.MaxAgeDays,
+	}
+
+	encoder := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+
+	return zapcore.NewCore(encoder, zapcore.AddSync(writer), zap.NewAtomicLevelAt(level))
+}
+
+// teeWithFile returns a core that writes to both the given core and the rotating log file
+func teeWithFile(core zapcore.Core, fc FileConfig, level zapcore.Level) zapcore.Core {
+	return zapcore.NewTee(core, createFileCore(fc, level))
+}
@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFileConfigFromEnv tests reading file logging settings from the environment
+func TestFileConfigFromEnv(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		// Act
+		fc := FileConfigFromEnv()
+
+		// Assert
+		assert.False(t, fc.Enabled())
+		assert.Equal(t, 100, fc.MaxSizeMB)
+		assert.Equal(t, 5, fc.MaxBackups)
+		assert.Equal(t, 30, fc.MaxAgeDays)
+	})
+
+	t.Run("CustomValues", func(t *testing.T) {
+		// Arrange
+		t.Setenv("LOG_FILE", "/var/log/cupid/api.log")
+		t.Setenv("LOG_MAX_SIZE_MB", "10")
+		t.Setenv("LOG_MAX_BACKUPS", "2")
+		t.Setenv("LOG_MAX_AGE_DAYS", "7")
+
+		// Act
+		fc := FileConfigFromEnv()
+
+		// Assert
+		assert.True(t, fc.Enabled())
+		assert.Equal(t, "/var/log/cupid/api.log", fc.Path)
+		assert.Equal(t, 10, fc.MaxSizeMB)
+		assert.Equal(t, 2, fc.MaxBackups)
+		assert.Equal(t, 7, fc.MaxAgeDays)
+	})
+}
+
+// TestInitLogger_FileOutput tests that logs are written to LOG_FILE when set
+func TestInitLogger_FileOutput(t *testing.T) {
+	for _, environment := range []string{"development", "production"} {
+		t.Run(environment, func(t *testing.T) {
+			// Arrange
+			previous := Logger
+			t.Cleanup(func() { Logger = previous })
+
+			logFile := filepath.Join(t.TempDir(), "api.log")
+			t.Setenv("GO_ENV", environment)
+			t.Setenv("LOG_LEVEL", "info")
+			t.Setenv("LOG_FILE", logFile)
+
+			// Act
+			require.NoError(t, InitLogger())
+			Info("written to file")
+			Sync()
+
+			// Assert
+			content, err := os.ReadFile(logFile)
+			require.NoError(t, err)
+			assert.Contains(t, string(content), "written to file")
+			assert.NotContains(t, string(content), "\033[")
+		})
+	}
+}
@@ -21,6 +21,9 @@ func InitLogger() error {
 	// Get environment (development or production)
 	environment := strings.ToLower(env.GetEnvString("GO_ENV", "development"))
 
+	// Optional rotating file output, tee'd alongside stdout
+	fileConfig := FileConfigFromEnv()
+
 	var core zapcore.Core
 	var err error
 
@@ -33,13 +36,23 @@ func InitLogger() error {
 		// Set log level
 		config.Level = zap.NewAtomicLevelAt(parseLogLevel(logLevel))
 
-		Logger, err = config.Build(
+		options := []zap.Option{
 			zap.AddCallerSkip(1),
 			zap.AddStacktrace(zapcore.ErrorLevel),
-		)
+		}
+		if fileConfig.Enabled() {
+			options = append(options, zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+				return teeWithFile(c, fileConfig, parseLogLevel(logLevel))
+			}))
+		}
+
+		Logger, err = config.Build(options...)
 	} else {
 		// Development configuration: Enhanced human-readable output
 		core = createDevelopmentCore(parseLogLevel(logLevel))
+		if fileConfig.Enabled() {
+			core = teeWithFile(core, fileConfig, parseLogLevel(logLevel))
+		}
 		Logger = zap.New(core,
 			zap.AddCaller(),
 			zap.AddCallerSkip(1),
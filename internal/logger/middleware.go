@@ -1,14 +1,59 @@
 package logger
 
 import (
+	"sync/atomic"
 	"time"
 
+	"github.com/barimehdi77/cupid-api/internal/env"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
+// SamplingConfig controls which successful requests are logged by the request middleware
+type SamplingConfig struct {
+	// Rate logs one out of every Rate successful requests (values <= 1 log every request)
+	Rate int
+	// SlowThreshold always logs requests taking longer than this (zero disables the check)
+	SlowThreshold time.Duration
+}
+
+// SamplingConfigFromEnv loads the request log sampling configuration from environment variables
+func SamplingConfigFromEnv() SamplingConfig {
+	return SamplingConfig{
+		Rate:          env.GetEnvInt("LOG_SAMPLE_RATE", 1),
+		SlowThreshold: env.GetEnvDuration("LOG_SLOW_REQUEST_THRESHOLD", time.Second),
+	}
+}
+
+// requestSampler decides whether a completed request should be logged
+type requestSampler struct {
+	config  SamplingConfig
+	counter atomic.Uint64
+}
+
+// shouldLog always logs errors and slow requests, and samples everything else
+func (s *requestSampler) shouldLog(statusCode int, latency time.Duration) bool {
+	if statusCode >= 400 {
+		return true
+	}
+	if s.config.SlowThreshold > 0 && latency >= s.config.SlowThreshold {
+		return true
+	}
+	if s.config.Rate <= 1 {
+		return true
+	}
+	return (s.counter.Add(1)-1)%uint64(s.config.Rate) == 0
+}
+
 // GinMiddleware returns a Gin middleware that logs HTTP requests using enhanced Zap logging
 func GinMiddleware() gin.HandlerFunc {
+	return GinMiddlewareWithSampling(SamplingConfigFromEnv())
+}
+
+// GinMiddlewareWithSampling returns a request logging middleware that samples successful requests
+func GinMiddlewareWithSampling(config SamplingConfig) gin.HandlerFunc {
+	sampler := &requestSampler{config: config}
+
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
@@ -27,6 +72,10 @@ func GinMiddleware() gin.HandlerFunc {
 		bodySize := c.Writer.Size()
 		userAgent := c.Request.UserAgent()
 
+		if !sampler.shouldLog(statusCode, latency) {
+			return
+		}
+
 		if raw != "" {
 			path = path + "?" + raw
 		}
@@ -37,6 +86,11 @@ func GinMiddleware() gin.HandlerFunc {
 			zap.Int("size", bodySize),
 		}
 
+		// Add request ID for correlating with handler logs
+		if requestID := c.GetString(requestIDKey); requestID != "" {
+			fields = append(fields, zap.String(requestIDKey, requestID))
+		}
+
 		// Add user agent for non-health checks
 		if path != "/health" && path != "/ping" {
 			fields = append(fields, zap.String("user_agent", userAgent))
@@ -56,6 +110,7 @@ func GinMiddleware() gin.HandlerFunc {
 func GinRecoveryMiddleware() gin.HandlerFunc {
 	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
 		Logger.Error("💥 Panic recovered - server error",
+			zap.String(requestIDKey, c.GetString(requestIDKey)),
 			zap.String("method", c.Request.Method),
 			zap.String("path", c.Request.URL.Path),
 			zap.String("ip", c.ClientIP()),
@@ -0,0 +1,143 @@
+package logger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// setupObservedLogger replaces the global logger with one that records entries in memory
+func setupObservedLogger(t *testing.T) *observer.ObservedLogs {
+	core, logs := observer.New(zapcore.DebugLevel)
+	previous := Logger
+	Logger = zap.New(core)
+	t.Cleanup(func() { Logger = previous })
+	return logs
+}
+
+func setupSampledRouter(config SamplingConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(GinMiddlewareWithSampling(config))
+	router.GET("/ok", func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.GET("/fail", func(c *gin.Context) { c.Status(http.StatusInternalServerError) })
+	router.GET("/bad", func(c *gin.Context) { c.Status(http.StatusBadRequest) })
+	router.GET("/slow", func(c *gin.Context) {
+		time.Sleep(20 * time.Millisecond)
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func sendRequests(router *gin.Engine, path string, count int) {
+	for i := 0; i < count; i++ {
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+}
+
+// TestGinMiddlewareWithSampling tests that successful requests are sampled while errors are always logged
+func TestGinMiddlewareWithSampling(t *testing.T) {
+	t.Run("SuccessfulRequestsAreSampled", func(t *testing.T) {
+		// Arrange
+		logs := setupObservedLogger(t)
+		router := setupSampledRouter(SamplingConfig{Rate: 5})
+
+		// Act
+		sendRequests(router, "/ok", 10)
+
+		// Assert
+		assert.Equal(t, 2, logs.Len())
+	})
+
+	t.Run("ErrorResponsesAreAlwaysLogged", func(t *testing.T) {
+		// Arrange
+		logs := setupObservedLogger(t)
+		router := setupSampledRouter(SamplingConfig{Rate: 100})
+
+		// Act
+		sendRequests(router, "/fail", 3)
+		sendRequests(router, "/bad", 3)
+
+		// Assert
+		assert.Equal(t, 6, logs.Len())
+	})
+
+	t.Run("SlowRequestsAreAlwaysLogged", func(t *testing.T) {
+		// Arrange
+		logs := setupObservedLogger(t)
+		router := setupSampledRouter(SamplingConfig{Rate: 100, SlowThreshold: 10 * time.Millisecond})
+
+		// Act
+		sendRequests(router, "/slow", 3)
+
+		// Assert
+		assert.Equal(t, 3, logs.Len())
+	})
+
+	t.Run("RateOfOneLogsEverything", func(t *testing.T) {
+		// Arrange
+		logs := setupObservedLogger(t)
+		router := setupSampledRouter(SamplingConfig{Rate: 1})
+
+		// Act
+		sendRequests(router, "/ok", 4)
+
+		// Assert
+		assert.Equal(t, 4, logs.Len())
+	})
+}
+
+// TestRequestIDMiddleware tests request ID generation, propagation, and logging
+func TestRequestIDMiddleware(t *testing.T) {
+	setupRouter := func() *gin.Engine {
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.Use(RequestIDMiddleware())
+		router.Use(GinMiddlewareWithSampling(SamplingConfig{Rate: 1}))
+		router.GET("/ok", func(c *gin.Context) {
+			c.String(http.StatusOK, RequestIDFromContext(c.Request.Context()))
+		})
+		return router
+	}
+
+	t.Run("UsesIncomingHeader", func(t *testing.T) {
+		// Arrange
+		logs := setupObservedLogger(t)
+		router := setupRouter()
+		req, _ := http.NewRequest("GET", "/ok", nil)
+		req.Header.Set(RequestIDHeader, "abc-123")
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, "abc-123", w.Header().Get(RequestIDHeader))
+		assert.Equal(t, "abc-123", w.Body.String())
+		assert.Equal(t, 1, logs.FilterField(zap.String("request_id", "abc-123")).Len())
+	})
+
+	t.Run("GeneratesWhenMissing", func(t *testing.T) {
+		// Arrange
+		setupObservedLogger(t)
+		router := setupRouter()
+		req, _ := http.NewRequest("GET", "/ok", nil)
+		w := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(w, req)
+
+		// Assert
+		requestID := w.Header().Get(RequestIDHeader)
+		assert.Len(t, requestID, 32)
+		assert.Equal(t, requestID, w.Body.String())
+	})
+}
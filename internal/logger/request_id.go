@@ -0,0 +1,60 @@
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RequestIDHeader is the header used to read and echo the request ID
+const RequestIDHeader = "X-Request-ID"
+
+// requestIDKey is the gin and request context key holding the request ID
+const requestIDKey = "request_id"
+
+type requestIDContextKey struct{}
+
+// RequestIDMiddleware reads the request ID from X-Request-ID (or generates one), stores it in the
+// gin and request contexts, and echoes it back in the response header
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if requestID == "" || len(requestID) > 128 {
+			requestID = newRequestID()
+		}
+
+		c.Set(requestIDKey, requestID)
+		c.Request = c.Request.WithContext(ContextWithRequestID(c.Request.Context(), requestID))
+		c.Header(RequestIDHeader, requestID)
+
+		c.Next()
+	}
+}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, or an empty string
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
+
+// RequestIDField returns a log field with the request ID stored in ctx
+func RequestIDField(ctx context.Context) zap.Field {
+	return zap.String(requestIDKey, RequestIDFromContext(ctx))
+}
+
+// newRequestID generates a random 128-bit hex request ID
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGinMiddleware tests that requests are counted per route template and status code
+func TestGinMiddleware(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(GinMiddleware())
+	router.GET("/api/v1/properties/:id", func(c *gin.Context) {
+		c.Status(http.StatusNotFound)
+	})
+
+	before := testutil.ToFloat64(httpRequestsTotal.WithLabelValues("GET", "/api/v1/properties/:id", "404"))
+	beforeUnmatched := testutil.ToFloat64(httpRequestsTotal.WithLabelValues("GET", "unmatched", "404"))
+
+	// Act
+	for _, path := range []string{"/api/v1/properties/1", "/api/v1/properties/2", "/missing"} {
+		req, _ := http.NewRequest("GET", path, nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	// Assert
+	assert.Equal(t, before+2, testutil.ToFloat64(httpRequestsTotal.WithLabelValues("GET", "/api/v1/properties/:id", "404")))
+	assert.Equal(t, beforeUnmatched+1, testutil.ToFloat64(httpRequestsTotal.WithLabelValues("GET", "unmatched", "404")))
+}
+
+// TestGinMiddleware_Panic tests that a request recovered from a panic by an inner middleware is counted as a 500
+func TestGinMiddleware_Panic(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(GinMiddleware())
+	router.Use(gin.Recovery())
+	router.GET("/api/v1/panic", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	before := testutil.ToFloat64(httpRequestsTotal.WithLabelValues("GET", "/api/v1/panic", "500"))
+
+	// Act
+	req, _ := http.NewRequest("GET", "/api/v1/panic", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, before+1, testutil.ToFloat64(httpRequestsTotal.WithLabelValues("GET", "/api/v1/panic", "500")))
+}
+
+// TestRecordSync tests the sync run counters and last success gauge
+func TestRecordSync(t *testing.T) {
+	// Arrange
+	completedAt := time.Date(2025, 9, 12, 8, 0, 0, 0, time.UTC)
+	beforeUpdated := testutil.ToFloat64(syncPropertiesTotal.WithLabelValues("updated"))
+	beforeFailed := testutil.ToFloat64(syncPropertiesTotal.WithLabelValues("failed"))
+	beforeFailures := testutil.ToFloat64(syncFailuresTotal)
+
+	// Act
+	RecordSyncSuccess(3, 5, 1, completedAt)
+	RecordSyncFailure()
+
+	// Assert
+	assert.Equal(t, beforeUpdated+3, testutil.ToFloat64(syncPropertiesTotal.WithLabelValues("updated")))
+	assert.Equal(t, beforeFailed+1, testutil.ToFloat64(syncPropertiesTotal.WithLabelValues("failed")))
+	assert.Equal(t, beforeFailures+1, testutil.ToFloat64(syncFailuresTotal))
+	assert.Equal(t, float64(completedAt.Unix()), testutil.ToFloat64(syncLastSuccessTimestamp))
+}
+
+// TestHandler tests that the registry is served in the Prometheus text format
+func TestHandler(t *testing.T) {
+	// Arrange
+	RecordSyncFailure()
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	Handler().ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "sync_failures_total")
+	assert.Contains(t, w.Body.String(), "go_goroutines")
+}
@@ -0,0 +1,73 @@
+package store
+
+import (
+	"github.com/barimehdi77/cupid-api/internal/env"
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"go.uber.org/zap"
+)
+
+// Config holds storage configuration
+type Config struct {
+	// SkipBadRows logs and skips rows that fail to scan instead of failing the whole query
+	SkipBadRows bool
+	// MaxDetailPhotos caps the photos kept in property details, for the property and for each room; 0 disables the cap
+	MaxDetailPhotos int
+	// MaxDetailRooms caps the rooms kept in property details; 0 disables the cap
+	MaxDetailRooms int
+	// MaxDetailsBytes rejects property details whose serialized JSON is larger than this many bytes; 0 disables the limit
+	MaxDetailsBytes int
+	// QualityReviewWeight is the exponent applied to the review volume in the quality score,
+	// rating * ln(review_count + 1) ^ weight: 0 ranks by rating alone and higher values favor well reviewed properties
+	QualityReviewWeight float64
+	// StoreRoomAmenities keeps the normalized room amenity rows used for room amenity filtering up to date on every store
+	StoreRoomAmenities bool
+	// ReviewScoreScale is the highest score a stored review can have. Review averages are normalized from it onto
+	// the 0-10 scale of property ratings (RatingScale), so a source scoring reviews out of 5 still compares with ratings.
+	ReviewScoreScale float64
+	// SearchSimilarityThreshold is the pg_trgm word similarity, between 0 and 1, from which a fuzzy search matches a
+	// field: lower values tolerate more typos but match more unrelated properties. ConfigFromEnv clamps it to that range.
+	SearchSimilarityThreshold float64
+}
+
+// DefaultConfig returns default storage configuration
+func DefaultConfig() *Config {
+	return &Config{
+		SkipBadRows:               false,
+		MaxDetailPhotos:           500,
+		MaxDetailRooms:            200,
+		MaxDetailsBytes:           1 << 20,
+		QualityReviewWeight:       1,
+		StoreRoomAmenities:        true,
+		ReviewScoreScale:          10,
+		SearchSimilarityThreshold: 0.4,
+	}
+}
+
+// ConfigFromEnv returns storage configuration overridden by environment variables
+func ConfigFromEnv() *Config {
+	config := DefaultConfig()
+	config.SkipBadRows = env.GetEnvBool("STORE_SKIP_BAD_ROWS", config.SkipBadRows)
+	config.MaxDetailPhotos = env.GetEnvInt("STORE_MAX_DETAIL_PHOTOS", config.MaxDetailPhotos)
+	config.MaxDetailRooms = env.GetEnvInt("STORE_MAX_DETAIL_ROOMS", config.MaxDetailRooms)
+	config.MaxDetailsBytes = env.GetEnvInt("STORE_MAX_DETAILS_BYTES", config.MaxDetailsBytes)
+	config.QualityReviewWeight = env.GetEnvFloat("STORE_QUALITY_REVIEW_WEIGHT", config.QualityReviewWeight)
+	config.StoreRoomAmenities = env.GetEnvBool("STORE_ROOM_AMENITIES", config.StoreRoomAmenities)
+	config.ReviewScoreScale = env.GetEnvFloat("STORE_REVIEW_SCORE_SCALE", config.ReviewScoreScale)
+	config.SearchSimilarityThreshold = clampSimilarityThreshold(
+		env.GetEnvFloat("STORE_SEARCH_SIMILARITY_THRESHOLD", config.SearchSimilarityThreshold),
+	)
+	return config
+}
+
+// clampSimilarityThreshold keeps a similarity threshold within 0 to 1, the range of pg_trgm similarities, so an
+// out of range value cannot make every fuzzy search match everything or nothing
+func clampSimilarityThreshold(threshold float64) float64 {
+	clamped := min(max(threshold, 0), 1)
+	if clamped != threshold {
+		logger.Warn("STORE_SEARCH_SIMILARITY_THRESHOLD is out of range, clamping it",
+			zap.Float64("threshold", threshold),
+			zap.Float64("clamped", clamped),
+		)
+	}
+	return clamped
+}
@@ -0,0 +1,194 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/barimehdi77/cupid-api/internal/cupid"
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"github.com/barimehdi77/cupid-api/internal/tracing"
+	"go.uber.org/zap"
+)
+
+// PurgeOrphans deletes child rows whose property_id has no matching property
+func (s *storage) PurgeOrphans(ctx context.Context) (*OrphanPurgeResult, error) {
+	ctx, span := tracing.StartSpan(ctx, "store.PurgeOrphans")
+	defer span.End()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result := &OrphanPurgeResult{}
+	targets := []struct {
+		table string
+		count *int64
+	}{
+		{"reviews", &result.Reviews},
+		{"translations", &result.Translations},
+		{"property_details", &result.PropertyDetails},
+		{"property_facilities", &result.PropertyFacilities},
+		{"property_room_amenities", &result.RoomAmenities},
+	}
+
+	for _, target := range targets {
+		query := fmt.Sprintf(`
+			DELETE FROM %s c
+			WHERE NOT EXISTS (SELECT 1 FROM properties p WHERE p.hotel_id = c.property_id)`, target.table)
+
+		res, err := tx.ExecContext(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to purge orphaned %s: %w", target.table, err)
+		}
+		if *target.count, err = res.RowsAffected(); err != nil {
+			return nil, fmt.Errorf("failed to count purged %s: %w", target.table, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	logger.Info("Orphaned rows purged",
+		zap.Int64("reviews", result.Reviews),
+		zap.Int64("translations", result.Translations),
+		zap.Int64("property_details", result.PropertyDetails),
+		zap.Int64("property_facilities", result.PropertyFacilities),
+		zap.Int64("property_room_amenities", result.RoomAmenities),
+	)
+
+	return result, nil
+}
+
+// DeletePropertyReviewsOlderThan deletes the reviews of every property dated before cutoff and returns how many
+// were deleted. Only the date part of cutoff is used, as review dates have no time; undated reviews are kept.
+func (s *storage) DeletePropertyReviewsOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	ctx, span := tracing.StartSpan(ctx, "store.DeletePropertyReviewsOlderThan")
+	defer span.End()
+
+	res, err := s.db.ExecContext(ctx, `DELETE FROM reviews WHERE date < $1::date`, cutoff.Format(time.DateOnly))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old reviews: %w", err)
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted reviews: %w", err)
+	}
+
+	logger.Info("Old reviews deleted",
+		zap.String("cutoff", cutoff.Format(time.DateOnly)),
+		zap.Int64("reviews", deleted),
+	)
+
+	return deleted, nil
+}
+
+// GetPropertiesWithDuplicateCoordinates returns the groups of properties sharing the exact same latitude and longitude,
+// which usually means a hotel was imported more than once. Properties without coordinates (0, 0) are ignored.
+// Clusters are ordered by coordinates and the properties of a cluster by hotel ID.
+func (s *storage) GetPropertiesWithDuplicateCoordinates(ctx context.Context) ([]CoordinateCluster, error) {
+	query := `SELECT ` + selectPropertyColumns("p") + `
+		FROM properties p
+		JOIN (
+			SELECT latitude, longitude
+			FROM properties
+			WHERE NOT (latitude = 0 AND longitude = 0)
+			GROUP BY latitude, longitude
+			HAVING COUNT(*) > 1
+		) d ON p.latitude = d.latitude AND p.longitude = d.longitude
+		ORDER BY p.latitude, p.longitude, p.hotel_id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find duplicate coordinates: %w", err)
+	}
+	defer rows.Close()
+
+	properties, err := s.scanProperties(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := []CoordinateCluster{}
+	for _, property := range properties {
+		last := len(clusters) - 1
+		if last < 0 || clusters[last].Latitude != property.Latitude || clusters[last].Longitude != property.Longitude {
+			clusters = append(clusters, CoordinateCluster{
+				Latitude:  property.Latitude,
+				Longitude: property.Longitude,
+			})
+			last++
+		}
+		clusters[last].Properties = append(clusters[last].Properties, property)
+	}
+
+	return clusters, nil
+}
+
+// GetPropertiesWithConflictingChains returns the groups of properties where the same chain name maps to several
+// chain IDs, or the same chain ID to several chain names, which points at inconsistent upstream data.
+// Properties without a chain (empty name or zero ID) are ignored. Name conflicts come first, ordered by name,
+// then ID conflicts ordered by ID; the properties of a group are ordered by hotel ID.
+func (s *storage) GetPropertiesWithConflictingChains(ctx context.Context) ([]ChainConflict, error) {
+	query := `SELECT ` + selectPropertyColumns("p") + `, c.field
+		FROM (
+			SELECT 'chain' AS field, chain, 0 AS chain_id
+			FROM properties
+			WHERE chain <> '' AND chain_id <> 0
+			GROUP BY chain
+			HAVING COUNT(DISTINCT chain_id) > 1
+			UNION ALL
+			SELECT 'chain_id' AS field, '' AS chain, chain_id
+			FROM properties
+			WHERE chain <> '' AND chain_id <> 0
+			GROUP BY chain_id
+			HAVING COUNT(DISTINCT chain) > 1
+		) c
+		JOIN properties p ON (c.field = 'chain' AND p.chain = c.chain) OR (c.field = 'chain_id' AND p.chain_id = c.chain_id)
+		WHERE p.chain <> '' AND p.chain_id <> 0
+		ORDER BY c.field, c.chain, c.chain_id, p.hotel_id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find conflicting chains: %w", err)
+	}
+	defer rows.Close()
+
+	type conflictingProperty struct {
+		property *cupid.Property
+		field    string
+	}
+	results, skipped, err := scanRows(rows, s.config.SkipBadRows, func(r rowScanner) (conflictingProperty, error) {
+		var field string
+		property, err := scanProperty(r, &field)
+		return conflictingProperty{property: property, field: field}, err
+	})
+	logSkippedRows(ctx, "properties", skipped)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts := []ChainConflict{}
+	for _, result := range results {
+		conflict := ChainConflict{Field: result.field}
+		if result.field == ChainConflictName {
+			conflict.Chain = result.property.Chain
+		} else {
+			conflict.ChainID = result.property.ChainID
+		}
+
+		last := len(conflicts) - 1
+		if last < 0 || conflicts[last].Field != conflict.Field || conflicts[last].Chain != conflict.Chain || conflicts[last].ChainID != conflict.ChainID {
+			conflicts = append(conflicts, conflict)
+			last++
+		}
+		conflicts[last].Properties = append(conflicts[last].Properties, result.property)
+	}
+
+	return conflicts, nil
+}
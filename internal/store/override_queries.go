@@ -0,0 +1,119 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/barimehdi77/cupid-api/internal/cupid"
+)
+
+// OverridableFields are the properties columns whose value can be overridden manually.
+// An override always wins: it replaces the stored value on read and the fetched value when a sync compares it,
+// while the upstream value keeps being stored underneath.
+var OverridableFields = []string{
+	"hotel_name", "description", "markdown_description", "important_info", "phone", "fax", "email",
+}
+
+// ErrInvalidOverrideField is returned when an override targets a field outside OverridableFields
+var ErrInvalidOverrideField = errors.New("field cannot be overridden")
+
+// isOverridableField reports whether field is listed in OverridableFields
+func isOverridableField(field string) bool {
+	return slices.Contains(OverridableFields, field)
+}
+
+// ApplyPropertyOverrides replaces the fields of property that have an override
+func ApplyPropertyOverrides(property *cupid.Property, overrides map[string]string) {
+	for field, value := range overrides {
+		switch field {
+		case "hotel_name":
+			property.HotelName = value
+		case "description":
+			property.Description = value
+		case "markdown_description":
+			property.MarkdownDescription = value
+		case "important_info":
+			property.ImportantInfo = value
+		case "phone":
+			property.Phone = value
+		case "fax":
+			property.Fax = value
+		case "email":
+			property.Email = value
+		}
+	}
+}
+
+// SetPropertyOverrides records field overrides for a property in a single transaction.
+// A nil value removes the override of its field, so the stored upstream value applies again.
+// Returns ErrInvalidOverrideField for fields outside OverridableFields and ErrPropertyNotFound when no property
+// has the given hotel ID.
+func (s *storage) SetPropertyOverrides(ctx context.Context, hotelID int64, overrides map[string]*string) error {
+	fields := make([]string, 0, len(overrides))
+	for field := range overrides {
+		if !isOverridableField(field) {
+			return fmt.Errorf("%w: %s", ErrInvalidOverrideField, field)
+		}
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM properties WHERE hotel_id = $1)`, hotelID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check property: %w", err)
+	}
+	if !exists {
+		return ErrPropertyNotFound
+	}
+
+	for _, field := range fields {
+		value := overrides[field]
+		if value == nil {
+			_, err = tx.ExecContext(ctx, `DELETE FROM property_overrides WHERE property_id = $1 AND field = $2`, hotelID, field)
+		} else {
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO property_overrides (property_id, field, value) VALUES ($1, $2, $3)
+				ON CONFLICT (property_id, field) DO UPDATE SET value = EXCLUDED.value, updated_at = NOW()`,
+				hotelID, field, *value,
+			)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to set override of %s: %w", field, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetPropertyOverrides returns the field overrides of a property keyed by field, empty when it has none
+func (s *storage) GetPropertyOverrides(ctx context.Context, hotelID int64) (map[string]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT field, value FROM property_overrides WHERE property_id = $1`, hotelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query property overrides: %w", err)
+	}
+	defer rows.Close()
+
+	overrides := make(map[string]string)
+	for rows.Next() {
+		var field, value string
+		if err := rows.Scan(&field, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan property override: %w", err)
+		}
+		overrides[field] = value
+	}
+
+	return overrides, rows.Err()
+}
@@ -3,9 +3,16 @@ package store
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"strings"
+	"time"
 
 	"github.com/barimehdi77/cupid-api/internal/cupid"
+	"github.com/barimehdi77/cupid-api/internal/tracing"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // GetProperty retrieves a complete property with all its data
@@ -16,6 +23,12 @@ func (s *storage) GetProperty(ctx context.Context, hotelID int64) (*cupid.Proper
 		return nil, err
 	}
 
+	// Get check-in information, only kept in the JSONB details
+	property.CheckIn, err = s.getPropertyCheckIn(ctx, hotelID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Get reviews
 	reviews, err := s.GetPropertyReviews(ctx, hotelID)
 	if err != nil {
@@ -28,103 +41,185 @@ func (s *storage) GetProperty(ctx context.Context, hotelID int64) (*cupid.Proper
 		return nil, err
 	}
 
+	// Get overrides, already applied to the property, so a sync can apply them to fetched data
+	overrides, err := s.GetPropertyOverrides(ctx, hotelID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &cupid.PropertyData{
 		Property:     *property,
 		Reviews:      reviews,
 		Translations: translations,
+		Overrides:    overrides,
 	}, nil
 }
 
+// GetPropertySummary retrieves the main property data only, in a single query, without reviews or translations.
+// Returns ErrPropertyNotFound when no property has the given hotel ID.
+func (s *storage) GetPropertySummary(ctx context.Context, hotelID int64) (*cupid.Property, error) {
+	return s.getMainProperty(ctx, hotelID)
+}
+
+// GetRawProperty retrieves the raw Cupid API response last captured for a property.
+// Returns ErrPropertyNotFound when no property has the given hotel ID and ErrRawPropertyNotCaptured when
+// it was never stored with CUPID_CAPTURE_RAW enabled.
+func (s *storage) GetRawProperty(ctx context.Context, hotelID int64) (json.RawMessage, error) {
+	query := `
+		SELECT d.raw_property_json
+		FROM properties p
+		LEFT JOIN property_details d ON d.property_id = p.hotel_id
+		WHERE p.hotel_id = $1
+	`
+
+	var raw []byte
+	if err := s.db.QueryRowContext(ctx, query, hotelID).Scan(&raw); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrPropertyNotFound
+		}
+		return nil, err
+	}
+	if raw == nil {
+		return nil, ErrRawPropertyNotCaptured
+	}
+
+	return json.RawMessage(raw), nil
+}
+
 // getMainProperty retrieves the main property data
 func (s *storage) getMainProperty(ctx context.Context, hotelID int64) (*cupid.Property, error) {
-	query := `
-		SELECT hotel_id, cupid_id, hotel_name, hotel_type, hotel_type_id,
-			   chain, chain_id, latitude, longitude, stars, rating, review_count,
-			   airport_code, city, state, country, postal_code, main_image_th
+	query := `SELECT ` + selectPropertyColumns("") + `
 		FROM properties
 		WHERE hotel_id = $1
 	`
 
-	var property cupid.Property
-	err := s.db.QueryRowContext(ctx, query, hotelID).Scan(
-		&property.HotelID, &property.CupidID, &property.HotelName, &property.HotelType, &property.HotelTypeID,
-		&property.Chain, &property.ChainID, &property.Latitude, &property.Longitude, &property.Stars,
-		&property.Rating, &property.ReviewCount, &property.AirportCode, &property.Address.City,
-		&property.Address.State, &property.Address.Country, &property.Address.PostalCode, &property.MainImageTh,
-	)
-
+	property, err := scanProperty(s.db.QueryRowContext(ctx, query, hotelID))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("property not found")
+			return nil, ErrPropertyNotFound
 		}
 		return nil, err
 	}
 
-	return &property, nil
+	return property, nil
 }
 
-// ListProperties retrieves a list of properties with optional filtering
-func (s *storage) ListProperties(ctx context.Context, limit, offset int, filters PropertyFilters) ([]*cupid.Property, error) {
-	query := `
-		SELECT hotel_id, cupid_id, hotel_name, hotel_type, hotel_type_id,
-			   chain, chain_id, latitude, longitude, stars, rating, review_count,
-			   airport_code, city, state, country, postal_code, main_image_th
-		FROM properties
-		WHERE 1=1
-	`
-	args := []interface{}{}
-	argIndex := 1
+// getPropertyCheckIn retrieves the check-in information stored in the property details.
+// A property without stored details gets an empty check-in.
+func (s *storage) getPropertyCheckIn(ctx context.Context, hotelID int64) (cupid.CheckIn, error) {
+	query := `SELECT checkin_info FROM property_details WHERE property_id = $1`
 
-	// Apply filters
-	if filters.City != "" {
-		query += fmt.Sprintf(" AND city ILIKE $%d", argIndex)
-		args = append(args, "%"+filters.City+"%")
-		argIndex++
+	var checkIn cupid.CheckIn
+	var raw []byte
+	if err := s.db.QueryRowContext(ctx, query, hotelID).Scan(&raw); err != nil {
+		if err == sql.ErrNoRows {
+			return checkIn, nil
+		}
+		return checkIn, err
+	}
+	if len(raw) == 0 {
+		return checkIn, nil
 	}
 
-	if filters.Country != "" {
-		query += fmt.Sprintf(" AND country ILIKE $%d", argIndex)
-		args = append(args, "%"+filters.Country+"%")
-		argIndex++
+	if err := json.Unmarshal(raw, &checkIn); err != nil {
+		return checkIn, fmt.Errorf("failed to decode check-in information: %w", err)
 	}
+	return checkIn, nil
+}
 
-	if filters.MinStars > 0 {
-		query += fmt.Sprintf(" AND stars >= $%d", argIndex)
-		args = append(args, filters.MinStars)
-		argIndex++
+// CompareStoredPropertyDetails compares the stored JSONB details of a property with the sections the given data
+// would be stored as, and returns the columns that differ, in column order.
+// Returns ErrPropertyNotFound when no details are stored for the property.
+func (s *storage) CompareStoredPropertyDetails(ctx context.Context, propertyData *cupid.PropertyData) ([]string, error) {
+	expected, err := s.marshalPropertyDetails(&propertyData.Property)
+	if err != nil {
+		return nil, err
 	}
 
-	if filters.MaxStars > 0 {
-		query += fmt.Sprintf(" AND stars <= $%d", argIndex)
-		args = append(args, filters.MaxStars)
-		argIndex++
+	query := `SELECT ` + strings.Join(propertyDetailColumns, ", ") + ` FROM property_details WHERE property_id = $1`
+
+	stored := make([][]byte, len(propertyDetailColumns))
+	dest := make([]interface{}, len(stored))
+	for i := range stored {
+		dest[i] = &stored[i]
+	}
+	if err := s.db.QueryRowContext(ctx, query, propertyData.Property.HotelID).Scan(dest...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrPropertyNotFound
+		}
+		return nil, err
 	}
 
-	if filters.MinRating > 0 {
-		query += fmt.Sprintf(" AND rating >= $%d", argIndex)
-		args = append(args, filters.MinRating)
-		argIndex++
+	var mismatches []string
+	for i, column := range propertyDetailColumns {
+		if !jsonEqual(expected[i], stored[i]) {
+			mismatches = append(mismatches, column)
+		}
 	}
+	return mismatches, nil
+}
 
-	if filters.MaxRating > 0 {
-		query += fmt.Sprintf(" AND rating <= $%d", argIndex)
-		args = append(args, filters.MaxRating)
-		argIndex++
+// jsonEqual reports whether two JSON documents hold the same value, regardless of key order and formatting.
+// A missing or undecodable document only equals another one with the same bytes.
+func jsonEqual(a, b []byte) bool {
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return string(a) == string(b)
 	}
+	return reflect.DeepEqual(va, vb)
+}
 
-	if filters.HotelType != "" {
-		query += fmt.Sprintf(" AND hotel_type ILIKE $%d", argIndex)
-		args = append(args, "%"+filters.HotelType+"%")
-		argIndex++
+// ListProperties retrieves a list of properties with optional filtering
+func (s *storage) ListProperties(ctx context.Context, limit, offset int, filters PropertyFilters) ([]*cupid.Property, error) {
+	query := `SELECT ` + selectPropertyColumns("") + `
+		FROM properties
+		WHERE 1=1
+	`
+	where, args := buildPropertyFilters(filters, 1)
+	query += where
+
+	if filters.After != nil {
+		clause, cursorArgs := propertyCursorClause(*filters.After, "", len(args)+1)
+		query += clause
+		args = append(args, cursorArgs...)
 	}
 
-	if filters.Chain != "" {
-		query += fmt.Sprintf(" AND chain ILIKE $%d", argIndex)
-		args = append(args, "%"+filters.Chain+"%")
-		argIndex++
+	argIndex := len(args) + 1
+	query += fmt.Sprintf(" ORDER BY rating DESC, review_count DESC, hotel_id DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	args = append(args, limit, offset)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	query += fmt.Sprintf(" ORDER BY rating DESC, review_count DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+	return s.scanProperties(ctx, rows)
+}
+
+// ListPropertiesWithReviewAverages retrieves a filtered list of properties along with the
+// average score of their stored reviews, computed in the same query
+func (s *storage) ListPropertiesWithReviewAverages(ctx context.Context, limit, offset int, filters PropertyFilters) ([]*PropertyWithReviewAverage, error) {
+	query := `SELECT ` + selectPropertyColumns("p") + `, r.computed_rating
+		FROM properties p
+		LEFT JOIN (
+			SELECT property_id, AVG(average_score) AS computed_rating
+			FROM reviews
+			GROUP BY property_id
+		) r ON r.property_id = p.hotel_id
+		WHERE 1=1
+	`
+	where, args := buildPropertyFilters(filters, 1)
+	query += where
+
+	if filters.After != nil {
+		clause, cursorArgs := propertyCursorClause(*filters.After, "p", len(args)+1)
+		query += clause
+		args = append(args, cursorArgs...)
+	}
+
+	argIndex := len(args) + 1
+	query += fmt.Sprintf(" ORDER BY p.rating DESC, p.review_count DESC, p.hotel_id DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
 	args = append(args, limit, offset)
 
 	rows, err := s.db.QueryContext(ctx, query, args...)
@@ -133,95 +228,343 @@ func (s *storage) ListProperties(ctx context.Context, limit, offset int, filters
 	}
 	defer rows.Close()
 
-	var properties []*cupid.Property
-	for rows.Next() {
-		var property cupid.Property
-		err := rows.Scan(
-			&property.HotelID, &property.CupidID, &property.HotelName, &property.HotelType, &property.HotelTypeID,
-			&property.Chain, &property.ChainID, &property.Latitude, &property.Longitude, &property.Stars,
-			&property.Rating, &property.ReviewCount, &property.AirportCode, &property.Address.City,
-			&property.Address.State, &property.Address.Country, &property.Address.PostalCode, &property.MainImageTh,
-		)
+	results, skipped, err := scanRows(rows, s.config.SkipBadRows, func(r rowScanner) (*PropertyWithReviewAverage, error) {
+		var computedRating sql.NullFloat64
+		property, err := scanProperty(r, &computedRating)
 		if err != nil {
 			return nil, err
 		}
-		properties = append(properties, &property)
-	}
 
-	return properties, nil
+		result := &PropertyWithReviewAverage{Property: property}
+		if computedRating.Valid {
+			normalized := s.normalizeReviewScore(computedRating.Float64)
+			result.ComputedRating = &normalized
+		}
+		return result, nil
+	})
+	logSkippedRows(ctx, "properties", skipped)
+
+	return results, err
 }
 
 // CountProperties counts the total number of properties matching the given filters
 func (s *storage) CountProperties(ctx context.Context, filters PropertyFilters) (int, error) {
 	query := "SELECT COUNT(*) FROM properties WHERE 1=1"
+	where, args := buildPropertyFilters(filters, 1)
+	query += where
+
+	var count int
+	err := s.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count properties: %w", err)
+	}
+
+	return count, nil
+}
+
+// propertyFacetColumns are the properties columns GetPropertyFacets groups by
+var propertyFacetColumns = []string{"hotel_type", "stars", "country"}
+
+// GetPropertyFacets counts the properties matching filters grouped by hotel type, stars and country, so a facet
+// sidebar can be built along with a listing. Properties without a value for a column are left out of its counts.
+func (s *storage) GetPropertyFacets(ctx context.Context, filters PropertyFilters) (*PropertyFacets, error) {
+	where, args := buildPropertyFilters(filters, 1)
+
+	counts := make(map[string][]FacetCount, len(propertyFacetColumns))
+	for _, column := range propertyFacetColumns {
+		query := fmt.Sprintf(`
+			SELECT %[1]s::text, COUNT(*) AS property_count
+			FROM properties
+			WHERE %[1]s IS NOT NULL AND %[1]s::text <> ''%[2]s
+			GROUP BY %[1]s
+			ORDER BY property_count DESC, %[1]s ASC
+		`, column, where)
+
+		rows, err := s.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count properties by %s: %w", column, err)
+		}
+
+		facet, skipped, err := scanRows(rows, s.config.SkipBadRows, func(r rowScanner) (FacetCount, error) {
+			var count FacetCount
+			err := r.Scan(&count.Value, &count.Count)
+			return count, err
+		})
+		rows.Close()
+		logSkippedRows(ctx, "properties", skipped)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count properties by %s: %w", column, err)
+		}
+		counts[column] = facet
+	}
+
+	return &PropertyFacets{
+		HotelTypes: counts["hotel_type"],
+		Stars:      counts["stars"],
+		Countries:  counts["country"],
+	}, nil
+}
+
+// buildPropertyFilters builds the WHERE conditions shared by the property list and count queries.
+// Parameters are numbered from argIndex.
+func buildPropertyFilters(filters PropertyFilters, argIndex int) (string, []interface{}) {
+	where := ""
 	args := []interface{}{}
-	argIndex := 1
 
-	// Add filters
+	if len(filters.HotelIDs) > 0 {
+		where += fmt.Sprintf(" AND hotel_id = ANY($%d)", argIndex)
+		args = append(args, pq.Array(filters.HotelIDs))
+		argIndex++
+	}
+
 	if filters.City != "" {
-		query += fmt.Sprintf(" AND city ILIKE $%d", argIndex)
+		where += fmt.Sprintf(" AND city ILIKE $%d", argIndex)
 		args = append(args, "%"+filters.City+"%")
 		argIndex++
 	}
 
 	if filters.Country != "" {
-		query += fmt.Sprintf(" AND country ILIKE $%d", argIndex)
+		where += fmt.Sprintf(" AND country ILIKE $%d", argIndex)
 		args = append(args, "%"+filters.Country+"%")
 		argIndex++
 	}
 
-	if filters.MinStars > 0 {
-		query += fmt.Sprintf(" AND stars >= $%d", argIndex)
-		args = append(args, filters.MinStars)
+	if filters.MinStars != nil {
+		where += fmt.Sprintf(" AND stars >= $%d", argIndex)
+		args = append(args, *filters.MinStars)
+		argIndex++
+	}
+
+	if filters.MaxStars != nil {
+		where += fmt.Sprintf(" AND stars <= $%d", argIndex)
+		args = append(args, *filters.MaxStars)
 		argIndex++
 	}
 
-	if filters.MaxStars > 0 {
-		query += fmt.Sprintf(" AND stars <= $%d", argIndex)
-		args = append(args, filters.MaxStars)
+	if filters.MinRating != nil {
+		where += fmt.Sprintf(" AND rating >= $%d", argIndex)
+		args = append(args, *filters.MinRating)
 		argIndex++
 	}
 
-	if filters.MinRating > 0 {
-		query += fmt.Sprintf(" AND rating >= $%d", argIndex)
-		args = append(args, filters.MinRating)
+	if filters.MaxRating != nil {
+		where += fmt.Sprintf(" AND rating <= $%d", argIndex)
+		args = append(args, *filters.MaxRating)
 		argIndex++
 	}
 
-	if filters.MaxRating > 0 {
-		query += fmt.Sprintf(" AND rating <= $%d", argIndex)
-		args = append(args, filters.MaxRating)
+	if filters.MinPrice != nil {
+		where += fmt.Sprintf(" AND min_price >= $%d", argIndex)
+		args = append(args, *filters.MinPrice)
+		argIndex++
+	}
+
+	if filters.MaxPrice != nil {
+		where += fmt.Sprintf(" AND min_price <= $%d", argIndex)
+		args = append(args, *filters.MaxPrice)
+		argIndex++
+	}
+
+	if filters.Currency != "" {
+		where += fmt.Sprintf(" AND currency = $%d", argIndex)
+		args = append(args, strings.ToUpper(filters.Currency))
 		argIndex++
 	}
 
 	if filters.HotelType != "" {
-		query += fmt.Sprintf(" AND hotel_type ILIKE $%d", argIndex)
+		where += fmt.Sprintf(" AND hotel_type ILIKE $%d", argIndex)
 		args = append(args, "%"+filters.HotelType+"%")
 		argIndex++
 	}
 
 	if filters.Chain != "" {
-		query += fmt.Sprintf(" AND chain ILIKE $%d", argIndex)
-		args = append(args, "%"+filters.Chain+"%")
+		where += fmt.Sprintf(" AND LOWER(chain) = LOWER($%d)", argIndex)
+		args = append(args, filters.Chain)
+		argIndex++
+	}
+
+	if filters.PostalCodePrefix != "" {
+		where += fmt.Sprintf(" AND postal_code ILIKE $%d", argIndex)
+		args = append(args, filters.PostalCodePrefix+"%")
 		argIndex++
 	}
 
+	if len(filters.FacilityIDs) > 0 {
+		clause, facilityArgs := allOfFilterClause("property_facilities", "facility_id", filters.FacilityIDs, argIndex)
+		where += clause
+		args = append(args, facilityArgs...)
+		argIndex += len(facilityArgs)
+	}
+
+	if len(filters.RoomAmenityIDs) > 0 {
+		clause, amenityArgs := allOfFilterClause("property_room_amenities", "amenity_id", filters.RoomAmenityIDs, argIndex)
+		where += clause
+		args = append(args, amenityArgs...)
+	}
+
+	return where, args
+}
+
+// allOfFilterClause builds a condition matching properties that have a row in table for every given ID of column,
+// such as every given facility. Parameters are numbered from argIndex.
+func allOfFilterClause(table, column string, values []int, argIndex int) (string, []interface{}) {
+	unique := make(map[int64]bool, len(values))
+	ids := make([]int64, 0, len(values))
+	for _, id := range values {
+		if !unique[int64(id)] {
+			unique[int64(id)] = true
+			ids = append(ids, int64(id))
+		}
+	}
+
+	clause := fmt.Sprintf(` AND hotel_id IN (
+		SELECT property_id FROM %[1]s
+		WHERE %[2]s = ANY($%[3]d)
+		GROUP BY property_id
+		HAVING COUNT(DISTINCT %[2]s) = $%[4]d
+	)`, table, column, argIndex, argIndex+1)
+
+	return clause, []interface{}{pq.Array(ids), len(ids)}
+}
+
+// propertyCursorClause builds a condition matching properties that sort after cursor in listings.
+// Columns are qualified with alias when it is not empty and parameters are numbered from argIndex.
+func propertyCursorClause(cursor PropertyCursor, alias string, argIndex int) (string, []interface{}) {
+	prefix := ""
+	if alias != "" {
+		prefix = alias + "."
+	}
+
+	clause := fmt.Sprintf(" AND (%[1]srating, %[1]sreview_count, %[1]shotel_id) < ($%[2]d, $%[3]d, $%[4]d)",
+		prefix, argIndex, argIndex+1, argIndex+2)
+
+	return clause, []interface{}{cursor.Rating, cursor.ReviewCount, cursor.HotelID}
+}
+
+// GetPropertyReviews retrieves reviews for a specific property
+func (s *storage) GetPropertyReviews(ctx context.Context, hotelID int64) ([]cupid.Review, error) {
+	query := `
+		SELECT review_id, average_score, country, type, name, COALESCE(date_raw, ''), headline, language, pros, cons, source, imported
+		FROM reviews
+		WHERE property_id = $1
+		ORDER BY date DESC NULLS LAST, review_id ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, hotelID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return s.scanReviews(ctx, rows)
+}
+
+// ListPropertyReviews retrieves reviews for a specific property matching the given filters.
+// A zero Limit returns all matching reviews.
+func (s *storage) ListPropertyReviews(ctx context.Context, hotelID int64, filters ReviewFilters) ([]cupid.Review, error) {
+	query := `
+		SELECT review_id, average_score, country, type, name, COALESCE(date_raw, ''), headline, language, pros, cons, source, imported
+		FROM reviews
+		WHERE property_id = $1
+	`
+	where, args := buildReviewFilters(hotelID, filters)
+	query += where + reviewOrderClause(filters)
+
+	if filters.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+		args = append(args, filters.Limit, filters.Offset)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return s.scanReviews(ctx, rows)
+}
+
+// CountPropertyReviews counts the reviews for a specific property matching the given filters
+func (s *storage) CountPropertyReviews(ctx context.Context, hotelID int64, filters ReviewFilters) (int, error) {
+	query := "SELECT COUNT(*) FROM reviews WHERE property_id = $1"
+	where, args := buildReviewFilters(hotelID, filters)
+	query += where
+
 	var count int
 	err := s.db.QueryRowContext(ctx, query, args...).Scan(&count)
 	if err != nil {
-		return 0, fmt.Errorf("failed to count properties: %w", err)
+		return 0, fmt.Errorf("failed to count property reviews: %w", err)
 	}
 
 	return count, nil
 }
 
-// GetPropertyReviews retrieves reviews for a specific property
-func (s *storage) GetPropertyReviews(ctx context.Context, hotelID int64) ([]cupid.Review, error) {
+// buildReviewFilters builds the WHERE conditions shared by the per-property review queries.
+// The returned arguments start with the property ID bound to $1.
+func buildReviewFilters(hotelID int64, filters ReviewFilters) (string, []interface{}) {
+	where := ""
+	args := []interface{}{hotelID}
+	argIndex := 2
+
+	if filters.Type != "" {
+		where += fmt.Sprintf(" AND type = $%d", argIndex)
+		args = append(args, filters.Type)
+		argIndex++
+	}
+
+	if filters.Language != "" {
+		where += fmt.Sprintf(" AND LOWER(language) = LOWER($%d)", argIndex)
+		args = append(args, filters.Language)
+		argIndex++
+	}
+
+	if filters.Country != "" {
+		where += fmt.Sprintf(" AND LOWER(country) = LOWER($%d)", argIndex)
+		args = append(args, filters.Country)
+		argIndex++
+	}
+
+	if filters.MinScore > 0 {
+		where += fmt.Sprintf(" AND average_score >= $%d", argIndex)
+		args = append(args, filters.MinScore)
+		argIndex++
+	}
+
+	if filters.MaxScore > 0 {
+		where += fmt.Sprintf(" AND average_score <= $%d", argIndex)
+		args = append(args, filters.MaxScore)
+		argIndex++
+	}
+
+	return where, args
+}
+
+// reviewOrderClause builds the ORDER BY clause for per-property review queries.
+// Sort fields map to fixed columns so user input never reaches the SQL, and review_id keeps the order deterministic.
+// Reviews without a typed date come last in either direction.
+func reviewOrderClause(filters ReviewFilters) string {
+	column := "date"
+	nulls := " NULLS LAST"
+	if filters.SortBy == ReviewSortScore {
+		column, nulls = "average_score", ""
+	}
+
+	direction := "DESC"
+	if filters.SortAscending {
+		direction = "ASC"
+	}
+
+	return fmt.Sprintf(" ORDER BY %s %s%s, review_id ASC", column, direction, nulls)
+}
+
+// GetReviewStats computes the review count, average score and per-score distribution for a property
+func (s *storage) GetReviewStats(ctx context.Context, hotelID int64) (*ReviewStats, error) {
 	query := `
-		SELECT review_id, average_score, country, type, name, date, headline, language, pros, cons, source
+		SELECT average_score, COUNT(*)
 		FROM reviews
 		WHERE property_id = $1
-		ORDER BY date DESC
+		GROUP BY average_score
+		ORDER BY average_score
 	`
 
 	rows, err := s.db.QueryContext(ctx, query, hotelID)
@@ -230,21 +573,136 @@ func (s *storage) GetPropertyReviews(ctx context.Context, hotelID int64) ([]cupi
 	}
 	defer rows.Close()
 
-	var reviews []cupid.Review
-	for rows.Next() {
-		var review cupid.Review
-		err := rows.Scan(
-			&review.ReviewID, &review.AverageScore, &review.Country, &review.Type,
-			&review.Name, &review.Date, &review.Headline, &review.Language,
-			&review.Pros, &review.Cons, &review.Source,
-		)
+	buckets, skipped, err := scanRows(rows, s.config.SkipBadRows, func(r rowScanner) (ScoreBucket, error) {
+		var bucket ScoreBucket
+		err := r.Scan(&bucket.Score, &bucket.Count)
+		return bucket, err
+	})
+	logSkippedRows(ctx, "reviews", skipped)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &ReviewStats{Scale: RatingScale, Distribution: make([]ScoreBucket, 10)}
+	for i := range stats.Distribution {
+		stats.Distribution[i].Score = i + 1
+	}
+
+	total := 0
+	for _, bucket := range buckets {
+		if bucket.Score >= 1 && bucket.Score <= 10 {
+			stats.Distribution[bucket.Score-1].Count = bucket.Count
+		}
+		stats.Count += bucket.Count
+		total += bucket.Score * bucket.Count
+	}
+
+	if stats.Count > 0 {
+		stats.AverageScore = s.normalizeReviewScore(float64(total) / float64(stats.Count))
+	}
+
+	return stats, nil
+}
+
+// normalizeReviewScore converts a review score from Config.ReviewScoreScale onto the 0-10 RatingScale.
+// A scale that is not positive leaves the score unchanged.
+func (s *storage) normalizeReviewScore(score float64) float64 {
+	if s.config.ReviewScoreScale <= 0 || s.config.ReviewScoreScale == 10 {
+		return score
+	}
+	return score * 10 / s.config.ReviewScoreScale
+}
+
+// GetPropertiesWithOldestReviews retrieves properties ordered by their most recent review date, oldest first.
+// Properties without any stored reviews are not included.
+func (s *storage) GetPropertiesWithOldestReviews(ctx context.Context, limit int) ([]*PropertyReviewAge, error) {
+	query := `SELECT ` + selectPropertyColumns("p") + `, MAX(r.date) AS latest_review_date
+		FROM properties p
+		JOIN reviews r ON r.property_id = p.hotel_id
+		GROUP BY p.hotel_id
+		ORDER BY latest_review_date ASC NULLS FIRST, p.hotel_id ASC
+		LIMIT $1
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results, skipped, err := scanRows(rows, s.config.SkipBadRows, func(r rowScanner) (*PropertyReviewAge, error) {
+		var latestReviewDate sql.NullTime
+		property, err := scanProperty(r, &latestReviewDate)
+		if err != nil {
+			return nil, err
+		}
+
+		result := &PropertyReviewAge{Property: property}
+		if latestReviewDate.Valid {
+			result.LatestReviewDate = &latestReviewDate.Time
+		}
+		return result, nil
+	})
+	logSkippedRows(ctx, "properties", skipped)
+
+	return results, err
+}
+
+// GetPropertiesWithStaleTranslations retrieves properties whose translations were not refreshed since olderThan,
+// stalest first, to prioritize translation resyncs.
+// A property is stale as soon as one of its translations is; properties without translations come first.
+func (s *storage) GetPropertiesWithStaleTranslations(ctx context.Context, olderThan time.Time, limit int) ([]*PropertyTranslationAge, error) {
+	query := `SELECT ` + selectPropertyColumns("p") + `, MIN(t.updated_at) AS translations_updated_at
+		FROM properties p
+		LEFT JOIN translations t ON t.property_id = p.hotel_id
+		GROUP BY p.hotel_id
+		HAVING MIN(t.updated_at) IS NULL OR MIN(t.updated_at) < $1
+		ORDER BY translations_updated_at ASC NULLS FIRST, p.hotel_id ASC
+		LIMIT $2
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, olderThan, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results, skipped, err := scanRows(rows, s.config.SkipBadRows, func(r rowScanner) (*PropertyTranslationAge, error) {
+		var updatedAt sql.NullTime
+		property, err := scanProperty(r, &updatedAt)
 		if err != nil {
 			return nil, err
 		}
-		reviews = append(reviews, review)
+
+		result := &PropertyTranslationAge{Property: property}
+		if updatedAt.Valid {
+			result.TranslationsUpdatedAt = &updatedAt.Time
+		}
+		return result, nil
+	})
+	logSkippedRows(ctx, "properties", skipped)
+
+	return results, err
+}
+
+// GetStaleProperties retrieves properties whose last sync is older than olderThan, least recently synced first,
+// to target re-syncs of data falling behind. Properties never synced come first.
+// The cutoff is computed by the database, whose clock also sets last_synced_at.
+func (s *storage) GetStaleProperties(ctx context.Context, olderThan time.Duration, limit int) ([]*cupid.Property, error) {
+	query := `SELECT ` + selectPropertyColumns("") + `
+		FROM properties
+		WHERE last_synced_at IS NULL OR last_synced_at < NOW() - $1 * INTERVAL '1 second'
+		ORDER BY last_synced_at ASC NULLS FIRST, hotel_id ASC
+		LIMIT $2
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, olderThan.Seconds(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stale properties: %w", err)
 	}
+	defer rows.Close()
 
-	return reviews, nil
+	return s.scanProperties(ctx, rows)
 }
 
 // GetPropertyTranslations retrieves all translations for a specific property
@@ -261,31 +719,118 @@ func (s *storage) GetPropertyTranslations(ctx context.Context, hotelID int64) (m
 	}
 	defer rows.Close()
 
-	translations := make(map[string]*cupid.Property)
-	for rows.Next() {
-		var lang string
+	type translationRow struct {
+		language    string
+		translation *cupid.Property
+	}
+
+	scanned, skipped, err := scanRows(rows, s.config.SkipBadRows, func(r rowScanner) (translationRow, error) {
+		var row translationRow
 		var translation cupid.Property
-		err := rows.Scan(
-			&lang, &translation.HotelName, &translation.Description,
+		err := r.Scan(
+			&row.language, &translation.HotelName, &translation.Description,
 			&translation.MarkdownDescription, &translation.ImportantInfo,
 		)
-		if err != nil {
-			return nil, err
-		}
-		translations[lang] = &translation
+		row.translation = &translation
+		return row, err
+	})
+	logSkippedRows(ctx, "translations", skipped)
+	if err != nil {
+		return nil, err
+	}
+
+	translations := make(map[string]*cupid.Property, len(scanned))
+	for _, row := range scanned {
+		translations[row.language] = row.translation
 	}
 
 	return translations, nil
 }
 
+// GetTranslationCounts counts the stored translations of each of the given properties.
+// Properties without translations are reported with a zero count; unknown hotel IDs are left out of the result.
+func (s *storage) GetTranslationCounts(ctx context.Context, hotelIDs []int64) (map[int64]int, error) {
+	if len(hotelIDs) == 0 {
+		return map[int64]int{}, nil
+	}
+
+	query := `
+		SELECT p.hotel_id, COUNT(t.language)
+		FROM properties p
+		LEFT JOIN translations t ON t.property_id = p.hotel_id
+		WHERE p.hotel_id = ANY($1)
+		GROUP BY p.hotel_id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, pq.Array(hotelIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type translationCount struct {
+		hotelID int64
+		count   int
+	}
+
+	scanned, skipped, err := scanRows(rows, s.config.SkipBadRows, func(r rowScanner) (translationCount, error) {
+		var row translationCount
+		err := r.Scan(&row.hotelID, &row.count)
+		return row, err
+	})
+	logSkippedRows(ctx, "translations", skipped)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int64]int, len(scanned))
+	for _, row := range scanned {
+		counts[row.hotelID] = row.count
+	}
+
+	return counts, nil
+}
+
 // UpdateProperty updates an existing property
 func (s *storage) UpdateProperty(ctx context.Context, hotelID int64, propertyData *cupid.PropertyData) error {
 	return s.StoreProperty(ctx, propertyData)
 }
 
-// DeleteProperty deletes a property and all its related data
+// DeleteProperty deletes a property and all its related data.
+// Child rows are deleted explicitly in the same transaction so this does not depend on FK cascades.
+// Returns ErrPropertyNotFound when no property has the given hotel ID.
 func (s *storage) DeleteProperty(ctx context.Context, hotelID int64) error {
-	query := "DELETE FROM properties WHERE hotel_id = $1"
-	_, err := s.db.ExecContext(ctx, query, hotelID)
-	return err
+	ctx, span := tracing.StartSpan(ctx, "store.DeleteProperty", attribute.Int64("hotel_id", hotelID))
+	defer span.End()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, table := range []string{"reviews", "translations", "property_details", "property_facilities", "property_room_amenities"} {
+		query := fmt.Sprintf("DELETE FROM %s WHERE property_id = $1", table)
+		if _, err := tx.ExecContext(ctx, query, hotelID); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", table, err)
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, "DELETE FROM properties WHERE hotel_id = $1", hotelID)
+	if err != nil {
+		return fmt.Errorf("failed to delete property: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to count deleted properties: %w", err)
+	}
+	if affected == 0 {
+		return ErrPropertyNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
 }
@@ -5,20 +5,101 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/barimehdi77/cupid-api/internal/cupid"
 	"github.com/barimehdi77/cupid-api/internal/logger"
+	"github.com/barimehdi77/cupid-api/internal/tracing"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 )
 
 // StoreProperty stores a complete property with all its data
 func (s *storage) StoreProperty(ctx context.Context, propertyData *cupid.PropertyData) error {
+	ctx, span := tracing.StartSpan(ctx, "store.StoreProperty", attribute.Int64("hotel_id", propertyData.Property.HotelID))
+	defer span.End()
+
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
+	if err := s.storePropertyData(ctx, tx, propertyData); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	logger.Info("Property stored successfully",
+		zap.Int64("hotel_id", propertyData.Property.HotelID),
+		zap.String("hotel_name", propertyData.Property.HotelName),
+	)
+
+	return nil
+}
+
+// StorePropertiesBatch stores many properties in a single transaction.
+// Each property is written inside its own savepoint, so a property that fails is rolled back and
+// reported in the returned map (keyed by hotel ID) without affecting the rest of the batch.
+// The error is only set when the batch as a whole could not be written.
+func (s *storage) StorePropertiesBatch(ctx context.Context, properties []*cupid.PropertyData) (map[int64]error, error) {
+	ctx, span := tracing.StartSpan(ctx, "store.StorePropertiesBatch", attribute.Int("batch_size", len(properties)))
+	defer span.End()
+
+	failures := make(map[int64]error)
+	if len(properties) == 0 {
+		return failures, nil
+	}
+
+	start := time.Now()
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for i, propertyData := range properties {
+		hotelID := propertyData.Property.HotelID
+		savepoint := fmt.Sprintf("property_%d", i)
+
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT "+savepoint); err != nil {
+			return nil, fmt.Errorf("failed to create savepoint: %w", err)
+		}
+
+		if err := s.storePropertyData(ctx, tx, propertyData); err != nil {
+			failures[hotelID] = err
+			if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+savepoint); err != nil {
+				return nil, fmt.Errorf("failed to roll back property %d: %w", hotelID, err)
+			}
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT "+savepoint); err != nil {
+			return nil, fmt.Errorf("failed to release savepoint: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	logger.Info("Property batch stored",
+		zap.Int("total", len(properties)),
+		zap.Int("stored", len(properties)-len(failures)),
+		zap.Int("failed", len(failures)),
+		zap.Duration("duration", time.Since(start)),
+	)
+
+	return failures, nil
+}
+
+// storePropertyData writes a property and all its related rows within tx
+func (s *storage) storePropertyData(ctx context.Context, tx *sql.Tx, propertyData *cupid.PropertyData) error {
 	// Store main property
 	if err := s.storeMainProperty(ctx, tx, &propertyData.Property); err != nil {
 		return fmt.Errorf("failed to store main property: %w", err)
@@ -29,8 +110,27 @@ func (s *storage) StoreProperty(ctx context.Context, propertyData *cupid.Propert
 		return fmt.Errorf("failed to store property details: %w", err)
 	}
 
+	// Store the raw upstream response, only captured with CUPID_CAPTURE_RAW
+	if len(propertyData.RawProperty) > 0 {
+		if err := s.storeRawProperty(ctx, tx, propertyData.Property.HotelID, propertyData.RawProperty); err != nil {
+			return fmt.Errorf("failed to store raw property: %w", err)
+		}
+	}
+
+	// Store normalized facilities
+	if err := s.storeFacilities(ctx, tx, propertyData.Property.HotelID, propertyData.Property.Facilities); err != nil {
+		return fmt.Errorf("failed to store facilities: %w", err)
+	}
+
+	// Store normalized room amenities
+	if s.config.StoreRoomAmenities {
+		if err := s.storeRoomAmenities(ctx, tx, propertyData.Property.HotelID, propertyData.Property.Rooms); err != nil {
+			return fmt.Errorf("failed to store room amenities: %w", err)
+		}
+	}
+
 	// Store reviews
-	if err := s.storeReviews(ctx, tx, propertyData.Property.HotelID, propertyData.Reviews); err != nil {
+	if err := s.storeReviews(ctx, tx, propertyData.Property.HotelID, propertyData.Reviews, propertyData.ReviewsComplete); err != nil {
 		return fmt.Errorf("failed to store reviews: %w", err)
 	}
 
@@ -39,15 +139,6 @@ func (s *storage) StoreProperty(ctx context.Context, propertyData *cupid.Propert
 		return fmt.Errorf("failed to store translations: %w", err)
 	}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
-	logger.Info("Property stored successfully",
-		zap.Int64("hotel_id", propertyData.Property.HotelID),
-		zap.String("hotel_name", propertyData.Property.HotelName),
-	)
-
 	return nil
 }
 
@@ -57,9 +148,12 @@ func (s *storage) storeMainProperty(ctx context.Context, tx *sql.Tx, property *c
 		INSERT INTO properties (
 			hotel_id, cupid_id, hotel_name, hotel_type, hotel_type_id,
 			chain, chain_id, latitude, longitude, stars, rating, review_count,
-			airport_code, city, state, country, postal_code, main_image_th
+			airport_code, city, state, country, postal_code, main_image_th,
+			phone, fax, email, description, markdown_description, important_info,
+			parking, group_room_min, child_allowed, pets_allowed, min_price, currency, address, last_synced_at
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18,
+			$19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, NOW()
 		) ON CONFLICT (hotel_id) DO UPDATE SET
 			cupid_id = EXCLUDED.cupid_id,
 			hotel_name = EXCLUDED.hotel_name,
@@ -78,46 +172,124 @@ func (s *storage) storeMainProperty(ctx context.Context, tx *sql.Tx, property *c
 			country = EXCLUDED.country,
 			postal_code = EXCLUDED.postal_code,
 			main_image_th = EXCLUDED.main_image_th,
+			phone = EXCLUDED.phone,
+			fax = EXCLUDED.fax,
+			email = EXCLUDED.email,
+			description = EXCLUDED.description,
+			markdown_description = EXCLUDED.markdown_description,
+			important_info = EXCLUDED.important_info,
+			parking = EXCLUDED.parking,
+			group_room_min = EXCLUDED.group_room_min,
+			child_allowed = EXCLUDED.child_allowed,
+			pets_allowed = EXCLUDED.pets_allowed,
+			min_price = EXCLUDED.min_price,
+			currency = EXCLUDED.currency,
+			address = EXCLUDED.address,
+			last_synced_at = NOW(),
 			updated_at = NOW()
 	`
 
+	minPrice, currency := lowestRoomRate(property.Rooms)
+
 	_, err := tx.ExecContext(ctx, query,
 		property.HotelID, property.CupidID, property.HotelName, property.HotelType, property.HotelTypeID,
 		property.Chain, property.ChainID, property.Latitude, property.Longitude, property.Stars,
 		property.Rating, property.ReviewCount, property.AirportCode, property.Address.City,
 		property.Address.State, property.Address.Country, property.Address.PostalCode, property.MainImageTh,
+		property.Phone, property.Fax, property.Email, property.Description,
+		property.MarkdownDescription, property.ImportantInfo,
+		property.Parking, property.GroupRoomMin, property.ChildAllowed, property.PetsAllowed,
+		minPrice, currency, property.Address.Address,
 	)
 
 	return err
 }
 
-// storePropertyDetails stores complex data as JSONB
-func (s *storage) storePropertyDetails(ctx context.Context, tx *sql.Tx, propertyData *cupid.PropertyData) error {
-	// Prepare JSONB data
-	details := map[string]interface{}{
-		"address":    propertyData.Property.Address,
-		"checkin":    propertyData.Property.CheckIn,
-		"facilities": propertyData.Property.Facilities,
-		"policies":   propertyData.Property.Policies,
-		"rooms":      propertyData.Property.Rooms,
-		"photos":     propertyData.Property.Photos,
-		"contact_info": map[string]interface{}{
-			"phone": propertyData.Property.Phone,
-			"email": propertyData.Property.Email,
-			"fax":   propertyData.Property.Fax,
+// lowestRoomRate returns the lowest nightly rate among rooms and its currency, or nil when no room has a rate.
+// Rates are only comparable within a currency, so rooms priced in another currency than the first priced room are ignored.
+func lowestRoomRate(rooms []cupid.Room) (*float64, string) {
+	var lowest *float64
+	currency := ""
+	for _, room := range rooms {
+		if room.MinNightlyRate == nil || *room.MinNightlyRate < 0 {
+			continue
+		}
+		roomCurrency := strings.ToUpper(room.Currency)
+		if lowest == nil {
+			currency = roomCurrency
+		} else if roomCurrency != currency {
+			continue
+		}
+		if lowest == nil || *room.MinNightlyRate < *lowest {
+			rate := *room.MinNightlyRate
+			lowest = &rate
+		}
+	}
+	return lowest, currency
+}
+
+// propertyDetailColumns lists the property_details columns holding a JSONB section, in marshalPropertyDetails order
+var propertyDetailColumns = []string{"address", "checkin_info", "facilities", "policies", "rooms", "photos", "contact_info", "metadata"}
+
+// marshalPropertyDetails marshals the JSONB section of every details column, in propertyDetailColumns order.
+// Rooms and photos are capped before marshaling.
+func (s *storage) marshalPropertyDetails(property *cupid.Property) ([][]byte, error) {
+	rooms, photos := s.capDetailArrays(property)
+
+	sections := []interface{}{
+		property.Address,
+		property.CheckIn,
+		property.Facilities,
+		property.Policies,
+		rooms,
+		photos,
+		map[string]interface{}{
+			"phone": property.Phone,
+			"email": property.Email,
+			"fax":   property.Fax,
 		},
-		"metadata": map[string]interface{}{
-			"parking":        propertyData.Property.Parking,
-			"group_room_min": propertyData.Property.GroupRoomMin,
-			"child_allowed":  propertyData.Property.ChildAllowed,
-			"pets_allowed":   propertyData.Property.PetsAllowed,
+		map[string]interface{}{
+			"parking":        property.Parking,
+			"group_room_min": property.GroupRoomMin,
+			"child_allowed":  property.ChildAllowed,
+			"pets_allowed":   property.PetsAllowed,
 		},
 	}
 
-	// Convert to JSON
-	jsonData, err := json.Marshal(details)
+	details := make([][]byte, 0, len(sections))
+	for _, section := range sections {
+		jsonData, err := json.Marshal(section)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal property details: %w", err)
+		}
+		details = append(details, jsonData)
+	}
+	return details, nil
+}
+
+// storePropertyDetails stores complex data as JSONB, each section in its own column.
+// Rooms and photos are capped before marshaling and details larger than the configured size in total are rejected,
+// so a malformed upstream response cannot bloat the table.
+func (s *storage) storePropertyDetails(ctx context.Context, tx *sql.Tx, propertyData *cupid.PropertyData) error {
+	property := &propertyData.Property
+	details, err := s.marshalPropertyDetails(property)
 	if err != nil {
-		return fmt.Errorf("failed to marshal property details: %w", err)
+		return err
+	}
+
+	args := []interface{}{property.HotelID}
+	size := 0
+	for _, jsonData := range details {
+		size += len(jsonData)
+		args = append(args, jsonData)
+	}
+	if s.config.MaxDetailsBytes > 0 && size > s.config.MaxDetailsBytes {
+		logger.Warn("Rejecting oversized property details",
+			zap.Int64("hotel_id", property.HotelID),
+			zap.Int("size_bytes", size),
+			zap.Int("max_bytes", s.config.MaxDetailsBytes),
+		)
+		return fmt.Errorf("%w: %d bytes, maximum is %d", ErrPropertyDetailsTooLarge, size, s.config.MaxDetailsBytes)
 	}
 
 	query := `
@@ -135,53 +307,295 @@ func (s *storage) storePropertyDetails(ctx context.Context, tx *sql.Tx, property
 			updated_at = NOW()
 	`
 
-	_, err = tx.ExecContext(ctx, query,
-		propertyData.Property.HotelID,
-		jsonData, // address
-		jsonData, // checkin_info
-		jsonData, // facilities
-		jsonData, // policies
-		jsonData, // rooms
-		jsonData, // photos
-		jsonData, // contact_info
-		jsonData, // metadata
-	)
-
+	_, err = tx.ExecContext(ctx, query, args...)
 	return err
 }
 
-// storeReviews stores property reviews
-func (s *storage) storeReviews(ctx context.Context, tx *sql.Tx, hotelID int64, reviews []cupid.Review) error {
-	if len(reviews) == 0 {
+// storeRawProperty stores the raw Cupid API response of a property next to its details.
+// A response larger than Config.MaxDetailsBytes is skipped rather than failing the store, since it is only
+// kept for debugging; properties stored without a captured response keep their previous one.
+func (s *storage) storeRawProperty(ctx context.Context, tx *sql.Tx, hotelID int64, raw json.RawMessage) error {
+	if s.config.MaxDetailsBytes > 0 && len(raw) > s.config.MaxDetailsBytes {
+		logger.Warn("Skipping oversized raw property response",
+			zap.Int64("hotel_id", hotelID),
+			zap.Int("size_bytes", len(raw)),
+			zap.Int("max_bytes", s.config.MaxDetailsBytes),
+		)
 		return nil
 	}
 
-	// Delete existing reviews for this property
-	_, err := tx.ExecContext(ctx, "DELETE FROM reviews WHERE property_id = $1", hotelID)
+	_, err := tx.ExecContext(ctx, `UPDATE property_details SET raw_property_json = $2 WHERE property_id = $1`, hotelID, []byte(raw))
+	return err
+}
+
+// capDetailArrays returns the rooms and photos of property truncated to the configured maximums,
+// including the photos of each room. The property itself is left untouched.
+func (s *storage) capDetailArrays(property *cupid.Property) ([]cupid.Room, []cupid.Photo) {
+	photos := s.capPhotos(property.HotelID, "property", property.Photos)
+
+	rooms := property.Rooms
+	if s.config.MaxDetailRooms > 0 && len(rooms) > s.config.MaxDetailRooms {
+		logger.Warn("Truncating property rooms",
+			zap.Int64("hotel_id", property.HotelID),
+			zap.Int("count", len(rooms)),
+			zap.Int("max", s.config.MaxDetailRooms),
+		)
+		rooms = rooms[:s.config.MaxDetailRooms]
+	}
+	if len(rooms) == 0 {
+		return rooms, photos
+	}
+
+	capped := make([]cupid.Room, len(rooms))
+	for i, room := range rooms {
+		room.Photos = s.capPhotos(property.HotelID, fmt.Sprintf("room %d", room.ID), room.Photos)
+		capped[i] = room
+	}
+
+	return capped, photos
+}
+
+// capPhotos truncates photos to the configured maximum, logging a warning naming owner when it does
+func (s *storage) capPhotos(hotelID int64, owner string, photos []cupid.Photo) []cupid.Photo {
+	if s.config.MaxDetailPhotos <= 0 || len(photos) <= s.config.MaxDetailPhotos {
+		return photos
+	}
+
+	logger.Warn("Truncating property photos",
+		zap.Int64("hotel_id", hotelID),
+		zap.String("owner", owner),
+		zap.Int("count", len(photos)),
+		zap.Int("max", s.config.MaxDetailPhotos),
+	)
+	return photos[:s.config.MaxDetailPhotos]
+}
+
+// storeFacilities replaces the normalized facility rows used for facility filtering
+func (s *storage) storeFacilities(ctx context.Context, tx *sql.Tx, hotelID int64, facilities []cupid.Facility) error {
+	// Delete existing facilities for this property
+	_, err := tx.ExecContext(ctx, "DELETE FROM property_facilities WHERE property_id = $1", hotelID)
 	if err != nil {
-		return fmt.Errorf("failed to delete existing reviews: %w", err)
+		return fmt.Errorf("failed to delete existing facilities: %w", err)
 	}
 
-	// Insert new reviews
+	// Insert new facilities
 	query := `
-		INSERT INTO reviews (property_id, review_id, average_score, country, type, name, date, headline, language, pros, cons, source)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		INSERT INTO property_facilities (property_id, facility_id, name)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (property_id, facility_id) DO NOTHING
 	`
 
-	for _, review := range reviews {
-		_, err := tx.ExecContext(ctx, query,
-			hotelID, review.ReviewID, review.AverageScore, review.Country, review.Type,
-			review.Name, review.Date, review.Headline, review.Language, review.Pros,
-			review.Cons, review.Source,
-		)
+	for _, facility := range facilities {
+		_, err := tx.ExecContext(ctx, query, hotelID, facility.FacilityID, facility.Name)
+		if err != nil {
+			return fmt.Errorf("failed to insert facility: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// storeRoomAmenities replaces the normalized room amenity rows used for room amenity filtering.
+// Each amenity gets one row with the number of rooms offering it.
+func (s *storage) storeRoomAmenities(ctx context.Context, tx *sql.Tx, hotelID int64, rooms []cupid.Room) error {
+	// Delete existing room amenities for this property
+	_, err := tx.ExecContext(ctx, "DELETE FROM property_room_amenities WHERE property_id = $1", hotelID)
+	if err != nil {
+		return fmt.Errorf("failed to delete existing room amenities: %w", err)
+	}
+
+	// Count the rooms offering each amenity, keeping the first name seen and the upstream order
+	var amenities []cupid.RoomAmenity
+	roomCounts := make(map[int]int)
+	for _, room := range rooms {
+		inRoom := make(map[int]bool, len(room.RoomAmenities))
+		for _, amenity := range room.RoomAmenities {
+			if inRoom[amenity.AmenitiesID] {
+				continue
+			}
+			inRoom[amenity.AmenitiesID] = true
+			if roomCounts[amenity.AmenitiesID] == 0 {
+				amenities = append(amenities, amenity)
+			}
+			roomCounts[amenity.AmenitiesID]++
+		}
+	}
+
+	// Insert new room amenities
+	query := `
+		INSERT INTO property_room_amenities (property_id, amenity_id, name, room_count)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	for _, amenity := range amenities {
+		_, err := tx.ExecContext(ctx, query, hotelID, amenity.AmenitiesID, amenity.Name, roomCounts[amenity.AmenitiesID])
 		if err != nil {
-			return fmt.Errorf("failed to insert review: %w", err)
+			return fmt.Errorf("failed to insert room amenity: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// reviewInsertChunkSize caps the rows per multi-row review INSERT, keeping the
+// statement well under PostgreSQL's 65535 bind parameter limit (14 per row)
+const reviewInsertChunkSize = 500
+
+// storeReviews upserts property reviews and, when complete reports that reviews holds every upstream review,
+// removes the synced reviews that no longer exist upstream, all of them for an empty list. An incomplete fetch,
+// failed or capped, keeps the stored reviews it did not return.
+// Reviews are written with multi-row INSERTs; existing rows are updated in place so their
+// created_at stays stable across syncs.
+func (s *storage) storeReviews(ctx context.Context, tx *sql.Tx, hotelID int64, reviews []cupid.Review, complete bool) error {
+	unique, reviewIDs := uniqueReviews(reviews)
+	if len(unique) > 0 {
+		if err := upsertReviews(ctx, tx, hotelID, unique, false); err != nil {
+			return err
 		}
 	}
+	if !complete {
+		return nil
+	}
+
+	// Remove reviews that are no longer returned upstream, keeping imported ones
+	_, err := tx.ExecContext(ctx,
+		"DELETE FROM reviews WHERE property_id = $1 AND NOT imported AND NOT (review_id = ANY($2))",
+		hotelID, pq.Array(reviewIDs),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete stale reviews: %w", err)
+	}
 
 	return nil
 }
 
+// ImportReviews upserts reviews of a property that did not come from a sync, such as a historical export.
+// Unlike a sync it never removes stored reviews, and the reviews it stores are marked imported so that syncs
+// keep them too. A review ID listed several times counts once, with its last
+// occurrence winning. Returns ErrPropertyNotFound when no property has the given hotel ID.
+func (s *storage) ImportReviews(ctx context.Context, hotelID int64, reviews []cupid.Review) (*ReviewImportResult, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM properties WHERE hotel_id = $1)`, hotelID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("failed to check property: %w", err)
+	}
+	if !exists {
+		return nil, ErrPropertyNotFound
+	}
+
+	unique, reviewIDs := uniqueReviews(reviews)
+
+	var existing int
+	err = tx.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM reviews WHERE property_id = $1 AND review_id = ANY($2)`,
+		hotelID, pq.Array(reviewIDs),
+	).Scan(&existing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count existing reviews: %w", err)
+	}
+
+	if err := upsertReviews(ctx, tx, hotelID, unique, true); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &ReviewImportResult{
+		Inserted: len(unique) - existing,
+		Updated:  existing,
+	}, nil
+}
+
+// uniqueReviews keeps the last occurrence of each review ID with cupid.DedupeReviews, since a multi-row upsert
+// cannot touch the same row twice, and returns the kept reviews along with their IDs
+func uniqueReviews(reviews []cupid.Review) ([]cupid.Review, []int64) {
+	unique := cupid.DedupeReviews(reviews)
+	reviewIDs := make([]int64, len(unique))
+	for i, review := range unique {
+		reviewIDs[i] = review.ReviewID
+	}
+	return unique, reviewIDs
+}
+
+// upsertReviews upserts reviews in chunks of reviewInsertChunkSize. Review IDs must be unique.
+// Imported reviews are marked so, and a review once imported stays marked when a sync returns it.
+func upsertReviews(ctx context.Context, tx *sql.Tx, hotelID int64, reviews []cupid.Review, imported bool) error {
+	for start := 0; start < len(reviews); start += reviewInsertChunkSize {
+		end := min(start+reviewInsertChunkSize, len(reviews))
+		if err := upsertReviewChunk(ctx, tx, hotelID, reviews[start:end], imported); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// upsertReviewChunk upserts reviews with a single multi-row INSERT
+func upsertReviewChunk(ctx context.Context, tx *sql.Tx, hotelID int64, reviews []cupid.Review, imported bool) error {
+	const columnCount = 14
+	placeholders := make([]string, 0, len(reviews))
+	args := make([]interface{}, 0, len(reviews)*columnCount)
+
+	for i, review := range reviews {
+		params := make([]string, columnCount)
+		for j := range params {
+			params[j] = fmt.Sprintf("$%d", i*columnCount+j+1)
+		}
+		placeholders = append(placeholders, "("+strings.Join(params, ", ")+")")
+		args = append(args,
+			hotelID, review.ReviewID, review.AverageScore, review.Country, review.Type,
+			review.Name, reviewDate(hotelID, review), review.Date, review.Headline, review.Language,
+			review.Pros, review.Cons, review.Source, imported,
+		)
+	}
+
+	query := `
+		INSERT INTO reviews (property_id, review_id, average_score, country, type, name, date, date_raw, headline, language, pros, cons, source, imported)
+		VALUES ` + strings.Join(placeholders, ", ") + `
+		ON CONFLICT (property_id, review_id) DO UPDATE SET
+			average_score = EXCLUDED.average_score,
+			country = EXCLUDED.country,
+			type = EXCLUDED.type,
+			name = EXCLUDED.name,
+			date = EXCLUDED.date,
+			date_raw = EXCLUDED.date_raw,
+			headline = EXCLUDED.headline,
+			language = EXCLUDED.language,
+			pros = EXCLUDED.pros,
+			cons = EXCLUDED.cons,
+			source = EXCLUDED.source,
+			imported = reviews.imported OR EXCLUDED.imported
+	`
+
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to upsert reviews: %w", err)
+	}
+	return nil
+}
+
+// reviewDate returns the typed date column value of a review, parsed from its upstream date.
+// A date in an unrecognized format is stored as NULL, keeping only the raw value, and logged.
+func reviewDate(hotelID int64, review cupid.Review) interface{} {
+	date, ok := cupid.ParseReviewDate(review.Date)
+	if !ok {
+		if review.Date != "" {
+			logger.Warn("Unrecognized review date, storing it without a typed date",
+				zap.Int64("property_id", hotelID),
+				zap.Int64("review_id", review.ReviewID),
+				zap.String("date", review.Date),
+			)
+		}
+		return nil
+	}
+	return date
+}
+
 // storeTranslations stores property translations
 func (s *storage) storeTranslations(ctx context.Context, tx *sql.Tx, hotelID int64, translations map[string]*cupid.Property) error {
 	if len(translations) == 0 {
@@ -0,0 +1,159 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/barimehdi77/cupid-api/internal/cupid"
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"go.uber.org/zap"
+)
+
+// propertyColumns lists the properties table columns read by scanProperty, in scan order
+var propertyColumns = []string{
+	"hotel_id", "cupid_id", "hotel_name", "hotel_type", "hotel_type_id",
+	"chain", "chain_id", "latitude", "longitude", "stars", "rating", "review_count",
+	"airport_code", "address", "city", "state", "country", "postal_code", "main_image_th",
+	"phone", "fax", "email", "description", "markdown_description", "important_info",
+	"parking", "group_room_min", "child_allowed", "pets_allowed", "min_price", "currency", "last_synced_at",
+}
+
+// selectPropertyColumns returns the property column list for a SELECT, optionally qualified by a table alias.
+// Overridable columns are read from property_overrides when the property has an override for them.
+func selectPropertyColumns(alias string) string {
+	table := alias
+	if table == "" {
+		table = "properties"
+	}
+
+	selected := make([]string, len(propertyColumns))
+	for i, column := range propertyColumns {
+		selected[i] = column
+		if alias != "" {
+			selected[i] = alias + "." + column
+		}
+		if isOverridableField(column) {
+			selected[i] = fmt.Sprintf(
+				"COALESCE((SELECT o.value FROM property_overrides o WHERE o.property_id = %s.hotel_id AND o.field = '%s'), %s) AS %s",
+				table, column, selected[i], column,
+			)
+		}
+	}
+	return strings.Join(selected, ", ")
+}
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanProperty scans the property columns, followed by any extra destinations, from a row
+func scanProperty(scanner rowScanner, extra ...interface{}) (*cupid.Property, error) {
+	var property cupid.Property
+	dest := []interface{}{
+		&property.HotelID, &property.CupidID, &property.HotelName, &property.HotelType, &property.HotelTypeID,
+		&property.Chain, &property.ChainID, &property.Latitude, &property.Longitude, &property.Stars,
+		&property.Rating, &property.ReviewCount, &property.AirportCode, &property.Address.Address, &property.Address.City,
+		&property.Address.State, &property.Address.Country, &property.Address.PostalCode, &property.MainImageTh,
+		&property.Phone, &property.Fax, &property.Email, &property.Description,
+		&property.MarkdownDescription, &property.ImportantInfo,
+		&property.Parking, &property.GroupRoomMin, &property.ChildAllowed, &property.PetsAllowed,
+		&property.MinPrice, &property.Currency, &property.LastSyncedAt,
+	}
+	dest = append(dest, extra...)
+
+	if err := scanner.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return &property, nil
+}
+
+// rowIterator is the subset of *sql.Rows used by the scanning helpers
+type rowIterator interface {
+	rowScanner
+	Next() bool
+	Err() error
+}
+
+// scanRows scans every remaining row with scanFn. When skipBadRows is set, rows that fail to
+// scan are logged and skipped, and the number of skipped rows is returned with the good ones.
+// Otherwise the first scan error is returned.
+func scanRows[T any](rows rowIterator, skipBadRows bool, scanFn func(rowScanner) (T, error)) ([]T, int, error) {
+	var results []T
+	skipped := 0
+	for rows.Next() {
+		result, err := scanFn(rows)
+		if err != nil {
+			if !skipBadRows {
+				return nil, skipped, err
+			}
+			skipped++
+			logger.Warn("Skipping row that failed to scan", zap.Error(err))
+			continue
+		}
+		results = append(results, result)
+	}
+
+	return results, skipped, rows.Err()
+}
+
+// scanReview scans the review columns from a row
+func scanReview(scanner rowScanner) (cupid.Review, error) {
+	var review cupid.Review
+	err := scanner.Scan(
+		&review.ReviewID, &review.AverageScore, &review.Country, &review.Type,
+		&review.Name, &review.Date, &review.Headline, &review.Language,
+		&review.Pros, &review.Cons, &review.Source, &review.Imported,
+	)
+	return review, err
+}
+
+// scanProperties scans every remaining row into a property
+func (s *storage) scanProperties(ctx context.Context, rows rowIterator) ([]*cupid.Property, error) {
+	properties, skipped, err := scanRows(rows, s.config.SkipBadRows, func(r rowScanner) (*cupid.Property, error) {
+		return scanProperty(r)
+	})
+	logSkippedRows(ctx, "properties", skipped)
+	return properties, err
+}
+
+// scanReviews scans every remaining row into a review
+func (s *storage) scanReviews(ctx context.Context, rows rowIterator) ([]cupid.Review, error) {
+	reviews, skipped, err := scanRows(rows, s.config.SkipBadRows, scanReview)
+	logSkippedRows(ctx, "reviews", skipped)
+	return reviews, err
+}
+
+// logSkippedRows reports how many rows of a result set were skipped because they failed to scan,
+// adding them to the counter of ctx when it comes from WithSkippedRows
+func logSkippedRows(ctx context.Context, table string, skipped int) {
+	if skipped > 0 {
+		logger.Warn("Returned partial results after skipping bad rows",
+			zap.String("table", table),
+			zap.Int("skipped", skipped),
+		)
+		if counter, ok := ctx.Value(skippedRowsKey{}).(*atomic.Int64); ok {
+			counter.Add(int64(skipped))
+		}
+	}
+}
+
+// skippedRowsKey is the context key of the counter added by WithSkippedRows
+type skippedRowsKey struct{}
+
+// WithSkippedRows returns a context counting the rows skipped by the queries run with it, so a caller such as
+// an API request can report partial results. The count is read with SkippedRows.
+func WithSkippedRows(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skippedRowsKey{}, new(atomic.Int64))
+}
+
+// SkippedRows returns the number of rows skipped so far by queries run with a context from WithSkippedRows,
+// or 0 for any other context
+func SkippedRows(ctx context.Context) int {
+	if counter, ok := ctx.Value(skippedRowsKey{}).(*atomic.Int64); ok {
+		return int(counter.Load())
+	}
+	return 0
+}
@@ -3,18 +3,24 @@ package store
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/barimehdi77/cupid-api/internal/cupid"
+	"github.com/lib/pq"
 )
 
 // GetReviewsByScore retrieves reviews within a score range
 func (s *storage) GetReviewsByScore(ctx context.Context, minScore, maxScore int, limit, offset int) ([]cupid.Review, error) {
 	query := `
-		SELECT r.review_id, r.average_score, r.country, r.type, r.name, r.date, r.headline, r.language, r.pros, r.cons, r.source
+		SELECT r.review_id, r.average_score, r.country, r.type, r.name, COALESCE(r.date_raw, ''), r.headline, r.language, r.pros, r.cons, r.source, r.imported
 		FROM reviews r
 		WHERE r.average_score >= $1 AND r.average_score <= $2
-		ORDER BY r.average_score DESC, r.date DESC
+		ORDER BY r.average_score DESC, r.date DESC NULLS LAST
 		LIMIT $3 OFFSET $4
 	`
 
@@ -24,21 +30,7 @@ func (s *storage) GetReviewsByScore(ctx context.Context, minScore, maxScore int,
 	}
 	defer rows.Close()
 
-	var reviews []cupid.Review
-	for rows.Next() {
-		var review cupid.Review
-		err := rows.Scan(
-			&review.ReviewID, &review.AverageScore, &review.Country, &review.Type,
-			&review.Name, &review.Date, &review.Headline, &review.Language,
-			&review.Pros, &review.Cons, &review.Source,
-		)
-		if err != nil {
-			return nil, err
-		}
-		reviews = append(reviews, review)
-	}
-
-	return reviews, nil
+	return s.scanReviews(ctx, rows)
 }
 
 // GetTranslationByLanguage retrieves a specific translation
@@ -65,55 +57,130 @@ func (s *storage) GetTranslationByLanguage(ctx context.Context, hotelID int64, l
 	return &translation, nil
 }
 
-// SearchProperties performs a text search on properties
-func (s *storage) SearchProperties(ctx context.Context, query string, limit, offset int) ([]*cupid.Property, error) {
-	searchQuery := `
-		SELECT hotel_id, cupid_id, hotel_name, hotel_type, hotel_type_id,
-			   chain, chain_id, latitude, longitude, stars, rating, review_count,
-			   airport_code, city, state, country, postal_code, main_image_th
+// SearchableFields are the properties columns a text search may match, all backed by a trigram index.
+// Restricting searches to them keeps a search from scanning the whole table.
+var SearchableFields = []string{"hotel_name", "city", "country", "address", "state", "description"}
+
+// ErrInvalidSearchField is returned when a search targets a field outside SearchableFields
+var ErrInvalidSearchField = errors.New("field cannot be searched")
+
+// searchFilter builds the WHERE condition matching query against fields, or every searchable field when empty,
+// along with its arguments. A fuzzy search also matches fields holding words similar to the query, catching
+// misspellings such as "hiltn", and returns the relevance expression to rank matches by; an exact search returns
+// an empty relevance. Fuzzy matching uses the trigram-indexable <% operator, so it must run where beginSearch set
+// its threshold.
+func (s *storage) searchFilter(query string, fields []string, fuzzy bool) (condition, relevance string, args []interface{}, err error) {
+	if len(fields) == 0 {
+		fields = SearchableFields
+	}
+
+	args = []interface{}{"%" + query + "%"}
+	if fuzzy {
+		args = append(args, query)
+	}
+
+	conditions := make([]string, 0, len(fields))
+	similarities := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if !slices.Contains(SearchableFields, field) {
+			return "", "", nil, fmt.Errorf("%w: %s", ErrInvalidSearchField, field)
+		}
+		if !fuzzy {
+			conditions = append(conditions, field+" ILIKE $1")
+			continue
+		}
+		conditions = append(conditions, fmt.Sprintf("%[1]s ILIKE $1 OR $2 <%% %[1]s", field))
+		similarities = append(similarities, "word_similarity($2, "+field+")")
+	}
+
+	if fuzzy {
+		relevance = "GREATEST(" + strings.Join(similarities, ", ") + ")"
+	}
+	return strings.Join(conditions, " OR "), relevance, args, nil
+}
+
+// searchQueryer runs search queries, on the database or in a transaction
+type searchQueryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// beginSearch returns where to run a search query and a function to call once its rows are read.
+// A fuzzy search runs in a read-only transaction whose pg_trgm.word_similarity_threshold is
+// SearchSimilarityThreshold, the threshold applied by the <% operator; an exact search runs on the database.
+func (s *storage) beginSearch(ctx context.Context, fuzzy bool) (searchQueryer, func(), error) {
+	if !fuzzy {
+		return s.db, func() {}, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin search transaction: %w", err)
+	}
+
+	threshold := strconv.FormatFloat(s.config.SearchSimilarityThreshold, 'f', -1, 64)
+	if _, err := tx.ExecContext(ctx, "SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)", threshold); err != nil {
+		tx.Rollback()
+		return nil, nil, fmt.Errorf("failed to set search similarity threshold: %w", err)
+	}
+
+	// Nothing is written, so rolling back just ends the transaction
+	return tx, func() { tx.Rollback() }, nil
+}
+
+// SearchProperties performs a text search on the given fields of properties, or on every searchable field when empty.
+// A fuzzy search also tolerates typos and ranks the closest matches first.
+func (s *storage) SearchProperties(ctx context.Context, query string, fields []string, fuzzy bool, limit, offset int) ([]*cupid.Property, error) {
+	condition, relevance, args, err := s.searchFilter(query, fields, fuzzy)
+	if err != nil {
+		return nil, err
+	}
+
+	orderBy := "rating DESC, review_count DESC"
+	if relevance != "" {
+		orderBy = relevance + " DESC, " + orderBy
+	}
+
+	searchQuery := `SELECT ` + selectPropertyColumns("") + `
 		FROM properties
-		WHERE hotel_name ILIKE $1 OR city ILIKE $1 OR country ILIKE $1
-		ORDER BY rating DESC, review_count DESC
-		LIMIT $2 OFFSET $3
-	`
+		WHERE ` + condition + `
+		ORDER BY ` + orderBy + fmt.Sprintf(`
+		LIMIT $%d OFFSET $%d
+	`, len(args)+1, len(args)+2)
 
-	searchTerm := "%" + query + "%"
-	rows, err := s.db.QueryContext(ctx, searchQuery, searchTerm, limit, offset)
+	db, done, err := s.beginSearch(ctx, fuzzy)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	defer done()
 
-	var properties []*cupid.Property
-	for rows.Next() {
-		var property cupid.Property
-		err := rows.Scan(
-			&property.HotelID, &property.CupidID, &property.HotelName, &property.HotelType, &property.HotelTypeID,
-			&property.Chain, &property.ChainID, &property.Latitude, &property.Longitude, &property.Stars,
-			&property.Rating, &property.ReviewCount, &property.AirportCode, &property.Address.City,
-			&property.Address.State, &property.Address.Country, &property.Address.PostalCode, &property.MainImageTh,
-		)
-		if err != nil {
-			return nil, err
-		}
-		properties = append(properties, &property)
+	args = append(args, limit, offset)
+	rows, err := db.QueryContext(ctx, searchQuery, args...)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	return properties, nil
+	return s.scanProperties(ctx, rows)
 }
 
-// CountSearchProperties counts the total number of properties matching the search query
-func (s *storage) CountSearchProperties(ctx context.Context, query string) (int, error) {
-	sqlQuery := `
-		SELECT COUNT(*) 
-		FROM properties 
-		WHERE hotel_name ILIKE $1 
-		   OR city ILIKE $1 
-		   OR country ILIKE $1
-	`
+// CountSearchProperties counts the total number of properties matching the search query on the given fields
+func (s *storage) CountSearchProperties(ctx context.Context, query string, fields []string, fuzzy bool) (int, error) {
+	condition, _, args, err := s.searchFilter(query, fields, fuzzy)
+	if err != nil {
+		return 0, err
+	}
+
+	sqlQuery := `SELECT COUNT(*) FROM properties WHERE ` + condition
+
+	db, done, err := s.beginSearch(ctx, fuzzy)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count search properties: %w", err)
+	}
+	defer done()
 
 	var count int
-	err := s.db.QueryRowContext(ctx, sqlQuery, "%"+query+"%").Scan(&count)
+	err = db.QueryRowContext(ctx, sqlQuery, args...).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count search properties: %w", err)
 	}
@@ -170,10 +237,305 @@ func (s *storage) GetPropertiesByLocation(ctx context.Context, city, country str
 	return s.ListProperties(ctx, limit, offset, filters)
 }
 
+// GetPropertiesByCountries retrieves every property whose country matches one of countries exactly,
+// ignoring case, ordered by hotel ID. Unlike the location filters it does not paginate or match substrings,
+// so "fr" does not also select properties in another country containing those letters.
+func (s *storage) GetPropertiesByCountries(ctx context.Context, countries []string) ([]*cupid.Property, error) {
+	if len(countries) == 0 {
+		return []*cupid.Property{}, nil
+	}
+
+	normalized := make([]string, len(countries))
+	for i, country := range countries {
+		normalized[i] = strings.ToLower(strings.TrimSpace(country))
+	}
+
+	query := `SELECT ` + selectPropertyColumns("") + `
+		FROM properties
+		WHERE LOWER(country) = ANY($1)
+		ORDER BY hotel_id ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, pq.Array(normalized))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return s.scanProperties(ctx, rows)
+}
+
+// GetPropertiesByPostalCodePrefix retrieves properties whose postal code starts with prefix
+func (s *storage) GetPropertiesByPostalCodePrefix(ctx context.Context, prefix string, limit, offset int) ([]*cupid.Property, error) {
+	filters := PropertyFilters{
+		PostalCodePrefix: prefix,
+	}
+	return s.ListProperties(ctx, limit, offset, filters)
+}
+
+// CountPropertiesByPostalCodePrefix counts properties whose postal code starts with prefix
+func (s *storage) CountPropertiesByPostalCodePrefix(ctx context.Context, prefix string) (int, error) {
+	return s.CountProperties(ctx, PropertyFilters{PostalCodePrefix: prefix})
+}
+
 // GetPropertiesByRating retrieves properties by minimum rating
 func (s *storage) GetPropertiesByRating(ctx context.Context, minRating float64, limit, offset int) ([]*cupid.Property, error) {
 	filters := PropertyFilters{
-		MinRating: minRating,
+		MinRating: &minRating,
 	}
 	return s.ListProperties(ctx, limit, offset, filters)
 }
+
+// GetPropertiesByQualityScore retrieves properties ordered by their quality score, best first.
+// The score, rating * ln(review_count + 1) ^ Config.QualityReviewWeight, balances the rating with the number of
+// reviews behind it, so a highly rated property with a handful of reviews does not outrank an equally good,
+// well reviewed one. Ties are broken by hotel ID. The score is computed from the stored upstream rating and review
+// count: neither is in OverridableFields, so overrides change the returned properties but never their ranking.
+func (s *storage) GetPropertiesByQualityScore(ctx context.Context, limit, offset int) ([]*PropertyWithQualityScore, error) {
+	query := `SELECT ` + selectPropertyColumns("") + `,
+			rating * POWER(LN(review_count + 1), $1) AS quality_score
+		FROM properties
+		ORDER BY quality_score DESC, hotel_id ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, max(s.config.QualityReviewWeight, 0), limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query properties by quality score: %w", err)
+	}
+	defer rows.Close()
+
+	results, skipped, err := scanRows(rows, s.config.SkipBadRows, func(r rowScanner) (*PropertyWithQualityScore, error) {
+		result := &PropertyWithQualityScore{}
+		property, err := scanProperty(r, &result.QualityScore)
+		if err != nil {
+			return nil, err
+		}
+		result.Property = property
+		return result, nil
+	})
+	logSkippedRows(ctx, "properties", skipped)
+
+	return results, err
+}
+
+// earthRadiusKm is the mean Earth radius used by distance computations
+const earthRadiusKm = 6371.0
+
+// haversineKm returns the great-circle distance in kilometers between two points given in degrees.
+// It mirrors the distance expression of GetNearbyProperties term for term.
+func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+	a := math.Pow(math.Sin(toRadians(lat2-lat1)/2), 2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Pow(math.Sin(toRadians(lon2-lon1)/2), 2)
+	return earthRadiusKm * 2 * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// boundingBox returns the latitude and longitude ranges, in degrees, enclosing every point within radiusKm of the
+// given point. The longitude range spans the whole globe when the circle reaches a pole or crosses the antimeridian,
+// so the box never leaves out a point of the circle.
+func boundingBox(latitude, longitude, radiusKm float64) (minLat, maxLat, minLon, maxLon float64) {
+	angular := radiusKm / earthRadiusKm
+	latDelta := angular * 180 / math.Pi
+	minLat, maxLat = math.Max(latitude-latDelta, -90), math.Min(latitude+latDelta, 90)
+
+	ratio := math.Sin(angular) / math.Cos(latitude*math.Pi/180)
+	if angular >= math.Pi/2 || ratio >= 1 {
+		return minLat, maxLat, -180, 180
+	}
+	lonDelta := math.Asin(ratio) * 180 / math.Pi
+	minLon, maxLon = longitude-lonDelta, longitude+lonDelta
+	if minLon < -180 || maxLon > 180 {
+		return minLat, maxLat, -180, 180
+	}
+	return minLat, maxLat, minLon, maxLon
+}
+
+// GetNearbyProperties retrieves the properties within radiusKm of the given property, nearest first, excluding the
+// property itself. Distances are haversine great-circle distances. Properties without coordinates (0, 0) are never
+// nearby, and a property without coordinates has no nearby properties. Candidates are first narrowed to the
+// bounding box of the radius so the location index is used before distances are computed. Returns
+// ErrPropertyNotFound when no property has the given hotel ID.
+func (s *storage) GetNearbyProperties(ctx context.Context, hotelID int64, radiusKm float64, limit int) ([]*PropertyWithDistance, error) {
+	var latitude, longitude float64
+	err := s.db.QueryRowContext(ctx,
+		`SELECT latitude, longitude FROM properties WHERE hotel_id = $1`, hotelID,
+	).Scan(&latitude, &longitude)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrPropertyNotFound
+		}
+		return nil, fmt.Errorf("failed to get property coordinates: %w", err)
+	}
+	if latitude == 0 && longitude == 0 {
+		return []*PropertyWithDistance{}, nil
+	}
+
+	query := `SELECT ` + selectPropertyColumns("p") + `, p.distance_km
+		FROM (
+			SELECT *, $1 * 2 * ASIN(LEAST(1, SQRT(
+				POWER(SIN(RADIANS(latitude - $2) / 2), 2) +
+				COS(RADIANS($2)) * COS(RADIANS(latitude)) * POWER(SIN(RADIANS(longitude - $3) / 2), 2)
+			))) AS distance_km
+			FROM properties
+			WHERE hotel_id <> $4
+				AND latitude BETWEEN $5 AND $6 AND longitude BETWEEN $7 AND $8
+				AND NOT (latitude = 0 AND longitude = 0)
+		) p
+		WHERE p.distance_km <= $9
+		ORDER BY p.distance_km ASC, p.hotel_id ASC
+		LIMIT $10
+	`
+
+	minLat, maxLat, minLon, maxLon := boundingBox(latitude, longitude, radiusKm)
+	rows, err := s.db.QueryContext(ctx, query, earthRadiusKm, latitude, longitude, hotelID,
+		minLat, maxLat, minLon, maxLon, radiusKm, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query nearby properties: %w", err)
+	}
+	defer rows.Close()
+
+	results, skipped, err := scanRows(rows, s.config.SkipBadRows, func(r rowScanner) (*PropertyWithDistance, error) {
+		result := &PropertyWithDistance{}
+		property, err := scanProperty(r, &result.DistanceKm)
+		if err != nil {
+			return nil, err
+		}
+		result.Property = property
+		return result, nil
+	})
+	logSkippedRows(ctx, "properties", skipped)
+
+	return results, err
+}
+
+// GetTopRatedByCity retrieves the best rated property of each city, best first, up to limit cities.
+// Ties within a city are broken by review count then hotel ID, so the same property is picked on every call;
+// properties without a city are left out.
+func (s *storage) GetTopRatedByCity(ctx context.Context, limit int) ([]*cupid.Property, error) {
+	query := `SELECT ` + selectPropertyColumns("p") + `
+		FROM (
+			SELECT *, ROW_NUMBER() OVER (
+				PARTITION BY city
+				ORDER BY rating DESC, review_count DESC, hotel_id ASC
+			) AS city_rank
+			FROM properties
+			WHERE city <> ''
+		) p
+		WHERE p.city_rank = 1
+		ORDER BY p.rating DESC, p.review_count DESC, p.hotel_id ASC
+		LIMIT $1
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query top rated properties by city: %w", err)
+	}
+	defer rows.Close()
+
+	return s.scanProperties(ctx, rows)
+}
+
+// ListFacilities retrieves every distinct facility along with the number of properties offering it
+func (s *storage) ListFacilities(ctx context.Context) ([]FacilitySummary, error) {
+	query := `
+		SELECT facility_id, COALESCE(MAX(name), ''), COUNT(*) AS property_count
+		FROM property_facilities
+		GROUP BY facility_id
+		ORDER BY property_count DESC, facility_id ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	facilities, skipped, err := scanRows(rows, s.config.SkipBadRows, func(r rowScanner) (FacilitySummary, error) {
+		var facility FacilitySummary
+		err := r.Scan(&facility.FacilityID, &facility.Name, &facility.PropertyCount)
+		return facility, err
+	})
+	logSkippedRows(ctx, "property_facilities", skipped)
+
+	return facilities, err
+}
+
+// ListRoomAmenities retrieves every distinct room amenity along with the number of properties having rooms offering it
+func (s *storage) ListRoomAmenities(ctx context.Context) ([]RoomAmenitySummary, error) {
+	query := `
+		SELECT amenity_id, COALESCE(MAX(name), ''), COUNT(*) AS property_count
+		FROM property_room_amenities
+		GROUP BY amenity_id
+		ORDER BY property_count DESC, amenity_id ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	amenities, skipped, err := scanRows(rows, s.config.SkipBadRows, func(r rowScanner) (RoomAmenitySummary, error) {
+		var amenity RoomAmenitySummary
+		err := r.Scan(&amenity.AmenityID, &amenity.Name, &amenity.PropertyCount)
+		return amenity, err
+	})
+	logSkippedRows(ctx, "property_room_amenities", skipped)
+
+	return amenities, err
+}
+
+// ListChains retrieves every distinct hotel chain along with its number of properties, largest first.
+// Properties without a chain are left out.
+func (s *storage) ListChains(ctx context.Context) ([]ChainSummary, error) {
+	query := `
+		SELECT chain, COUNT(*) AS property_count
+		FROM properties
+		WHERE chain <> ''
+		GROUP BY chain
+		ORDER BY property_count DESC, chain ASC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	chains, skipped, err := scanRows(rows, s.config.SkipBadRows, func(r rowScanner) (ChainSummary, error) {
+		var chain ChainSummary
+		err := r.Scan(&chain.Chain, &chain.PropertyCount)
+		return chain, err
+	})
+	logSkippedRows(ctx, "properties", skipped)
+
+	return chains, err
+}
+
+// GetAverageRatingByStars retrieves the average guest rating of each star category, lowest stars first.
+// Properties without stars or with a zero rating (not yet rated) are left out so they do not skew the averages.
+func (s *storage) GetAverageRatingByStars(ctx context.Context) ([]StarRatingAverage, error) {
+	query := `
+		SELECT stars, AVG(rating), COUNT(*)
+		FROM properties
+		WHERE stars IS NOT NULL AND rating > 0
+		GROUP BY stars
+		ORDER BY stars
+	`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	averages, skipped, err := scanRows(rows, s.config.SkipBadRows, func(r rowScanner) (StarRatingAverage, error) {
+		var average StarRatingAverage
+		err := r.Scan(&average.Stars, &average.AverageRating, &average.PropertyCount)
+		return average, err
+	})
+	logSkippedRows(ctx, "properties", skipped)
+
+	return averages, err
+}
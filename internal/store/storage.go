@@ -2,56 +2,339 @@ package store
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"time"
 
 	"github.com/barimehdi77/cupid-api/internal/cupid"
 	"github.com/barimehdi77/cupid-api/internal/database"
 )
 
+// ErrPropertyNotFound is returned when no property exists with the requested hotel ID
+var ErrPropertyNotFound = errors.New("property not found")
+
+// ErrPropertyDetailsTooLarge is returned when the serialized property details exceed Config.MaxDetailsBytes
+var ErrPropertyDetailsTooLarge = errors.New("property details too large")
+
+// ErrRawPropertyNotCaptured is returned when a property exists but no raw Cupid API response was stored for it
+var ErrRawPropertyNotCaptured = errors.New("raw property response not captured")
+
 // Storage interface defines all storage operations
 type Storage interface {
 	// Property operations
 	StoreProperty(ctx context.Context, propertyData *cupid.PropertyData) error
+	StorePropertiesBatch(ctx context.Context, properties []*cupid.PropertyData) (map[int64]error, error)
 	GetProperty(ctx context.Context, hotelID int64) (*cupid.PropertyData, error)
+	GetPropertySummary(ctx context.Context, hotelID int64) (*cupid.Property, error)
+	CompareStoredPropertyDetails(ctx context.Context, propertyData *cupid.PropertyData) ([]string, error)
 	ListProperties(ctx context.Context, limit, offset int, filters PropertyFilters) ([]*cupid.Property, error)
+	ListPropertiesWithReviewAverages(ctx context.Context, limit, offset int, filters PropertyFilters) ([]*PropertyWithReviewAverage, error)
 	CountProperties(ctx context.Context, filters PropertyFilters) (int, error)
+	GetPropertyFacets(ctx context.Context, filters PropertyFilters) (*PropertyFacets, error)
 	UpdateProperty(ctx context.Context, hotelID int64, propertyData *cupid.PropertyData) error
 	DeleteProperty(ctx context.Context, hotelID int64) error
 
+	// Override operations
+	SetPropertyOverrides(ctx context.Context, hotelID int64, overrides map[string]*string) error
+	GetPropertyOverrides(ctx context.Context, hotelID int64) (map[string]string, error)
+
 	// Review operations
 	GetPropertyReviews(ctx context.Context, hotelID int64) ([]cupid.Review, error)
+	ListPropertyReviews(ctx context.Context, hotelID int64, filters ReviewFilters) ([]cupid.Review, error)
+	CountPropertyReviews(ctx context.Context, hotelID int64, filters ReviewFilters) (int, error)
+	GetReviewStats(ctx context.Context, hotelID int64) (*ReviewStats, error)
 	GetReviewsByScore(ctx context.Context, minScore, maxScore int, limit, offset int) ([]cupid.Review, error)
+	ImportReviews(ctx context.Context, hotelID int64, reviews []cupid.Review) (*ReviewImportResult, error)
+	GetPropertiesWithOldestReviews(ctx context.Context, limit int) ([]*PropertyReviewAge, error)
 
 	// Translation operations
 	GetPropertyTranslations(ctx context.Context, hotelID int64) (map[string]*cupid.Property, error)
 	GetTranslationByLanguage(ctx context.Context, hotelID int64, language string) (*cupid.Property, error)
+	GetTranslationCounts(ctx context.Context, hotelIDs []int64) (map[int64]int, error)
+	GetPropertiesWithStaleTranslations(ctx context.Context, olderThan time.Time, limit int) ([]*PropertyTranslationAge, error)
+	GetStaleProperties(ctx context.Context, olderThan time.Duration, limit int) ([]*cupid.Property, error)
+
+	// Facility operations
+	ListFacilities(ctx context.Context) ([]FacilitySummary, error)
+	ListRoomAmenities(ctx context.Context) ([]RoomAmenitySummary, error)
+	ListChains(ctx context.Context) ([]ChainSummary, error)
+
+	// Statistics operations
+	GetAverageRatingByStars(ctx context.Context) ([]StarRatingAverage, error)
 
 	// Search operations
-	SearchProperties(ctx context.Context, query string, limit, offset int) ([]*cupid.Property, error)
-	CountSearchProperties(ctx context.Context, query string) (int, error)
+	SearchProperties(ctx context.Context, query string, fields []string, fuzzy bool, limit, offset int) ([]*cupid.Property, error)
+	CountSearchProperties(ctx context.Context, query string, fields []string, fuzzy bool) (int, error)
 	GetPropertiesByLocation(ctx context.Context, city, country string, limit, offset int) ([]*cupid.Property, error)
 	CountPropertiesByLocation(ctx context.Context, city, country string) (int, error)
+	GetPropertiesByCountries(ctx context.Context, countries []string) ([]*cupid.Property, error)
+	GetPropertiesByPostalCodePrefix(ctx context.Context, prefix string, limit, offset int) ([]*cupid.Property, error)
+	CountPropertiesByPostalCodePrefix(ctx context.Context, prefix string) (int, error)
 	GetPropertiesByRating(ctx context.Context, minRating float64, limit, offset int) ([]*cupid.Property, error)
 	CountPropertiesByRating(ctx context.Context, minRating float64) (int, error)
+	GetPropertiesByQualityScore(ctx context.Context, limit, offset int) ([]*PropertyWithQualityScore, error)
+	GetTopRatedByCity(ctx context.Context, limit int) ([]*cupid.Property, error)
+	GetNearbyProperties(ctx context.Context, hotelID int64, radiusKm float64, limit int) ([]*PropertyWithDistance, error)
+
+	// Sync history operations
+	RecordPropertySync(ctx context.Context, hotelID int64, changes []string) error
+	GetPropertySyncHistory(ctx context.Context, hotelID int64, limit int) ([]PropertySyncEntry, error)
+	GetRawProperty(ctx context.Context, hotelID int64) (json.RawMessage, error)
+	MarkPropertySynced(ctx context.Context, hotelID int64, raw json.RawMessage) error
+
+	// Sync lock operations
+	TryAcquireSyncLock(ctx context.Context) (release func(), acquired bool, err error)
+
+	// Sync log operations
+	CreateSyncLog(ctx context.Context, entry *SyncLogRecord) error
+	UpdateSyncLog(ctx context.Context, entry *SyncLogRecord) error
+	ListSyncLogs(ctx context.Context, filters SyncLogFilters) ([]SyncLogRecord, error)
+	EachSyncLog(ctx context.Context, filters SyncLogFilters, fn func(SyncLogRecord) error) error
+	CountSyncLogs(ctx context.Context, filters SyncLogFilters) (int, error)
+
+	// Maintenance operations
+	PurgeOrphans(ctx context.Context) (*OrphanPurgeResult, error)
+	DeletePropertyReviewsOlderThan(ctx context.Context, cutoff time.Time) (int64, error)
+	GetPropertiesWithDuplicateCoordinates(ctx context.Context) ([]CoordinateCluster, error)
+	GetPropertiesWithConflictingChains(ctx context.Context) ([]ChainConflict, error)
 }
 
-// PropertyFilters contains filtering options for property queries
+// PropertyFilters contains filtering options for property queries.
+// The star and rating bounds are inclusive and applied whenever they are set, so a zero bound is honored.
 type PropertyFilters struct {
+	// HotelIDs restricts results to the listed properties
+	HotelIDs  []int64
 	City      string
 	Country   string
-	MinStars  int
-	MaxStars  int
-	MinRating float64
-	MaxRating float64
+	MinStars  *int
+	MaxStars  *int
+	MinRating *float64
+	MaxRating *float64
 	HotelType string
-	Chain     string
+	// Chain matches the whole chain name, case-insensitively, so the values listed by ListChains can be used as is
+	Chain string
+	// MinPrice and MaxPrice bound the lowest nightly rate; properties without a known rate never match them
+	MinPrice *float64
+	MaxPrice *float64
+	// Currency matches the currency of the lowest nightly rate, case-insensitively
+	Currency string
+	// PostalCodePrefix matches postal codes starting with the prefix, case-insensitively
+	PostalCodePrefix string
+	// FacilityIDs restricts results to properties having every listed facility
+	FacilityIDs []int
+	// RoomAmenityIDs restricts results to properties where every listed amenity is offered by at least one room
+	RoomAmenityIDs []int
+	// After restricts list results to properties sorting strictly after the cursor (keyset pagination).
+	// Count queries ignore it.
+	After *PropertyCursor
+}
+
+// PropertyCursor is the sort key of a property in listings, ordered by rating, review count
+// and hotel ID, all descending
+type PropertyCursor struct {
+	Rating      float64
+	ReviewCount int
+	HotelID     int64
+}
+
+// Review sort fields accepted in ReviewFilters.SortBy
+const (
+	ReviewSortDate  = "date"
+	ReviewSortScore = "score"
+)
+
+// ReviewFilters contains filtering, ordering and pagination options for review queries
+type ReviewFilters struct {
+	Type     string
+	Language string
+	Country  string
+	MinScore int
+	MaxScore int
+	// SortBy is ReviewSortDate (default) or ReviewSortScore; ties are broken by review ID
+	SortBy string
+	// SortAscending reverses the default newest/highest first order
+	SortAscending bool
+	Limit         int
+	Offset        int
+}
+
+// RatingScale names the scale review averages are reported on, the same as property ratings
+const RatingScale = "0-10"
+
+// ReviewStats summarizes the stored reviews of a property.
+// AverageScore is normalized onto Scale; the distribution keeps the stored scores.
+type ReviewStats struct {
+	AverageScore float64 `json:"average_score"`
+	Scale        string  `json:"scale"`
+	Count        int     `json:"count"`
+	// Distribution holds the number of reviews for each score from 1 to 10, in order
+	Distribution []ScoreBucket `json:"distribution"`
+}
+
+// ScoreBucket is the number of reviews with a given score
+type ScoreBucket struct {
+	Score int `json:"score"`
+	Count int `json:"count"`
+}
+
+// FacilitySummary describes a facility and how many properties offer it
+type FacilitySummary struct {
+	FacilityID    int    `json:"facility_id"`
+	Name          string `json:"name"`
+	PropertyCount int    `json:"property_count"`
+}
+
+// RoomAmenitySummary describes a room amenity and how many properties have rooms offering it
+type RoomAmenitySummary struct {
+	AmenityID     int    `json:"amenity_id"`
+	Name          string `json:"name"`
+	PropertyCount int    `json:"property_count"`
+}
+
+// ReviewImportResult counts the reviews written by an import
+type ReviewImportResult struct {
+	Inserted int `json:"inserted"`
+	Updated  int `json:"updated"`
+}
+
+// FacetCount is the number of matching properties having a value of a facet column
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// PropertyFacets are the counts of matching properties per hotel type, star category and country,
+// each ordered by count, largest first
+type PropertyFacets struct {
+	HotelTypes []FacetCount `json:"hotel_types"`
+	Stars      []FacetCount `json:"stars"`
+	Countries  []FacetCount `json:"countries"`
+}
+
+// ChainSummary describes a hotel chain and how many properties belong to it
+type ChainSummary struct {
+	Chain         string `json:"chain"`
+	PropertyCount int    `json:"property_count"`
+}
+
+// StarRatingAverage is the average guest rating of the rated properties in a star category
+type StarRatingAverage struct {
+	Stars         int     `json:"stars"`
+	AverageRating float64 `json:"average_rating"`
+	PropertyCount int     `json:"property_count"`
+}
+
+// PropertyWithReviewAverage pairs a property with the average score of its stored reviews.
+// ComputedRating is normalized onto RatingScale and is nil when the property has no stored reviews.
+type PropertyWithReviewAverage struct {
+	Property       *cupid.Property `json:"property"`
+	ComputedRating *float64        `json:"computed_rating"`
+}
+
+// PropertyWithQualityScore pairs a property with the composite quality score it was ranked by
+type PropertyWithQualityScore struct {
+	Property     *cupid.Property `json:"property"`
+	QualityScore float64         `json:"quality_score"`
+}
+
+// PropertyWithDistance pairs a property with its great-circle distance from the property it was found near
+type PropertyWithDistance struct {
+	Property   *cupid.Property `json:"property"`
+	DistanceKm float64         `json:"distance_km"`
+}
+
+// PropertyReviewAge pairs a property with the date of its most recent stored review
+type PropertyReviewAge struct {
+	Property         *cupid.Property `json:"property"`
+	LatestReviewDate *time.Time      `json:"latest_review_date"`
+}
+
+// PropertyTranslationAge pairs a property with the last refresh time of its least recently updated translation.
+// TranslationsUpdatedAt is nil when the property has no stored translations.
+type PropertyTranslationAge struct {
+	Property              *cupid.Property `json:"property"`
+	TranslationsUpdatedAt *time.Time      `json:"translations_updated_at"`
+}
+
+// OrphanPurgeResult holds the number of orphaned rows deleted from each child table
+type OrphanPurgeResult struct {
+	Reviews            int64 `json:"reviews"`
+	Translations       int64 `json:"translations"`
+	PropertyDetails    int64 `json:"property_details"`
+	PropertyFacilities int64 `json:"property_facilities"`
+	RoomAmenities      int64 `json:"property_room_amenities"`
+}
+
+// CoordinateCluster is a group of properties located at exactly the same coordinates
+type CoordinateCluster struct {
+	Latitude   float64           `json:"latitude"`
+	Longitude  float64           `json:"longitude"`
+	Properties []*cupid.Property `json:"properties"`
+}
+
+// Chain conflict kinds reported in ChainConflict.Field
+const (
+	ChainConflictName = "chain"
+	ChainConflictID   = "chain_id"
+)
+
+// ChainConflict is a group of properties on which upstream disagrees about a hotel chain.
+// When Field is ChainConflictName, the properties share Chain but have several chain IDs;
+// when it is ChainConflictID, they share ChainID but have several chain names.
+type ChainConflict struct {
+	Field      string            `json:"field"`
+	Chain      string            `json:"chain,omitempty"`
+	ChainID    int               `json:"chain_id,omitempty"`
+	Properties []*cupid.Property `json:"properties"`
+}
+
+// PropertySyncEntry is one recorded sync of a property.
+// Changes lists the parts of the property that changed and is empty when the sync found nothing new.
+type PropertySyncEntry struct {
+	SyncedAt time.Time `json:"synced_at"`
+	Changes  []string  `json:"changes"`
+}
+
+// SyncLogRecord is a persisted synchronization run from the sync_logs table
+type SyncLogRecord struct {
+	SyncID            string
+	SyncType          string
+	Status            string
+	StartedAt         time.Time
+	CompletedAt       *time.Time
+	TotalProperties   int
+	UpdatedProperties int
+	FailedProperties  int
+	ErrorMessage      string
+}
+
+// SyncLogFilters contains filtering options for sync log queries
+type SyncLogFilters struct {
+	// From and To bound started_at; From is inclusive, To is exclusive and zero values are not applied
+	From time.Time
+	To   time.Time
+	// Limit caps the number of logs returned; zero returns every matching log
+	Limit  int
+	Offset int
+	// NewestFirst orders logs by start time descending instead of ascending
+	NewestFirst bool
 }
 
 // storage implements the Storage interface
 type storage struct {
-	db *database.DB
+	db     *database.DB
+	config *Config
 }
 
-// NewStorage creates a new storage instance
+// NewStorage creates a new storage instance with default configuration
 func NewStorage(db *database.DB) Storage {
-	return &storage{db: db}
+	return NewStorageWithConfig(db, nil)
+}
+
+// NewStorageWithConfig creates a new storage instance with custom configuration
+func NewStorageWithConfig(db *database.DB, config *Config) Storage {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	return &storage{db: db, config: config}
 }
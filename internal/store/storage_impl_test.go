@@ -1,13 +1,50 @@
 package store
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"testing"
+	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/barimehdi77/cupid-api/internal/cupid"
+	"github.com/barimehdi77/cupid-api/internal/database"
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
 )
 
+// newMockStorage creates a storage backed by sqlmock so queries and row scanning can be tested without a database
+func newMockStorage(t *testing.T) (*storage, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return &storage{db: &database.DB{DB: db}, config: DefaultConfig()}, mock
+}
+
+// propertyRow returns the property column values for a sqlmock row, in propertyColumns order
+func propertyRow(hotelID int64, name string, rating float64, reviewCount int) []driver.Value {
+	return []driver.Value{
+		hotelID, hotelID, name, "hotel", 1,
+		"Test Chain", 1, 48.8566, 2.3522, 4, rating, reviewCount,
+		"CDG", "8 Rue de Test", "Paris", "Île-de-France", "fr", "75008", "https://example.com/image.jpg",
+		"+33 1 23 45 67 89", "", "info@example.com", "A test hotel", "A **test** hotel", "",
+		"Free parking", nil, true, nil, nil, "", nil,
+	}
+}
+
 // getSamplePropertyData creates sample property data for testing
 func getSamplePropertyData() *cupid.PropertyData {
 	return &cupid.PropertyData{
@@ -116,6 +153,10 @@ func boolPtr(b bool) *bool {
 	return &b
 }
 
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
 // TestStorage_StoreProperty tests the StoreProperty method
 func TestStorage_StoreProperty(t *testing.T) {
 	t.Run("ValidPropertyData", func(t *testing.T) {
@@ -171,6 +212,189 @@ func TestStorage_GetProperty(t *testing.T) {
 	})
 }
 
+// TestStorage_GetRawProperty tests reading the captured raw Cupid API response of a property
+func TestStorage_GetRawProperty(t *testing.T) {
+	query := `SELECT d.raw_property_json\s+FROM properties p\s+LEFT JOIN property_details d ON d.property_id = p.hotel_id\s+WHERE p.hotel_id = \$1`
+
+	t.Run("Captured", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(query).
+			WithArgs(int64(12345)).
+			WillReturnRows(sqlmock.NewRows([]string{"raw_property_json"}).AddRow([]byte(`{"hotel_id":12345}`)))
+
+		// Act
+		raw, err := s.GetRawProperty(context.Background(), 12345)
+
+		// Assert
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"hotel_id":12345}`, string(raw))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NotCaptured", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(query).
+			WithArgs(int64(12345)).
+			WillReturnRows(sqlmock.NewRows([]string{"raw_property_json"}).AddRow(nil))
+
+		// Act
+		_, err := s.GetRawProperty(context.Background(), 12345)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrRawPropertyNotCaptured)
+	})
+
+	t.Run("PropertyNotFound", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(query).
+			WithArgs(int64(12345)).
+			WillReturnRows(sqlmock.NewRows([]string{"raw_property_json"}))
+
+		// Act
+		_, err := s.GetRawProperty(context.Background(), 12345)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrPropertyNotFound)
+	})
+}
+
+// TestStorage_StoreRawProperty tests that a captured response is stored and an oversized one skipped
+func TestStorage_StoreRawProperty(t *testing.T) {
+	logger.Logger = zap.NewNop()
+
+	t.Run("Stored", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE property_details SET raw_property_json = \$2 WHERE property_id = \$1`).
+			WithArgs(int64(12345), []byte(`{"hotel_id":12345}`)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		tx, err := s.db.BeginTx(context.Background(), nil)
+		require.NoError(t, err)
+
+		// Act
+		err = s.storeRawProperty(context.Background(), tx, 12345, json.RawMessage(`{"hotel_id":12345}`))
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Oversized", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		s.config.MaxDetailsBytes = 8
+		mock.ExpectBegin()
+		tx, err := s.db.BeginTx(context.Background(), nil)
+		require.NoError(t, err)
+
+		// Act
+		err = s.storeRawProperty(context.Background(), tx, 12345, json.RawMessage(`{"hotel_id":12345}`))
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestStorage_GetPropertyCheckIn tests reading the check-in information from the JSONB details
+func TestStorage_GetPropertyCheckIn(t *testing.T) {
+	t.Run("Stored", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`SELECT checkin_info FROM property_details WHERE property_id = \$1`).
+			WithArgs(int64(12345)).
+			WillReturnRows(sqlmock.NewRows([]string{"checkin"}).
+				AddRow([]byte(`{"checkin_start":"15:00","checkin_end":"23:00","checkout":"11:00","special_instructions":"Key box","instructions":[{"id":1,"instruction":"Photo ID required"}]}`)))
+
+		// Act
+		checkIn, err := s.getPropertyCheckIn(context.Background(), 12345)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "15:00", checkIn.CheckInStart)
+		assert.Equal(t, "23:00", checkIn.CheckInEnd)
+		assert.Equal(t, "11:00", checkIn.Checkout)
+		assert.Equal(t, "Key box", checkIn.SpecialInstructions)
+		assert.Equal(t, []cupid.Instruction{{ID: 1, Instruction: "Photo ID required"}}, checkIn.Instructions)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NoDetails", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`FROM property_details`).
+			WithArgs(int64(12345)).
+			WillReturnRows(sqlmock.NewRows([]string{"checkin"}))
+
+		// Act
+		checkIn, err := s.getPropertyCheckIn(context.Background(), 12345)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, cupid.CheckIn{}, checkIn)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NullCheckIn", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`FROM property_details`).
+			WithArgs(int64(12345)).
+			WillReturnRows(sqlmock.NewRows([]string{"checkin"}).AddRow(nil))
+
+		// Act
+		checkIn, err := s.getPropertyCheckIn(context.Background(), 12345)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, cupid.CheckIn{}, checkIn)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestStorage_GetPropertySummary tests that the summary is loaded with a single query
+func TestStorage_GetPropertySummary(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		lastSyncedAt := time.Date(2025, 9, 12, 6, 0, 0, 0, time.UTC)
+		row := propertyRow(12345, "Test Hotel", 8.5, 10)
+		row[len(row)-1] = lastSyncedAt
+		mock.ExpectQuery(`SELECT .* FROM properties\s+WHERE hotel_id = \$1`).
+			WithArgs(int64(12345)).
+			WillReturnRows(sqlmock.NewRows(propertyColumns).AddRow(row...))
+
+		// Act
+		property, err := s.GetPropertySummary(context.Background(), 12345)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "Test Hotel", property.HotelName)
+		require.NotNil(t, property.LastSyncedAt)
+		assert.Equal(t, lastSyncedAt, *property.LastSyncedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`SELECT .* FROM properties`).
+			WithArgs(int64(99999)).
+			WillReturnRows(sqlmock.NewRows(propertyColumns))
+
+		// Act
+		_, err := s.GetPropertySummary(context.Background(), 99999)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrPropertyNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 // TestStorage_ListProperties tests the ListProperties method
 func TestStorage_ListProperties(t *testing.T) {
 	t.Run("ValidFilters", func(t *testing.T) {
@@ -178,10 +402,10 @@ func TestStorage_ListProperties(t *testing.T) {
 		filters := PropertyFilters{
 			City:      "Paris",
 			Country:   "France",
-			MinStars:  4,
-			MaxStars:  5,
-			MinRating: 4.0,
-			MaxRating: 5.0,
+			MinStars:  intPtr(4),
+			MaxStars:  intPtr(5),
+			MinRating: float64Ptr(4.0),
+			MaxRating: float64Ptr(5.0),
 		}
 		limit := 10
 		offset := 0
@@ -189,10 +413,10 @@ func TestStorage_ListProperties(t *testing.T) {
 		// Act & Assert
 		assert.Equal(t, "Paris", filters.City)
 		assert.Equal(t, "France", filters.Country)
-		assert.Equal(t, 4, filters.MinStars)
-		assert.Equal(t, 5, filters.MaxStars)
-		assert.Equal(t, 4.0, filters.MinRating)
-		assert.Equal(t, 5.0, filters.MaxRating)
+		assert.Equal(t, 4, *filters.MinStars)
+		assert.Equal(t, 5, *filters.MaxStars)
+		assert.Equal(t, 4.0, *filters.MinRating)
+		assert.Equal(t, 5.0, *filters.MaxRating)
 		assert.Equal(t, 10, limit)
 		assert.Equal(t, 0, offset)
 	})
@@ -206,10 +430,10 @@ func TestStorage_ListProperties(t *testing.T) {
 		// Act & Assert
 		assert.Empty(t, filters.City)
 		assert.Empty(t, filters.Country)
-		assert.Equal(t, 0, filters.MinStars)
-		assert.Equal(t, 0, filters.MaxStars)
-		assert.Equal(t, 0.0, filters.MinRating)
-		assert.Equal(t, 0.0, filters.MaxRating)
+		assert.Nil(t, filters.MinStars)
+		assert.Nil(t, filters.MaxStars)
+		assert.Nil(t, filters.MinRating)
+		assert.Nil(t, filters.MaxRating)
 		assert.Equal(t, 20, limit)
 		assert.Equal(t, 0, offset)
 	})
@@ -239,6 +463,54 @@ func TestStorage_CountProperties(t *testing.T) {
 	})
 }
 
+// TestStorage_GetPropertyFacets tests counting matching properties per hotel type, stars and country
+func TestStorage_GetPropertyFacets(t *testing.T) {
+	columns := []string{"value", "property_count"}
+
+	t.Run("HonorsFilters", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		minStars := 3
+		filters := PropertyFilters{City: "Paris", MinStars: &minStars}
+
+		mock.ExpectQuery(`SELECT hotel_type::text, COUNT\(\*\) AS property_count\s+FROM properties\s+WHERE hotel_type IS NOT NULL AND hotel_type::text <> '' AND city ILIKE \$1 AND stars >= \$2\s+GROUP BY hotel_type\s+ORDER BY property_count DESC, hotel_type ASC`).
+			WithArgs("%Paris%", 3).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("Hotels", 120).AddRow("Apartments", 30))
+		mock.ExpectQuery(`SELECT stars::text, COUNT\(\*\) AS property_count\s+FROM properties\s+WHERE stars IS NOT NULL AND stars::text <> '' AND city ILIKE \$1 AND stars >= \$2\s+GROUP BY stars`).
+			WithArgs("%Paris%", 3).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("4", 90).AddRow("3", 60))
+		mock.ExpectQuery(`SELECT country::text, COUNT\(\*\) AS property_count\s+FROM properties\s+WHERE country IS NOT NULL AND country::text <> '' AND city ILIKE \$1 AND stars >= \$2\s+GROUP BY country`).
+			WithArgs("%Paris%", 3).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("fr", 150))
+
+		// Act
+		facets, err := s.GetPropertyFacets(context.Background(), filters)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []FacetCount{{Value: "Hotels", Count: 120}, {Value: "Apartments", Count: 30}}, facets.HotelTypes)
+		assert.Equal(t, []FacetCount{{Value: "4", Count: 90}, {Value: "3", Count: 60}}, facets.Stars)
+		assert.Equal(t, []FacetCount{{Value: "fr", Count: 150}}, facets.Countries)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("QueryError", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`GROUP BY hotel_type`).WillReturnRows(sqlmock.NewRows(columns))
+		mock.ExpectQuery(`GROUP BY stars`).WillReturnError(errors.New("connection reset"))
+
+		// Act
+		facets, err := s.GetPropertyFacets(context.Background(), PropertyFilters{})
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to count properties by stars")
+		assert.Nil(t, facets)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 // TestStorage_UpdateProperty tests the UpdateProperty method
 func TestStorage_UpdateProperty(t *testing.T) {
 	t.Run("ValidUpdate", func(t *testing.T) {
@@ -280,6 +552,52 @@ func TestStorage_DeleteProperty(t *testing.T) {
 		// Act & Assert
 		assert.Equal(t, int64(0), hotelID)
 	})
+
+	t.Run("DeletesChildrenThenParent", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+
+		mock.ExpectBegin()
+		for _, table := range []string{"reviews", "translations", "property_details", "property_facilities", "property_room_amenities"} {
+			mock.ExpectExec(`DELETE FROM ` + table + ` WHERE property_id = \$1`).
+				WithArgs(int64(12345)).
+				WillReturnResult(sqlmock.NewResult(0, 2))
+		}
+		mock.ExpectExec(`DELETE FROM properties WHERE hotel_id = \$1`).
+			WithArgs(int64(12345)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		// Act
+		err := s.DeleteProperty(context.Background(), 12345)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NotFoundRollsBack", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+
+		mock.ExpectBegin()
+		for _, table := range []string{"reviews", "translations", "property_details", "property_facilities", "property_room_amenities"} {
+			mock.ExpectExec(`DELETE FROM ` + table).
+				WithArgs(int64(99999)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+		mock.ExpectExec(`DELETE FROM properties WHERE hotel_id = \$1`).
+			WithArgs(int64(99999)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		// Act
+		err := s.DeleteProperty(context.Background(), 99999)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrPropertyNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
 }
 
 // TestStorage_GetPropertyReviews tests the GetPropertyReviews method
@@ -353,6 +671,58 @@ func TestStorage_GetPropertyTranslations(t *testing.T) {
 	})
 }
 
+// TestStorage_GetTranslationCounts tests counting the translations of several properties
+func TestStorage_GetTranslationCounts(t *testing.T) {
+	t.Run("CountsPerProperty", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`SELECT p\.hotel_id, COUNT\(t\.language\)\s+FROM properties p\s+LEFT JOIN translations t ON t\.property_id = p\.hotel_id\s+WHERE p\.hotel_id = ANY\(\$1\)\s+GROUP BY p\.hotel_id`).
+			WithArgs(pq.Array([]int64{1, 2, 3, 99})).
+			WillReturnRows(sqlmock.NewRows([]string{"hotel_id", "count"}).
+				AddRow(1, 5).
+				AddRow(2, 1).
+				AddRow(3, 0))
+
+		// Act
+		counts, err := s.GetTranslationCounts(context.Background(), []int64{1, 2, 3, 99})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, map[int64]int{1: 5, 2: 1, 3: 0}, counts)
+		assert.NotContains(t, counts, int64(99))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NoHotelIDs", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+
+		// Act
+		counts, err := s.GetTranslationCounts(context.Background(), nil)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, counts)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DatabaseError", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`FROM properties p\s+LEFT JOIN translations t`).
+			WithArgs(pq.Array([]int64{1})).
+			WillReturnError(errors.New("connection refused"))
+
+		// Act
+		counts, err := s.GetTranslationCounts(context.Background(), []int64{1})
+
+		// Assert
+		assert.Nil(t, counts)
+		assert.EqualError(t, err, "connection refused")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 // TestStorage_GetTranslationByLanguage tests the GetTranslationByLanguage method
 func TestStorage_GetTranslationByLanguage(t *testing.T) {
 	t.Run("ValidParameters", func(t *testing.T) {
@@ -425,6 +795,93 @@ func TestStorage_CountSearchProperties(t *testing.T) {
 	})
 }
 
+// TestStorage_SearchProperties_Fields tests which columns a search matches
+func TestStorage_SearchProperties_Fields(t *testing.T) {
+	t.Run("AllSearchableFieldsByDefault", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		rows := sqlmock.NewRows(propertyColumns).AddRow(propertyRow(1, "Beach Hotel", 9.0, 20)...)
+		mock.ExpectQuery(`FROM properties\s+WHERE hotel_name ILIKE \$1 OR city ILIKE \$1 OR country ILIKE \$1 OR address ILIKE \$1 OR state ILIKE \$1 OR description ILIKE \$1\s+ORDER BY rating DESC, review_count DESC\s+LIMIT \$2 OFFSET \$3`).
+			WithArgs("%near the beach%", 10, 0).
+			WillReturnRows(rows)
+
+		// Act
+		properties, err := s.SearchProperties(context.Background(), "near the beach", nil, false, 10, 0)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, properties, 1)
+		assert.Equal(t, "8 Rue de Test", properties[0].Address.Address)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("RestrictedFields", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM properties WHERE address ILIKE \$1 OR state ILIKE \$1$`).
+			WithArgs("%Rue de Rivoli%").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		// Act
+		count, err := s.CountSearchProperties(context.Background(), "Rue de Rivoli", []string{"address", "state"}, false)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Fuzzy", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		s.config.SearchSimilarityThreshold = 0.3
+		rows := sqlmock.NewRows(propertyColumns).AddRow(propertyRow(1, "Hilton Paris", 9.0, 20)...)
+		expectThreshold := func() {
+			mock.ExpectBegin()
+			mock.ExpectExec(`SELECT set_config\('pg_trgm.word_similarity_threshold', \$1, true\)`).
+				WithArgs("0.3").
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+		expectThreshold()
+		mock.ExpectQuery(`FROM properties\s+WHERE hotel_name ILIKE \$1 OR \$2 <% hotel_name OR city ILIKE \$1 OR \$2 <% city\s+`+
+			`ORDER BY GREATEST\(word_similarity\(\$2, hotel_name\), word_similarity\(\$2, city\)\) DESC, rating DESC, review_count DESC\s+LIMIT \$3 OFFSET \$4`).
+			WithArgs("%hiltn%", "hiltn", 10, 0).
+			WillReturnRows(rows)
+		mock.ExpectRollback()
+		expectThreshold()
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM properties WHERE hotel_name ILIKE \$1 OR \$2 <% hotel_name OR city ILIKE \$1 OR \$2 <% city$`).
+			WithArgs("%hiltn%", "hiltn").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectRollback()
+
+		// Act
+		properties, searchErr := s.SearchProperties(context.Background(), "hiltn", []string{"hotel_name", "city"}, true, 10, 0)
+		count, countErr := s.CountSearchProperties(context.Background(), "hiltn", []string{"hotel_name", "city"}, true)
+
+		// Assert
+		require.NoError(t, searchErr)
+		require.NoError(t, countErr)
+		require.Len(t, properties, 1)
+		assert.Equal(t, 1, count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("UnsearchableField", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+
+		// Act
+		properties, searchErr := s.SearchProperties(context.Background(), "paris", []string{"important_info"}, false, 10, 0)
+		_, countErr := s.CountSearchProperties(context.Background(), "paris", []string{"hotel_name", "phone"}, false)
+
+		// Assert
+		assert.Nil(t, properties)
+		assert.ErrorIs(t, searchErr, ErrInvalidSearchField)
+		assert.ErrorIs(t, countErr, ErrInvalidSearchField)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 // TestStorage_GetPropertiesByLocation tests the GetPropertiesByLocation method
 func TestStorage_GetPropertiesByLocation(t *testing.T) {
 	t.Run("ValidLocation", func(t *testing.T) {
@@ -513,6 +970,224 @@ func TestStorage_GetPropertiesByRating(t *testing.T) {
 	})
 }
 
+// TestStorage_GetPropertiesByQualityScore tests ranking properties by rating weighted by review volume
+func TestStorage_GetPropertiesByQualityScore(t *testing.T) {
+	columns := append(append([]string{}, propertyColumns...), "quality_score")
+
+	t.Run("OrdersByScore", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		rows := sqlmock.NewRows(columns).
+			AddRow(append(propertyRow(1, "Well Reviewed Hotel", 9.0, 500), 55.93)...)
+
+		// The ranking is done by the database, so the generated score and ORDER BY are what is asserted
+		mock.ExpectQuery(`,\s+rating \* POWER\(LN\(review_count \+ 1\), \$1\) AS quality_score\s+FROM properties\s+ORDER BY quality_score DESC, hotel_id ASC\s+LIMIT \$2 OFFSET \$3\s*$`).
+			WithArgs(1.0, 10, 0).
+			WillReturnRows(rows)
+
+		// Act
+		properties, err := s.GetPropertiesByQualityScore(context.Background(), 10, 0)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, properties, 1)
+		assert.Equal(t, int64(1), properties[0].Property.HotelID)
+		assert.Equal(t, 55.93, properties[0].QualityScore)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ConfiguredWeight", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		s.config.QualityReviewWeight = -2
+		mock.ExpectQuery(`AS quality_score`).
+			WithArgs(0.0, 20, 40).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		// Act
+		properties, err := s.GetPropertiesByQualityScore(context.Background(), 20, 40)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, properties)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestStorage_GetTopRatedByCity tests picking the best rated property of each city with deterministic ties
+func TestStorage_GetTopRatedByCity(t *testing.T) {
+	t.Run("OnePropertyPerCity", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		rows := sqlmock.NewRows(propertyColumns).
+			AddRow(propertyRow(1, "Paris Hotel", 9.5, 120)...).
+			AddRow(propertyRow(2, "Lyon Hotel", 8.7, 40)...)
+		mock.ExpectQuery(`ROW_NUMBER\(\) OVER \(\s+PARTITION BY city\s+ORDER BY rating DESC, review_count DESC, hotel_id ASC\s+\) AS city_rank\s+FROM properties\s+WHERE city <> ''\s+\) p\s+WHERE p.city_rank = 1\s+ORDER BY p.rating DESC, p.review_count DESC, p.hotel_id ASC\s+LIMIT \$1`).
+			WithArgs(10).
+			WillReturnRows(rows)
+
+		// Act
+		properties, err := s.GetTopRatedByCity(context.Background(), 10)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, properties, 2)
+		assert.Equal(t, int64(1), properties[0].HotelID)
+		assert.Equal(t, int64(2), properties[1].HotelID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("QueryError", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`city_rank`).WithArgs(5).WillReturnError(errors.New("connection reset"))
+
+		// Act
+		properties, err := s.GetTopRatedByCity(context.Background(), 5)
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to query top rated properties by city")
+		assert.Nil(t, properties)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestStorage_GetNearbyProperties tests finding the properties around a stored property
+func TestStorage_GetNearbyProperties(t *testing.T) {
+	t.Run("NearestFirstWithDistance", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`SELECT latitude, longitude FROM properties WHERE hotel_id = \$1`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"latitude", "longitude"}).AddRow(48.8566, 2.3522))
+		rows := sqlmock.NewRows(append(propertyColumns, "distance_km")).
+			AddRow(append(propertyRow(2, "Louvre Hotel", 8.8, 30), 0.4)...).
+			AddRow(append(propertyRow(3, "Bastille Hotel", 8.1, 12), 2.9)...)
+		minLat, maxLat, minLon, maxLon := boundingBox(48.8566, 2.3522, 5)
+		mock.ExpectQuery(`WHERE hotel_id <> \$4\s+AND latitude BETWEEN \$5 AND \$6 AND longitude BETWEEN \$7 AND \$8\s+AND NOT \(latitude = 0 AND longitude = 0\)\s+\) p\s+WHERE p.distance_km <= \$9\s+ORDER BY p.distance_km ASC, p.hotel_id ASC\s+LIMIT \$10`).
+			WithArgs(6371.0, 48.8566, 2.3522, int64(1), minLat, maxLat, minLon, maxLon, 5.0, 10).
+			WillReturnRows(rows)
+
+		// Act
+		properties, err := s.GetNearbyProperties(context.Background(), 1, 5, 10)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, properties, 2)
+		assert.Equal(t, int64(2), properties[0].Property.HotelID)
+		assert.Equal(t, 0.4, properties[0].DistanceKm)
+		assert.Equal(t, 2.9, properties[1].DistanceKm)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("OriginWithoutCoordinates", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`SELECT latitude, longitude FROM properties`).
+			WithArgs(int64(1)).
+			WillReturnRows(sqlmock.NewRows([]string{"latitude", "longitude"}).AddRow(0.0, 0.0))
+
+		// Act
+		properties, err := s.GetNearbyProperties(context.Background(), 1, 5, 10)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, properties)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`SELECT latitude, longitude FROM properties`).
+			WithArgs(int64(99)).
+			WillReturnRows(sqlmock.NewRows([]string{"latitude", "longitude"}))
+
+		// Act
+		properties, err := s.GetNearbyProperties(context.Background(), 99, 5, 10)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrPropertyNotFound)
+		assert.Nil(t, properties)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestHaversineKm tests the nearby distance formula against known distances
+func TestHaversineKm(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+		expected               float64
+	}{
+		{name: "SamePoint", lat1: 48.8566, lon1: 2.3522, lat2: 48.8566, lon2: 2.3522, expected: 0},
+		{name: "ParisToLondon", lat1: 48.8566, lon1: 2.3522, lat2: 51.5074, lon2: -0.1278, expected: 343.56},
+		{name: "OneDegreeOfEquator", lat1: 0, lon1: 0, lat2: 0, lon2: 1, expected: 111.19},
+		{name: "AcrossAntimeridian", lat1: 0, lon1: 179.5, lat2: 0, lon2: -179.5, expected: 111.19},
+		{name: "Antipodes", lat1: 0, lon1: 0, lat2: 0, lon2: 180, expected: math.Pi * 6371},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			distance := haversineKm(tt.lat1, tt.lon1, tt.lat2, tt.lon2)
+
+			// Assert
+			assert.InDelta(t, tt.expected, distance, 0.01)
+		})
+	}
+}
+
+// TestBoundingBox tests that the nearby prefilter box encloses the whole search radius
+func TestBoundingBox(t *testing.T) {
+	t.Run("EnclosesRadius", func(t *testing.T) {
+		// Arrange
+		latitude, longitude, radiusKm := 48.8566, 2.3522, 50.0
+
+		// Act
+		minLat, maxLat, minLon, maxLon := boundingBox(latitude, longitude, radiusKm)
+
+		// Assert
+		assert.InDelta(t, radiusKm, haversineKm(latitude, longitude, maxLat, longitude), 0.01)
+		assert.InDelta(t, radiusKm, haversineKm(latitude, longitude, minLat, longitude), 0.01)
+		for bearing := 0; bearing < 360; bearing += 5 {
+			lat, lon := destination(latitude, longitude, float64(bearing), radiusKm)
+			assert.True(t, lat >= minLat && lat <= maxLat, "latitude %f outside [%f, %f]", lat, minLat, maxLat)
+			assert.True(t, lon >= minLon && lon <= maxLon, "longitude %f outside [%f, %f]", lon, minLon, maxLon)
+		}
+	})
+
+	t.Run("NearPole", func(t *testing.T) {
+		// Act
+		minLat, maxLat, minLon, maxLon := boundingBox(89.9, 10, 50)
+
+		// Assert
+		assert.Less(t, minLat, 89.9)
+		assert.Equal(t, 90.0, maxLat)
+		assert.Equal(t, -180.0, minLon)
+		assert.Equal(t, 180.0, maxLon)
+	})
+
+	t.Run("AcrossAntimeridian", func(t *testing.T) {
+		// Act
+		_, _, minLon, maxLon := boundingBox(0, 179.9, 50)
+
+		// Assert
+		assert.Equal(t, -180.0, minLon)
+		assert.Equal(t, 180.0, maxLon)
+	})
+}
+
+// destination returns the point reached by travelling distanceKm from the given point along the given bearing
+func destination(latitude, longitude, bearing, distanceKm float64) (float64, float64) {
+	lat, lon, theta := latitude*math.Pi/180, longitude*math.Pi/180, bearing*math.Pi/180
+	angular := distanceKm / 6371
+	lat2 := math.Asin(math.Sin(lat)*math.Cos(angular) + math.Cos(lat)*math.Sin(angular)*math.Cos(theta))
+	lon2 := lon + math.Atan2(math.Sin(theta)*math.Sin(angular)*math.Cos(lat), math.Cos(angular)-math.Sin(lat)*math.Sin(lat2))
+	return lat2 * 180 / math.Pi, lon2 * 180 / math.Pi
+}
+
 // TestStorage_CountPropertiesByRating tests the CountPropertiesByRating method
 func TestStorage_CountPropertiesByRating(t *testing.T) {
 	t.Run("ValidRating", func(t *testing.T) {
@@ -534,3 +1209,2148 @@ func TestStorage_CountPropertiesByRating(t *testing.T) {
 		assert.Less(t, minRating, 0.0)
 	})
 }
+
+// TestStorage_GetPropertiesWithOldestReviews tests the GetPropertiesWithOldestReviews method
+func TestStorage_GetPropertiesWithOldestReviews(t *testing.T) {
+	t.Run("OrdersByOldestLatestReview", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		oldest := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+		newest := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+
+		columns := append(append([]string{}, propertyColumns...), "latest_review_date")
+		rows := sqlmock.NewRows(columns).
+			AddRow(append(propertyRow(2, "Stale Reviews Hotel", 8.1, 10), oldest)...).
+			AddRow(append(propertyRow(1, "Fresh Reviews Hotel", 9.0, 20), newest)...)
+
+		mock.ExpectQuery(`MAX\(r\.date\) AS latest_review_date.*ORDER BY latest_review_date ASC`).
+			WithArgs(10).
+			WillReturnRows(rows)
+
+		// Act
+		results, err := s.GetPropertiesWithOldestReviews(context.Background(), 10)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, int64(2), results[0].Property.HotelID)
+		assert.Equal(t, oldest, *results[0].LatestReviewDate)
+		assert.Equal(t, int64(1), results[1].Property.HotelID)
+		assert.True(t, results[0].LatestReviewDate.Before(*results[1].LatestReviewDate))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NullLatestReviewDate", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		columns := append(append([]string{}, propertyColumns...), "latest_review_date")
+		rows := sqlmock.NewRows(columns).
+			AddRow(append(propertyRow(3, "Undated Reviews Hotel", 7.5, 3), nil)...)
+
+		mock.ExpectQuery(`ORDER BY latest_review_date ASC NULLS FIRST`).
+			WithArgs(5).
+			WillReturnRows(rows)
+
+		// Act
+		results, err := s.GetPropertiesWithOldestReviews(context.Background(), 5)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Nil(t, results[0].LatestReviewDate)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestStorage_GetPropertiesWithStaleTranslations tests finding properties whose translations predate the cutoff
+func TestStorage_GetPropertiesWithStaleTranslations(t *testing.T) {
+	t.Run("AppliesCutoffAndOrdersStalestFirst", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		cutoff := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+		stalest := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+		stale := time.Date(2025, 8, 31, 23, 0, 0, 0, time.UTC)
+
+		columns := append(append([]string{}, propertyColumns...), "translations_updated_at")
+		rows := sqlmock.NewRows(columns).
+			AddRow(append(propertyRow(3, "Untranslated Hotel", 7.9, 4), nil)...).
+			AddRow(append(propertyRow(2, "Stalest Hotel", 8.1, 10), stalest)...).
+			AddRow(append(propertyRow(1, "Stale Hotel", 9.0, 20), stale)...)
+
+		mock.ExpectQuery(`MIN\(t\.updated_at\) AS translations_updated_at\s+FROM properties p\s+LEFT JOIN translations t ON t\.property_id = p\.hotel_id\s+GROUP BY p\.hotel_id\s+HAVING MIN\(t\.updated_at\) IS NULL OR MIN\(t\.updated_at\) < \$1\s+ORDER BY translations_updated_at ASC NULLS FIRST, p\.hotel_id ASC\s+LIMIT \$2`).
+			WithArgs(cutoff, 10).
+			WillReturnRows(rows)
+
+		// Act
+		results, err := s.GetPropertiesWithStaleTranslations(context.Background(), cutoff, 10)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.Equal(t, int64(3), results[0].Property.HotelID)
+		assert.Nil(t, results[0].TranslationsUpdatedAt)
+		assert.Equal(t, int64(2), results[1].Property.HotelID)
+		assert.Equal(t, stalest, *results[1].TranslationsUpdatedAt)
+		assert.Equal(t, int64(1), results[2].Property.HotelID)
+		for _, result := range results[1:] {
+			assert.True(t, result.TranslationsUpdatedAt.Before(cutoff))
+		}
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DatabaseError", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		cutoff := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(`FROM properties p\s+LEFT JOIN translations t`).
+			WithArgs(cutoff, 5).
+			WillReturnError(errors.New("connection refused"))
+
+		// Act
+		results, err := s.GetPropertiesWithStaleTranslations(context.Background(), cutoff, 5)
+
+		// Assert
+		assert.Nil(t, results)
+		assert.EqualError(t, err, "connection refused")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestStorage_GetStaleProperties tests listing properties whose last sync is older than a threshold
+func TestStorage_GetStaleProperties(t *testing.T) {
+	t.Run("NeverSyncedFirstThenOldest", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		oldest := time.Date(2025, 9, 10, 6, 0, 0, 0, time.UTC)
+		older := time.Date(2025, 9, 11, 6, 0, 0, 0, time.UTC)
+		syncedRow := func(hotelID int64, name string, lastSyncedAt time.Time) []driver.Value {
+			row := propertyRow(hotelID, name, 8.0, 10)
+			row[len(row)-1] = lastSyncedAt
+			return row
+		}
+		rows := sqlmock.NewRows(propertyColumns).
+			AddRow(propertyRow(7, "Never Synced Hotel", 8.0, 10)...).
+			AddRow(syncedRow(3, "Oldest Hotel", oldest)...).
+			AddRow(syncedRow(5, "Older Hotel", older)...)
+
+		mock.ExpectQuery(`FROM properties\s+WHERE last_synced_at IS NULL OR last_synced_at < NOW\(\) - \$1 \* INTERVAL '1 second'\s+ORDER BY last_synced_at ASC NULLS FIRST, hotel_id ASC\s+LIMIT \$2`).
+			WithArgs(6*time.Hour.Seconds(), 10).
+			WillReturnRows(rows)
+
+		// Act
+		properties, err := s.GetStaleProperties(context.Background(), 6*time.Hour, 10)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, properties, 3)
+		assert.Equal(t, int64(7), properties[0].HotelID)
+		assert.Nil(t, properties[0].LastSyncedAt)
+		assert.Equal(t, int64(3), properties[1].HotelID)
+		assert.Equal(t, oldest, *properties[1].LastSyncedAt)
+		assert.Equal(t, int64(5), properties[2].HotelID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DatabaseError", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`WHERE last_synced_at IS NULL`).
+			WithArgs(12*time.Hour.Seconds(), 5).
+			WillReturnError(errors.New("connection refused"))
+
+		// Act
+		properties, err := s.GetStaleProperties(context.Background(), 12*time.Hour, 5)
+
+		// Assert
+		assert.Nil(t, properties)
+		assert.ErrorContains(t, err, "connection refused")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestStorage_TryAcquireSyncLock tests taking and releasing the advisory lock guarding scheduled syncs
+func TestStorage_TryAcquireSyncLock(t *testing.T) {
+	t.Run("Acquired", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`SELECT pg_try_advisory_lock\(\$1\)`).
+			WithArgs(syncLockKey).
+			WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(true))
+		mock.ExpectExec(`SELECT pg_advisory_unlock\(\$1\)`).
+			WithArgs(syncLockKey).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// Act
+		release, acquired, err := s.TryAcquireSyncLock(context.Background())
+		require.NoError(t, err)
+		require.True(t, acquired)
+		release()
+
+		// Assert
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("HeldElsewhere", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`SELECT pg_try_advisory_lock\(\$1\)`).
+			WithArgs(syncLockKey).
+			WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_lock"}).AddRow(false))
+
+		// Act
+		release, acquired, err := s.TryAcquireSyncLock(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.False(t, acquired)
+		assert.Nil(t, release)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// reviewColumns lists the review columns returned by review queries, in scan order
+var reviewColumns = []string{"review_id", "average_score", "country", "type", "name", "date", "headline", "language", "pros", "cons", "source", "imported"}
+
+// TestStorage_ListPropertyReviews tests the ListPropertyReviews method
+func TestStorage_ListPropertyReviews(t *testing.T) {
+	t.Run("FilterByType", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		rows := sqlmock.NewRows(reviewColumns).
+			AddRow(1, 9, "US", "family", "John Doe", "2024-01-15", "Great stay", "en", "Pool", "None", "booking.com", false)
+
+		mock.ExpectQuery(`WHERE property_id = \$1 AND type = \$2 ORDER BY date DESC`).
+			WithArgs(int64(12345), "family").
+			WillReturnRows(rows)
+
+		// Act
+		reviews, err := s.ListPropertyReviews(context.Background(), 12345, ReviewFilters{Type: "family"})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, reviews, 1)
+		assert.Equal(t, "family", reviews[0].Type)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NoTypeFilter", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		rows := sqlmock.NewRows(reviewColumns).
+			AddRow(1, 9, "US", "family", "John Doe", "2024-01-15", "Great stay", "en", "Pool", "None", "booking.com", false).
+			AddRow(2, 7, "FR", "solo", "Marie", "2024-01-10", "Fine", "fr", "Location", "Noise", "booking.com", false)
+
+		mock.ExpectQuery(`WHERE property_id = \$1 ORDER BY date DESC`).
+			WithArgs(int64(12345)).
+			WillReturnRows(rows)
+
+		// Act
+		reviews, err := s.ListPropertyReviews(context.Background(), 12345, ReviewFilters{})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Len(t, reviews, 2)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ScoreRangeWithPagination", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		rows := sqlmock.NewRows(reviewColumns).
+			AddRow(3, 8, "DE", "couple", "Anna", "2024-01-05", "Nice", "de", "Breakfast", "Parking", "booking.com", false)
+
+		mock.ExpectQuery(`WHERE property_id = \$1 AND average_score >= \$2 AND average_score <= \$3 ORDER BY date DESC NULLS LAST, review_id ASC LIMIT \$4 OFFSET \$5`).
+			WithArgs(int64(12345), 7, 9, 10, 20).
+			WillReturnRows(rows)
+
+		// Act
+		reviews, err := s.ListPropertyReviews(context.Background(), 12345, ReviewFilters{MinScore: 7, MaxScore: 9, Limit: 10, Offset: 20})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, reviews, 1)
+		assert.Equal(t, 8, reviews[0].AverageScore)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("LanguageCountryAndMinScore", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		rows := sqlmock.NewRows(reviewColumns).
+			AddRow(1, 9, "GB", "solo", "Jane", "2024-01-15", "Lovely", "en", "Staff", "None", "booking.com", false)
+
+		mock.ExpectQuery(`WHERE property_id = \$1 AND LOWER\(language\) = LOWER\(\$2\) AND LOWER\(country\) = LOWER\(\$3\) AND average_score >= \$4 ORDER BY date DESC NULLS LAST, review_id ASC LIMIT \$5 OFFSET \$6`).
+			WithArgs(int64(12345), "en", "gb", 8, 20, 0).
+			WillReturnRows(rows)
+
+		// Act
+		reviews, err := s.ListPropertyReviews(context.Background(), 12345, ReviewFilters{Language: "en", Country: "gb", MinScore: 8, Limit: 20})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, reviews, 1)
+		assert.Equal(t, "en", reviews[0].Language)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("SortByScoreAscending", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		rows := sqlmock.NewRows(reviewColumns).
+			AddRow(2, 7, "FR", "solo", "Marie", "2024-01-10", "Fine", "fr", "Location", "Noise", "booking.com", false).
+			AddRow(1, 9, "US", "family", "John Doe", "2024-01-15", "Great stay", "en", "Pool", "None", "booking.com", false)
+
+		mock.ExpectQuery(`WHERE property_id = \$1 ORDER BY average_score ASC, review_id ASC LIMIT \$2 OFFSET \$3`).
+			WithArgs(int64(12345), 20, 0).
+			WillReturnRows(rows)
+
+		// Act
+		reviews, err := s.ListPropertyReviews(context.Background(), 12345, ReviewFilters{SortBy: ReviewSortScore, SortAscending: true, Limit: 20})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, reviews, 2)
+		assert.Equal(t, 7, reviews[0].AverageScore)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestStorage_CountPropertyReviews tests the CountPropertyReviews method
+func TestStorage_CountPropertyReviews(t *testing.T) {
+	// Arrange
+	s, mock := newMockStorage(t)
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM reviews WHERE property_id = \$1 AND type = \$2 AND average_score >= \$3`).
+		WithArgs(int64(12345), "family", 7).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+	// Act
+	count, err := s.CountPropertyReviews(context.Background(), 12345, ReviewFilters{Type: "family", MinScore: 7, Limit: 10})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// stubRows is a rowIterator that fails to scan the rows listed in failAt
+type stubRows struct {
+	values [][]interface{}
+	failAt map[int]bool
+	index  int
+}
+
+func (r *stubRows) Next() bool {
+	r.index++
+	return r.index <= len(r.values)
+}
+
+func (r *stubRows) Scan(dest ...interface{}) error {
+	if r.failAt[r.index-1] {
+		return errors.New("corrupt value")
+	}
+	for i, value := range r.values[r.index-1] {
+		*dest[i].(*int64) = value.(int64)
+	}
+	return nil
+}
+
+func (r *stubRows) Err() error {
+	return nil
+}
+
+// TestScanRows tests scanning with and without skipping bad rows
+func TestScanRows(t *testing.T) {
+	logger.Logger = zap.NewNop()
+
+	scanID := func(r rowScanner) (int64, error) {
+		var id int64
+		err := r.Scan(&id)
+		return id, err
+	}
+	newRows := func() *stubRows {
+		return &stubRows{
+			values: [][]interface{}{{int64(1)}, {int64(2)}, {int64(3)}},
+			failAt: map[int]bool{1: true},
+		}
+	}
+
+	t.Run("SkipBadRows", func(t *testing.T) {
+		// Act
+		ids, skipped, err := scanRows(newRows(), true, scanID)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 3}, ids)
+		assert.Equal(t, 1, skipped)
+	})
+
+	t.Run("FailOnBadRow", func(t *testing.T) {
+		// Act
+		ids, _, err := scanRows(newRows(), false, scanID)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, ids)
+	})
+}
+
+// TestStorage_SkipBadRows tests that a query returns the good rows when one row fails to scan
+func TestStorage_SkipBadRows(t *testing.T) {
+	logger.Logger = zap.NewNop()
+
+	newRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows(reviewColumns).
+			AddRow(1, 9, "US", "family", "John Doe", "2024-01-15", "Great stay", "en", "Pool", "None", "booking.com", false).
+			AddRow(2, "not-a-score", "FR", "solo", "Marie", "2024-01-10", "Fine", "fr", "Location", "Noise", "booking.com", false).
+			AddRow(3, 7, "DE", "couple", "Anna", "2024-01-05", "Nice", "de", "Breakfast", "Parking", "booking.com", false)
+	}
+
+	t.Run("Enabled", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		s.config.SkipBadRows = true
+		mock.ExpectQuery(`FROM reviews`).WillReturnRows(newRows())
+
+		// Act
+		reviews, err := s.GetPropertyReviews(context.Background(), 12345)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, reviews, 2)
+		assert.Equal(t, int64(1), reviews[0].ReviewID)
+		assert.Equal(t, int64(3), reviews[1].ReviewID)
+	})
+
+	t.Run("CountedOnContext", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		s.config.SkipBadRows = true
+		mock.ExpectQuery(`FROM reviews`).WillReturnRows(newRows())
+		mock.ExpectQuery(`FROM reviews`).WillReturnRows(newRows())
+		ctx := WithSkippedRows(context.Background())
+
+		// Act
+		_, err := s.GetPropertyReviews(ctx, 12345)
+		require.NoError(t, err)
+		_, err = s.GetPropertyReviews(ctx, 12345)
+		require.NoError(t, err)
+
+		// Assert
+		assert.Equal(t, 2, SkippedRows(ctx))
+		assert.Equal(t, 0, SkippedRows(context.Background()))
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`FROM reviews`).WillReturnRows(newRows())
+
+		// Act
+		reviews, err := s.GetPropertyReviews(context.Background(), 12345)
+
+		// Assert
+		assert.Error(t, err)
+		assert.Nil(t, reviews)
+	})
+}
+
+// TestStorage_StoreReviews tests that reviews are upserted in one statement with their typed and raw dates,
+// and only stale ones deleted
+func TestStorage_StoreReviews(t *testing.T) {
+	// Arrange
+	s, mock := newMockStorage(t)
+	reviews := []cupid.Review{
+		{ReviewID: 1, AverageScore: 5, Name: "Outdated"},
+		{ReviewID: 2, AverageScore: 7, Name: "Marie", Date: "2024-1-5"},
+		{ReviewID: 1, AverageScore: 9, Name: "John Doe"},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO reviews .* VALUES \(\$1, .*, \$14\), \(\$15, .*, \$28\)\s+ON CONFLICT \(property_id, review_id\) DO UPDATE SET`).
+		WithArgs(
+			int64(12345), int64(2), 7, "", "", "Marie", time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), "2024-1-5", "", "", "", "", "", false,
+			int64(12345), int64(1), 9, "", "", "John Doe", nil, "", "", "", "", "", "", false,
+		).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`DELETE FROM reviews WHERE property_id = \$1 AND NOT imported AND NOT \(review_id = ANY\(\$2\)\)`).
+		WithArgs(int64(12345), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	tx, err := s.db.BeginTx(context.Background(), nil)
+	require.NoError(t, err)
+
+	// Act
+	err = s.storeReviews(context.Background(), tx, 12345, reviews, true)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestStorage_StoreReviews_Incomplete tests that stale reviews are only deleted after a complete fetch
+func TestStorage_StoreReviews_Incomplete(t *testing.T) {
+	t.Run("IncompleteKeepsStoredReviews", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO reviews`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		tx, err := s.db.BeginTx(context.Background(), nil)
+		require.NoError(t, err)
+
+		// Act
+		err = s.storeReviews(context.Background(), tx, 12345, []cupid.Review{{ReviewID: 1, AverageScore: 8}}, false)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("IncompleteEmptyIsANoop", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectBegin()
+
+		tx, err := s.db.BeginTx(context.Background(), nil)
+		require.NoError(t, err)
+
+		// Act
+		err = s.storeReviews(context.Background(), tx, 12345, []cupid.Review{}, false)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("CompleteEmptyDeletesAll", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM reviews WHERE property_id = \$1 AND NOT imported AND NOT \(review_id = ANY\(\$2\)\)`).
+			WithArgs(int64(12345), pq.Array([]int64{})).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+
+		tx, err := s.db.BeginTx(context.Background(), nil)
+		require.NoError(t, err)
+
+		// Act
+		err = s.storeReviews(context.Background(), tx, 12345, []cupid.Review{}, true)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestStorage_ImportReviews tests upserting imported reviews without deleting stored ones
+func TestStorage_ImportReviews(t *testing.T) {
+	t.Run("CountsInsertedAndUpdated", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		reviews := []cupid.Review{
+			{ReviewID: 1, AverageScore: 5, Name: "Outdated", Date: "2020-03-01"},
+			{ReviewID: 2, AverageScore: 7, Name: "Marie", Date: "2020-03-02"},
+			{ReviewID: 1, AverageScore: 9, Name: "John Doe", Date: "2020-03-01"},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM properties WHERE hotel_id = \$1\)`).
+			WithArgs(int64(12345)).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM reviews WHERE property_id = \$1 AND review_id = ANY\(\$2\)`).
+			WithArgs(int64(12345), sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectExec(`INSERT INTO reviews .* VALUES \(\$1, .*, \$14\), \(\$15, .*, \$28\)\s+ON CONFLICT \(property_id, review_id\) DO UPDATE SET`).
+			WithArgs(
+				int64(12345), int64(2), 7, "", "", "Marie", time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC), "2020-03-02", "", "", "", "", "", true,
+				int64(12345), int64(1), 9, "", "", "John Doe", time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), "2020-03-01", "", "", "", "", "", true,
+			).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		// Act
+		result, err := s.ImportReviews(context.Background(), 12345, reviews)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, &ReviewImportResult{Inserted: 1, Updated: 1}, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT EXISTS`).
+			WithArgs(int64(99999)).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectRollback()
+
+		// Act
+		result, err := s.ImportReviews(context.Background(), 99999, []cupid.Review{{ReviewID: 1, AverageScore: 8}})
+
+		// Assert
+		assert.ErrorIs(t, err, ErrPropertyNotFound)
+		assert.Nil(t, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestStorage_StorePropertiesBatch tests that one failing property does not roll back the batch
+func TestStorage_StorePropertiesBatch(t *testing.T) {
+	// Arrange
+	logger.Logger = zap.NewNop()
+	s, mock := newMockStorage(t)
+	good := &cupid.PropertyData{Property: cupid.Property{HotelID: 1, HotelName: "Good Hotel"}}
+	bad := &cupid.PropertyData{Property: cupid.Property{HotelID: 2, HotelName: "Bad Hotel"}}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`SAVEPOINT property_0`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO properties`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO property_details`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM property_facilities`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`DELETE FROM property_room_amenities`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`RELEASE SAVEPOINT property_0`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`SAVEPOINT property_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO properties`).WillReturnError(errors.New("value too long"))
+	mock.ExpectExec(`ROLLBACK TO SAVEPOINT property_1`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	// Act
+	failures, err := s.StorePropertiesBatch(context.Background(), []*cupid.PropertyData{good, bad})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, failures, 1)
+	assert.ErrorContains(t, failures[2], "value too long")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestStorage_ListProperties_ScalarColumns tests that description, contact and policy columns are scanned
+func TestStorage_ListProperties_ScalarColumns(t *testing.T) {
+	// Arrange
+	s, mock := newMockStorage(t)
+	rows := sqlmock.NewRows(propertyColumns).AddRow(propertyRow(1, "Test Hotel", 9.0, 20)...)
+
+	mock.ExpectQuery(`SELECT hotel_id, .*AS description, .*AS markdown_description, .*AS important_info, parking, group_room_min, child_allowed, pets_allowed`).
+		WithArgs(10, 0).
+		WillReturnRows(rows)
+
+	// Act
+	properties, err := s.ListProperties(context.Background(), 10, 0, PropertyFilters{})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, properties, 1)
+	property := properties[0]
+	assert.Equal(t, "+33 1 23 45 67 89", property.Phone)
+	assert.Equal(t, "info@example.com", property.Email)
+	assert.Equal(t, "A test hotel", property.Description)
+	assert.Equal(t, "A **test** hotel", property.MarkdownDescription)
+	require.NotNil(t, property.Parking)
+	assert.Equal(t, "Free parking", *property.Parking)
+	assert.Nil(t, property.GroupRoomMin)
+	require.NotNil(t, property.ChildAllowed)
+	assert.True(t, *property.ChildAllowed)
+	assert.Nil(t, property.PetsAllowed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestStorage_ListProperties_FacilityFilter tests that facility filters require every facility
+func TestStorage_ListProperties_FacilityFilter(t *testing.T) {
+	// Arrange
+	s, mock := newMockStorage(t)
+	rows := sqlmock.NewRows(propertyColumns).AddRow(propertyRow(1, "Pool Hotel", 9.0, 20)...)
+
+	mock.ExpectQuery(`AND hotel_id IN \(\s*SELECT property_id FROM property_facilities\s*WHERE facility_id = ANY\(\$1\)\s*GROUP BY property_id\s*HAVING COUNT\(DISTINCT facility_id\) = \$2\s*\) ORDER BY .* LIMIT \$3 OFFSET \$4`).
+		WithArgs(sqlmock.AnyArg(), 2, 10, 0).
+		WillReturnRows(rows)
+
+	// Act
+	properties, err := s.ListProperties(context.Background(), 10, 0, PropertyFilters{FacilityIDs: []int{5, 47, 5}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, properties, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestStorage_ListProperties_HotelIDsFilter tests that an ID filter restricts results to the listed properties
+func TestStorage_ListProperties_HotelIDsFilter(t *testing.T) {
+	// Arrange
+	s, mock := newMockStorage(t)
+	rows := sqlmock.NewRows(propertyColumns).AddRow(propertyRow(1, "Listed Hotel", 9.0, 20)...)
+
+	mock.ExpectQuery(`WHERE 1=1 AND hotel_id = ANY\(\$1\) ORDER BY .* LIMIT \$2 OFFSET \$3`).
+		WithArgs(sqlmock.AnyArg(), 10, 0).
+		WillReturnRows(rows)
+
+	// Act
+	properties, err := s.ListProperties(context.Background(), 10, 0, PropertyFilters{HotelIDs: []int64{1, 2}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, properties, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestStorage_ListProperties_RoomAmenityFilter tests that room amenity filters require every amenity and are
+// numbered after the facility filter
+func TestStorage_ListProperties_RoomAmenityFilter(t *testing.T) {
+	// Arrange
+	s, mock := newMockStorage(t)
+	rows := sqlmock.NewRows(propertyColumns).AddRow(propertyRow(1, "Balcony Hotel", 9.0, 20)...)
+
+	mock.ExpectQuery(`SELECT property_id FROM property_facilities\s*WHERE facility_id = ANY\(\$1\)\s*GROUP BY property_id\s*HAVING COUNT\(DISTINCT facility_id\) = \$2\s*\) AND hotel_id IN \(\s*SELECT property_id FROM property_room_amenities\s*WHERE amenity_id = ANY\(\$3\)\s*GROUP BY property_id\s*HAVING COUNT\(DISTINCT amenity_id\) = \$4\s*\) ORDER BY .* LIMIT \$5 OFFSET \$6`).
+		WithArgs(sqlmock.AnyArg(), 1, sqlmock.AnyArg(), 2, 10, 0).
+		WillReturnRows(rows)
+
+	// Act
+	properties, err := s.ListProperties(context.Background(), 10, 0, PropertyFilters{FacilityIDs: []int{5}, RoomAmenityIDs: []int{12, 30, 12}})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, properties, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestStorage_ListProperties_ZeroBounds tests that zero star and rating bounds are applied rather than ignored
+func TestStorage_ListProperties_ZeroBounds(t *testing.T) {
+	// Arrange
+	s, mock := newMockStorage(t)
+	filters := PropertyFilters{MinStars: intPtr(0), MaxRating: float64Ptr(0)}
+
+	mock.ExpectQuery(`WHERE 1=1 AND stars >= \$1 AND rating <= \$2 ORDER BY .* LIMIT \$3 OFFSET \$4`).
+		WithArgs(0, 0.0, 10, 0).
+		WillReturnRows(sqlmock.NewRows(propertyColumns))
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM properties WHERE 1=1 AND stars >= \$1 AND rating <= \$2`).
+		WithArgs(0, 0.0).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	// Act
+	properties, listErr := s.ListProperties(context.Background(), 10, 0, filters)
+	count, countErr := s.CountProperties(context.Background(), filters)
+
+	// Assert
+	require.NoError(t, listErr)
+	require.NoError(t, countErr)
+	assert.Empty(t, properties)
+	assert.Equal(t, 0, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestStorage_ListProperties_PriceFilters tests filtering on the lowest nightly rate and its currency
+func TestStorage_ListProperties_PriceFilters(t *testing.T) {
+	// Arrange
+	s, mock := newMockStorage(t)
+	filters := PropertyFilters{MinPrice: float64Ptr(50), MaxPrice: float64Ptr(150), Currency: "eur"}
+	row := propertyRow(1, "Affordable Hotel", 8.0, 12)
+	row[len(row)-3], row[len(row)-2] = "89.50", "EUR"
+
+	mock.ExpectQuery(`WHERE 1=1 AND min_price >= \$1 AND min_price <= \$2 AND currency = \$3 ORDER BY .* LIMIT \$4 OFFSET \$5`).
+		WithArgs(50.0, 150.0, "EUR", 10, 0).
+		WillReturnRows(sqlmock.NewRows(propertyColumns).AddRow(row...))
+
+	// Act
+	properties, err := s.ListProperties(context.Background(), 10, 0, filters)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, properties, 1)
+	require.NotNil(t, properties[0].MinPrice)
+	assert.Equal(t, 89.5, *properties[0].MinPrice)
+	assert.Equal(t, "EUR", properties[0].Currency)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestStorage_ListProperties_ChainFilter tests that the chain filter matches whole chain names, as listed by ListChains
+func TestStorage_ListProperties_ChainFilter(t *testing.T) {
+	// Arrange
+	s, mock := newMockStorage(t)
+	filters := PropertyFilters{Chain: "Hilton"}
+
+	mock.ExpectQuery(`WHERE 1=1 AND LOWER\(chain\) = LOWER\(\$1\) ORDER BY .* LIMIT \$2 OFFSET \$3`).
+		WithArgs("Hilton", 10, 0).
+		WillReturnRows(sqlmock.NewRows(propertyColumns).AddRow(propertyRow(1, "Hilton Paris", 8.0, 12)...))
+
+	// Act
+	properties, err := s.ListProperties(context.Background(), 10, 0, filters)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, properties, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestLowestRoomRate tests deriving the property price from its rooms
+func TestLowestRoomRate(t *testing.T) {
+	tests := []struct {
+		name             string
+		rooms            []cupid.Room
+		expectedPrice    *float64
+		expectedCurrency string
+	}{
+		{name: "NoRooms"},
+		{name: "NoRates", rooms: []cupid.Room{{ID: 1}, {ID: 2}}},
+		{
+			name: "LowestRate",
+			rooms: []cupid.Room{
+				{ID: 1, MinNightlyRate: float64Ptr(120), Currency: "eur"},
+				{ID: 2},
+				{ID: 3, MinNightlyRate: float64Ptr(95.5), Currency: "EUR"},
+			},
+			expectedPrice:    float64Ptr(95.5),
+			expectedCurrency: "EUR",
+		},
+		{
+			name: "OtherCurrencyIgnored",
+			rooms: []cupid.Room{
+				{ID: 1, MinNightlyRate: float64Ptr(120), Currency: "EUR"},
+				{ID: 2, MinNightlyRate: float64Ptr(80), Currency: "USD"},
+			},
+			expectedPrice:    float64Ptr(120),
+			expectedCurrency: "EUR",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			price, currency := lowestRoomRate(tt.rooms)
+
+			// Assert
+			assert.Equal(t, tt.expectedPrice, price)
+			assert.Equal(t, tt.expectedCurrency, currency)
+		})
+	}
+}
+
+// TestStorage_PostalCodePrefix tests that postal code lookups match on prefix only
+func TestStorage_PostalCodePrefix(t *testing.T) {
+	t.Run("GetProperties", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		rows := sqlmock.NewRows(propertyColumns).AddRow(propertyRow(1, "Westminster Hotel", 9.0, 20)...)
+
+		mock.ExpectQuery(`WHERE 1=1 AND postal_code ILIKE \$1 ORDER BY .* LIMIT \$2 OFFSET \$3`).
+			WithArgs("SW1%", 20, 40).
+			WillReturnRows(rows)
+
+		// Act
+		properties, err := s.GetPropertiesByPostalCodePrefix(context.Background(), "SW1", 20, 40)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Len(t, properties, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("CountProperties", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM properties WHERE 1=1 AND postal_code ILIKE \$1`).
+			WithArgs("750%").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+
+		// Act
+		count, err := s.CountPropertiesByPostalCodePrefix(context.Background(), "750")
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 12, count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestStorage_StoreFacilities tests that facility rows are replaced for a property
+func TestStorage_StoreFacilities(t *testing.T) {
+	// Arrange
+	s, mock := newMockStorage(t)
+	facilities := []cupid.Facility{
+		{FacilityID: 5, Name: "Pool"},
+		{FacilityID: 47, Name: "WiFi"},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM property_facilities WHERE property_id = \$1`).
+		WithArgs(int64(12345)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO property_facilities`).
+		WithArgs(int64(12345), 5, "Pool").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO property_facilities`).
+		WithArgs(int64(12345), 47, "WiFi").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	tx, err := s.db.BeginTx(context.Background(), nil)
+	require.NoError(t, err)
+
+	// Act
+	err = s.storeFacilities(context.Background(), tx, 12345, facilities)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestStorage_StoreRoomAmenities tests that room amenity rows are replaced with the number of rooms offering each
+func TestStorage_StoreRoomAmenities(t *testing.T) {
+	t.Run("CountsRoomsPerAmenity", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		rooms := []cupid.Room{
+			{ID: 1, RoomAmenities: []cupid.RoomAmenity{{AmenitiesID: 12, Name: "Balcony"}, {AmenitiesID: 30, Name: "Minibar"}}},
+			{ID: 2, RoomAmenities: []cupid.RoomAmenity{{AmenitiesID: 30, Name: "Mini bar"}, {AmenitiesID: 30, Name: "Minibar"}}},
+			{ID: 3},
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM property_room_amenities WHERE property_id = \$1`).
+			WithArgs(int64(12345)).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+		mock.ExpectExec(`INSERT INTO property_room_amenities`).
+			WithArgs(int64(12345), 12, "Balcony", 1).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO property_room_amenities`).
+			WithArgs(int64(12345), 30, "Minibar", 2).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		tx, err := s.db.BeginTx(context.Background(), nil)
+		require.NoError(t, err)
+
+		// Act
+		err = s.storeRoomAmenities(context.Background(), tx, 12345, rooms)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		s.config.StoreRoomAmenities = false
+		propertyData := &cupid.PropertyData{Property: cupid.Property{
+			HotelID: 12345,
+			Rooms:   []cupid.Room{{ID: 1, RoomAmenities: []cupid.RoomAmenity{{AmenitiesID: 12, Name: "Balcony"}}}},
+		}}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO properties`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO property_details`).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM property_facilities`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		tx, err := s.db.BeginTx(context.Background(), nil)
+		require.NoError(t, err)
+
+		// Act
+		err = s.storePropertyData(context.Background(), tx, propertyData)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestStorage_ListRoomAmenities tests listing distinct room amenities with property counts
+func TestStorage_ListRoomAmenities(t *testing.T) {
+	// Arrange
+	s, mock := newMockStorage(t)
+	rows := sqlmock.NewRows([]string{"amenity_id", "name", "property_count"}).
+		AddRow(30, "Minibar", 80).
+		AddRow(12, "Balcony", 25)
+
+	mock.ExpectQuery(`FROM property_room_amenities\s+GROUP BY amenity_id`).WillReturnRows(rows)
+
+	// Act
+	amenities, err := s.ListRoomAmenities(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, amenities, 2)
+	assert.Equal(t, RoomAmenitySummary{AmenityID: 12, Name: "Balcony", PropertyCount: 25}, amenities[1])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestStorage_ListChains tests listing distinct chains with property counts
+func TestStorage_ListChains(t *testing.T) {
+	// Arrange
+	s, mock := newMockStorage(t)
+	rows := sqlmock.NewRows([]string{"chain", "property_count"}).
+		AddRow("Accor", 42).
+		AddRow("Hilton", 7)
+
+	mock.ExpectQuery(`FROM properties\s+WHERE chain <> ''\s+GROUP BY chain\s+ORDER BY property_count DESC, chain ASC`).WillReturnRows(rows)
+
+	// Act
+	chains, err := s.ListChains(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, chains, 2)
+	assert.Equal(t, ChainSummary{Chain: "Accor", PropertyCount: 42}, chains[0])
+	assert.Equal(t, ChainSummary{Chain: "Hilton", PropertyCount: 7}, chains[1])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestStorage_ListFacilities tests listing distinct facilities with property counts
+func TestStorage_ListFacilities(t *testing.T) {
+	// Arrange
+	s, mock := newMockStorage(t)
+	rows := sqlmock.NewRows([]string{"facility_id", "name", "property_count"}).
+		AddRow(47, "WiFi", 120).
+		AddRow(5, "Pool", 30)
+
+	mock.ExpectQuery(`FROM property_facilities\s+GROUP BY facility_id`).WillReturnRows(rows)
+
+	// Act
+	facilities, err := s.ListFacilities(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, facilities, 2)
+	assert.Equal(t, FacilitySummary{FacilityID: 47, Name: "WiFi", PropertyCount: 120}, facilities[0])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestStorage_GetAverageRatingByStars tests averaging guest ratings per star category
+func TestStorage_GetAverageRatingByStars(t *testing.T) {
+	// Arrange
+	s, mock := newMockStorage(t)
+	// Seeded ratings: 3 stars has 7.0 and 8.0; 5 stars has 9.0, 8.5 and 9.5
+	rows := sqlmock.NewRows([]string{"stars", "avg", "count"}).
+		AddRow(3, "7.5000000000000000", 2).
+		AddRow(5, "9.0000000000000000", 3)
+
+	mock.ExpectQuery(`SELECT stars, AVG\(rating\), COUNT\(\*\)\s+FROM properties\s+WHERE stars IS NOT NULL AND rating > 0\s+GROUP BY stars\s+ORDER BY stars`).
+		WillReturnRows(rows)
+
+	// Act
+	averages, err := s.GetAverageRatingByStars(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, averages, 2)
+	assert.Equal(t, 3, averages[0].Stars)
+	assert.InDelta(t, (7.0+8.0)/2, averages[0].AverageRating, 0.0001)
+	assert.Equal(t, 2, averages[0].PropertyCount)
+	assert.Equal(t, 5, averages[1].Stars)
+	assert.InDelta(t, (9.0+8.5+9.5)/3, averages[1].AverageRating, 0.0001)
+	assert.Equal(t, 3, averages[1].PropertyCount)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestStorage_GetAverageRatingByStars_Empty tests that no rated properties yields an empty result
+func TestStorage_GetAverageRatingByStars_Empty(t *testing.T) {
+	// Arrange
+	s, mock := newMockStorage(t)
+	mock.ExpectQuery(`FROM properties\s+WHERE stars IS NOT NULL AND rating > 0`).
+		WillReturnRows(sqlmock.NewRows([]string{"stars", "avg", "count"}))
+
+	// Act
+	averages, err := s.GetAverageRatingByStars(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, averages)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestStorage_ListPropertiesWithReviewAverages tests listing properties with their computed review average
+func TestStorage_ListPropertiesWithReviewAverages(t *testing.T) {
+	// Arrange
+	s, mock := newMockStorage(t)
+	columns := append(append([]string{}, propertyColumns...), "computed_rating")
+	// Seeded reviews: hotel 1 has scores 9, 8 and 7; hotel 2 has none
+	rows := sqlmock.NewRows(columns).
+		AddRow(append(propertyRow(1, "Reviewed Hotel", 9.0, 3), "8.0000000000000000")...).
+		AddRow(append(propertyRow(2, "Unreviewed Hotel", 8.1, 0), nil)...)
+
+	mock.ExpectQuery(`LEFT JOIN \(\s*SELECT property_id, AVG\(average_score\) AS computed_rating\s*FROM reviews\s*GROUP BY property_id\s*\) r ON r.property_id = p.hotel_id\s*WHERE 1=1 AND city ILIKE \$1 ORDER BY p.rating DESC, p.review_count DESC, p.hotel_id DESC LIMIT \$2 OFFSET \$3`).
+		WithArgs("%Paris%", 10, 0).
+		WillReturnRows(rows)
+
+	// Act
+	results, err := s.ListPropertiesWithReviewAverages(context.Background(), 10, 0, PropertyFilters{City: "Paris"})
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, int64(1), results[0].Property.HotelID)
+	require.NotNil(t, results[0].ComputedRating)
+	assert.InDelta(t, (9.0+8.0+7.0)/3, *results[0].ComputedRating, 0.0001)
+	assert.Nil(t, results[1].ComputedRating)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	t.Run("NormalizesReviewScale", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		s.config.ReviewScoreScale = 5
+		mock.ExpectQuery(`AS computed_rating`).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(append(propertyRow(1, "Reviewed Hotel", 9.0, 2), "4.5000000000000000")...))
+
+		// Act
+		results, err := s.ListPropertiesWithReviewAverages(context.Background(), 10, 0, PropertyFilters{})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.NotNil(t, results[0].ComputedRating)
+		assert.InDelta(t, 9.0, *results[0].ComputedRating, 0.0001)
+	})
+}
+
+// TestStorage_GetPropertiesByCountries tests the exact, case-insensitive country match used by country syncs
+func TestStorage_GetPropertiesByCountries(t *testing.T) {
+	t.Run("MatchesCountries", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`FROM properties\s+WHERE LOWER\(country\) = ANY\(\$1\)\s+ORDER BY hotel_id ASC`).
+			WithArgs(pq.Array([]string{"fr", "es"})).
+			WillReturnRows(sqlmock.NewRows(propertyColumns).AddRow(propertyRow(1, "Paris Hotel", 8.5, 10)...))
+
+		// Act
+		properties, err := s.GetPropertiesByCountries(context.Background(), []string{"FR", " es "})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, properties, 1)
+		assert.Equal(t, int64(1), properties[0].HotelID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NoCountries", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+
+		// Act
+		properties, err := s.GetPropertiesByCountries(context.Background(), nil)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, properties)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestStorage_ListProperties_Cursor tests that keyset pagination continues after the cursor's sort key
+func TestStorage_ListProperties_Cursor(t *testing.T) {
+	t.Run("ListProperties", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		rows := sqlmock.NewRows(propertyColumns).AddRow(propertyRow(7, "Next Hotel", 8.5, 12)...)
+		filters := PropertyFilters{
+			Country: "France",
+			After:   &PropertyCursor{Rating: 8.5, ReviewCount: 40, HotelID: 12},
+		}
+
+		mock.ExpectQuery(`WHERE 1=1 AND country ILIKE \$1 AND \(rating, review_count, hotel_id\) < \(\$2, \$3, \$4\) ORDER BY rating DESC, review_count DESC, hotel_id DESC LIMIT \$5 OFFSET \$6`).
+			WithArgs("%France%", 8.5, 40, int64(12), 20, 0).
+			WillReturnRows(rows)
+
+		// Act
+		properties, err := s.ListProperties(context.Background(), 20, 0, filters)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, properties, 1)
+		assert.Equal(t, int64(7), properties[0].HotelID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ListPropertiesWithReviewAverages", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		columns := append(append([]string{}, propertyColumns...), "computed_rating")
+		filters := PropertyFilters{After: &PropertyCursor{Rating: 9, ReviewCount: 3, HotelID: 1}}
+
+		mock.ExpectQuery(`WHERE 1=1 AND \(p.rating, p.review_count, p.hotel_id\) < \(\$1, \$2, \$3\) ORDER BY p.rating DESC, p.review_count DESC, p.hotel_id DESC LIMIT \$4 OFFSET \$5`).
+			WithArgs(9.0, 3, int64(1), 10, 0).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		// Act
+		results, err := s.ListPropertiesWithReviewAverages(context.Background(), 10, 0, filters)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, results)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("CountIgnoresCursor", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		filters := PropertyFilters{After: &PropertyCursor{Rating: 9, ReviewCount: 3, HotelID: 1}}
+
+		mock.ExpectQuery(`^SELECT COUNT\(\*\) FROM properties WHERE 1=1$`).
+			WithArgs().
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+
+		// Act
+		count, err := s.CountProperties(context.Background(), filters)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 42, count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestStorage_GetReviewStats tests review aggregation into an average and score distribution
+func TestStorage_GetReviewStats(t *testing.T) {
+	t.Run("WithReviews", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		rows := sqlmock.NewRows([]string{"average_score", "count"}).
+			AddRow(7, 1).
+			AddRow(9, 3)
+
+		mock.ExpectQuery(`SELECT average_score, COUNT\(\*\)\s+FROM reviews\s+WHERE property_id = \$1\s+GROUP BY average_score`).
+			WithArgs(int64(12345)).
+			WillReturnRows(rows)
+
+		// Act
+		stats, err := s.GetReviewStats(context.Background(), 12345)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 4, stats.Count)
+		assert.InDelta(t, 8.5, stats.AverageScore, 0.0001)
+		assert.Equal(t, RatingScale, stats.Scale)
+		require.Len(t, stats.Distribution, 10)
+		assert.Equal(t, ScoreBucket{Score: 7, Count: 1}, stats.Distribution[6])
+		assert.Equal(t, ScoreBucket{Score: 9, Count: 3}, stats.Distribution[8])
+		assert.Equal(t, ScoreBucket{Score: 1, Count: 0}, stats.Distribution[0])
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NoReviews", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`FROM reviews`).
+			WithArgs(int64(12345)).
+			WillReturnRows(sqlmock.NewRows([]string{"average_score", "count"}))
+
+		// Act
+		stats, err := s.GetReviewStats(context.Background(), 12345)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 0, stats.Count)
+		assert.Equal(t, float64(0), stats.AverageScore)
+		assert.Equal(t, RatingScale, stats.Scale)
+		assert.Len(t, stats.Distribution, 10)
+	})
+
+	t.Run("NormalizesReviewScale", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		s.config.ReviewScoreScale = 5
+		mock.ExpectQuery(`FROM reviews`).
+			WithArgs(int64(12345)).
+			WillReturnRows(sqlmock.NewRows([]string{"average_score", "count"}).
+				AddRow(3, 1).
+				AddRow(4, 1))
+
+		// Act
+		stats, err := s.GetReviewStats(context.Background(), 12345)
+
+		// Assert
+		require.NoError(t, err)
+		assert.InDelta(t, 7.0, stats.AverageScore, 0.0001, "an average of 3.5 out of 5 is 7 out of 10")
+		assert.Equal(t, RatingScale, stats.Scale)
+		assert.Equal(t, ScoreBucket{Score: 4, Count: 1}, stats.Distribution[3])
+	})
+}
+
+// TestStorage_PurgeOrphans tests that orphaned child rows are deleted and counted per table
+func TestStorage_PurgeOrphans(t *testing.T) {
+	t.Run("PurgesEveryChildTable", func(t *testing.T) {
+		// Arrange
+		logger.Logger = zap.NewNop()
+		s, mock := newMockStorage(t)
+		orphanCondition := `WHERE NOT EXISTS \(SELECT 1 FROM properties p WHERE p.hotel_id = c.property_id\)`
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM reviews c\s+` + orphanCondition).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(`DELETE FROM translations c\s+` + orphanCondition).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(`DELETE FROM property_details c\s+` + orphanCondition).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM property_facilities c\s+` + orphanCondition).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`DELETE FROM property_room_amenities c\s+` + orphanCondition).
+			WillReturnResult(sqlmock.NewResult(0, 4))
+		mock.ExpectCommit()
+
+		// Act
+		result, err := s.PurgeOrphans(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, &OrphanPurgeResult{Reviews: 3, Translations: 2, PropertyDetails: 1, RoomAmenities: 4}, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("RollsBackOnError", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM reviews`).
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(`DELETE FROM translations`).
+			WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		// Act
+		result, err := s.PurgeOrphans(context.Background())
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "orphaned translations")
+		assert.Nil(t, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestStorage_DeletePropertyReviewsOlderThan tests that reviews dated before the cutoff day are deleted
+func TestStorage_DeletePropertyReviewsOlderThan(t *testing.T) {
+	t.Run("DeletesBeforeCutoffDate", func(t *testing.T) {
+		// Arrange
+		logger.Logger = zap.NewNop()
+		s, mock := newMockStorage(t)
+		cutoff := time.Date(2024, 10, 15, 13, 30, 0, 0, time.UTC)
+
+		mock.ExpectExec(`DELETE FROM reviews WHERE date < \$1::date`).
+			WithArgs("2024-10-15").
+			WillReturnResult(sqlmock.NewResult(0, 42))
+
+		// Act
+		deleted, err := s.DeletePropertyReviewsOlderThan(context.Background(), cutoff)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DatabaseError", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+
+		mock.ExpectExec(`DELETE FROM reviews`).
+			WillReturnError(errors.New("connection reset"))
+
+		// Act
+		deleted, err := s.DeletePropertyReviewsOlderThan(context.Background(), time.Now())
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to delete old reviews")
+		assert.Zero(t, deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestStorage_GetPropertiesWithDuplicateCoordinates tests that properties at the same coordinates are grouped
+func TestStorage_GetPropertiesWithDuplicateCoordinates(t *testing.T) {
+	t.Run("GroupsByCoordinates", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		atCoordinates := func(hotelID int64, name string, lat, lng float64) []driver.Value {
+			row := propertyRow(hotelID, name, 8.0, 10)
+			row[7], row[8] = lat, lng
+			return row
+		}
+		rows := sqlmock.NewRows(propertyColumns).
+			AddRow(atCoordinates(1, "Hotel Paris", 48.8566, 2.3522)...).
+			AddRow(atCoordinates(2, "Hotel Paris (copy)", 48.8566, 2.3522)...).
+			AddRow(atCoordinates(5, "Hotel Lyon", 45.764, 4.8357)...).
+			AddRow(atCoordinates(9, "Lyon Hotel", 45.764, 4.8357)...).
+			AddRow(atCoordinates(12, "Hotel de Lyon", 45.764, 4.8357)...)
+
+		mock.ExpectQuery(`FROM properties p\s+JOIN \(\s+SELECT latitude, longitude\s+FROM properties\s+WHERE NOT \(latitude = 0 AND longitude = 0\)\s+GROUP BY latitude, longitude\s+HAVING COUNT\(\*\) > 1`).
+			WillReturnRows(rows)
+
+		// Act
+		clusters, err := s.GetPropertiesWithDuplicateCoordinates(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, clusters, 2)
+		assert.Equal(t, 48.8566, clusters[0].Latitude)
+		assert.Equal(t, 2.3522, clusters[0].Longitude)
+		require.Len(t, clusters[0].Properties, 2)
+		assert.Equal(t, int64(1), clusters[0].Properties[0].HotelID)
+		assert.Equal(t, int64(2), clusters[0].Properties[1].HotelID)
+		require.Len(t, clusters[1].Properties, 3)
+		assert.Equal(t, int64(12), clusters[1].Properties[2].HotelID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NoDuplicates", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`HAVING COUNT\(\*\) > 1`).WillReturnRows(sqlmock.NewRows(propertyColumns))
+
+		// Act
+		clusters, err := s.GetPropertiesWithDuplicateCoordinates(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotNil(t, clusters)
+		assert.Empty(t, clusters)
+	})
+}
+
+// TestStorage_GetPropertiesWithConflictingChains tests that inconsistent chain names and IDs are grouped by conflict
+func TestStorage_GetPropertiesWithConflictingChains(t *testing.T) {
+	t.Run("GroupsByConflict", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		inChain := func(hotelID int64, chain string, chainID int, field string) []driver.Value {
+			row := propertyRow(hotelID, "Hotel", 8.0, 10)
+			row[5], row[6] = chain, chainID
+			return append(row, field)
+		}
+		columns := append(append([]string{}, propertyColumns...), "field")
+		rows := sqlmock.NewRows(columns).
+			AddRow(inChain(1, "Accor", 10, "chain")...).
+			AddRow(inChain(4, "Accor", 11, "chain")...).
+			AddRow(inChain(2, "Hilton", 20, "chain_id")...).
+			AddRow(inChain(3, "Hilton Hotels", 20, "chain_id")...).
+			AddRow(inChain(7, "Marriott", 30, "chain_id")...).
+			AddRow(inChain(8, "Marriott Intl", 30, "chain_id")...)
+
+		mock.ExpectQuery(`GROUP BY chain\s+HAVING COUNT\(DISTINCT chain_id\) > 1\s+UNION ALL.+GROUP BY chain_id\s+HAVING COUNT\(DISTINCT chain\) > 1`).
+			WillReturnRows(rows)
+
+		// Act
+		conflicts, err := s.GetPropertiesWithConflictingChains(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, conflicts, 3)
+
+		assert.Equal(t, ChainConflictName, conflicts[0].Field)
+		assert.Equal(t, "Accor", conflicts[0].Chain)
+		assert.Zero(t, conflicts[0].ChainID)
+		require.Len(t, conflicts[0].Properties, 2)
+		assert.Equal(t, 11, conflicts[0].Properties[1].ChainID)
+
+		assert.Equal(t, ChainConflictID, conflicts[1].Field)
+		assert.Equal(t, 20, conflicts[1].ChainID)
+		assert.Empty(t, conflicts[1].Chain)
+		require.Len(t, conflicts[1].Properties, 2)
+		assert.Equal(t, "Hilton Hotels", conflicts[1].Properties[1].Chain)
+
+		assert.Equal(t, 30, conflicts[2].ChainID)
+		require.Len(t, conflicts[2].Properties, 2)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NoConflicts", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		columns := append(append([]string{}, propertyColumns...), "field")
+		mock.ExpectQuery(`UNION ALL`).WillReturnRows(sqlmock.NewRows(columns))
+
+		// Act
+		conflicts, err := s.GetPropertiesWithConflictingChains(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotNil(t, conflicts)
+		assert.Empty(t, conflicts)
+	})
+
+	t.Run("QueryError", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`UNION ALL`).WillReturnError(assert.AnError)
+
+		// Act
+		conflicts, err := s.GetPropertiesWithConflictingChains(context.Background())
+
+		// Assert
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to find conflicting chains")
+		assert.Nil(t, conflicts)
+	})
+}
+
+// TestStorage_GetPropertySyncHistory tests that sync history is returned newest first with its changes
+func TestStorage_GetPropertySyncHistory(t *testing.T) {
+	t.Run("NewestFirst", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		newest := time.Date(2025, 9, 11, 12, 0, 0, 0, time.UTC)
+		rows := sqlmock.NewRows([]string{"synced_at", "changes"}).
+			AddRow(newest, "{property,reviews}").
+			AddRow(newest.Add(-12*time.Hour), "{}").
+			AddRow(newest.Add(-24*time.Hour), "{created}")
+
+		mock.ExpectQuery(`SELECT synced_at, changes\s+FROM property_sync_history\s+WHERE property_id = \$1\s+ORDER BY synced_at DESC, id DESC\s+LIMIT \$2`).
+			WithArgs(int64(12345), 10).
+			WillReturnRows(rows)
+
+		// Act
+		history, err := s.GetPropertySyncHistory(context.Background(), 12345, 10)
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, history, 3)
+		assert.Equal(t, PropertySyncEntry{SyncedAt: newest, Changes: []string{"property", "reviews"}}, history[0])
+		assert.Equal(t, PropertySyncEntry{SyncedAt: newest.Add(-12 * time.Hour), Changes: []string{}}, history[1])
+		assert.Equal(t, PropertySyncEntry{SyncedAt: newest.Add(-24 * time.Hour), Changes: []string{"created"}}, history[2])
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("QueryError", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`FROM property_sync_history`).
+			WithArgs(int64(12345), 10).
+			WillReturnError(errors.New("connection reset"))
+
+		// Act
+		history, err := s.GetPropertySyncHistory(context.Background(), 12345, 10)
+
+		// Assert
+		assert.Nil(t, history)
+		assert.ErrorContains(t, err, "failed to query property sync history")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestStorage_RecordPropertySync tests that a sync entry is inserted with its changes
+func TestStorage_RecordPropertySync(t *testing.T) {
+	// Arrange
+	s, mock := newMockStorage(t)
+	mock.ExpectExec(`INSERT INTO property_sync_history \(property_id, changes\) VALUES \(\$1, \$2\)`).
+		WithArgs(int64(12345), "{}").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	// Act
+	err := s.RecordPropertySync(context.Background(), 12345, nil)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestStorage_MarkPropertySynced tests refreshing the sync timestamp of an unchanged property
+func TestStorage_MarkPropertySynced(t *testing.T) {
+	t.Run("Updated", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectExec(`UPDATE properties SET last_synced_at = NOW\(\) WHERE hotel_id = \$1`).
+			WithArgs(int64(12345)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// Act
+		err := s.MarkPropertySynced(context.Background(), 12345, nil)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectExec(`UPDATE properties SET last_synced_at`).
+			WithArgs(int64(99999)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		// Act
+		err := s.MarkPropertySynced(context.Background(), 99999, nil)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrPropertyNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("StoresRawResponse", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		raw := json.RawMessage(`{"hotel_id":12345}`)
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE properties SET last_synced_at = NOW\(\) WHERE hotel_id = \$1`).
+			WithArgs(int64(12345)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE property_details SET raw_property_json = \$2 WHERE property_id = \$1`).
+			WithArgs(int64(12345), []byte(raw)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		// Act
+		err := s.MarkPropertySynced(context.Background(), 12345, raw)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("RawResponseNotFound", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`UPDATE properties SET last_synced_at`).
+			WithArgs(int64(99999)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		// Act
+		err := s.MarkPropertySynced(context.Background(), 99999, json.RawMessage(`{}`))
+
+		// Assert
+		assert.ErrorIs(t, err, ErrPropertyNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestStorage_SetPropertyOverrides tests recording and removing manual field overrides
+func TestStorage_SetPropertyOverrides(t *testing.T) {
+	name := "Corrected Name"
+
+	t.Run("SetsAndRemovesOverrides", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT EXISTS \(SELECT 1 FROM properties WHERE hotel_id = \$1\)`).
+			WithArgs(int64(12345)).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+		mock.ExpectExec(`DELETE FROM property_overrides WHERE property_id = \$1 AND field = \$2`).
+			WithArgs(int64(12345), "description").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`INSERT INTO property_overrides \(property_id, field, value\) VALUES \(\$1, \$2, \$3\)\s+ON CONFLICT \(property_id, field\) DO UPDATE`).
+			WithArgs(int64(12345), "hotel_name", name).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		// Act
+		err := s.SetPropertyOverrides(context.Background(), 12345, map[string]*string{
+			"hotel_name":  &name,
+			"description": nil,
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("InvalidField", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+
+		// Act
+		err := s.SetPropertyOverrides(context.Background(), 12345, map[string]*string{"rating": &name})
+
+		// Assert
+		assert.ErrorIs(t, err, ErrInvalidOverrideField)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT EXISTS`).
+			WithArgs(int64(99999)).
+			WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectRollback()
+
+		// Act
+		err := s.SetPropertyOverrides(context.Background(), 99999, map[string]*string{"hotel_name": &name})
+
+		// Assert
+		assert.ErrorIs(t, err, ErrPropertyNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestStorage_GetPropertyOverrides tests loading the overrides of a property
+func TestStorage_GetPropertyOverrides(t *testing.T) {
+	// Arrange
+	s, mock := newMockStorage(t)
+	mock.ExpectQuery(`SELECT field, value FROM property_overrides WHERE property_id = \$1`).
+		WithArgs(int64(12345)).
+		WillReturnRows(sqlmock.NewRows([]string{"field", "value"}).
+			AddRow("hotel_name", "Corrected Name").
+			AddRow("email", "contact@example.com"))
+
+	// Act
+	overrides, err := s.GetPropertyOverrides(context.Background(), 12345)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"hotel_name": "Corrected Name", "email": "contact@example.com"}, overrides)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// TestApplyPropertyOverrides tests that overrides replace the matching property fields only
+func TestApplyPropertyOverrides(t *testing.T) {
+	// Arrange
+	property := &cupid.Property{HotelName: "Wrong Name", Description: "Upstream description", Phone: "+33 1 00 00 00 00"}
+
+	// Act
+	ApplyPropertyOverrides(property, map[string]string{"hotel_name": "Corrected Name", "description": ""})
+
+	// Assert
+	assert.Equal(t, "Corrected Name", property.HotelName)
+	assert.Empty(t, property.Description)
+	assert.Equal(t, "+33 1 00 00 00 00", property.Phone)
+}
+
+// TestSelectPropertyColumns tests that overridable columns are read from property_overrides first
+func TestSelectPropertyColumns(t *testing.T) {
+	// Act
+	unqualified := selectPropertyColumns("")
+	qualified := selectPropertyColumns("p")
+
+	// Assert
+	assert.Contains(t, unqualified, "COALESCE((SELECT o.value FROM property_overrides o WHERE o.property_id = properties.hotel_id AND o.field = 'hotel_name'), hotel_name) AS hotel_name")
+	assert.Contains(t, qualified, "COALESCE((SELECT o.value FROM property_overrides o WHERE o.property_id = p.hotel_id AND o.field = 'hotel_name'), p.hotel_name) AS hotel_name")
+	assert.Contains(t, qualified, "p.rating, p.review_count")
+}
+
+// TestStorage_SyncLogs tests persisting and listing synchronization runs
+func TestStorage_SyncLogs(t *testing.T) {
+	startedAt := time.Date(2025, 9, 10, 6, 0, 0, 0, time.UTC)
+	completedAt := startedAt.Add(90 * time.Second)
+	syncLogColumns := []string{
+		"sync_id", "sync_type", "status", "started_at", "completed_at",
+		"total_properties", "updated_properties", "failed_properties", "error_message",
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectExec(`INSERT INTO sync_logs \(sync_id, sync_type, status, started_at\)`).
+			WithArgs("sync_20250910_060000", "scheduled", "running", startedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		// Act
+		err := s.CreateSyncLog(context.Background(), &SyncLogRecord{
+			SyncID: "sync_20250910_060000", SyncType: "scheduled", Status: "running", StartedAt: startedAt,
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectExec(`UPDATE sync_logs\s+SET status = \$2, completed_at = \$3, .*WHERE sync_id = \$1`).
+			WithArgs("sync_20250910_060000", "failed", &completedAt, 0, 0, 0, "upstream unavailable").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		// Act
+		err := s.UpdateSyncLog(context.Background(), &SyncLogRecord{
+			SyncID: "sync_20250910_060000", Status: "failed", CompletedAt: &completedAt, ErrorMessage: "upstream unavailable",
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ListWithDateRange", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		from := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 9, 11, 0, 0, 0, 0, time.UTC)
+		rows := sqlmock.NewRows(syncLogColumns).
+			AddRow("sync_20250910_060000", "scheduled", "completed", startedAt, completedAt, 120, 7, 1, "").
+			AddRow("sync_20250910_120000", "manual", "running", startedAt.Add(6*time.Hour), nil, 0, 0, 0, "")
+
+		mock.ExpectQuery(`FROM sync_logs\s+WHERE 1=1 AND started_at >= \$1 AND started_at < \$2 ORDER BY started_at ASC, id ASC$`).
+			WithArgs(from, to).
+			WillReturnRows(rows)
+
+		// Act
+		logs, err := s.ListSyncLogs(context.Background(), SyncLogFilters{From: from, To: to})
+
+		// Assert
+		require.NoError(t, err)
+		require.Len(t, logs, 2)
+		assert.Equal(t, SyncLogRecord{
+			SyncID: "sync_20250910_060000", SyncType: "scheduled", Status: "completed", StartedAt: startedAt,
+			CompletedAt: &completedAt, TotalProperties: 120, UpdatedProperties: 7, FailedProperties: 1,
+		}, logs[0])
+		assert.Nil(t, logs[1].CompletedAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ListWithLimit", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`FROM sync_logs\s+WHERE 1=1 ORDER BY started_at ASC, id ASC LIMIT \$1 OFFSET \$2`).
+			WithArgs(10, 20).
+			WillReturnRows(sqlmock.NewRows(syncLogColumns))
+
+		// Act
+		logs, err := s.ListSyncLogs(context.Background(), SyncLogFilters{Limit: 10, Offset: 20})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, logs)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Each", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		rows := sqlmock.NewRows(syncLogColumns).
+			AddRow("sync_20250910_060000", "scheduled", "completed", startedAt, completedAt, 120, 7, 1, "").
+			AddRow("sync_20250910_120000", "manual", "running", startedAt.Add(6*time.Hour), nil, 0, 0, 0, "")
+
+		mock.ExpectQuery(`FROM sync_logs\s+WHERE 1=1 ORDER BY started_at ASC, id ASC$`).WillReturnRows(rows)
+
+		// Act
+		var ids []string
+		err := s.EachSyncLog(context.Background(), SyncLogFilters{}, func(entry SyncLogRecord) error {
+			ids = append(ids, entry.SyncID)
+			return nil
+		})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, []string{"sync_20250910_060000", "sync_20250910_120000"}, ids)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("EachStopsOnError", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		rows := sqlmock.NewRows(syncLogColumns).
+			AddRow("sync_20250910_060000", "scheduled", "completed", startedAt, completedAt, 120, 7, 1, "").
+			AddRow("sync_20250910_120000", "manual", "running", startedAt.Add(6*time.Hour), nil, 0, 0, 0, "")
+
+		mock.ExpectQuery(`FROM sync_logs`).WillReturnRows(rows)
+
+		// Act
+		calls := 0
+		err := s.EachSyncLog(context.Background(), SyncLogFilters{}, func(entry SyncLogRecord) error {
+			calls++
+			return assert.AnError
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("ListNewestFirst", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(`FROM sync_logs\s+WHERE 1=1 ORDER BY started_at DESC, id DESC LIMIT \$1 OFFSET \$2`).
+			WithArgs(10, 0).
+			WillReturnRows(sqlmock.NewRows(syncLogColumns))
+
+		// Act
+		logs, err := s.ListSyncLogs(context.Background(), SyncLogFilters{Limit: 10, NewestFirst: true})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, logs)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		from := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM sync_logs WHERE 1=1 AND started_at >= \$1$`).
+			WithArgs(from).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(42))
+
+		// Act
+		count, err := s.CountSyncLogs(context.Background(), SyncLogFilters{From: from, Limit: 10, Offset: 20})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, 42, count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestStorage_QuerySpans tests that each query runs in its own span under the caller's span
+func TestStorage_QuerySpans(t *testing.T) {
+	// Arrange
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	s, mock := newMockStorage(t)
+	mock.ExpectQuery("SELECT COUNT").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("SELECT COUNT").WillReturnError(errors.New("connection reset"))
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "request")
+
+	// Act
+	count, err := s.CountPropertiesByRating(ctx, 4.0)
+	_, failErr := s.CountPropertiesByRating(ctx, 4.0)
+	parent.End()
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Error(t, failErr)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	for _, span := range spans[:2] {
+		assert.Equal(t, "db.query", span.Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	}
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+}
+
+// jsonArg is a sqlmock argument matcher capturing the JSON bytes it is compared with
+type jsonArg struct {
+	value []byte
+}
+
+func (a *jsonArg) Match(v driver.Value) bool {
+	b, ok := v.([]byte)
+	a.value = b
+	return ok
+}
+
+// TestStorage_StorePropertyDetails_Caps tests that oversized rooms and photos arrays are capped
+// and that details over the size limit are rejected
+func TestStorage_StorePropertyDetails_Caps(t *testing.T) {
+	logger.Logger = zap.NewNop()
+
+	newPropertyData := func(rooms, photos int) *cupid.PropertyData {
+		pd := &cupid.PropertyData{Property: cupid.Property{HotelID: 12345}}
+		for i := 0; i < photos; i++ {
+			pd.Property.Photos = append(pd.Property.Photos, cupid.Photo{URL: fmt.Sprintf("https://example.com/%d.jpg", i)})
+		}
+		for i := 0; i < rooms; i++ {
+			pd.Property.Rooms = append(pd.Property.Rooms, cupid.Room{ID: int64(i), Photos: pd.Property.Photos})
+		}
+		return pd
+	}
+
+	t.Run("TruncatesArrays", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		s.config.MaxDetailRooms = 2
+		s.config.MaxDetailPhotos = 3
+		pd := newPropertyData(5, 10)
+		rooms, photos := &jsonArg{}, &jsonArg{}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO property_details`).
+			WithArgs(int64(12345), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				rooms, photos, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		tx, err := s.db.BeginTx(context.Background(), nil)
+		require.NoError(t, err)
+
+		// Act
+		err = s.storePropertyDetails(context.Background(), tx, pd)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		var storedRooms []cupid.Room
+		var storedPhotos []cupid.Photo
+		require.NoError(t, json.Unmarshal(rooms.value, &storedRooms))
+		require.NoError(t, json.Unmarshal(photos.value, &storedPhotos))
+		assert.Len(t, storedPhotos, 3)
+		require.Len(t, storedRooms, 2)
+		for _, room := range storedRooms {
+			assert.Len(t, room.Photos, 3)
+		}
+
+		// The caller's data is not modified
+		assert.Len(t, pd.Property.Rooms, 5)
+		assert.Len(t, pd.Property.Photos, 10)
+		assert.Len(t, pd.Property.Rooms[0].Photos, 10)
+	})
+
+	t.Run("SectionPerColumn", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		pd := newPropertyData(0, 0)
+		pd.Property.Address = cupid.Address{City: "Paris"}
+		pd.Property.Phone = "+33 1 23 45 67 89"
+		petsAllowed := true
+		pd.Property.PetsAllowed = &petsAllowed
+		address, contact, metadata := &jsonArg{}, &jsonArg{}, &jsonArg{}
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO property_details`).
+			WithArgs(int64(12345), address, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				sqlmock.AnyArg(), sqlmock.AnyArg(), contact, metadata).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		tx, err := s.db.BeginTx(context.Background(), nil)
+		require.NoError(t, err)
+
+		// Act
+		err = s.storePropertyDetails(context.Background(), tx, pd)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+
+		var storedAddress cupid.Address
+		require.NoError(t, json.Unmarshal(address.value, &storedAddress))
+		assert.Equal(t, "Paris", storedAddress.City)
+
+		var storedContact map[string]interface{}
+		require.NoError(t, json.Unmarshal(contact.value, &storedContact))
+		assert.Equal(t, "+33 1 23 45 67 89", storedContact["phone"])
+		assert.NotContains(t, storedContact, "address")
+
+		var storedMetadata map[string]interface{}
+		require.NoError(t, json.Unmarshal(metadata.value, &storedMetadata))
+		assert.Equal(t, true, storedMetadata["pets_allowed"])
+		assert.NotContains(t, storedMetadata, "metadata")
+	})
+
+	t.Run("RejectsOversizedDetails", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		s.config.MaxDetailsBytes = 512
+		pd := newPropertyData(1, 20)
+
+		mock.ExpectBegin()
+
+		tx, err := s.db.BeginTx(context.Background(), nil)
+		require.NoError(t, err)
+
+		// Act
+		err = s.storePropertyDetails(context.Background(), tx, pd)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrPropertyDetailsTooLarge)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestStorage_CompareStoredPropertyDetails tests that stored JSONB details are compared section by section
+func TestStorage_CompareStoredPropertyDetails(t *testing.T) {
+	logger.Logger = zap.NewNop()
+
+	pd := &cupid.PropertyData{Property: cupid.Property{
+		HotelID: 12345,
+		Address: cupid.Address{City: "Paris", Country: "fr"},
+		Phone:   "+33 1 23 45 67 89",
+		Rooms:   []cupid.Room{{ID: 1, RoomName: "Double"}},
+		Photos:  []cupid.Photo{{URL: "https://example.com/1.jpg"}},
+	}}
+	columns := []string{"address", "checkin_info", "facilities", "policies", "rooms", "photos", "contact_info", "metadata"}
+	query := `SELECT address, checkin_info, facilities, policies, rooms, photos, contact_info, metadata FROM property_details WHERE property_id = \$1`
+
+	t.Run("Matching", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		sections, err := s.marshalPropertyDetails(&pd.Property)
+		require.NoError(t, err)
+		values := make([]driver.Value, len(sections))
+		for i, section := range sections {
+			values[i] = section
+		}
+		// Key order and whitespace differ in JSONB output
+		values[0] = []byte(`{"postal_code": "", "country": "fr", "state": "", "city": "Paris", "address": ""}`)
+
+		mock.ExpectQuery(query).
+			WithArgs(int64(12345)).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(values...))
+
+		// Act
+		mismatches, err := s.CompareStoredPropertyDetails(context.Background(), pd)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, mismatches)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("DuplicatedDetails", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		blob, err := json.Marshal(map[string]interface{}{
+			"address":      pd.Property.Address,
+			"checkin":      pd.Property.CheckIn,
+			"facilities":   pd.Property.Facilities,
+			"policies":     pd.Property.Policies,
+			"rooms":        pd.Property.Rooms,
+			"photos":       pd.Property.Photos,
+			"contact_info": map[string]interface{}{"phone": pd.Property.Phone, "email": "", "fax": ""},
+			"metadata":     map[string]interface{}{"parking": nil, "group_room_min": nil, "child_allowed": nil, "pets_allowed": nil},
+		})
+		require.NoError(t, err)
+
+		mock.ExpectQuery(query).
+			WithArgs(int64(12345)).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(blob, blob, blob, blob, blob, blob, blob, blob))
+
+		// Act
+		mismatches, err := s.CompareStoredPropertyDetails(context.Background(), pd)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, columns, mismatches)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		// Arrange
+		s, mock := newMockStorage(t)
+		mock.ExpectQuery(query).
+			WithArgs(int64(12345)).
+			WillReturnError(sql.ErrNoRows)
+
+		// Act
+		mismatches, err := s.CompareStoredPropertyDetails(context.Background(), pd)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrPropertyNotFound)
+		assert.Nil(t, mismatches)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+// TestConfigFromEnv_SearchSimilarityThreshold tests that out of range similarity thresholds are clamped to 0-1
+func TestConfigFromEnv_SearchSimilarityThreshold(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected float64
+	}{
+		{"0.3", 0.3},
+		{"-0.5", 0},
+		{"40", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			// Arrange
+			t.Setenv("STORE_SEARCH_SIMILARITY_THRESHOLD", tt.value)
+
+			// Act
+			config := ConfigFromEnv()
+
+			// Assert
+			assert.Equal(t, tt.expected, config.SearchSimilarityThreshold)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// RecordPropertySync appends an entry to a property's sync history.
+// A nil or empty changes slice records a sync that found no changes.
+func (s *storage) RecordPropertySync(ctx context.Context, hotelID int64, changes []string) error {
+	if changes == nil {
+		changes = []string{}
+	}
+
+	query := `INSERT INTO property_sync_history (property_id, changes) VALUES ($1, $2)`
+	if _, err := s.db.ExecContext(ctx, query, hotelID, pq.Array(changes)); err != nil {
+		return fmt.Errorf("failed to record property sync: %w", err)
+	}
+
+	return nil
+}
+
+// MarkPropertySynced sets the last_synced_at timestamp of a property that was synced without being rewritten.
+// A captured raw Cupid API response is stored with it, so the raw response stays current for unchanged properties.
+// Returns ErrPropertyNotFound when no property has the given hotel ID.
+func (s *storage) MarkPropertySynced(ctx context.Context, hotelID int64, raw json.RawMessage) error {
+	if len(raw) == 0 {
+		return markPropertySynced(ctx, s.db, hotelID)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := markPropertySynced(ctx, tx, hotelID); err != nil {
+		return err
+	}
+	if err := s.storeRawProperty(ctx, tx, hotelID, raw); err != nil {
+		return fmt.Errorf("failed to store raw property: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// execer is implemented by both the database and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// markPropertySynced refreshes the last_synced_at timestamp of a property
+func markPropertySynced(ctx context.Context, exec execer, hotelID int64) error {
+	result, err := exec.ExecContext(ctx, `UPDATE properties SET last_synced_at = NOW() WHERE hotel_id = $1`, hotelID)
+	if err != nil {
+		return fmt.Errorf("failed to mark property synced: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to count synced properties: %w", err)
+	}
+	if affected == 0 {
+		return ErrPropertyNotFound
+	}
+
+	return nil
+}
+
+// GetPropertySyncHistory returns the most recent syncs of a property, newest first
+func (s *storage) GetPropertySyncHistory(ctx context.Context, hotelID int64, limit int) ([]PropertySyncEntry, error) {
+	query := `
+		SELECT synced_at, changes
+		FROM property_sync_history
+		WHERE property_id = $1
+		ORDER BY synced_at DESC, id DESC
+		LIMIT $2`
+
+	rows, err := s.db.QueryContext(ctx, query, hotelID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query property sync history: %w", err)
+	}
+	defer rows.Close()
+
+	history, skipped, err := scanRows(rows, s.config.SkipBadRows, func(r rowScanner) (PropertySyncEntry, error) {
+		var entry PropertySyncEntry
+		var changes pq.StringArray
+		if err := r.Scan(&entry.SyncedAt, &changes); err != nil {
+			return entry, err
+		}
+		entry.Changes = []string(changes)
+		if entry.Changes == nil {
+			entry.Changes = []string{}
+		}
+		return entry, nil
+	})
+	logSkippedRows(ctx, "property_sync_history", skipped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan property sync history: %w", err)
+	}
+
+	return history, nil
+}
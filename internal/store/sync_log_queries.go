@@ -0,0 +1,205 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"go.uber.org/zap"
+)
+
+// syncLockKey is the PostgreSQL advisory lock key held by the replica running a scheduled sync
+const syncLockKey int64 = 0x637570696473796e // "cupidsyn"
+
+// TryAcquireSyncLock takes the cluster-wide sync lock without waiting, using a PostgreSQL session advisory lock.
+// The lock lives on a dedicated connection kept until release is called; when acquired is false, release is nil.
+// If the process dies the connection closes and PostgreSQL frees the lock.
+func (s *storage) TryAcquireSyncLock(ctx context.Context) (func(), bool, error) {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get connection for sync lock: %w", err)
+	}
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", syncLockKey).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, false, fmt.Errorf("failed to acquire sync lock: %w", err)
+	}
+	if !acquired {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	release := func() {
+		// The caller's context may be cancelled by then, the unlock must still go through
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", syncLockKey); err != nil {
+			logger.Warn("Failed to release sync lock, discarding its connection", zap.Error(err))
+			// Closing the session is the only other way to free the lock, so keep it out of the pool
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+		conn.Close()
+	}
+	return release, true, nil
+}
+
+// CreateSyncLog records the start of a synchronization run
+func (s *storage) CreateSyncLog(ctx context.Context, entry *SyncLogRecord) error {
+	query := `
+		INSERT INTO sync_logs (sync_id, sync_type, status, started_at)
+		VALUES ($1, $2, $3, $4)`
+
+	if _, err := s.db.ExecContext(ctx, query, entry.SyncID, entry.SyncType, entry.Status, entry.StartedAt); err != nil {
+		return fmt.Errorf("failed to create sync log: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateSyncLog records the outcome of a synchronization run created with CreateSyncLog
+func (s *storage) UpdateSyncLog(ctx context.Context, entry *SyncLogRecord) error {
+	query := `
+		UPDATE sync_logs
+		SET status = $2, completed_at = $3, total_properties = $4, updated_properties = $5,
+			failed_properties = $6, error_message = $7
+		WHERE sync_id = $1`
+
+	var errorMessage sql.NullString
+	if entry.ErrorMessage != "" {
+		errorMessage = sql.NullString{String: entry.ErrorMessage, Valid: true}
+	}
+
+	_, err := s.db.ExecContext(ctx, query,
+		entry.SyncID, entry.Status, entry.CompletedAt, entry.TotalProperties,
+		entry.UpdatedProperties, entry.FailedProperties, errorMessage,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update sync log: %w", err)
+	}
+
+	return nil
+}
+
+// ListSyncLogs retrieves synchronization runs, oldest first unless filters.NewestFirst is set
+func (s *storage) ListSyncLogs(ctx context.Context, filters SyncLogFilters) ([]SyncLogRecord, error) {
+	query, args := buildSyncLogQuery(filters)
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sync logs: %w", err)
+	}
+	defer rows.Close()
+
+	logs, skipped, err := scanRows(rows, s.config.SkipBadRows, scanSyncLog)
+	logSkippedRows(ctx, "sync_logs", skipped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan sync logs: %w", err)
+	}
+
+	return logs, nil
+}
+
+// EachSyncLog calls fn with each synchronization run matching filters as it is read from the database cursor,
+// in the order of ListSyncLogs, so large exports are never held in memory. An error from fn stops the iteration
+// and is returned as is.
+func (s *storage) EachSyncLog(ctx context.Context, filters SyncLogFilters, fn func(SyncLogRecord) error) error {
+	query, args := buildSyncLogQuery(filters)
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to query sync logs: %w", err)
+	}
+	defer rows.Close()
+
+	skipped := 0
+	defer func() { logSkippedRows(ctx, "sync_logs", skipped) }()
+	for rows.Next() {
+		entry, err := scanSyncLog(rows)
+		if err != nil {
+			if !s.config.SkipBadRows {
+				return fmt.Errorf("failed to scan sync logs: %w", err)
+			}
+			skipped++
+			logger.Warn("Skipping row that failed to scan", zap.Error(err))
+			continue
+		}
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to scan sync logs: %w", err)
+	}
+
+	return nil
+}
+
+// buildSyncLogQuery builds the query listing the synchronization runs matching filters
+func buildSyncLogQuery(filters SyncLogFilters) (string, []interface{}) {
+	where, args := buildSyncLogFilters(filters)
+	argIndex := len(args) + 1
+
+	query := `
+		SELECT sync_id, sync_type, status, started_at, completed_at,
+			COALESCE(total_properties, 0), COALESCE(updated_properties, 0),
+			COALESCE(failed_properties, 0), COALESCE(error_message, '')
+		FROM sync_logs
+		WHERE 1=1` + where
+
+	if filters.NewestFirst {
+		query += " ORDER BY started_at DESC, id DESC"
+	} else {
+		query += " ORDER BY started_at ASC, id ASC"
+	}
+
+	if filters.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
+		args = append(args, filters.Limit, filters.Offset)
+	}
+
+	return query, args
+}
+
+// scanSyncLog scans the columns selected by buildSyncLogQuery from a row
+func scanSyncLog(r rowScanner) (SyncLogRecord, error) {
+	var entry SyncLogRecord
+	var completedAt sql.NullTime
+	err := r.Scan(
+		&entry.SyncID, &entry.SyncType, &entry.Status, &entry.StartedAt, &completedAt,
+		&entry.TotalProperties, &entry.UpdatedProperties, &entry.FailedProperties, &entry.ErrorMessage,
+	)
+	if completedAt.Valid {
+		entry.CompletedAt = &completedAt.Time
+	}
+	return entry, err
+}
+
+// CountSyncLogs counts the synchronization runs matching filters; Limit, Offset and NewestFirst are ignored
+func (s *storage) CountSyncLogs(ctx context.Context, filters SyncLogFilters) (int, error) {
+	where, args := buildSyncLogFilters(filters)
+
+	var count int
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM sync_logs WHERE 1=1"+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count sync logs: %w", err)
+	}
+
+	return count, nil
+}
+
+// buildSyncLogFilters builds the WHERE conditions shared by the sync log list and count queries.
+// Parameters are numbered from 1.
+func buildSyncLogFilters(filters SyncLogFilters) (string, []interface{}) {
+	where := ""
+	args := []interface{}{}
+
+	if !filters.From.IsZero() {
+		args = append(args, filters.From)
+		where += fmt.Sprintf(" AND started_at >= $%d", len(args))
+	}
+
+	if !filters.To.IsZero() {
+		args = append(args, filters.To)
+		where += fmt.Sprintf(" AND started_at < $%d", len(args))
+	}
+
+	return where, args
+}
@@ -35,7 +35,8 @@ func (dc *DataComparator) ComparePropertyData(fetched, stored *cupid.PropertyDat
 	}
 
 	// Compare main property data
-	if dc.compareProperty(&fetched.Property, &stored.Property) {
+	if dc.compareProperty(&fetched.Property, &stored.Property) ||
+		dc.compareContactAndPolicies(&fetched.Property, &stored.Property) {
 		changes.PropertyChanged = true
 		changes.Changes = append(changes.Changes, "property")
 	}
@@ -66,7 +67,10 @@ func (dc *DataComparator) compareProperty(fetched, stored *cupid.Property) bool
 		fetched.Stars != stored.Stars ||
 		fetched.Rating != stored.Rating ||
 		fetched.ReviewCount != stored.ReviewCount ||
-		fetched.MainImageTh != stored.MainImageTh {
+		fetched.MainImageTh != stored.MainImageTh ||
+		fetched.Description != stored.Description ||
+		fetched.MarkdownDescription != stored.MarkdownDescription ||
+		fetched.ImportantInfo != stored.ImportantInfo {
 		return true
 	}
 
@@ -84,6 +88,26 @@ func (dc *DataComparator) compareProperty(fetched, stored *cupid.Property) bool
 	return false
 }
 
+// compareContactAndPolicies compares the contact details and policies of two properties.
+// They are kept out of compareProperty since translations do not store them.
+func (dc *DataComparator) compareContactAndPolicies(fetched, stored *cupid.Property) bool {
+	return fetched.Phone != stored.Phone ||
+		fetched.Email != stored.Email ||
+		fetched.Fax != stored.Fax ||
+		!equalPtr(fetched.Parking, stored.Parking) ||
+		!equalPtr(fetched.GroupRoomMin, stored.GroupRoomMin) ||
+		!equalPtr(fetched.ChildAllowed, stored.ChildAllowed) ||
+		!equalPtr(fetched.PetsAllowed, stored.PetsAllowed)
+}
+
+// equalPtr reports whether two optional values are both unset or both set to the same value
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 // compareAddress compares two address objects
 func (dc *DataComparator) compareAddress(fetched, stored *cupid.Address) bool {
 	return fetched.Address != stored.Address ||
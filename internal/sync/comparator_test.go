@@ -55,6 +55,33 @@ func TestDataComparator_ComparePropertyData(t *testing.T) {
 		assert.Contains(t, changes.Changes, "property")
 	})
 
+	t.Run("DifferentDescriptionContactAndPolicies", func(t *testing.T) {
+		comparator := NewDataComparator()
+		for name, modify := range map[string]func(p *cupid.Property){
+			"description":   func(p *cupid.Property) { p.Description = "Renovated in 2024" },
+			"markdown":      func(p *cupid.Property) { p.MarkdownDescription = "**Renovated**" },
+			"importantInfo": func(p *cupid.Property) { p.ImportantInfo = "Cash only" },
+			"phone":         func(p *cupid.Property) { p.Phone = "+33 1 98 76 54 32" },
+			"email":         func(p *cupid.Property) { p.Email = "front@example.com" },
+			"fax":           func(p *cupid.Property) { p.Fax = "+33 1 98 76 54 33" },
+			"parking":       func(p *cupid.Property) { p.Parking = nil },
+			"groupRoomMin":  func(p *cupid.Property) { p.GroupRoomMin = intPtr(5) },
+			"petsAllowed":   func(p *cupid.Property) { p.PetsAllowed = boolPtr(true) },
+		} {
+			// Arrange
+			propertyData1 := getSamplePropertyData()
+			propertyData2 := getSamplePropertyData()
+			modify(&propertyData2.Property)
+
+			// Act
+			changes := comparator.ComparePropertyData(propertyData1, propertyData2)
+
+			// Assert
+			assert.True(t, changes.PropertyChanged, name)
+			assert.Equal(t, []string{"property"}, changes.Changes, name)
+		}
+	})
+
 	t.Run("DifferentReviews", func(t *testing.T) {
 		// Arrange
 		comparator := NewDataComparator()
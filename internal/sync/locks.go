@@ -0,0 +1,76 @@
+package sync
+
+import (
+	"sort"
+	"sync"
+)
+
+// propertyLocks serializes writes to the same property across concurrent syncs.
+// Entries are reference counted so the map only holds properties that are currently locked.
+type propertyLocks struct {
+	mu    sync.Mutex
+	locks map[int64]*propertyLock
+}
+
+// propertyLock is a per-property mutex together with the number of holders and waiters
+type propertyLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// newPropertyLocks creates an empty set of per-property locks
+func newPropertyLocks() *propertyLocks {
+	return &propertyLocks{
+		locks: make(map[int64]*propertyLock),
+	}
+}
+
+// Lock blocks until the lock for hotelID is held and returns the function that releases it
+func (l *propertyLocks) Lock(hotelID int64) func() {
+	l.mu.Lock()
+	lock, ok := l.locks[hotelID]
+	if !ok {
+		lock = &propertyLock{}
+		l.locks[hotelID] = lock
+	}
+	lock.refs++
+	l.mu.Unlock()
+
+	lock.mu.Lock()
+
+	return func() {
+		lock.mu.Unlock()
+
+		l.mu.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(l.locks, hotelID)
+		}
+		l.mu.Unlock()
+	}
+}
+
+// LockAll locks every distinct hotel ID in ascending order, so callers locking overlapping sets
+// cannot deadlock, and returns the function that releases them all
+func (l *propertyLocks) LockAll(hotelIDs []int64) func() {
+	ids := make([]int64, 0, len(hotelIDs))
+	seen := make(map[int64]bool, len(hotelIDs))
+	for _, id := range hotelIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	unlocks := make([]func(), 0, len(ids))
+	for _, id := range ids {
+		unlocks = append(unlocks, l.Lock(id))
+	}
+
+	return func() {
+		for i := len(unlocks) - 1; i >= 0; i-- {
+			unlocks[i]()
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/barimehdi77/cupid-api/internal/cupid"
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPropertyLocks tests the keyed mutex used to serialize property writes
+func TestPropertyLocks(t *testing.T) {
+	t.Run("SameIDBlocks", func(t *testing.T) {
+		// Arrange
+		locks := newPropertyLocks()
+		unlock := locks.Lock(1)
+		acquired := make(chan struct{})
+
+		// Act
+		go func() {
+			release := locks.Lock(1)
+			close(acquired)
+			release()
+		}()
+
+		// Assert
+		select {
+		case <-acquired:
+			t.Fatal("second lock on the same ID acquired while the first was held")
+		case <-time.After(50 * time.Millisecond):
+		}
+		unlock()
+		select {
+		case <-acquired:
+		case <-time.After(time.Second):
+			t.Fatal("second lock was not acquired after release")
+		}
+	})
+
+	t.Run("DifferentIDsDoNotBlock", func(t *testing.T) {
+		// Arrange
+		locks := newPropertyLocks()
+		unlock := locks.Lock(1)
+		defer unlock()
+
+		// Act
+		release := locks.Lock(2)
+		release()
+
+		// Assert
+		assert.Len(t, locks.locks, 1)
+	})
+
+	t.Run("ReleasedEntriesAreRemoved", func(t *testing.T) {
+		// Arrange
+		locks := newPropertyLocks()
+
+		// Act
+		locks.LockAll([]int64{3, 1, 2, 1})()
+
+		// Assert
+		assert.Empty(t, locks.locks)
+	})
+}
+
+// TestCompareAndUpdateProperty_ConcurrentSameID tests that overlapping updates of one property never interleave
+func TestCompareAndUpdateProperty_ConcurrentSameID(t *testing.T) {
+	logger.InitLogger()
+
+	// Arrange
+	mockStorage := new(MockStorage)
+	config := DefaultConfig()
+	config.AllowOverlap = true
+	service := NewSyncService(nil, mockStorage, config)
+
+	var inFlight, maxInFlight int32
+	var storedMu sync.Mutex
+	var stored *cupid.PropertyData
+
+	mockStorage.On("GetProperty", mock.Anything, int64(12345)).Return(nil, errors.New("property not found"))
+	mockStorage.On("StoreProperty", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+
+		// Write the property field by field so interleaved writers would mix their data
+		pd := args.Get(1).(*cupid.PropertyData)
+		storedMu.Lock()
+		if stored == nil {
+			stored = &cupid.PropertyData{}
+		}
+		stored.Property.HotelID = pd.Property.HotelID
+		storedMu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		storedMu.Lock()
+		stored.Property.HotelName = pd.Property.HotelName
+		storedMu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		storedMu.Lock()
+		stored.Property.Stars = pd.Property.Stars
+		storedMu.Unlock()
+	}).Return(nil)
+	mockStorage.On("RecordPropertySync", mock.Anything, int64(12345), []string{"created"}).Return(nil)
+
+	manual := &cupid.PropertyData{Property: cupid.Property{HotelID: 12345, HotelName: "Manual Refresh", Stars: 3}}
+	scheduled := &cupid.PropertyData{Property: cupid.Property{HotelID: 12345, HotelName: "Scheduled Sync", Stars: 5}}
+
+	// Act
+	var wg sync.WaitGroup
+	for _, pd := range []*cupid.PropertyData{manual, scheduled} {
+		wg.Add(1)
+		go func(pd *cupid.PropertyData) {
+			defer wg.Done()
+			updated, err := service.compareAndUpdateProperty(context.Background(), pd)
+			assert.NoError(t, err)
+			assert.True(t, updated)
+		}(pd)
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, int32(1), atomic.LoadInt32(&maxInFlight))
+	require.NotNil(t, stored)
+	switch stored.Property.HotelName {
+	case manual.Property.HotelName:
+		assert.Equal(t, manual.Property.Stars, stored.Property.Stars)
+	case scheduled.Property.HotelName:
+		assert.Equal(t, scheduled.Property.Stars, stored.Property.Stars)
+	default:
+		t.Fatalf("unexpected stored hotel name %q", stored.Property.HotelName)
+	}
+	mockStorage.AssertNumberOfCalls(t, "StoreProperty", 2)
+	assert.Empty(t, service.propertyLocks.locks)
+}
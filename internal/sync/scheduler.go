@@ -2,22 +2,35 @@ package sync
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
 	"sync"
 	"time"
 
 	"github.com/barimehdi77/cupid-api/internal/logger"
+	"github.com/robfig/cron/v3"
 	"go.uber.org/zap"
 )
 
-// Scheduler manages automatic synchronization timing
+// Scheduler manages automatic synchronization timing.
+// It fires every interval, or on a cron schedule when created with NewCronScheduler.
 type Scheduler struct {
 	interval  time.Duration
+	schedule  cron.Schedule
+	spec      string
 	ticker    *time.Ticker
 	stopChan  chan struct{}
 	isRunning bool
 	mu        sync.RWMutex
 	nextRun   time.Time
 	syncFunc  func(context.Context) (*SyncResult, error)
+	onResult  func(context.Context, *SyncResult, error)
+	// jitter is the upper bound of the random delay added before each run and, in interval mode,
+	// of the startup splay; it spreads replicas started together over time
+	jitter time.Duration
+	// initialDelay replaces the interval before the first run in interval mode; 0 waits a full interval
+	initialDelay time.Duration
 }
 
 // NewScheduler creates a new scheduler
@@ -30,6 +43,28 @@ func NewScheduler(interval time.Duration, syncFunc func(context.Context) (*SyncR
 	}
 }
 
+// NewCronScheduler creates a scheduler firing on a standard five-field cron spec, e.g. "0 3 * * *" for 3am daily.
+// An empty spec falls back to a fixed interval scheduler; an invalid spec is rejected.
+func NewCronScheduler(spec string, interval time.Duration, syncFunc func(context.Context) (*SyncResult, error)) (*Scheduler, error) {
+	if spec == "" {
+		return NewScheduler(interval, syncFunc), nil
+	}
+
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sync schedule %q: %w", spec, err)
+	}
+
+	return &Scheduler{
+		interval: interval,
+		schedule: schedule,
+		spec:     spec,
+		stopChan: make(chan struct{}),
+		syncFunc: syncFunc,
+		nextRun:  schedule.Next(time.Now()),
+	}, nil
+}
+
 // Start begins the scheduler
 func (s *Scheduler) Start(ctx context.Context) {
 	s.mu.Lock()
@@ -40,24 +75,91 @@ func (s *Scheduler) Start(ctx context.Context) {
 	s.isRunning = true
 	s.mu.Unlock()
 
-	s.ticker = time.NewTicker(s.interval)
-	defer s.ticker.Stop()
+	if s.schedule != nil {
+		s.startCron(ctx)
+		return
+	}
+
+	s.mu.RLock()
+	initialDelay := s.initialDelay
+	s.mu.RUnlock()
+	firstRun := s.interval
+	if initialDelay > 0 {
+		firstRun = initialDelay
+	}
+
+	// Shift the ticks of this instance by a random splay so replicas started together do not fire together
+	splay := s.randomJitter()
+	s.mu.Lock()
+	s.nextRun = time.Now().Add(splay + firstRun)
+	s.mu.Unlock()
+	if splay > 0 {
+		logger.Info("Delaying scheduler start", zap.Duration("splay", splay))
+		if !s.wait(ctx, splay) {
+			logger.Info("Scheduler stopped before its first run")
+			return
+		}
+	}
 
 	logger.Info("Scheduler started",
 		zap.Duration("interval", s.interval),
-		zap.Time("next_run", s.nextRun),
+		zap.Duration("initial_delay", initialDelay),
+		zap.Time("next_run", s.GetNextRun()),
+	)
+
+	// Run the first sync after the initial delay, then fall back to the steady interval
+	if initialDelay > 0 {
+		if !s.wait(ctx, initialDelay) || !s.wait(ctx, s.randomJitter()) {
+			logger.Info("Scheduler stopped before its first run")
+			return
+		}
+		s.runSync(ctx)
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	s.mu.Lock()
+	s.ticker = ticker
+	s.mu.Unlock()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Scheduler stopped due to context cancellation")
+			return
+		case <-s.stopChan:
+			logger.Info("Scheduler stopped manually")
+			return
+		case <-ticker.C:
+			if s.wait(ctx, s.randomJitter()) {
+				s.runSync(ctx)
+			}
+		}
+	}
+}
+
+// startCron runs the scheduler loop in cron mode, sleeping until each next run
+func (s *Scheduler) startCron(ctx context.Context) {
+	logger.Info("Scheduler started",
+		zap.String("schedule", s.spec),
+		zap.Time("next_run", s.GetNextRun()),
 	)
 
 	for {
+		timer := time.NewTimer(time.Until(s.GetNextRun()))
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			logger.Info("Scheduler stopped due to context cancellation")
 			return
 		case <-s.stopChan:
+			timer.Stop()
 			logger.Info("Scheduler stopped manually")
 			return
-		case <-s.ticker.C:
-			s.runSync(ctx)
+		case <-timer.C:
+			if s.wait(ctx, s.randomJitter()) {
+				s.runSync(ctx)
+			}
 		}
 	}
 }
@@ -81,6 +183,63 @@ func (s *Scheduler) Stop() {
 	logger.Info("Scheduler stopped")
 }
 
+// SetResultHook registers fn to receive the outcome of every scheduled synchronization.
+// Runs skipped because another synchronization was in progress, or because another instance holds
+// the sync lock, are not reported.
+func (s *Scheduler) SetResultHook(fn func(context.Context, *SyncResult, error)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onResult = fn
+}
+
+// SetJitter sets the upper bound of the random delay added before each run, and of the startup splay in interval mode.
+// Zero, the default, runs exactly on schedule.
+func (s *Scheduler) SetJitter(jitter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jitter = jitter
+}
+
+// SetInitialDelay sets the delay before the first run in interval mode, so a fresh deployment does not wait
+// a full interval for its first scheduled sync. Later runs follow the interval. Zero, the default, waits a full
+// interval; cron schedules are not affected.
+func (s *Scheduler) SetInitialDelay(delay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.initialDelay = delay
+}
+
+// randomJitter returns a random delay below the configured jitter, or 0 without jitter
+func (s *Scheduler) randomJitter() time.Duration {
+	s.mu.RLock()
+	jitter := s.jitter
+	s.mu.RUnlock()
+
+	if jitter <= 0 {
+		return 0
+	}
+	return rand.N(jitter)
+}
+
+// wait blocks for d and reports whether the scheduler is still running afterwards
+func (s *Scheduler) wait(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-s.stopChan:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
 // IsRunning returns whether the scheduler is running
 func (s *Scheduler) IsRunning() bool {
 	s.mu.RLock()
@@ -88,6 +247,11 @@ func (s *Scheduler) IsRunning() bool {
 	return s.isRunning
 }
 
+// Spec returns the cron spec of the scheduler, or an empty string in interval mode
+func (s *Scheduler) Spec() string {
+	return s.spec
+}
+
 // GetNextRun returns the next scheduled run time
 func (s *Scheduler) GetNextRun() time.Time {
 	s.mu.RLock()
@@ -103,7 +267,10 @@ func (s *Scheduler) runSync(ctx context.Context) {
 	result, err := s.syncFunc(ctx)
 	duration := time.Since(startTime)
 
-	if err != nil {
+	skipped := errors.Is(err, ErrSyncInProgress) || errors.Is(err, ErrNotSyncLeader)
+	if skipped {
+		logger.Info("Scheduled sync skipped", zap.String("reason", err.Error()))
+	} else if err != nil {
 		logger.LogError("Scheduled sync failed", err,
 			zap.Duration("duration", duration),
 		)
@@ -116,9 +283,20 @@ func (s *Scheduler) runSync(ctx context.Context) {
 		)
 	}
 
+	s.mu.RLock()
+	onResult := s.onResult
+	s.mu.RUnlock()
+	if onResult != nil && !skipped {
+		onResult(ctx, result, err)
+	}
+
 	// Update next run time
 	s.mu.Lock()
-	s.nextRun = time.Now().Add(s.interval)
+	if s.schedule != nil {
+		s.nextRun = s.schedule.Next(time.Now())
+	} else {
+		s.nextRun = time.Now().Add(s.interval)
+	}
 	s.mu.Unlock()
 
 	logger.Debug("Next sync scheduled",
@@ -2,11 +2,14 @@ package sync
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/barimehdi77/cupid-api/internal/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockSyncFunc is a mock sync function
@@ -113,3 +116,265 @@ func TestScheduler_Constructor(t *testing.T) {
 		}
 	})
 }
+
+// TestScheduler_ResultHook tests that the result hook receives scheduled outcomes but not skipped runs
+func TestScheduler_ResultHook(t *testing.T) {
+	logger.InitLogger()
+
+	t.Run("ReportsFailure", func(t *testing.T) {
+		// Arrange
+		mockSyncFunc := &MockSyncFunc{}
+		scheduler := NewScheduler(time.Hour, mockSyncFunc.Sync)
+		result := &SyncResult{SyncID: "sync_1", Status: "failed"}
+		mockSyncFunc.On("Sync", mock.Anything).Return(result, errors.New("upstream unavailable"))
+
+		var gotResult *SyncResult
+		var gotErr error
+		scheduler.SetResultHook(func(ctx context.Context, r *SyncResult, err error) {
+			gotResult, gotErr = r, err
+		})
+
+		// Act
+		scheduler.runSync(context.Background())
+
+		// Assert
+		assert.Equal(t, result, gotResult)
+		assert.EqualError(t, gotErr, "upstream unavailable")
+	})
+
+	t.Run("IgnoresSkippedRun", func(t *testing.T) {
+		// Arrange
+		mockSyncFunc := &MockSyncFunc{}
+		scheduler := NewScheduler(time.Hour, mockSyncFunc.Sync)
+		mockSyncFunc.On("Sync", mock.Anything).Return(nil, ErrSyncInProgress)
+
+		called := false
+		scheduler.SetResultHook(func(context.Context, *SyncResult, error) { called = true })
+
+		// Act
+		scheduler.runSync(context.Background())
+
+		// Assert
+		assert.False(t, called)
+	})
+}
+
+// TestNewCronScheduler tests creating a scheduler from a cron spec
+func TestNewCronScheduler(t *testing.T) {
+	t.Run("ComputesNextRunFromSpec", func(t *testing.T) {
+		// Arrange
+		mockSyncFunc := &MockSyncFunc{}
+
+		// Act
+		scheduler, err := NewCronScheduler("0 3 * * *", time.Hour, mockSyncFunc.Sync)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "0 3 * * *", scheduler.Spec())
+		nextRun := scheduler.GetNextRun()
+		assert.Equal(t, 3, nextRun.Hour())
+		assert.Zero(t, nextRun.Minute())
+		assert.True(t, nextRun.After(time.Now()))
+		assert.True(t, nextRun.Before(time.Now().Add(24*time.Hour+time.Minute)))
+	})
+
+	t.Run("EmptySpecFallsBackToInterval", func(t *testing.T) {
+		// Arrange
+		mockSyncFunc := &MockSyncFunc{}
+
+		// Act
+		scheduler, err := NewCronScheduler("", 2*time.Hour, mockSyncFunc.Sync)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Nil(t, scheduler.schedule)
+		assert.Empty(t, scheduler.Spec())
+		assert.Equal(t, 2*time.Hour, scheduler.interval)
+	})
+
+	t.Run("InvalidSpec", func(t *testing.T) {
+		// Arrange
+		mockSyncFunc := &MockSyncFunc{}
+
+		// Act
+		scheduler, err := NewCronScheduler("every night", time.Hour, mockSyncFunc.Sync)
+
+		// Assert
+		assert.Nil(t, scheduler)
+		assert.ErrorContains(t, err, `invalid sync schedule "every night"`)
+	})
+}
+
+// TestScheduler_CronFires tests that a cron scheduler runs the sync on schedule and computes the following run
+func TestScheduler_CronFires(t *testing.T) {
+	logger.InitLogger()
+
+	// Arrange
+	mockSyncFunc := &MockSyncFunc{}
+	scheduler, err := NewCronScheduler("* * * * *", time.Hour, mockSyncFunc.Sync)
+	require.NoError(t, err)
+
+	fired := make(chan struct{}, 1)
+	mockSyncFunc.On("Sync", mock.Anything).Run(func(mock.Arguments) {
+		fired <- struct{}{}
+	}).Return(&SyncResult{}, nil)
+
+	// Run immediately instead of waiting for the next minute
+	scheduler.mu.Lock()
+	scheduler.nextRun = time.Now()
+	scheduler.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Act
+	done := make(chan struct{})
+	go func() {
+		scheduler.Start(ctx)
+		close(done)
+	}()
+
+	// Assert
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("cron scheduler did not fire")
+	}
+	assert.Eventually(t, func() bool {
+		nextRun := scheduler.GetNextRun()
+		return nextRun.After(time.Now()) && nextRun.Second() == 0
+	}, time.Second, 10*time.Millisecond)
+	scheduler.Stop()
+	<-done
+}
+
+// TestScheduler_Jitter tests the random delay spreading runs of replicas started together
+func TestScheduler_Jitter(t *testing.T) {
+	logger.InitLogger()
+
+	t.Run("StaysBelowBound", func(t *testing.T) {
+		// Arrange
+		scheduler := NewScheduler(time.Hour, (&MockSyncFunc{}).Sync)
+		scheduler.SetJitter(50 * time.Millisecond)
+
+		// Act & Assert
+		for i := 0; i < 100; i++ {
+			delay := scheduler.randomJitter()
+			assert.GreaterOrEqual(t, delay, time.Duration(0))
+			assert.Less(t, delay, 50*time.Millisecond)
+		}
+	})
+
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		// Arrange
+		scheduler := NewScheduler(time.Hour, (&MockSyncFunc{}).Sync)
+
+		// Act & Assert
+		assert.Zero(t, scheduler.randomJitter())
+	})
+
+	t.Run("StartupSplayDelaysFirstRun", func(t *testing.T) {
+		// Arrange
+		mockSyncFunc := &MockSyncFunc{}
+		scheduler := NewScheduler(time.Hour, mockSyncFunc.Sync)
+		scheduler.SetJitter(time.Hour)
+		before := time.Now()
+
+		// Act
+		done := make(chan struct{})
+		go func() {
+			scheduler.Start(context.Background())
+			close(done)
+		}()
+
+		// Assert
+		assert.Eventually(t, func() bool {
+			return scheduler.GetNextRun().After(before.Add(time.Hour))
+		}, time.Second, 10*time.Millisecond)
+		scheduler.Stop()
+		<-done
+		mockSyncFunc.AssertNotCalled(t, "Sync", mock.Anything)
+	})
+
+	t.Run("WaitInterruptedByStop", func(t *testing.T) {
+		// Arrange
+		scheduler := NewScheduler(time.Hour, (&MockSyncFunc{}).Sync)
+		scheduler.isRunning = true
+		done := make(chan bool)
+
+		// Act
+		go func() { done <- scheduler.wait(context.Background(), time.Hour) }()
+		scheduler.Stop()
+
+		// Assert
+		select {
+		case completed := <-done:
+			assert.False(t, completed)
+		case <-time.After(time.Second):
+			t.Fatal("wait was not interrupted")
+		}
+	})
+}
+
+// TestScheduler_InitialDelay tests that the first run honors the initial delay instead of the interval
+func TestScheduler_InitialDelay(t *testing.T) {
+	logger.InitLogger()
+
+	t.Run("FirstRunAfterInitialDelay", func(t *testing.T) {
+		// Arrange
+		mockSyncFunc := &MockSyncFunc{}
+		scheduler := NewScheduler(time.Hour, mockSyncFunc.Sync)
+		scheduler.SetInitialDelay(50 * time.Millisecond)
+
+		fired := make(chan time.Time, 1)
+		mockSyncFunc.On("Sync", mock.Anything).Run(func(mock.Arguments) {
+			fired <- time.Now()
+		}).Return(&SyncResult{}, nil)
+		start := time.Now()
+
+		// Act
+		done := make(chan struct{})
+		go func() {
+			scheduler.Start(context.Background())
+			close(done)
+		}()
+
+		// Assert
+		select {
+		case firedAt := <-fired:
+			assert.GreaterOrEqual(t, firedAt.Sub(start), 50*time.Millisecond)
+		case <-time.After(time.Second):
+			t.Fatal("first run did not honor the initial delay")
+		}
+		assert.Eventually(t, func() bool {
+			return scheduler.GetNextRun().After(start.Add(59 * time.Minute))
+		}, time.Second, 10*time.Millisecond)
+		scheduler.Stop()
+		<-done
+		mockSyncFunc.AssertNumberOfCalls(t, "Sync", 1)
+	})
+
+	t.Run("NextRunReflectsInitialDelay", func(t *testing.T) {
+		// Arrange
+		mockSyncFunc := &MockSyncFunc{}
+		scheduler := NewScheduler(12*time.Hour, mockSyncFunc.Sync)
+		scheduler.SetInitialDelay(time.Hour)
+		start := time.Now()
+
+		// Act
+		done := make(chan struct{})
+		go func() {
+			scheduler.Start(context.Background())
+			close(done)
+		}()
+
+		// Assert
+		assert.Eventually(t, func() bool {
+			nextRun := scheduler.GetNextRun()
+			return !nextRun.Before(start.Add(time.Hour)) && nextRun.Before(start.Add(2*time.Hour))
+		}, time.Second, 10*time.Millisecond)
+		scheduler.Stop()
+		<-done
+		mockSyncFunc.AssertNotCalled(t, "Sync", mock.Anything)
+	})
+}
@@ -11,6 +11,22 @@ type SyncStats struct {
 	FailedProperties  int       `json:"failed_properties"`
 	LastSync          time.Time `json:"last_sync"`
 	LastError         error     `json:"last_error,omitempty"`
+	// PropertyChanges, PropertyChangesTruncated and the verification fields are copied from the last SyncResult
+	PropertyChanges          []PropertyChangeSummary `json:"property_changes,omitempty"`
+	PropertyChangesTruncated bool                    `json:"property_changes_truncated,omitempty"`
+	VerifiedProperties       int                     `json:"verified_properties,omitempty"`
+	VerificationMismatches   []PropertyChangeSummary `json:"verification_mismatches,omitempty"`
+}
+
+// PropertyChangeSummary describes what a synchronization did to one property.
+// Changes lists the changed parts of the property ("created", "property", "reviews", "translations")
+// and Error is set when the property could not be compared or stored.
+// Warnings copies the fetch warnings of the property, listing the parts of its data left incomplete.
+type PropertyChangeSummary struct {
+	HotelID  int64    `json:"hotel_id"`
+	Changes  []string `json:"changes,omitempty"`
+	Error    string   `json:"error,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 // SyncResult represents the result of a synchronization operation
@@ -24,18 +40,61 @@ type SyncResult struct {
 	UpdatedProperties int           `json:"updated_properties"`
 	FailedProperties  int           `json:"failed_properties"`
 	Error             error         `json:"error,omitempty"`
+	// PropertyChanges lists changed and failed properties and those fetched with warnings, other unchanged ones
+	// are left out.
+	// It holds at most Config.MaxChangeSummaries entries; PropertyChangesTruncated reports dropped ones.
+	PropertyChanges          []PropertyChangeSummary `json:"property_changes,omitempty"`
+	PropertyChangesTruncated bool                    `json:"property_changes_truncated,omitempty"`
+	// VerifiedProperties is the number of updated properties read back when Config.VerifySampleSize is set.
+	// VerificationMismatches lists those whose stored data did not match the fetched data or could not be read.
+	VerifiedProperties     int                     `json:"verified_properties,omitempty"`
+	VerificationMismatches []PropertyChangeSummary `json:"verification_mismatches,omitempty"`
 }
 
 // SyncStatus represents the current status of the sync service
 type SyncStatus struct {
 	IsRunning         bool      `json:"is_running"`
+	AutoSyncEnabled   bool      `json:"auto_sync_enabled"`
+	ScheduleState     string    `json:"schedule_state"`
+	IsSyncLeader      bool      `json:"is_sync_leader"`
+	CurrentSyncID     string    `json:"current_sync_id,omitempty"`
 	LastSync          time.Time `json:"last_sync"`
 	NextSync          time.Time `json:"next_sync"`
 	TotalProperties   int       `json:"total_properties"`
 	UpdatedProperties int       `json:"updated_properties"`
 	FailedProperties  int       `json:"failed_properties"`
 	SyncInterval      string    `json:"sync_interval"`
+	SyncSchedule      string    `json:"sync_schedule,omitempty"`
 	LastError         error     `json:"last_error,omitempty"`
+	// PropertyChanges and PropertyChangesTruncated describe the last completed synchronization
+	PropertyChanges          []PropertyChangeSummary `json:"property_changes,omitempty"`
+	PropertyChangesTruncated bool                    `json:"property_changes_truncated,omitempty"`
+	// VerifiedProperties and VerificationMismatches report the storage verification of the last synchronization
+	VerifiedProperties     int                     `json:"verified_properties,omitempty"`
+	VerificationMismatches []PropertyChangeSummary `json:"verification_mismatches,omitempty"`
+}
+
+// Automatic synchronization states reported in SyncStatus.ScheduleState and ScheduleInfo.State
+const (
+	// ScheduleStateDisabled means automatic sync is turned off in the configuration
+	ScheduleStateDisabled = "disabled"
+	// ScheduleStateStopped means automatic sync is enabled but the scheduler is not running
+	ScheduleStateStopped = "stopped"
+	// ScheduleStateScheduled means the scheduler is running and NextRun is set
+	ScheduleStateScheduled = "scheduled"
+)
+
+// ScheduleInfo describes the effective automatic synchronization schedule.
+// NextRun and NextRunIn are only set in the scheduled state; the actual run may start up to Jitter later.
+type ScheduleInfo struct {
+	AutoSyncEnabled bool       `json:"auto_sync_enabled"`
+	State           string     `json:"state"`
+	Mode            string     `json:"mode"`
+	Interval        string     `json:"interval,omitempty"`
+	Schedule        string     `json:"schedule,omitempty"`
+	Jitter          string     `json:"jitter,omitempty"`
+	NextRun         *time.Time `json:"next_run,omitempty"`
+	NextRunIn       string     `json:"next_run_in,omitempty"`
 }
 
 // SyncLog represents a sync operation log entry
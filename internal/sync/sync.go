@@ -2,28 +2,52 @@ package sync
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/barimehdi77/cupid-api/internal/cupid"
+	"github.com/barimehdi77/cupid-api/internal/env"
 	"github.com/barimehdi77/cupid-api/internal/logger"
+	"github.com/barimehdi77/cupid-api/internal/metrics"
 	"github.com/barimehdi77/cupid-api/internal/store"
+	"github.com/robfig/cron/v3"
 	"go.uber.org/zap"
 )
 
+// CupidService fetches property data from the Cupid API; it is implemented by *cupid.Service
+type CupidService interface {
+	FetchAllProperties(ctx context.Context) ([]*cupid.PropertyData, error)
+	FetchProperty(ctx context.Context, propertyID int64) (*cupid.PropertyData, error)
+	FetchProperties(ctx context.Context, propertyIDs []int64) ([]*cupid.PropertyData, error)
+}
+
 // SyncService manages data synchronization between Cupid API and database
 type SyncService struct {
-	cupidService *cupid.Service
-	storage      store.Storage
-	scheduler    *Scheduler
-	config       *Config
-	isRunning    bool
-	lastSync     time.Time
-	stats        *SyncStats
-	mu           sync.RWMutex
+	cupidService  CupidService
+	storage       store.Storage
+	scheduler     *Scheduler
+	config        *Config
+	isRunning     bool
+	isLeader      bool
+	currentSyncID string
+	lastSync      time.Time
+	stats         *SyncStats
+	mu            sync.RWMutex
+	syncLock      sync.Mutex
+	propertyLocks *propertyLocks
 }
 
+// ErrSyncInProgress is returned when a sync is requested while another one is still running
+var ErrSyncInProgress = errors.New("a synchronization is already in progress")
+
+// ErrNotSyncLeader is returned when a scheduled sync is skipped because another replica holds the sync lock
+var ErrNotSyncLeader = errors.New("another instance holds the sync lock")
+
 // Config holds synchronization configuration
 type Config struct {
 	Interval        time.Duration
@@ -33,32 +57,96 @@ type Config struct {
 	RetryDelay      time.Duration
 	RateLimitPerSec int
 	EnableAuto      bool
+	// AllowOverlap lets manual, scheduled and per-property syncs run at the same time.
+	// Writes to the same property are still serialized.
+	AllowOverlap bool
+	// SyncOnStartup runs a synchronization in the background as soon as the service starts,
+	// so a fresh deployment does not wait a full interval for data
+	SyncOnStartup bool
+	// Schedule is a standard five-field cron spec, e.g. "0 3 * * *" for 3am daily, used instead of Interval when set
+	Schedule string
+	// InitialDelay is the delay before the first scheduled sync in interval mode, independent of Interval;
+	// 0 waits a full interval. It does not apply to cron schedules.
+	InitialDelay time.Duration
+	// Jitter is the upper bound of a random delay added before each scheduled run, and of a startup splay
+	// in interval mode, so replicas started together spread their syncs out; 0 disables it
+	Jitter time.Duration
+	// DistributedLock makes scheduled syncs take a database advisory lock first, so only one replica
+	// runs each of them; the others skip it
+	DistributedLock bool
+	// MaxChangeSummaries caps the per-property change summaries kept in a SyncResult; 0 keeps none
+	MaxChangeSummaries int
+	// WebhookURL receives a JSON summary of scheduled synchronizations; empty disables notifications.
+	// Posts are retried RetryAttempts times, RetryDelay apart with exponential backoff.
+	WebhookURL string
+	// WebhookFailureRate is the failure rate, in percent, from which a completed run is posted.
+	// Failed runs are always posted; 0 posts every run.
+	WebhookFailureRate float64
+	// VerifySampleSize is the number of updated properties read back after a sync to check that the stored
+	// data matches what was fetched; 0 disables verification
+	VerifySampleSize int
+	// ReviewRetentionDays drops fetched reviews dated more than this many days ago before they are compared and
+	// stored, so reviews pruned by the prune-reviews maintenance endpoint are not stored again; 0 keeps every review
+	ReviewRetentionDays int
 }
 
 // DefaultConfig returns default synchronization configuration
 func DefaultConfig() *Config {
 	return &Config{
-		Interval:        12 * time.Hour,
-		BatchSize:       10,
-		MaxConcurrent:   5,
-		RetryAttempts:   3,
-		RetryDelay:      5 * time.Second,
-		RateLimitPerSec: 10,
-		EnableAuto:      true,
+		Interval:           12 * time.Hour,
+		BatchSize:          10,
+		MaxConcurrent:      5,
+		RetryAttempts:      3,
+		RetryDelay:         5 * time.Second,
+		RateLimitPerSec:    10,
+		EnableAuto:         true,
+		AllowOverlap:       false,
+		SyncOnStartup:      false,
+		MaxChangeSummaries: 100,
+		DistributedLock:    true,
+	}
+}
+
+// ConfigFromEnv returns synchronization configuration overridden by environment variables
+func ConfigFromEnv() *Config {
+	config := DefaultConfig()
+	config.SyncOnStartup = env.GetEnvBool("SYNC_ON_STARTUP", config.SyncOnStartup)
+	config.Schedule = env.GetEnvString("SYNC_SCHEDULE", config.Schedule)
+	config.InitialDelay = env.GetEnvDuration("SYNC_INITIAL_DELAY", config.InitialDelay)
+	config.Jitter = env.GetEnvDuration("SYNC_JITTER", config.Jitter)
+	config.DistributedLock = env.GetEnvBool("SYNC_DISTRIBUTED_LOCK", config.DistributedLock)
+	config.MaxChangeSummaries = env.GetEnvInt("SYNC_MAX_CHANGE_SUMMARIES", config.MaxChangeSummaries)
+	config.WebhookURL = env.GetEnvString("SYNC_WEBHOOK_URL", config.WebhookURL)
+	config.WebhookFailureRate = env.GetEnvFloat("SYNC_WEBHOOK_FAILURE_RATE", config.WebhookFailureRate)
+	config.VerifySampleSize = env.GetEnvInt("SYNC_VERIFY_SAMPLE_SIZE", config.VerifySampleSize)
+	config.ReviewRetentionDays = env.GetEnvInt("SYNC_REVIEW_RETENTION_DAYS", config.ReviewRetentionDays)
+	return config
+}
+
+// Validate reports configuration that would otherwise only fail once the scheduler starts
+func (c *Config) Validate() error {
+	if c.Schedule == "" {
+		return nil
+	}
+	if _, err := cron.ParseStandard(c.Schedule); err != nil {
+		return fmt.Errorf("invalid sync schedule %q: %w", c.Schedule, err)
 	}
+	return nil
 }
 
 // NewSyncService creates a new synchronization service
-func NewSyncService(cupidService *cupid.Service, storage store.Storage, config *Config) *SyncService {
+func NewSyncService(cupidService CupidService, storage store.Storage, config *Config) *SyncService {
 	if config == nil {
 		config = DefaultConfig()
 	}
 
 	return &SyncService{
-		cupidService: cupidService,
-		storage:      storage,
-		config:       config,
-		stats:        &SyncStats{},
+		cupidService:  cupidService,
+		storage:       storage,
+		config:        config,
+		isLeader:      !config.DistributedLock,
+		stats:         &SyncStats{},
+		propertyLocks: newPropertyLocks(),
 	}
 }
 
@@ -71,16 +159,32 @@ func (s *SyncService) Start(ctx context.Context) error {
 		return fmt.Errorf("sync service is already running")
 	}
 
+	if s.config.SyncOnStartup {
+		go s.runStartupSync(ctx)
+	}
+
 	if !s.config.EnableAuto {
 		logger.Info("Automatic sync is disabled")
 		return nil
 	}
 
-	s.scheduler = NewScheduler(s.config.Interval, s.performSync)
+	scheduler, err := NewCronScheduler(s.config.Schedule, s.config.Interval, s.runScheduledSync)
+	if err != nil {
+		return err
+	}
+	s.scheduler = scheduler
+	s.scheduler.SetJitter(s.config.Jitter)
+	s.scheduler.SetInitialDelay(s.config.InitialDelay)
+	if notifier := newWebhookNotifier(s.config); notifier != nil {
+		s.scheduler.SetResultHook(notifier.Notify)
+	}
 	s.isRunning = true
 
 	logger.LogStartup("Sync Service",
 		zap.Duration("interval", s.config.Interval),
+		zap.String("schedule", s.config.Schedule),
+		zap.Duration("initial_delay", s.config.InitialDelay),
+		zap.Duration("jitter", s.config.Jitter),
 		zap.Int("batch_size", s.config.BatchSize),
 		zap.Int("max_concurrent", s.config.MaxConcurrent),
 	)
@@ -110,17 +214,175 @@ func (s *SyncService) Stop() error {
 	return nil
 }
 
-// SyncNow performs an immediate synchronization
+// SyncNow performs an immediate synchronization.
+// It returns ErrSyncInProgress if another synchronization is already running.
 func (s *SyncService) SyncNow(ctx context.Context) (*SyncResult, error) {
+	unlock, err := s.lockSync("manual")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	return s.runManualSync(ctx)
+}
+
+// TriggerSync starts a manual synchronization in the background and returns without waiting for it.
+// If another synchronization is already running, it returns ErrSyncInProgress along with the ID of
+// the running sync, which is empty if that sync has not created its log yet.
+func (s *SyncService) TriggerSync(ctx context.Context) (string, error) {
+	unlock, err := s.lockSync("manual")
+	if err != nil {
+		return s.CurrentSyncID(), err
+	}
+
+	go func() {
+		defer unlock()
+		_, _ = s.runManualSync(ctx)
+	}()
+
+	return "", nil
+}
+
+// runManualSync performs a manual synchronization; the caller must hold the sync lock
+func (s *SyncService) runManualSync(ctx context.Context) (*SyncResult, error) {
 	logger.Info("Starting manual synchronization")
 
-	result, err := s.performSync(ctx)
+	result, err := s.performSync(ctx, "manual")
 	if err != nil {
 		logger.LogError("Manual sync failed", err)
 		return result, err
 	}
 
 	logger.LogSuccess("Manual sync completed",
+		zap.String("sync_id", result.SyncID),
+		zap.Int("total_properties", result.TotalProperties),
+		zap.Int("updated_properties", result.UpdatedProperties),
+		zap.Int("failed_properties", result.FailedProperties),
+		zap.Any("property_changes", result.PropertyChanges),
+		zap.Duration("duration", result.Duration),
+	)
+
+	return result, nil
+}
+
+// SyncProperty fetches a single property from the Cupid API and updates it if it changed.
+// It returns ErrSyncInProgress if another synchronization is already running.
+func (s *SyncService) SyncProperty(ctx context.Context, hotelID int64) (bool, error) {
+	var updated bool
+	err := s.runExclusive("property", func() error {
+		propertyData, err := s.cupidService.FetchProperty(ctx, hotelID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch property: %w", err)
+		}
+
+		updated, err = s.compareAndUpdateProperty(ctx, propertyData)
+		return err
+	})
+
+	return updated, err
+}
+
+// SyncCountries resyncs only the stored properties whose country is one of countries, for a market that
+// reported stale data. The run is logged like any other sync but, being partial, leaves the status of the
+// last full synchronization untouched.
+// It returns ErrSyncInProgress if another synchronization is already running.
+func (s *SyncService) SyncCountries(ctx context.Context, countries []string) (*SyncResult, error) {
+	var result *SyncResult
+	err := s.runExclusive("country", func() error {
+		var err error
+		result, err = s.performCountrySync(ctx, newSyncID("sync_country", time.Now()), countries)
+		return err
+	})
+	return result, err
+}
+
+// TriggerCountrySync starts a country sync (see SyncCountries) in the background and returns its sync ID without
+// waiting for it. If another synchronization is already running, it returns ErrSyncInProgress along with the ID of
+// the running sync.
+func (s *SyncService) TriggerCountrySync(ctx context.Context, countries []string) (string, error) {
+	unlock, err := s.lockSync("country")
+	if err != nil {
+		return s.CurrentSyncID(), err
+	}
+
+	syncID := newSyncID("sync_country", time.Now())
+	go func() {
+		defer unlock()
+		if _, err := s.performCountrySync(ctx, syncID, countries); err != nil {
+			logger.LogError("Country sync failed", err, zap.String("sync_id", syncID), zap.Strings("countries", countries))
+		}
+	}()
+
+	return syncID, nil
+}
+
+// newSyncID returns the ID of a sync starting at startTime: prefix, the start time to the second, then a random
+// suffix so runs starting within the same second never share an ID
+func newSyncID(prefix string, startTime time.Time) string {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return fmt.Sprintf("%s_%s", prefix, startTime.Format("20060102_150405.000000000"))
+	}
+	return fmt.Sprintf("%s_%s_%s", prefix, startTime.Format("20060102_150405"), hex.EncodeToString(suffix))
+}
+
+// performCountrySync fetches and processes the stored properties of the given countries
+func (s *SyncService) performCountrySync(ctx context.Context, syncID string, countries []string) (*SyncResult, error) {
+	result := &SyncResult{
+		SyncID:    syncID,
+		StartTime: time.Now(),
+		Status:    "running",
+	}
+
+	s.setCurrentSyncID(syncID)
+	defer s.setCurrentSyncID("")
+
+	if err := s.createSyncLog(ctx, "country", result); err != nil {
+		logger.Warn("Failed to create sync log", zap.Error(err))
+	}
+
+	fail := func(err error) (*SyncResult, error) {
+		result.Status = "failed"
+		result.Error = err
+		result.EndTime = time.Now()
+		result.Duration = result.EndTime.Sub(result.StartTime)
+		s.updateSyncLog(ctx, result)
+		return result, err
+	}
+
+	stored, err := s.storage.GetPropertiesByCountries(ctx, countries)
+	if err != nil {
+		return fail(fmt.Errorf("failed to list properties: %w", err))
+	}
+
+	propertyIDs := make([]int64, 0, len(stored))
+	for _, property := range stored {
+		propertyIDs = append(propertyIDs, property.HotelID)
+	}
+	logger.Info("Fetching country properties from Cupid API",
+		zap.String("sync_id", result.SyncID),
+		zap.Strings("countries", countries),
+		zap.Int("count", len(propertyIDs)),
+	)
+
+	var properties []*cupid.PropertyData
+	if len(propertyIDs) > 0 {
+		properties, err = s.cupidService.FetchProperties(ctx, propertyIDs)
+		if err != nil {
+			return fail(fmt.Errorf("failed to fetch properties: %w", err))
+		}
+	}
+
+	result.TotalProperties = len(properties)
+	s.processProperties(ctx, result, properties)
+	result.EndTime = time.Now()
+	result.Duration = result.EndTime.Sub(result.StartTime)
+	result.Status = "completed"
+	s.updateSyncLog(ctx, result)
+
+	logger.LogSuccess("Country sync completed",
+		zap.String("sync_id", result.SyncID),
+		zap.Strings("countries", countries),
 		zap.Int("total_properties", result.TotalProperties),
 		zap.Int("updated_properties", result.UpdatedProperties),
 		zap.Int("failed_properties", result.FailedProperties),
@@ -130,51 +392,222 @@ func (s *SyncService) SyncNow(ctx context.Context) (*SyncResult, error) {
 	return result, nil
 }
 
+// runScheduledSync is the scheduler's entry point into a guarded synchronization.
+// With DistributedLock, it returns ErrNotSyncLeader when another replica holds the sync lock.
+func (s *SyncService) runScheduledSync(ctx context.Context) (*SyncResult, error) {
+	var result *SyncResult
+	err := s.runExclusive("scheduled", func() error {
+		if s.config.DistributedLock {
+			release, err := s.acquireSyncLock(ctx)
+			if err != nil {
+				return err
+			}
+			defer release()
+		}
+
+		var err error
+		result, err = s.performSync(ctx, "scheduled")
+		return err
+	})
+	return result, err
+}
+
+// acquireSyncLock takes the cluster-wide sync lock and records whether this instance is the sync leader.
+// The returned function releases the lock, after which this instance is no longer the leader.
+func (s *SyncService) acquireSyncLock(ctx context.Context) (func(), error) {
+	release, acquired, err := s.storage.TryAcquireSyncLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.isLeader = acquired
+	s.mu.Unlock()
+
+	if !acquired {
+		logger.Info("Skipping scheduled synchronization, another instance holds the sync lock")
+		return nil, ErrNotSyncLeader
+	}
+	return func() {
+		release()
+		s.mu.Lock()
+		s.isLeader = false
+		s.mu.Unlock()
+	}, nil
+}
+
+// runStartupSync runs the initial synchronization requested by SyncOnStartup.
+// It is skipped when another synchronization is already in progress.
+func (s *SyncService) runStartupSync(ctx context.Context) {
+	logger.Info("Starting startup synchronization")
+
+	var result *SyncResult
+	err := s.runExclusive("startup", func() error {
+		var err error
+		result, err = s.performSync(ctx, "startup")
+		return err
+	})
+	if errors.Is(err, ErrSyncInProgress) {
+		return
+	}
+	if err != nil {
+		logger.LogError("Startup sync failed", err)
+		return
+	}
+
+	logger.LogSuccess("Startup sync completed",
+		zap.Int("total_properties", result.TotalProperties),
+		zap.Int("updated_properties", result.UpdatedProperties),
+		zap.Int("failed_properties", result.FailedProperties),
+		zap.Duration("duration", result.Duration),
+	)
+}
+
+// runExclusive runs fn unless another synchronization of any kind is already in progress.
+// Every sync entry point goes through here, or lockSync, so manual, scheduled and per-property syncs never overlap.
+func (s *SyncService) runExclusive(syncType string, fn func() error) error {
+	unlock, err := s.lockSync(syncType)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return fn()
+}
+
+// lockSync takes the local sync lock without waiting and returns the function releasing it.
+// It returns ErrSyncInProgress if another synchronization holds the lock; with AllowOverlap nothing is locked.
+func (s *SyncService) lockSync(syncType string) (func(), error) {
+	if s.config.AllowOverlap {
+		return func() {}, nil
+	}
+
+	if !s.syncLock.TryLock() {
+		logger.Warn("Skipping synchronization, another one is in progress",
+			zap.String("sync_type", syncType),
+		)
+		return nil, ErrSyncInProgress
+	}
+	return s.syncLock.Unlock, nil
+}
+
+// CurrentSyncID returns the ID of the full or country synchronization in progress, or an empty string if none is
+// running
+func (s *SyncService) CurrentSyncID() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.currentSyncID
+}
+
+// setCurrentSyncID records the ID of the full or country synchronization in progress
+func (s *SyncService) setCurrentSyncID(syncID string) {
+	s.mu.Lock()
+	s.currentSyncID = syncID
+	s.mu.Unlock()
+}
+
 // GetStatus returns the current synchronization status
 func (s *SyncService) GetStatus() *SyncStatus {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	nextSync := time.Time{}
-	if s.scheduler != nil {
+	state := s.scheduleState()
+	if state == ScheduleStateScheduled {
 		nextSync = s.scheduler.GetNextRun()
 	}
 
 	return &SyncStatus{
-		IsRunning:         s.isRunning,
-		LastSync:          s.lastSync,
-		NextSync:          nextSync,
-		TotalProperties:   s.stats.TotalProperties,
-		UpdatedProperties: s.stats.UpdatedProperties,
-		FailedProperties:  s.stats.FailedProperties,
-		SyncInterval:      s.config.Interval.String(),
-		LastError:         s.stats.LastError,
+		IsRunning:                s.isRunning,
+		AutoSyncEnabled:          s.config.EnableAuto,
+		ScheduleState:            state,
+		IsSyncLeader:             s.isLeader,
+		CurrentSyncID:            s.currentSyncID,
+		LastSync:                 s.lastSync,
+		NextSync:                 nextSync,
+		TotalProperties:          s.stats.TotalProperties,
+		UpdatedProperties:        s.stats.UpdatedProperties,
+		FailedProperties:         s.stats.FailedProperties,
+		SyncInterval:             s.config.Interval.String(),
+		SyncSchedule:             s.config.Schedule,
+		LastError:                s.stats.LastError,
+		PropertyChanges:          s.stats.PropertyChanges,
+		PropertyChangesTruncated: s.stats.PropertyChangesTruncated,
+		VerifiedProperties:       s.stats.VerifiedProperties,
+		VerificationMismatches:   s.stats.VerificationMismatches,
 	}
 }
 
-// performSync performs the actual synchronization work
-func (s *SyncService) performSync(ctx context.Context) (*SyncResult, error) {
-	startTime := time.Now()
-	syncID := fmt.Sprintf("sync_%s", startTime.Format("20060102_150405"))
+// GetSchedule returns the effective automatic synchronization schedule and, when scheduled, the next run time
+func (s *SyncService) GetSchedule() *ScheduleInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-	// Create sync log entry
-	if err := s.createSyncLog(ctx, syncID, "running"); err != nil {
-		logger.Warn("Failed to create sync log", zap.Error(err))
+	info := &ScheduleInfo{
+		AutoSyncEnabled: s.config.EnableAuto,
+		State:           s.scheduleState(),
+		Mode:            "interval",
+		Interval:        s.config.Interval.String(),
+	}
+	if s.config.Schedule != "" {
+		info.Mode = "cron"
+		info.Interval = ""
+		info.Schedule = s.config.Schedule
+	}
+	if s.config.Jitter > 0 {
+		info.Jitter = s.config.Jitter.String()
+	}
+
+	if info.State == ScheduleStateScheduled {
+		nextRun := s.scheduler.GetNextRun()
+		info.NextRun = &nextRun
+		info.NextRunIn = max(time.Until(nextRun), 0).Round(time.Second).String()
 	}
 
+	return info
+}
+
+// scheduleState returns the state of automatic synchronization; the caller must hold s.mu
+func (s *SyncService) scheduleState() string {
+	switch {
+	case !s.config.EnableAuto:
+		return ScheduleStateDisabled
+	case !s.isRunning || s.scheduler == nil:
+		return ScheduleStateStopped
+	default:
+		return ScheduleStateScheduled
+	}
+}
+
+// performSync performs the actual synchronization work
+func (s *SyncService) performSync(ctx context.Context, syncType string) (*SyncResult, error) {
+	startTime := time.Now()
+	syncID := newSyncID("sync", startTime)
+
 	result := &SyncResult{
 		SyncID:    syncID,
 		StartTime: startTime,
 		Status:    "running",
 	}
 
+	s.setCurrentSyncID(syncID)
+	defer s.setCurrentSyncID("")
+
+	// Create sync log entry
+	if err := s.createSyncLog(ctx, syncType, result); err != nil {
+		logger.Warn("Failed to create sync log", zap.Error(err))
+	}
+
 	// Fetch all properties from Cupid API
 	logger.Info("Fetching properties from Cupid API")
 	properties, err := s.cupidService.FetchAllProperties(ctx)
 	if err != nil {
 		result.Status = "failed"
 		result.Error = err
-		s.updateSyncLog(ctx, syncID, "failed", err)
+		result.EndTime = time.Now()
+		result.Duration = result.EndTime.Sub(result.StartTime)
+		s.updateSyncLog(ctx, result)
+		metrics.RecordSyncFailure()
 		return result, fmt.Errorf("failed to fetch properties: %w", err)
 	}
 
@@ -183,9 +616,40 @@ func (s *SyncService) performSync(ctx context.Context) (*SyncResult, error) {
 		zap.Int("count", len(properties)),
 	)
 
-	// Process properties in batches
+	s.processProperties(ctx, result, properties)
+	result.EndTime = time.Now()
+	result.Duration = result.EndTime.Sub(result.StartTime)
+	result.Status = "completed"
+
+	// Update sync log
+	s.updateSyncLog(ctx, result)
+	metrics.RecordSyncSuccess(result.UpdatedProperties, result.TotalProperties-result.UpdatedProperties-result.FailedProperties, result.FailedProperties, result.EndTime)
+
+	// Update stats
+	s.mu.Lock()
+	s.lastSync = result.EndTime
+	s.stats = &SyncStats{
+		TotalProperties:          result.TotalProperties,
+		UpdatedProperties:        result.UpdatedProperties,
+		FailedProperties:         result.FailedProperties,
+		LastSync:                 result.EndTime,
+		LastError:                nil,
+		PropertyChanges:          result.PropertyChanges,
+		PropertyChangesTruncated: result.PropertyChangesTruncated,
+		VerifiedProperties:       result.VerifiedProperties,
+		VerificationMismatches:   result.VerificationMismatches,
+	}
+	s.mu.Unlock()
+
+	return result, nil
+}
+
+// processProperties compares and stores fetched properties in batches of Config.BatchSize, then verifies a
+// sample of the stored ones. It records the updated and failed counts, change summaries and verification in result.
+func (s *SyncService) processProperties(ctx context.Context, result *SyncResult, properties []*cupid.PropertyData) {
 	updatedCount := 0
 	failedCount := 0
+	var stored []*cupid.PropertyData
 
 	for i := 0; i < len(properties); i += s.config.BatchSize {
 		end := i + s.config.BatchSize
@@ -194,7 +658,7 @@ func (s *SyncService) performSync(ctx context.Context) (*SyncResult, error) {
 		}
 
 		batch := properties[i:end]
-		batchUpdated, batchFailed, err := s.processBatch(ctx, batch)
+		batchUpdated, batchFailed, summaries, err := s.processBatch(ctx, batch)
 		if err != nil {
 			logger.LogError("Failed to process batch", err,
 				zap.Int("batch_start", i),
@@ -205,41 +669,60 @@ func (s *SyncService) performSync(ctx context.Context) (*SyncResult, error) {
 			updatedCount += batchUpdated
 			failedCount += batchFailed
 		}
+		s.appendChangeSummaries(result, summaries)
+		if s.config.VerifySampleSize > 0 {
+			stored = append(stored, storedProperties(batch, summaries)...)
+		}
+	}
+
+	if sample := sampleForVerification(stored, s.config.VerifySampleSize); len(sample) > 0 {
+		result.VerifiedProperties = len(sample)
+		result.VerificationMismatches = s.verifyStoredProperties(ctx, sample)
+		logger.Info("Verified stored properties",
+			zap.String("sync_id", result.SyncID),
+			zap.Int("verified", result.VerifiedProperties),
+			zap.Int("mismatches", len(result.VerificationMismatches)),
+		)
 	}
 
-	// Update result
 	result.UpdatedProperties = updatedCount
 	result.FailedProperties = failedCount
-	result.EndTime = time.Now()
-	result.Duration = result.EndTime.Sub(result.StartTime)
-	result.Status = "completed"
-
-	// Update sync log
-	s.updateSyncLog(ctx, syncID, "completed", nil)
+}
 
-	// Update stats
-	s.mu.Lock()
-	s.lastSync = result.EndTime
-	s.stats = &SyncStats{
-		TotalProperties:   result.TotalProperties,
-		UpdatedProperties: result.UpdatedProperties,
-		FailedProperties:  result.FailedProperties,
-		LastSync:          result.EndTime,
-		LastError:         nil,
+// appendChangeSummaries adds the change summaries of a batch to result, up to Config.MaxChangeSummaries
+func (s *SyncService) appendChangeSummaries(result *SyncResult, summaries []PropertyChangeSummary) {
+	room := s.config.MaxChangeSummaries - len(result.PropertyChanges)
+	if room < 0 {
+		room = 0
 	}
-	s.mu.Unlock()
-
-	return result, nil
+	if len(summaries) > room {
+		summaries = summaries[:room]
+		result.PropertyChangesTruncated = true
+	}
+	result.PropertyChanges = append(result.PropertyChanges, summaries...)
 }
 
-// processBatch processes a batch of properties
-func (s *SyncService) processBatch(ctx context.Context, properties []*cupid.PropertyData) (int, int, error) {
+// processBatch processes a batch of properties.
+// Changed properties are detected concurrently and then written together with a single batch store.
+// The batch holds the locks of all its properties until the store completes.
+// It returns the updated and failed counts along with a change summary, ordered by hotel ID,
+// for every property that changed, failed or was fetched with warnings.
+func (s *SyncService) processBatch(ctx context.Context, properties []*cupid.PropertyData) (int, int, []PropertyChangeSummary, error) {
+	hotelIDs := make([]int64, 0, len(properties))
+	for _, pd := range properties {
+		hotelIDs = append(hotelIDs, pd.Property.HotelID)
+	}
+	unlock := s.propertyLocks.LockAll(hotelIDs)
+	defer unlock()
+
 	semaphore := make(chan struct{}, s.config.MaxConcurrent)
 	var wg sync.WaitGroup
 	var mu sync.Mutex
 
-	updatedCount := 0
 	failedCount := 0
+	var toStore []*cupid.PropertyData
+	changesByID := make(map[int64][]string)
+	var summaries []PropertyChangeSummary
 
 	for _, propertyData := range properties {
 		wg.Add(1)
@@ -252,85 +735,308 @@ func (s *SyncService) processBatch(ctx context.Context, properties []*cupid.Prop
 			// Add rate limiting
 			time.Sleep(time.Duration(1000/s.config.RateLimitPerSec) * time.Millisecond)
 
-			// Compare and update property
-			updated, err := s.compareAndUpdateProperty(ctx, pd)
+			// Compare with the stored property
+			changes, err := s.detectPropertyChanges(ctx, pd)
 
 			mu.Lock()
 			if err != nil {
 				failedCount++
-				logger.LogError("Failed to update property", err,
+				summaries = append(summaries, PropertyChangeSummary{
+					HotelID:  pd.Property.HotelID,
+					Error:    err.Error(),
+					Warnings: pd.FetchWarnings,
+				})
+				logger.LogError("Failed to compare property", err,
 					zap.Int64("property_id", pd.Property.HotelID),
 				)
-			} else if updated {
-				updatedCount++
+			} else if len(changes) > 0 {
+				toStore = append(toStore, pd)
+				changesByID[pd.Property.HotelID] = changes
+			} else if len(pd.FetchWarnings) > 0 {
+				summaries = append(summaries, PropertyChangeSummary{HotelID: pd.Property.HotelID, Warnings: pd.FetchWarnings})
 			}
 			mu.Unlock()
 		}(propertyData)
 	}
 
 	wg.Wait()
-	return updatedCount, failedCount, nil
+
+	if len(toStore) == 0 {
+		return 0, failedCount, sortChangeSummaries(summaries), nil
+	}
+
+	failures, err := s.storage.StorePropertiesBatch(ctx, toStore)
+	if err != nil {
+		err = fmt.Errorf("failed to store property batch: %w", err)
+		for _, pd := range toStore {
+			summaries = append(summaries, PropertyChangeSummary{
+				HotelID:  pd.Property.HotelID,
+				Changes:  changesByID[pd.Property.HotelID],
+				Error:    err.Error(),
+				Warnings: pd.FetchWarnings,
+			})
+		}
+		return 0, 0, sortChangeSummaries(summaries), err
+	}
+
+	for hotelID, storeErr := range failures {
+		logger.LogError("Failed to update property", storeErr,
+			zap.Int64("property_id", hotelID),
+		)
+	}
+
+	for _, pd := range toStore {
+		summary := PropertyChangeSummary{
+			HotelID:  pd.Property.HotelID,
+			Changes:  changesByID[pd.Property.HotelID],
+			Warnings: pd.FetchWarnings,
+		}
+		if storeErr, failed := failures[pd.Property.HotelID]; failed {
+			summary.Error = storeErr.Error()
+		} else {
+			s.recordPropertySync(ctx, pd.Property.HotelID, summary.Changes)
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return len(toStore) - len(failures), failedCount + len(failures), sortChangeSummaries(summaries), nil
+}
+
+// storedProperties returns the properties of a batch that its change summaries report as successfully stored
+func storedProperties(batch []*cupid.PropertyData, summaries []PropertyChangeSummary) []*cupid.PropertyData {
+	byID := make(map[int64]*cupid.PropertyData, len(batch))
+	for _, pd := range batch {
+		byID[pd.Property.HotelID] = pd
+	}
+
+	var stored []*cupid.PropertyData
+	for _, summary := range summaries {
+		if summary.Error == "" && len(summary.Changes) > 0 {
+			stored = append(stored, byID[summary.HotelID])
+		}
+	}
+	return stored
+}
+
+// sortChangeSummaries orders summaries by hotel ID so concurrent comparisons produce a stable report
+func sortChangeSummaries(summaries []PropertyChangeSummary) []PropertyChangeSummary {
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].HotelID < summaries[j].HotelID
+	})
+	return summaries
 }
 
 // compareAndUpdateProperty compares fetched data with stored data and updates if different
 func (s *SyncService) compareAndUpdateProperty(ctx context.Context, fetchedData *cupid.PropertyData) (bool, error) {
+	unlock := s.propertyLocks.Lock(fetchedData.Property.HotelID)
+	defer unlock()
+
+	changes, err := s.detectPropertyChanges(ctx, fetchedData)
+	if err != nil || len(changes) == 0 {
+		return false, err
+	}
+
+	if err := s.storage.StoreProperty(ctx, fetchedData); err != nil {
+		return false, fmt.Errorf("failed to update property: %w", err)
+	}
+
+	s.recordPropertySync(ctx, fetchedData.Property.HotelID, changes)
+	return true, nil
+}
+
+// detectPropertyChanges returns what differs between fetched data and the stored property,
+// or "created" for a property that is not stored yet.
+// Manual overrides of the stored property are merged over a copy of fetchedData for the comparison only: an
+// override wins over the upstream value, so it is not reported as a change, while fetchedData keeps the upstream
+// values that get stored. Likewise imported reviews and stored reviews an incomplete review fetch did not return
+// are not changes. Reviews outside the retention window are dropped from fetchedData first.
+// Unchanged properties get their sync timestamp refreshed and an empty history entry, and an empty slice is returned.
+func (s *SyncService) detectPropertyChanges(ctx context.Context, fetchedData *cupid.PropertyData) ([]string, error) {
+	s.dropExpiredReviews(fetchedData)
+
 	// Get stored property data
 	storedData, err := s.storage.GetProperty(ctx, fetchedData.Property.HotelID)
 	if err != nil {
-		// Property doesn't exist, store it
-		if err := s.storage.StoreProperty(ctx, fetchedData); err != nil {
-			return false, fmt.Errorf("failed to store new property: %w", err)
-		}
-		return true, nil
+		// Property doesn't exist, it needs to be stored
+		return []string{"created"}, nil
 	}
+	keepSkippedTranslations(fetchedData, storedData)
 
 	// Compare data
 	comparator := NewDataComparator()
-	changes := comparator.ComparePropertyData(fetchedData, storedData)
+	changes := comparator.ComparePropertyData(comparableData(fetchedData, storedData), storedData)
 	if !changes.HasChanges() {
 		// No changes, just update sync timestamp
-		return false, s.updateSyncTimestamp(ctx, fetchedData.Property.HotelID)
-	}
-
-	// Update property with changes
-	if err := s.storage.StoreProperty(ctx, fetchedData); err != nil {
-		return false, fmt.Errorf("failed to update property: %w", err)
+		if err := s.updateSyncTimestamp(ctx, fetchedData); err != nil {
+			return nil, err
+		}
+		s.recordPropertySync(ctx, fetchedData.Property.HotelID, nil)
+		return nil, nil
 	}
 
-	logger.Debug("Property updated",
+	logger.Debug("Property changed",
 		zap.Int64("property_id", fetchedData.Property.HotelID),
 		zap.Strings("changes", changes.Changes),
 	)
 
-	return true, nil
+	return changes.Changes, nil
 }
 
-// updateSyncTimestamp updates the last_synced timestamp for a property
-func (s *SyncService) updateSyncTimestamp(ctx context.Context, hotelID int64) error {
-	// This would be implemented in the storage layer
-	// For now, we'll just log it
-	logger.Debug("Updating sync timestamp",
-		zap.Int64("property_id", hotelID),
-	)
-	return nil
+// dropExpiredReviews removes from fetchedData the reviews dated before the ReviewRetentionDays window, the way
+// DeletePropertyReviewsOlderThan prunes stored ones; undated reviews are kept
+func (s *SyncService) dropExpiredReviews(fetchedData *cupid.PropertyData) {
+	if s.config.ReviewRetentionDays <= 0 {
+		return
+	}
+
+	year, month, day := time.Now().UTC().AddDate(0, 0, -s.config.ReviewRetentionDays).Date()
+	cutoff := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+
+	kept := make([]cupid.Review, 0, len(fetchedData.Reviews))
+	for _, review := range fetchedData.Reviews {
+		if date, ok := cupid.ParseReviewDate(review.Date); ok && date.Before(cutoff) {
+			continue
+		}
+		kept = append(kept, review)
+	}
+	if dropped := len(fetchedData.Reviews) - len(kept); dropped > 0 {
+		logger.Debug("Dropped reviews outside the retention window",
+			zap.Int64("property_id", fetchedData.Property.HotelID),
+			zap.Int("dropped", dropped),
+			zap.String("cutoff", cutoff.Format(time.DateOnly)),
+		)
+		fetchedData.Reviews = kept
+	}
 }
 
-// createSyncLog creates a new sync log entry
-func (s *SyncService) createSyncLog(ctx context.Context, syncID, status string) error {
-	// This would be implemented in the storage layer
-	logger.Debug("Creating sync log",
-		zap.String("sync_id", syncID),
-		zap.String("status", status),
-	)
+// keepSkippedTranslations copies into fetchedData the stored translations of the languages the fetch skipped
+// because of the per-property language cap, so they are neither reported as changed nor deleted on store
+func keepSkippedTranslations(fetchedData, storedData *cupid.PropertyData) {
+	for _, lang := range fetchedData.SkippedTranslations {
+		translation, ok := storedData.Translations[lang]
+		if !ok {
+			continue
+		}
+		if fetchedData.Translations == nil {
+			fetchedData.Translations = make(map[string]*cupid.Property)
+		}
+		fetchedData.Translations[lang] = translation
+	}
+}
+
+// comparableData returns a shallow copy of fetchedData for comparing it with storedData, which is read with its
+// overrides applied and keeps the imported reviews and the reviews an incomplete fetch did not return: the
+// overrides are applied to the copy, and the stored reviews missing from fetchedData that storing it would keep
+// are added to it
+func comparableData(fetchedData, storedData *cupid.PropertyData) *cupid.PropertyData {
+	compared := *fetchedData
+	store.ApplyPropertyOverrides(&compared.Property, storedData.Overrides)
+
+	fetched := make(map[int64]bool, len(fetchedData.Reviews))
+	for _, review := range fetchedData.Reviews {
+		fetched[review.ReviewID] = true
+	}
+	compared.Reviews = append([]cupid.Review(nil), fetchedData.Reviews...)
+	for _, review := range storedData.Reviews {
+		if !fetched[review.ReviewID] && (review.Imported || !fetchedData.ReviewsComplete) {
+			compared.Reviews = append(compared.Reviews, review)
+		}
+	}
+	return &compared
+}
+
+// recordPropertySync appends a sync history entry for a property.
+// History is informational, so a failure is logged and does not fail the sync.
+func (s *SyncService) recordPropertySync(ctx context.Context, hotelID int64, changes []string) {
+	if err := s.storage.RecordPropertySync(ctx, hotelID, changes); err != nil {
+		logger.Warn("Failed to record property sync history",
+			zap.Int64("property_id", hotelID),
+			zap.Error(err),
+		)
+	}
+}
+
+// updateSyncTimestamp refreshes the last_synced_at timestamp and the captured raw response of an unchanged property.
+// Changed properties get them refreshed when they are stored.
+func (s *SyncService) updateSyncTimestamp(ctx context.Context, fetchedData *cupid.PropertyData) error {
+	if err := s.storage.MarkPropertySynced(ctx, fetchedData.Property.HotelID, fetchedData.RawProperty); err != nil {
+		return fmt.Errorf("failed to update sync timestamp: %w", err)
+	}
 	return nil
 }
 
-// updateSyncLog updates a sync log entry
-func (s *SyncService) updateSyncLog(ctx context.Context, syncID, status string, err error) {
-	// This would be implemented in the storage layer
-	logger.Debug("Updating sync log",
-		zap.String("sync_id", syncID),
-		zap.String("status", status),
-		zap.Error(err),
-	)
+// createSyncLog persists the start of a synchronization run
+func (s *SyncService) createSyncLog(ctx context.Context, syncType string, result *SyncResult) error {
+	return s.storage.CreateSyncLog(ctx, &store.SyncLogRecord{
+		SyncID:    result.SyncID,
+		SyncType:  syncType,
+		Status:    result.Status,
+		StartedAt: result.StartTime,
+	})
+}
+
+// updateSyncLog persists the outcome of a synchronization run.
+// Failures are only logged so a logging problem never fails the sync itself.
+func (s *SyncService) updateSyncLog(ctx context.Context, result *SyncResult) {
+	entry := &store.SyncLogRecord{
+		SyncID:            result.SyncID,
+		Status:            result.Status,
+		CompletedAt:       &result.EndTime,
+		TotalProperties:   result.TotalProperties,
+		UpdatedProperties: result.UpdatedProperties,
+		FailedProperties:  result.FailedProperties,
+	}
+	if result.Error != nil {
+		entry.ErrorMessage = result.Error.Error()
+	}
+
+	if err := s.storage.UpdateSyncLog(ctx, entry); err != nil {
+		logger.Warn("Failed to update sync log",
+			zap.String("sync_id", result.SyncID),
+			zap.Error(err),
+		)
+	}
+}
+
+// ListSyncLogs returns persisted synchronization runs matching filters, oldest first unless filters.NewestFirst is set
+func (s *SyncService) ListSyncLogs(ctx context.Context, filters store.SyncLogFilters) ([]SyncLog, error) {
+	records, err := s.storage.ListSyncLogs(ctx, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]SyncLog, 0, len(records))
+	for _, record := range records {
+		logs = append(logs, syncLogFromRecord(record))
+	}
+
+	return logs, nil
+}
+
+// EachSyncLog calls fn with each persisted synchronization run matching filters as it is read, in the order of
+// ListSyncLogs; an error from fn stops the iteration and is returned
+func (s *SyncService) EachSyncLog(ctx context.Context, filters store.SyncLogFilters, fn func(SyncLog) error) error {
+	return s.storage.EachSyncLog(ctx, filters, func(record store.SyncLogRecord) error {
+		return fn(syncLogFromRecord(record))
+	})
+}
+
+// syncLogFromRecord converts a stored synchronization run
+func syncLogFromRecord(record store.SyncLogRecord) SyncLog {
+	return SyncLog{
+		SyncID:            record.SyncID,
+		SyncType:          record.SyncType,
+		Status:            record.Status,
+		StartedAt:         record.StartedAt,
+		CompletedAt:       record.CompletedAt,
+		TotalProperties:   record.TotalProperties,
+		UpdatedProperties: record.UpdatedProperties,
+		FailedProperties:  record.FailedProperties,
+		ErrorMessage:      record.ErrorMessage,
+	}
+}
+
+// CountSyncLogs counts the persisted synchronization runs matching filters
+func (s *SyncService) CountSyncLogs(ctx context.Context, filters store.SyncLogFilters) (int, error) {
+	return s.storage.CountSyncLogs(ctx, filters)
 }
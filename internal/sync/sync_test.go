@@ -2,13 +2,18 @@ package sync
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/barimehdi77/cupid-api/internal/cupid"
+	"github.com/barimehdi77/cupid-api/internal/logger"
 	"github.com/barimehdi77/cupid-api/internal/store"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // getSamplePropertyData creates sample property data for testing
@@ -140,6 +145,14 @@ func (m *MockCupidService) FetchProperty(ctx context.Context, propertyID int64)
 	return args.Get(0).(*cupid.PropertyData), args.Error(1)
 }
 
+func (m *MockCupidService) FetchProperties(ctx context.Context, propertyIDs []int64) ([]*cupid.PropertyData, error) {
+	args := m.Called(ctx, propertyIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*cupid.PropertyData), args.Error(1)
+}
+
 // MockStorage is a mock implementation of the Storage interface
 type MockStorage struct {
 	mock.Mock
@@ -213,16 +226,16 @@ func (m *MockStorage) GetTranslationByLanguage(ctx context.Context, hotelID int6
 	return args.Get(0).(*cupid.Property), args.Error(1)
 }
 
-func (m *MockStorage) SearchProperties(ctx context.Context, query string, limit, offset int) ([]*cupid.Property, error) {
-	args := m.Called(ctx, query, limit, offset)
+func (m *MockStorage) SearchProperties(ctx context.Context, query string, fields []string, fuzzy bool, limit, offset int) ([]*cupid.Property, error) {
+	args := m.Called(ctx, query, fields, fuzzy, limit, offset)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*cupid.Property), args.Error(1)
 }
 
-func (m *MockStorage) CountSearchProperties(ctx context.Context, query string) (int, error) {
-	args := m.Called(ctx, query)
+func (m *MockStorage) CountSearchProperties(ctx context.Context, query string, fields []string, fuzzy bool) (int, error) {
+	args := m.Called(ctx, query, fields, fuzzy)
 	return args.Int(0), args.Error(1)
 }
 
@@ -252,6 +265,291 @@ func (m *MockStorage) CountPropertiesByRating(ctx context.Context, minRating flo
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockStorage) GetPropertiesWithOldestReviews(ctx context.Context, limit int) ([]*store.PropertyReviewAge, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.PropertyReviewAge), args.Error(1)
+}
+
+func (m *MockStorage) ListPropertyReviews(ctx context.Context, hotelID int64, filters store.ReviewFilters) ([]cupid.Review, error) {
+	args := m.Called(ctx, hotelID, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]cupid.Review), args.Error(1)
+}
+
+func (m *MockStorage) CountPropertyReviews(ctx context.Context, hotelID int64, filters store.ReviewFilters) (int, error) {
+	args := m.Called(ctx, hotelID, filters)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStorage) ListFacilities(ctx context.Context) ([]store.FacilitySummary, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]store.FacilitySummary), args.Error(1)
+}
+
+func (m *MockStorage) ListPropertiesWithReviewAverages(ctx context.Context, limit, offset int, filters store.PropertyFilters) ([]*store.PropertyWithReviewAverage, error) {
+	args := m.Called(ctx, limit, offset, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.PropertyWithReviewAverage), args.Error(1)
+}
+
+func (m *MockStorage) GetReviewStats(ctx context.Context, hotelID int64) (*store.ReviewStats, error) {
+	args := m.Called(ctx, hotelID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.ReviewStats), args.Error(1)
+}
+
+func (m *MockStorage) PurgeOrphans(ctx context.Context) (*store.OrphanPurgeResult, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.OrphanPurgeResult), args.Error(1)
+}
+
+func (m *MockStorage) GetPropertiesByPostalCodePrefix(ctx context.Context, prefix string, limit, offset int) ([]*cupid.Property, error) {
+	args := m.Called(ctx, prefix, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*cupid.Property), args.Error(1)
+}
+
+func (m *MockStorage) CountPropertiesByPostalCodePrefix(ctx context.Context, prefix string) (int, error) {
+	args := m.Called(ctx, prefix)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStorage) StorePropertiesBatch(ctx context.Context, properties []*cupid.PropertyData) (map[int64]error, error) {
+	args := m.Called(ctx, properties)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]error), args.Error(1)
+}
+
+func (m *MockStorage) RecordPropertySync(ctx context.Context, hotelID int64, changes []string) error {
+	args := m.Called(ctx, hotelID, changes)
+	return args.Error(0)
+}
+
+func (m *MockStorage) GetPropertySyncHistory(ctx context.Context, hotelID int64, limit int) ([]store.PropertySyncEntry, error) {
+	args := m.Called(ctx, hotelID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]store.PropertySyncEntry), args.Error(1)
+}
+
+func (m *MockStorage) CreateSyncLog(ctx context.Context, entry *store.SyncLogRecord) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockStorage) UpdateSyncLog(ctx context.Context, entry *store.SyncLogRecord) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockStorage) ListSyncLogs(ctx context.Context, filters store.SyncLogFilters) ([]store.SyncLogRecord, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]store.SyncLogRecord), args.Error(1)
+}
+
+func (m *MockStorage) GetPropertySummary(ctx context.Context, hotelID int64) (*cupid.Property, error) {
+	args := m.Called(ctx, hotelID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cupid.Property), args.Error(1)
+}
+
+func (m *MockStorage) GetPropertiesWithDuplicateCoordinates(ctx context.Context) ([]store.CoordinateCluster, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]store.CoordinateCluster), args.Error(1)
+}
+
+func (m *MockStorage) GetAverageRatingByStars(ctx context.Context) ([]store.StarRatingAverage, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]store.StarRatingAverage), args.Error(1)
+}
+
+func (m *MockStorage) GetPropertiesWithStaleTranslations(ctx context.Context, olderThan time.Time, limit int) ([]*store.PropertyTranslationAge, error) {
+	args := m.Called(ctx, olderThan, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.PropertyTranslationAge), args.Error(1)
+}
+
+func (m *MockStorage) TryAcquireSyncLock(ctx context.Context) (func(), bool, error) {
+	args := m.Called(ctx)
+	release, _ := args.Get(0).(func())
+	return release, args.Bool(1), args.Error(2)
+}
+
+func (m *MockStorage) MarkPropertySynced(ctx context.Context, hotelID int64, raw json.RawMessage) error {
+	args := m.Called(ctx, hotelID, raw)
+	return args.Error(0)
+}
+
+func (m *MockStorage) GetPropertiesByQualityScore(ctx context.Context, limit, offset int) ([]*store.PropertyWithQualityScore, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.PropertyWithQualityScore), args.Error(1)
+}
+
+func (m *MockStorage) GetStaleProperties(ctx context.Context, olderThan time.Duration, limit int) ([]*cupid.Property, error) {
+	args := m.Called(ctx, olderThan, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*cupid.Property), args.Error(1)
+}
+
+func (m *MockStorage) SetPropertyOverrides(ctx context.Context, hotelID int64, overrides map[string]*string) error {
+	args := m.Called(ctx, hotelID, overrides)
+	return args.Error(0)
+}
+
+func (m *MockStorage) GetPropertyOverrides(ctx context.Context, hotelID int64) (map[string]string, error) {
+	args := m.Called(ctx, hotelID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (m *MockStorage) GetTranslationCounts(ctx context.Context, hotelIDs []int64) (map[int64]int, error) {
+	args := m.Called(ctx, hotelIDs)
+	counts, _ := args.Get(0).(map[int64]int)
+	return counts, args.Error(1)
+}
+
+func (m *MockStorage) GetPropertiesWithConflictingChains(ctx context.Context) ([]store.ChainConflict, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]store.ChainConflict), args.Error(1)
+}
+
+func (m *MockStorage) ListRoomAmenities(ctx context.Context) ([]store.RoomAmenitySummary, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]store.RoomAmenitySummary), args.Error(1)
+}
+
+func (m *MockStorage) GetPropertiesByCountries(ctx context.Context, countries []string) ([]*cupid.Property, error) {
+	args := m.Called(ctx, countries)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*cupid.Property), args.Error(1)
+}
+
+func (m *MockStorage) GetRawProperty(ctx context.Context, hotelID int64) (json.RawMessage, error) {
+	args := m.Called(ctx, hotelID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(json.RawMessage), args.Error(1)
+}
+
+func (m *MockStorage) GetTopRatedByCity(ctx context.Context, limit int) ([]*cupid.Property, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*cupid.Property), args.Error(1)
+}
+
+func (m *MockStorage) ListChains(ctx context.Context) ([]store.ChainSummary, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]store.ChainSummary), args.Error(1)
+}
+
+func (m *MockStorage) GetPropertyFacets(ctx context.Context, filters store.PropertyFilters) (*store.PropertyFacets, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.PropertyFacets), args.Error(1)
+}
+
+func (m *MockStorage) ImportReviews(ctx context.Context, hotelID int64, reviews []cupid.Review) (*store.ReviewImportResult, error) {
+	args := m.Called(ctx, hotelID, reviews)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*store.ReviewImportResult), args.Error(1)
+}
+
+func (m *MockStorage) DeletePropertyReviewsOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	args := m.Called(ctx, cutoff)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockStorage) GetNearbyProperties(ctx context.Context, hotelID int64, radiusKm float64, limit int) ([]*store.PropertyWithDistance, error) {
+	args := m.Called(ctx, hotelID, radiusKm, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*store.PropertyWithDistance), args.Error(1)
+}
+
+func (m *MockStorage) CountSyncLogs(ctx context.Context, filters store.SyncLogFilters) (int, error) {
+	args := m.Called(ctx, filters)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStorage) CompareStoredPropertyDetails(ctx context.Context, propertyData *cupid.PropertyData) ([]string, error) {
+	args := m.Called(ctx, propertyData)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockStorage) EachSyncLog(ctx context.Context, filters store.SyncLogFilters, fn func(store.SyncLogRecord) error) error {
+	args := m.Called(ctx, filters, fn)
+	if records, ok := args.Get(0).([]store.SyncLogRecord); ok {
+		for _, record := range records {
+			if err := fn(record); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
 // TestConfig tests the configuration structure
 func TestConfig(t *testing.T) {
 	t.Run("DefaultConfig", func(t *testing.T) {
@@ -291,6 +589,42 @@ func TestConfig(t *testing.T) {
 		assert.Equal(t, 5, config.RateLimitPerSec)
 		assert.False(t, config.EnableAuto)
 	})
+
+	t.Run("ConfigFromEnv", func(t *testing.T) {
+		// Arrange
+		t.Setenv("SYNC_ON_STARTUP", "true")
+		t.Setenv("SYNC_SCHEDULE", "0 3 * * *")
+		t.Setenv("SYNC_JITTER", "5m")
+		t.Setenv("SYNC_DISTRIBUTED_LOCK", "false")
+		t.Setenv("SYNC_WEBHOOK_URL", "https://hooks.example.com/sync")
+		t.Setenv("SYNC_WEBHOOK_FAILURE_RATE", "2.5")
+
+		// Act
+		config := ConfigFromEnv()
+
+		// Assert
+		assert.True(t, config.SyncOnStartup)
+		assert.Equal(t, "0 3 * * *", config.Schedule)
+		assert.Equal(t, 5*time.Minute, config.Jitter)
+		assert.False(t, config.DistributedLock)
+		assert.Equal(t, 100, config.MaxChangeSummaries)
+		assert.Equal(t, "https://hooks.example.com/sync", config.WebhookURL)
+		assert.Equal(t, 2.5, config.WebhookFailureRate)
+		assert.Equal(t, 12*time.Hour, config.Interval)
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		// Arrange
+		valid := DefaultConfig()
+		valid.Schedule = "0 3 * * *"
+		invalid := DefaultConfig()
+		invalid.Schedule = "every night"
+
+		// Act & Assert
+		assert.NoError(t, DefaultConfig().Validate())
+		assert.NoError(t, valid.Validate())
+		assert.ErrorContains(t, invalid.Validate(), "invalid sync schedule")
+	})
 }
 
 // TestSyncStats tests the SyncStats structure
@@ -485,3 +819,929 @@ func TestScheduler(t *testing.T) {
 		assert.Equal(t, interval, scheduler.interval)
 	})
 }
+
+// TestSyncService_ConcurrencyGuard tests that sync entry points never overlap
+func TestSyncService_ConcurrencyGuard(t *testing.T) {
+	logger.InitLogger()
+
+	t.Run("ScheduledSkippedDuringManual", func(t *testing.T) {
+		// Arrange
+		service := NewSyncService(nil, nil, DefaultConfig())
+		started := make(chan struct{})
+		release := make(chan struct{})
+		manualDone := make(chan error)
+
+		go func() {
+			manualDone <- service.runExclusive("manual", func() error {
+				close(started)
+				<-release
+				return nil
+			})
+		}()
+		<-started
+
+		scheduler := NewScheduler(time.Hour, service.runScheduledSync)
+		before := scheduler.GetNextRun()
+
+		// Act
+		// The scheduler tick must not reach performSync, which would panic without a Cupid service
+		scheduler.runSync(context.Background())
+		result, err := service.runScheduledSync(context.Background())
+
+		// Assert
+		assert.ErrorIs(t, err, ErrSyncInProgress)
+		assert.Nil(t, result)
+		assert.False(t, scheduler.GetNextRun().Before(before))
+
+		close(release)
+		assert.NoError(t, <-manualDone)
+	})
+
+	t.Run("ManualSkippedDuringScheduled", func(t *testing.T) {
+		// Arrange
+		service := NewSyncService(nil, nil, DefaultConfig())
+		started := make(chan struct{})
+		release := make(chan struct{})
+		done := make(chan error)
+
+		go func() {
+			done <- service.runExclusive("scheduled", func() error {
+				close(started)
+				<-release
+				return nil
+			})
+		}()
+		<-started
+
+		// Act
+		result, err := service.SyncNow(context.Background())
+		_, propertyErr := service.SyncProperty(context.Background(), 12345)
+
+		// Assert
+		assert.ErrorIs(t, err, ErrSyncInProgress)
+		assert.Nil(t, result)
+		assert.ErrorIs(t, propertyErr, ErrSyncInProgress)
+
+		close(release)
+		assert.NoError(t, <-done)
+	})
+
+	t.Run("AllowOverlap", func(t *testing.T) {
+		// Arrange
+		config := DefaultConfig()
+		config.AllowOverlap = true
+		service := NewSyncService(nil, nil, config)
+		started := make(chan struct{})
+		release := make(chan struct{})
+		done := make(chan error)
+
+		go func() {
+			done <- service.runExclusive("manual", func() error {
+				close(started)
+				<-release
+				return nil
+			})
+		}()
+		<-started
+
+		// Act
+		ran := false
+		err := service.runExclusive("scheduled", func() error {
+			ran = true
+			return nil
+		})
+
+		// Assert
+		assert.NoError(t, err)
+		assert.True(t, ran)
+
+		close(release)
+		assert.NoError(t, <-done)
+	})
+}
+
+// TestSyncService_ProcessBatch tests that changed properties are stored together and failures counted
+func TestSyncService_ProcessBatch(t *testing.T) {
+	logger.InitLogger()
+
+	// Arrange
+	mockStorage := new(MockStorage)
+	config := DefaultConfig()
+	config.RateLimitPerSec = 1000
+	service := NewSyncService(nil, mockStorage, config)
+
+	stored := &cupid.PropertyData{Property: cupid.Property{HotelID: 1, HotelName: "Unchanged Hotel"}}
+	newProperty := &cupid.PropertyData{Property: cupid.Property{HotelID: 2, HotelName: "New Hotel"}}
+	badProperty := &cupid.PropertyData{Property: cupid.Property{HotelID: 3, HotelName: "Bad Hotel"}}
+
+	mockStorage.On("GetProperty", mock.Anything, int64(1)).Return(stored, nil)
+	mockStorage.On("GetProperty", mock.Anything, int64(2)).Return(nil, errors.New("property not found"))
+	mockStorage.On("GetProperty", mock.Anything, int64(3)).Return(nil, errors.New("property not found"))
+	mockStorage.On("StorePropertiesBatch", mock.Anything, mock.MatchedBy(func(batch []*cupid.PropertyData) bool {
+		return len(batch) == 2
+	})).Return(map[int64]error{3: errors.New("value too long")}, nil)
+	mockStorage.On("RecordPropertySync", mock.Anything, int64(1), []string(nil)).Return(nil)
+	mockStorage.On("MarkPropertySynced", mock.Anything, int64(1), mock.Anything).Return(nil)
+	mockStorage.On("RecordPropertySync", mock.Anything, int64(2), []string{"created"}).Return(nil)
+
+	// Act
+	updated, failed, summaries, err := service.processBatch(context.Background(), []*cupid.PropertyData{stored, newProperty, badProperty})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, []PropertyChangeSummary{
+		{HotelID: 2, Changes: []string{"created"}},
+		{HotelID: 3, Changes: []string{"created"}, Error: "value too long"},
+	}, summaries)
+	mockStorage.AssertExpectations(t)
+	mockStorage.AssertNotCalled(t, "StoreProperty", mock.Anything, mock.Anything)
+}
+
+// TestSyncService_ProcessBatch_FetchWarnings tests that the fetch warnings of a property are reported in its
+// change summary, including for properties that did not change
+func TestSyncService_ProcessBatch_FetchWarnings(t *testing.T) {
+	logger.InitLogger()
+
+	// Arrange
+	mockStorage := new(MockStorage)
+	config := DefaultConfig()
+	config.RateLimitPerSec = 1000
+	service := NewSyncService(nil, mockStorage, config)
+
+	stored := &cupid.PropertyData{Property: cupid.Property{HotelID: 1, HotelName: "Unchanged Hotel"}}
+	unchanged := &cupid.PropertyData{
+		Property:      cupid.Property{HotelID: 1, HotelName: "Unchanged Hotel"},
+		FetchWarnings: []string{"fr translation fetch failed: timeout"},
+	}
+	newProperty := &cupid.PropertyData{
+		Property:      cupid.Property{HotelID: 2, HotelName: "New Hotel"},
+		FetchWarnings: []string{"reviews fetch failed: timeout"},
+	}
+
+	mockStorage.On("GetProperty", mock.Anything, int64(1)).Return(stored, nil)
+	mockStorage.On("GetProperty", mock.Anything, int64(2)).Return(nil, errors.New("property not found"))
+	mockStorage.On("StorePropertiesBatch", mock.Anything, []*cupid.PropertyData{newProperty}).Return(map[int64]error{}, nil)
+	mockStorage.On("RecordPropertySync", mock.Anything, int64(1), []string(nil)).Return(nil)
+	mockStorage.On("MarkPropertySynced", mock.Anything, int64(1), mock.Anything).Return(nil)
+	mockStorage.On("RecordPropertySync", mock.Anything, int64(2), []string{"created"}).Return(nil)
+
+	// Act
+	updated, failed, summaries, err := service.processBatch(context.Background(), []*cupid.PropertyData{unchanged, newProperty})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated)
+	assert.Equal(t, 0, failed)
+	assert.Equal(t, []PropertyChangeSummary{
+		{HotelID: 1, Warnings: []string{"fr translation fetch failed: timeout"}},
+		{HotelID: 2, Changes: []string{"created"}, Warnings: []string{"reviews fetch failed: timeout"}},
+	}, summaries)
+	assert.Equal(t, []*cupid.PropertyData{newProperty}, storedProperties([]*cupid.PropertyData{unchanged, newProperty}, summaries))
+	mockStorage.AssertExpectations(t)
+}
+
+// TestSyncService_ChangeSummaries tests that a run reports its changed properties up to the configured cap
+func TestSyncService_ChangeSummaries(t *testing.T) {
+	logger.InitLogger()
+
+	// Arrange
+	mockCupid := new(MockCupidService)
+	mockStorage := new(MockStorage)
+	config := DefaultConfig()
+	config.RateLimitPerSec = 1000
+	config.BatchSize = 2
+	config.MaxChangeSummaries = 2
+	service := NewSyncService(mockCupid, mockStorage, config)
+
+	properties := []*cupid.PropertyData{
+		{Property: cupid.Property{HotelID: 1, HotelName: "First Hotel"}},
+		{Property: cupid.Property{HotelID: 2, HotelName: "Second Hotel"}},
+		{Property: cupid.Property{HotelID: 3, HotelName: "Third Hotel"}},
+	}
+
+	mockStorage.On("CreateSyncLog", mock.Anything, mock.Anything).Return(nil)
+	mockCupid.On("FetchAllProperties", mock.Anything).Return(properties, nil)
+	mockStorage.On("GetProperty", mock.Anything, mock.Anything).Return(nil, errors.New("property not found"))
+	mockStorage.On("StorePropertiesBatch", mock.Anything, mock.Anything).Return(map[int64]error{}, nil)
+	mockStorage.On("RecordPropertySync", mock.Anything, mock.Anything, []string{"created"}).Return(nil)
+	mockStorage.On("UpdateSyncLog", mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	result, err := service.SyncNow(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.UpdatedProperties)
+	assert.Equal(t, []PropertyChangeSummary{
+		{HotelID: 1, Changes: []string{"created"}},
+		{HotelID: 2, Changes: []string{"created"}},
+	}, result.PropertyChanges)
+	assert.True(t, result.PropertyChangesTruncated)
+
+	status := service.GetStatus()
+	assert.Equal(t, result.PropertyChanges, status.PropertyChanges)
+	assert.True(t, status.PropertyChangesTruncated)
+}
+
+// TestSyncService_SyncCountries tests that a country sync refetches only the stored properties of that country
+// and leaves the status of the last full sync untouched
+func TestSyncService_SyncCountries(t *testing.T) {
+	logger.InitLogger()
+
+	t.Run("RefetchesMatchingProperties", func(t *testing.T) {
+		// Arrange
+		mockCupid := new(MockCupidService)
+		mockStorage := new(MockStorage)
+		config := DefaultConfig()
+		config.RateLimitPerSec = 1000
+		service := NewSyncService(mockCupid, mockStorage, config)
+
+		stored := []*cupid.Property{
+			{HotelID: 1, HotelName: "Paris Hotel", Address: cupid.Address{Country: "fr"}},
+			{HotelID: 3, HotelName: "Lyon Hotel", Address: cupid.Address{Country: "fr"}},
+		}
+		fetched := []*cupid.PropertyData{
+			{Property: cupid.Property{HotelID: 1, HotelName: "Paris Hotel Renamed"}},
+			{Property: cupid.Property{HotelID: 3, HotelName: "Lyon Hotel Renamed"}},
+		}
+
+		mockStorage.On("CreateSyncLog", mock.Anything, mock.MatchedBy(func(record *store.SyncLogRecord) bool {
+			return record.SyncType == "country"
+		})).Return(nil)
+		mockStorage.On("GetPropertiesByCountries", mock.Anything, []string{"fr"}).Return(stored, nil)
+		mockCupid.On("FetchProperties", mock.Anything, []int64{1, 3}).Return(fetched, nil)
+		mockStorage.On("GetProperty", mock.Anything, mock.Anything).Return(nil, errors.New("property not found"))
+		mockStorage.On("StorePropertiesBatch", mock.Anything, mock.Anything).Return(map[int64]error{}, nil)
+		mockStorage.On("RecordPropertySync", mock.Anything, mock.Anything, []string{"created"}).Return(nil)
+		mockStorage.On("UpdateSyncLog", mock.Anything, mock.Anything).Return(nil)
+
+		// Act
+		result, err := service.SyncCountries(context.Background(), []string{"fr"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "completed", result.Status)
+		assert.Equal(t, 2, result.TotalProperties)
+		assert.Equal(t, 2, result.UpdatedProperties)
+		assert.Equal(t, 0, result.FailedProperties)
+		assert.True(t, strings.HasPrefix(result.SyncID, "sync_country_"))
+		mockStorage.AssertExpectations(t)
+		mockCupid.AssertExpectations(t)
+		mockCupid.AssertNotCalled(t, "FetchAllProperties", mock.Anything)
+		assert.True(t, service.GetStatus().LastSync.IsZero(), "a country sync is not the last full sync")
+	})
+
+	t.Run("NoMatchingProperties", func(t *testing.T) {
+		// Arrange
+		mockCupid := new(MockCupidService)
+		mockStorage := new(MockStorage)
+		service := NewSyncService(mockCupid, mockStorage, nil)
+
+		mockStorage.On("CreateSyncLog", mock.Anything, mock.Anything).Return(nil)
+		mockStorage.On("GetPropertiesByCountries", mock.Anything, []string{"zz"}).Return([]*cupid.Property{}, nil)
+		mockStorage.On("UpdateSyncLog", mock.Anything, mock.Anything).Return(nil)
+
+		// Act
+		result, err := service.SyncCountries(context.Background(), []string{"zz"})
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "completed", result.Status)
+		assert.Equal(t, 0, result.TotalProperties)
+		mockCupid.AssertNotCalled(t, "FetchProperties", mock.Anything, mock.Anything)
+	})
+
+	t.Run("StorageError", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		service := NewSyncService(new(MockCupidService), mockStorage, nil)
+
+		mockStorage.On("CreateSyncLog", mock.Anything, mock.Anything).Return(nil)
+		mockStorage.On("GetPropertiesByCountries", mock.Anything, []string{"fr"}).Return(nil, errors.New("connection refused"))
+		mockStorage.On("UpdateSyncLog", mock.Anything, mock.Anything).Return(nil)
+
+		// Act
+		result, err := service.SyncCountries(context.Background(), []string{"fr"})
+
+		// Assert
+		assert.ErrorContains(t, err, "failed to list properties")
+		assert.Equal(t, "failed", result.Status)
+	})
+}
+
+// TestNewSyncID tests that syncs starting within the same second get distinct IDs
+func TestNewSyncID(t *testing.T) {
+	// Arrange
+	startTime := time.Date(2025, 9, 10, 6, 0, 0, 0, time.UTC)
+
+	// Act
+	first := newSyncID("sync", startTime)
+	second := newSyncID("sync", startTime)
+
+	// Assert
+	assert.True(t, strings.HasPrefix(first, "sync_20250910_060000_"))
+	assert.NotEqual(t, first, second)
+}
+
+// TestSyncService_TriggerCountrySync tests that a background country sync reports its ID and rejects further
+// triggers until it completes
+func TestSyncService_TriggerCountrySync(t *testing.T) {
+	logger.InitLogger()
+
+	// Arrange
+	mockStorage := new(MockStorage)
+	service := NewSyncService(new(MockCupidService), mockStorage, DefaultConfig())
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mockStorage.On("CreateSyncLog", mock.Anything, mock.Anything).Return(nil)
+	mockStorage.On("GetPropertiesByCountries", mock.Anything, []string{"fr"}).Run(func(mock.Arguments) {
+		close(started)
+		<-release
+	}).Return([]*cupid.Property{}, nil).Once()
+	mockStorage.On("UpdateSyncLog", mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	syncID, err := service.TriggerCountrySync(context.Background(), []string{"fr"})
+	require.NoError(t, err)
+	<-started
+	runningID, conflictErr := service.TriggerCountrySync(context.Background(), []string{"fr"})
+
+	// Assert
+	assert.True(t, strings.HasPrefix(syncID, "sync_country_"))
+	assert.ErrorIs(t, conflictErr, ErrSyncInProgress)
+	assert.Equal(t, syncID, runningID)
+	assert.Equal(t, syncID, service.GetStatus().CurrentSyncID)
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		return service.CurrentSyncID() == "" && service.syncLock.TryLock()
+	}, time.Second, 10*time.Millisecond)
+	service.syncLock.Unlock()
+}
+
+// TestSyncService_VerifyStoredProperties tests that properties read back after a sync are compared with the fetched data
+func TestSyncService_VerifyStoredProperties(t *testing.T) {
+	logger.InitLogger()
+
+	// Arrange
+	mockCupid := new(MockCupidService)
+	mockStorage := new(MockStorage)
+	config := DefaultConfig()
+	config.RateLimitPerSec = 1000
+	config.VerifySampleSize = 10
+	service := NewSyncService(mockCupid, mockStorage, config)
+
+	review := cupid.Review{ReviewID: 7, AverageScore: 8, Name: "Guest"}
+	properties := []*cupid.PropertyData{
+		{Property: cupid.Property{HotelID: 1, HotelName: "First Hotel"}, Reviews: []cupid.Review{review}},
+		{Property: cupid.Property{HotelID: 2, HotelName: "Second Hotel"}, Reviews: []cupid.Review{review}},
+		{Property: cupid.Property{HotelID: 3, HotelName: "Third Hotel"}},
+	}
+	// Property 2 is read back with its review stored twice
+	duplicated := &cupid.PropertyData{Property: properties[1].Property, Reviews: []cupid.Review{review, review}}
+
+	mockStorage.On("CreateSyncLog", mock.Anything, mock.Anything).Return(nil)
+	mockCupid.On("FetchAllProperties", mock.Anything).Return(properties, nil)
+	for _, pd := range properties {
+		mockStorage.On("GetProperty", mock.Anything, pd.Property.HotelID).Return(nil, errors.New("property not found")).Once()
+	}
+	mockStorage.On("GetProperty", mock.Anything, int64(1)).Return(properties[0], nil)
+	mockStorage.On("GetProperty", mock.Anything, int64(2)).Return(duplicated, nil)
+	mockStorage.On("GetProperty", mock.Anything, int64(3)).Return(nil, errors.New("connection reset"))
+	// Property 1 is read back with the whole details object in its rooms and photos columns
+	mockStorage.On("CompareStoredPropertyDetails", mock.Anything, properties[0]).Return([]string{"rooms", "photos"}, nil)
+	mockStorage.On("CompareStoredPropertyDetails", mock.Anything, properties[1]).Return(nil, nil)
+	mockStorage.On("StorePropertiesBatch", mock.Anything, mock.Anything).Return(map[int64]error{}, nil)
+	mockStorage.On("RecordPropertySync", mock.Anything, mock.Anything, []string{"created"}).Return(nil)
+	mockStorage.On("UpdateSyncLog", mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	result, err := service.SyncNow(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.VerifiedProperties)
+	assert.Equal(t, []PropertyChangeSummary{
+		{HotelID: 1, Changes: []string{"details.rooms", "details.photos"}},
+		{HotelID: 2, Changes: []string{"reviews"}},
+		{HotelID: 3, Error: "connection reset"},
+	}, result.VerificationMismatches)
+
+	status := service.GetStatus()
+	assert.Equal(t, 3, status.VerifiedProperties)
+	assert.Equal(t, result.VerificationMismatches, status.VerificationMismatches)
+	mockStorage.AssertNumberOfCalls(t, "GetProperty", 6)
+}
+
+// TestSampleForVerification tests that the verification sample is capped and drawn from the stored properties
+func TestSampleForVerification(t *testing.T) {
+	stored := []*cupid.PropertyData{
+		{Property: cupid.Property{HotelID: 1}},
+		{Property: cupid.Property{HotelID: 2}},
+		{Property: cupid.Property{HotelID: 3}},
+	}
+
+	assert.Nil(t, sampleForVerification(stored, 0))
+	assert.Nil(t, sampleForVerification(nil, 5))
+	assert.ElementsMatch(t, stored, sampleForVerification(stored, 5))
+
+	original := append([]*cupid.PropertyData(nil), stored...)
+	sample := sampleForVerification(stored, 2)
+	assert.Len(t, sample, 2)
+	assert.Subset(t, stored, sample)
+	assert.Equal(t, original, stored, "the input slice is left untouched")
+}
+
+// TestSyncService_UpdateSyncLog tests that a run's outcome is persisted with its error message
+func TestSyncService_UpdateSyncLog(t *testing.T) {
+	logger.InitLogger()
+
+	// Arrange
+	mockStorage := new(MockStorage)
+	service := NewSyncService(nil, mockStorage, DefaultConfig())
+	startTime := time.Date(2025, 9, 10, 6, 0, 0, 0, time.UTC)
+	result := &SyncResult{
+		SyncID:          "sync_20250910_060000",
+		Status:          "failed",
+		StartTime:       startTime,
+		EndTime:         startTime.Add(time.Minute),
+		TotalProperties: 4,
+		Error:           errors.New("upstream unavailable"),
+	}
+
+	mockStorage.On("UpdateSyncLog", mock.Anything, mock.MatchedBy(func(entry *store.SyncLogRecord) bool {
+		return entry.SyncID == result.SyncID &&
+			entry.Status == "failed" &&
+			entry.CompletedAt != nil && entry.CompletedAt.Equal(result.EndTime) &&
+			entry.TotalProperties == 4 &&
+			entry.ErrorMessage == "upstream unavailable"
+	})).Return(errors.New("connection refused"))
+
+	// Act
+	service.updateSyncLog(context.Background(), result)
+
+	// Assert
+	mockStorage.AssertExpectations(t)
+}
+
+// TestSyncService_GetSchedule tests the reported schedule in each automatic sync state
+func TestSyncService_GetSchedule(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		// Arrange
+		config := DefaultConfig()
+		config.EnableAuto = false
+		service := NewSyncService(nil, new(MockStorage), config)
+
+		// Act
+		schedule := service.GetSchedule()
+
+		// Assert
+		assert.False(t, schedule.AutoSyncEnabled)
+		assert.Equal(t, ScheduleStateDisabled, schedule.State)
+		assert.Nil(t, schedule.NextRun)
+		assert.Empty(t, schedule.NextRunIn)
+	})
+
+	t.Run("Interval", func(t *testing.T) {
+		// Arrange
+		config := DefaultConfig()
+		config.Interval = time.Hour
+		config.Jitter = time.Minute
+		service := NewSyncService(nil, new(MockStorage), config)
+		service.scheduler = NewScheduler(config.Interval, service.runScheduledSync)
+		service.isRunning = true
+
+		// Act
+		schedule := service.GetSchedule()
+
+		// Assert
+		assert.True(t, schedule.AutoSyncEnabled)
+		assert.Equal(t, ScheduleStateScheduled, schedule.State)
+		assert.Equal(t, "interval", schedule.Mode)
+		assert.Equal(t, "1h0m0s", schedule.Interval)
+		assert.Equal(t, "1m0s", schedule.Jitter)
+		require.NotNil(t, schedule.NextRun)
+		assert.Equal(t, service.scheduler.GetNextRun(), *schedule.NextRun)
+		assert.Equal(t, "1h0m0s", schedule.NextRunIn)
+
+		status := service.GetStatus()
+		assert.Equal(t, ScheduleStateScheduled, status.ScheduleState)
+		assert.Equal(t, *schedule.NextRun, status.NextSync)
+	})
+
+	t.Run("Cron", func(t *testing.T) {
+		// Arrange
+		config := DefaultConfig()
+		config.Schedule = "0 3 * * *"
+		service := NewSyncService(nil, new(MockStorage), config)
+		scheduler, err := NewCronScheduler(config.Schedule, config.Interval, service.runScheduledSync)
+		require.NoError(t, err)
+		service.scheduler = scheduler
+		service.isRunning = true
+
+		// Act
+		schedule := service.GetSchedule()
+
+		// Assert
+		assert.Equal(t, "cron", schedule.Mode)
+		assert.Equal(t, "0 3 * * *", schedule.Schedule)
+		assert.Empty(t, schedule.Interval)
+		require.NotNil(t, schedule.NextRun)
+		assert.Equal(t, 3, schedule.NextRun.Hour())
+		assert.Zero(t, schedule.NextRun.Minute())
+	})
+
+	t.Run("Stopped", func(t *testing.T) {
+		// Arrange
+		service := NewSyncService(nil, new(MockStorage), DefaultConfig())
+		service.scheduler = NewScheduler(time.Hour, service.runScheduledSync)
+
+		// Act
+		schedule := service.GetSchedule()
+
+		// Assert
+		assert.True(t, schedule.AutoSyncEnabled)
+		assert.Equal(t, ScheduleStateStopped, schedule.State)
+		assert.Nil(t, schedule.NextRun)
+		assert.True(t, service.GetStatus().NextSync.IsZero())
+	})
+}
+
+// TestSyncService_Start_InvalidSchedule tests that an invalid cron spec is rejected when starting
+func TestSyncService_Start_InvalidSchedule(t *testing.T) {
+	logger.InitLogger()
+
+	// Arrange
+	config := DefaultConfig()
+	config.Schedule = "0 25 * * *"
+	service := NewSyncService(nil, new(MockStorage), config)
+
+	// Act
+	err := service.Start(context.Background())
+
+	// Assert
+	assert.ErrorContains(t, err, "invalid sync schedule")
+	assert.False(t, service.GetStatus().IsRunning)
+}
+
+// TestKeepSkippedTranslations tests that translations skipped by the language cap are carried over from storage
+func TestKeepSkippedTranslations(t *testing.T) {
+	// Arrange
+	stored := &cupid.PropertyData{Translations: map[string]*cupid.Property{
+		"fr": {HotelName: "Hôtel"},
+		"es": {HotelName: "Hotel es"},
+		"de": {HotelName: "Hotel de"},
+	}}
+	fetched := &cupid.PropertyData{
+		Translations:        map[string]*cupid.Property{"fr": {HotelName: "Hôtel rénové"}},
+		SkippedTranslations: []string{"es", "it"},
+	}
+
+	// Act
+	keepSkippedTranslations(fetched, stored)
+
+	// Assert
+	assert.Equal(t, map[string]*cupid.Property{
+		"fr": {HotelName: "Hôtel rénové"},
+		"es": {HotelName: "Hotel es"},
+	}, fetched.Translations)
+}
+
+// TestSyncService_DetectPropertyChanges_Overrides tests that manual overrides win over fetched values
+func TestSyncService_DetectPropertyChanges_Overrides(t *testing.T) {
+	logger.InitLogger()
+
+	t.Run("OverriddenFieldIsNotAChange", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		service := NewSyncService(nil, mockStorage, DefaultConfig())
+		stored := &cupid.PropertyData{
+			Property:  cupid.Property{HotelID: 1, HotelName: "Corrected Name", Description: "Nice hotel"},
+			Overrides: map[string]string{"hotel_name": "Corrected Name"},
+		}
+		fetched := &cupid.PropertyData{Property: cupid.Property{HotelID: 1, HotelName: "Wrong Name", Description: "Nice hotel"}}
+		mockStorage.On("GetProperty", mock.Anything, int64(1)).Return(stored, nil)
+		mockStorage.On("MarkPropertySynced", mock.Anything, int64(1), mock.Anything).Return(nil)
+		mockStorage.On("RecordPropertySync", mock.Anything, int64(1), []string(nil)).Return(nil)
+
+		// Act
+		changes, err := service.detectPropertyChanges(context.Background(), fetched)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("OverrideIsNotPersisted", func(t *testing.T) {
+		// Arrange
+		mockStorage := new(MockStorage)
+		service := NewSyncService(nil, mockStorage, DefaultConfig())
+		stored := &cupid.PropertyData{
+			Property:  cupid.Property{HotelID: 1, HotelName: "Corrected Name", Stars: 3},
+			Overrides: map[string]string{"hotel_name": "Corrected Name"},
+		}
+		fetched := &cupid.PropertyData{Property: cupid.Property{HotelID: 1, HotelName: "Wrong Name", Stars: 4}}
+		mockStorage.On("GetProperty", mock.Anything, int64(1)).Return(stored, nil)
+
+		// Act
+		changes, err := service.detectPropertyChanges(context.Background(), fetched)
+
+		// Assert
+		require.NoError(t, err)
+		assert.NotEmpty(t, changes)
+		// The upstream value is stored, the override keeps applying on read
+		assert.Equal(t, "Wrong Name", fetched.Property.HotelName)
+		assert.Equal(t, 4, fetched.Property.Stars)
+	})
+}
+
+// TestSyncService_DetectPropertyChanges_RawProperty tests that the captured raw response of an unchanged property
+// is stored when its sync timestamp is refreshed
+func TestSyncService_DetectPropertyChanges_RawProperty(t *testing.T) {
+	logger.InitLogger()
+
+	// Arrange
+	mockStorage := new(MockStorage)
+	service := NewSyncService(nil, mockStorage, DefaultConfig())
+	raw := json.RawMessage(`{"hotel_id":1,"hotel_name":"Unchanged Hotel"}`)
+	stored := &cupid.PropertyData{Property: cupid.Property{HotelID: 1, HotelName: "Unchanged Hotel"}}
+	fetched := &cupid.PropertyData{Property: stored.Property, RawProperty: raw}
+	mockStorage.On("GetProperty", mock.Anything, int64(1)).Return(stored, nil)
+	mockStorage.On("MarkPropertySynced", mock.Anything, int64(1), raw).Return(nil)
+	mockStorage.On("RecordPropertySync", mock.Anything, int64(1), []string(nil)).Return(nil)
+
+	// Act
+	changes, err := service.detectPropertyChanges(context.Background(), fetched)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	mockStorage.AssertExpectations(t)
+}
+
+// TestSyncService_DetectPropertyChanges_IncompleteReviews tests that stored reviews missing from an incomplete
+// review fetch are not reported as changes, while a complete fetch reports them unless they were imported
+func TestSyncService_DetectPropertyChanges_IncompleteReviews(t *testing.T) {
+	logger.InitLogger()
+
+	tests := []struct {
+		name        string
+		complete    bool
+		imported    bool
+		wantChanges []string
+	}{
+		{"Incomplete", false, false, nil},
+		{"Complete", true, false, []string{"reviews"}},
+		{"CompleteImported", true, true, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockStorage := new(MockStorage)
+			service := NewSyncService(nil, mockStorage, DefaultConfig())
+			stored := &cupid.PropertyData{
+				Property: cupid.Property{HotelID: 1, HotelName: "Hotel"},
+				Reviews:  []cupid.Review{{ReviewID: 1, AverageScore: 8}, {ReviewID: 2, AverageScore: 6, Imported: tt.imported}},
+			}
+			fetched := &cupid.PropertyData{
+				Property:        stored.Property,
+				Reviews:         []cupid.Review{{ReviewID: 1, AverageScore: 8}},
+				ReviewsComplete: tt.complete,
+			}
+			mockStorage.On("GetProperty", mock.Anything, int64(1)).Return(stored, nil)
+			mockStorage.On("MarkPropertySynced", mock.Anything, int64(1), mock.Anything).Return(nil).Maybe()
+			mockStorage.On("RecordPropertySync", mock.Anything, int64(1), []string(nil)).Return(nil).Maybe()
+
+			// Act
+			changes, err := service.detectPropertyChanges(context.Background(), fetched)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantChanges, changes)
+			assert.Len(t, fetched.Reviews, 1, "the fetched reviews are left untouched")
+		})
+	}
+}
+
+// TestSyncService_DetectPropertyChanges_ReviewRetention tests that fetched reviews older than the retention
+// window are dropped before the comparison, while undated and recent reviews are kept
+func TestSyncService_DetectPropertyChanges_ReviewRetention(t *testing.T) {
+	logger.InitLogger()
+
+	// Arrange
+	mockStorage := new(MockStorage)
+	config := DefaultConfig()
+	config.ReviewRetentionDays = 730
+	service := NewSyncService(nil, mockStorage, config)
+	recent := time.Now().UTC().AddDate(0, -1, 0).Format(time.DateOnly)
+	old := time.Now().UTC().AddDate(-3, 0, 0).Format(time.DateOnly)
+	stored := &cupid.PropertyData{
+		Property: cupid.Property{HotelID: 1, HotelName: "Hotel"},
+		Reviews:  []cupid.Review{{ReviewID: 1, Date: recent}, {ReviewID: 3}},
+	}
+	fetched := &cupid.PropertyData{
+		Property:        stored.Property,
+		Reviews:         []cupid.Review{{ReviewID: 1, Date: recent}, {ReviewID: 2, Date: old}, {ReviewID: 3}},
+		ReviewsComplete: true,
+	}
+	mockStorage.On("GetProperty", mock.Anything, int64(1)).Return(stored, nil)
+	mockStorage.On("MarkPropertySynced", mock.Anything, int64(1), mock.Anything).Return(nil)
+	mockStorage.On("RecordPropertySync", mock.Anything, int64(1), []string(nil)).Return(nil)
+
+	// Act
+	changes, err := service.detectPropertyChanges(context.Background(), fetched)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Equal(t, []cupid.Review{{ReviewID: 1, Date: recent}, {ReviewID: 3}}, fetched.Reviews)
+	mockStorage.AssertExpectations(t)
+}
+
+// TestSyncService_TriggerSync tests that a background manual sync rejects further triggers until it completes
+func TestSyncService_TriggerSync(t *testing.T) {
+	logger.InitLogger()
+
+	// Arrange
+	mockCupid := new(MockCupidService)
+	mockStorage := new(MockStorage)
+	service := NewSyncService(mockCupid, mockStorage, DefaultConfig())
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mockStorage.On("CreateSyncLog", mock.Anything, mock.Anything).Return(nil)
+	mockCupid.On("FetchAllProperties", mock.Anything).Run(func(mock.Arguments) {
+		close(started)
+		<-release
+	}).Return([]*cupid.PropertyData{}, nil).Once()
+	mockStorage.On("UpdateSyncLog", mock.Anything, mock.Anything).Return(nil)
+
+	// Act
+	_, err := service.TriggerSync(context.Background())
+	require.NoError(t, err)
+	<-started
+	runningID, conflictErr := service.TriggerSync(context.Background())
+
+	// Assert
+	assert.ErrorIs(t, conflictErr, ErrSyncInProgress)
+	assert.NotEmpty(t, runningID)
+	assert.Equal(t, runningID, service.GetStatus().CurrentSyncID)
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		return service.CurrentSyncID() == "" && service.syncLock.TryLock()
+	}, time.Second, 10*time.Millisecond)
+	service.syncLock.Unlock()
+	mockCupid.AssertNumberOfCalls(t, "FetchAllProperties", 1)
+}
+
+// TestSyncService_DistributedLock tests that scheduled syncs only run on the instance holding the sync lock
+func TestSyncService_DistributedLock(t *testing.T) {
+	logger.InitLogger()
+
+	t.Run("SkippedWithoutLock", func(t *testing.T) {
+		// Arrange
+		mockCupid := new(MockCupidService)
+		mockStorage := new(MockStorage)
+		service := NewSyncService(mockCupid, mockStorage, DefaultConfig())
+		mockStorage.On("TryAcquireSyncLock", mock.Anything).Return(nil, false, nil)
+
+		// Act
+		result, err := service.runScheduledSync(context.Background())
+
+		// Assert
+		assert.ErrorIs(t, err, ErrNotSyncLeader)
+		assert.Nil(t, result)
+		assert.False(t, service.GetStatus().IsSyncLeader)
+		mockCupid.AssertNotCalled(t, "FetchAllProperties", mock.Anything)
+	})
+
+	t.Run("RunsAndReleasesWithLock", func(t *testing.T) {
+		// Arrange
+		mockCupid := new(MockCupidService)
+		mockStorage := new(MockStorage)
+		service := NewSyncService(mockCupid, mockStorage, DefaultConfig())
+
+		released := false
+		mockStorage.On("TryAcquireSyncLock", mock.Anything).Return(func() { released = true }, true, nil)
+		mockStorage.On("CreateSyncLog", mock.Anything, mock.Anything).Return(nil)
+		mockCupid.On("FetchAllProperties", mock.Anything).Run(func(mock.Arguments) {
+			assert.False(t, released, "lock released before the sync ran")
+			assert.True(t, service.GetStatus().IsSyncLeader, "leader while holding the lock")
+		}).Return([]*cupid.PropertyData{}, nil)
+		mockStorage.On("UpdateSyncLog", mock.Anything, mock.Anything).Return(nil)
+
+		// Act
+		result, err := service.runScheduledSync(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "completed", result.Status)
+		assert.True(t, released)
+		assert.False(t, service.GetStatus().IsSyncLeader, "no longer leader once the lock is released")
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("LockError", func(t *testing.T) {
+		// Arrange
+		mockCupid := new(MockCupidService)
+		mockStorage := new(MockStorage)
+		service := NewSyncService(mockCupid, mockStorage, DefaultConfig())
+		mockStorage.On("TryAcquireSyncLock", mock.Anything).Return(nil, false, errors.New("connection refused"))
+
+		// Act
+		_, err := service.runScheduledSync(context.Background())
+
+		// Assert
+		assert.EqualError(t, err, "connection refused")
+		mockCupid.AssertNotCalled(t, "FetchAllProperties", mock.Anything)
+	})
+
+	t.Run("DisabledAlwaysLeads", func(t *testing.T) {
+		// Arrange
+		config := DefaultConfig()
+		config.DistributedLock = false
+
+		// Act
+		service := NewSyncService(nil, new(MockStorage), config)
+
+		// Assert
+		assert.True(t, service.GetStatus().IsSyncLeader)
+	})
+}
+
+// TestSyncService_SyncOnStartup tests the initial synchronization triggered by Start
+func TestSyncService_SyncOnStartup(t *testing.T) {
+	logger.InitLogger()
+
+	t.Run("RunsWhenEnabled", func(t *testing.T) {
+		// Arrange
+		mockCupid := new(MockCupidService)
+		mockStorage := new(MockStorage)
+		config := DefaultConfig()
+		config.EnableAuto = false
+		config.SyncOnStartup = true
+		service := NewSyncService(mockCupid, mockStorage, config)
+
+		fetched := make(chan struct{})
+		mockStorage.On("CreateSyncLog", mock.Anything, mock.MatchedBy(func(entry *store.SyncLogRecord) bool {
+			return entry.SyncType == "startup"
+		})).Return(nil)
+		mockCupid.On("FetchAllProperties", mock.Anything).Run(func(mock.Arguments) {
+			close(fetched)
+		}).Return([]*cupid.PropertyData{}, nil)
+		mockStorage.On("UpdateSyncLog", mock.Anything, mock.Anything).Return(nil)
+
+		// Act
+		err := service.Start(context.Background())
+
+		// Assert
+		require.NoError(t, err)
+		select {
+		case <-fetched:
+		case <-time.After(time.Second):
+			t.Fatal("startup sync was not run")
+		}
+		assert.Eventually(t, func() bool {
+			return !service.GetStatus().LastSync.IsZero()
+		}, time.Second, 10*time.Millisecond)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		// Arrange
+		mockCupid := new(MockCupidService)
+		config := DefaultConfig()
+		config.EnableAuto = false
+		service := NewSyncService(mockCupid, new(MockStorage), config)
+
+		// Act
+		err := service.Start(context.Background())
+		time.Sleep(50 * time.Millisecond)
+
+		// Assert
+		require.NoError(t, err)
+		mockCupid.AssertNotCalled(t, "FetchAllProperties", mock.Anything)
+	})
+
+	t.Run("SkippedWhileSyncInProgress", func(t *testing.T) {
+		// Arrange
+		mockCupid := new(MockCupidService)
+		config := DefaultConfig()
+		config.SyncOnStartup = true
+		service := NewSyncService(mockCupid, new(MockStorage), config)
+		service.syncLock.Lock()
+		defer service.syncLock.Unlock()
+
+		// Act
+		service.runStartupSync(context.Background())
+
+		// Assert
+		mockCupid.AssertNotCalled(t, "FetchAllProperties", mock.Anything)
+	})
+}
@@ -0,0 +1,76 @@
+package sync
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+
+	"github.com/barimehdi77/cupid-api/internal/cupid"
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"go.uber.org/zap"
+)
+
+// sampleForVerification returns up to size properties picked at random from stored
+func sampleForVerification(stored []*cupid.PropertyData, size int) []*cupid.PropertyData {
+	if size <= 0 || len(stored) == 0 {
+		return nil
+	}
+
+	sample := make([]*cupid.PropertyData, len(stored))
+	copy(sample, stored)
+	rand.Shuffle(len(sample), func(i, j int) {
+		sample[i], sample[j] = sample[j], sample[i]
+	})
+	return sample[:min(size, len(sample))]
+}
+
+// verifyStoredProperties re-reads the given properties concurrently and compares them, including their JSONB
+// detail sections, with the fetched data, catching writes that silently stored something else than what was fetched.
+// It returns a summary, ordered by hotel ID, for every property that could not be read back or did not match.
+func (s *SyncService) verifyStoredProperties(ctx context.Context, properties []*cupid.PropertyData) []PropertyChangeSummary {
+	semaphore := make(chan struct{}, s.config.MaxConcurrent)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var mismatches []PropertyChangeSummary
+
+	comparator := NewDataComparator()
+	for _, propertyData := range properties {
+		wg.Add(1)
+		go func(pd *cupid.PropertyData) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}        // Acquire
+			defer func() { <-semaphore }() // Release
+
+			summary := PropertyChangeSummary{HotelID: pd.Property.HotelID}
+			storedData, err := s.storage.GetProperty(ctx, pd.Property.HotelID)
+			if err == nil {
+				summary.Changes = comparator.ComparePropertyData(comparableData(pd, storedData), storedData).Changes
+				var details []string
+				details, err = s.storage.CompareStoredPropertyDetails(ctx, pd)
+				for _, column := range details {
+					summary.Changes = append(summary.Changes, "details."+column)
+				}
+			}
+			if err != nil {
+				summary.Error = err.Error()
+			} else if len(summary.Changes) == 0 {
+				return
+			}
+
+			logger.Warn("Stored property does not match fetched data",
+				zap.Int64("property_id", summary.HotelID),
+				zap.Strings("mismatches", summary.Changes),
+				zap.String("error", summary.Error),
+			)
+
+			mu.Lock()
+			mismatches = append(mismatches, summary)
+			mu.Unlock()
+		}(propertyData)
+	}
+
+	wg.Wait()
+
+	return sortChangeSummaries(mismatches)
+}
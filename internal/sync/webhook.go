@@ -0,0 +1,141 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"go.uber.org/zap"
+)
+
+// webhookTimeout bounds each webhook POST attempt
+const webhookTimeout = 10 * time.Second
+
+// Webhook events
+const (
+	webhookEventCompleted = "sync.completed"
+	webhookEventFailed    = "sync.failed"
+)
+
+// webhookPayload is the JSON body posted to the sync webhook
+type webhookPayload struct {
+	Event             string  `json:"event"`
+	SyncID            string  `json:"sync_id,omitempty"`
+	Status            string  `json:"status"`
+	TotalProperties   int     `json:"total_properties"`
+	UpdatedProperties int     `json:"updated_properties"`
+	FailedProperties  int     `json:"failed_properties"`
+	FailureRate       float64 `json:"failure_rate"`
+	Duration          string  `json:"duration"`
+	Error             string  `json:"error,omitempty"`
+}
+
+// webhookNotifier posts synchronization outcomes to Config.WebhookURL
+type webhookNotifier struct {
+	url        string
+	threshold  float64
+	attempts   int
+	retryDelay time.Duration
+	httpClient *http.Client
+}
+
+// newWebhookNotifier creates a notifier from the sync configuration, or returns nil when no webhook URL is set
+func newWebhookNotifier(config *Config) *webhookNotifier {
+	if config.WebhookURL == "" {
+		return nil
+	}
+
+	return &webhookNotifier{
+		url:        config.WebhookURL,
+		threshold:  config.WebhookFailureRate,
+		attempts:   max(config.RetryAttempts, 1),
+		retryDelay: config.RetryDelay,
+		httpClient: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// Notify posts the outcome of a synchronization.
+// Failed runs are always posted; completed runs only when their failure rate reaches the configured threshold.
+// Delivery problems are logged and never affect the synchronization itself.
+func (n *webhookNotifier) Notify(ctx context.Context, result *SyncResult, syncErr error) {
+	payload := webhookPayload{Event: webhookEventCompleted, Status: "completed"}
+	if result != nil {
+		payload.SyncID = result.SyncID
+		payload.Status = result.Status
+		payload.TotalProperties = result.TotalProperties
+		payload.UpdatedProperties = result.UpdatedProperties
+		payload.FailedProperties = result.FailedProperties
+		payload.FailureRate = result.GetFailureRate()
+		payload.Duration = result.Duration.String()
+	}
+	if syncErr != nil {
+		payload.Event = webhookEventFailed
+		payload.Status = "failed"
+		payload.Error = syncErr.Error()
+	} else if payload.FailureRate < n.threshold {
+		return
+	}
+
+	if err := n.post(ctx, payload); err != nil {
+		logger.LogError("Failed to deliver sync webhook", err,
+			zap.String("event", payload.Event),
+			zap.String("sync_id", payload.SyncID),
+		)
+	}
+}
+
+// post sends payload to the webhook, retrying transport errors and non-2xx responses with exponential backoff
+func (n *webhookNotifier) post(ctx context.Context, payload webhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	delay := n.retryDelay
+	for attempt := 1; ; attempt++ {
+		err = n.attemptPost(ctx, body)
+		if err == nil || attempt >= n.attempts {
+			return err
+		}
+
+		logger.Debug("Retrying sync webhook",
+			zap.Int("attempt", attempt),
+			zap.Int("max_attempts", n.attempts),
+			zap.Duration("delay", delay),
+			zap.Error(err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("webhook cancelled while retrying: %w", ctx.Err())
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// attemptPost performs a single webhook POST
+func (n *webhookNotifier) attemptPost(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook error: status %d", resp.StatusCode)
+	}
+	return nil
+}
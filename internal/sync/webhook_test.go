@@ -0,0 +1,130 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/barimehdi77/cupid-api/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestWebhook starts a webhook server answering with the given status codes in turn, then 200
+func newTestWebhook(t *testing.T, statuses ...int) (*httptest.Server, *int32, chan webhookPayload) {
+	var calls int32
+	payloads := make(chan webhookPayload, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		var payload webhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err == nil {
+			payloads <- payload
+		}
+		if int(n) <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls, payloads
+}
+
+// testWebhookConfig returns a sync configuration posting to url with a short retry delay
+func testWebhookConfig(url string, failureRate float64) *Config {
+	config := DefaultConfig()
+	config.WebhookURL = url
+	config.WebhookFailureRate = failureRate
+	config.RetryDelay = time.Millisecond
+	return config
+}
+
+// TestNewWebhookNotifier tests that notifications are disabled without a webhook URL
+func TestNewWebhookNotifier(t *testing.T) {
+	assert.Nil(t, newWebhookNotifier(DefaultConfig()))
+	assert.NotNil(t, newWebhookNotifier(testWebhookConfig("http://example.com/hook", 0)))
+}
+
+// TestWebhookNotifier_Notify tests which outcomes are posted and their payload
+func TestWebhookNotifier_Notify(t *testing.T) {
+	logger.InitLogger()
+
+	t.Run("FailureAlwaysPosted", func(t *testing.T) {
+		// Arrange
+		server, calls, payloads := newTestWebhook(t)
+		notifier := newWebhookNotifier(testWebhookConfig(server.URL, 50))
+		result := &SyncResult{SyncID: "sync_1", Status: "failed", Duration: time.Second}
+
+		// Act
+		notifier.Notify(context.Background(), result, errors.New("upstream unavailable"))
+
+		// Assert
+		assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+		payload := <-payloads
+		assert.Equal(t, webhookEventFailed, payload.Event)
+		assert.Equal(t, "sync_1", payload.SyncID)
+		assert.Equal(t, "failed", payload.Status)
+		assert.Equal(t, "upstream unavailable", payload.Error)
+		assert.Equal(t, "1s", payload.Duration)
+	})
+
+	t.Run("CompletionAboveThreshold", func(t *testing.T) {
+		// Arrange
+		server, calls, payloads := newTestWebhook(t)
+		notifier := newWebhookNotifier(testWebhookConfig(server.URL, 20))
+		result := &SyncResult{SyncID: "sync_2", Status: "completed", TotalProperties: 10, UpdatedProperties: 5, FailedProperties: 3}
+
+		// Act
+		notifier.Notify(context.Background(), result, nil)
+
+		// Assert
+		require.Equal(t, int32(1), atomic.LoadInt32(calls))
+		payload := <-payloads
+		assert.Equal(t, webhookEventCompleted, payload.Event)
+		assert.Equal(t, 3, payload.FailedProperties)
+		assert.InDelta(t, 30.0, payload.FailureRate, 0.001)
+	})
+
+	t.Run("CompletionBelowThreshold", func(t *testing.T) {
+		// Arrange
+		server, calls, _ := newTestWebhook(t)
+		notifier := newWebhookNotifier(testWebhookConfig(server.URL, 20))
+		result := &SyncResult{Status: "completed", TotalProperties: 10, FailedProperties: 1}
+
+		// Act
+		notifier.Notify(context.Background(), result, nil)
+
+		// Assert
+		assert.Equal(t, int32(0), atomic.LoadInt32(calls))
+	})
+
+	t.Run("RetriesFailedPost", func(t *testing.T) {
+		// Arrange
+		server, calls, _ := newTestWebhook(t, http.StatusInternalServerError, http.StatusBadGateway)
+		notifier := newWebhookNotifier(testWebhookConfig(server.URL, 0))
+
+		// Act
+		notifier.Notify(context.Background(), &SyncResult{Status: "completed"}, nil)
+
+		// Assert
+		assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	})
+
+	t.Run("GivesUpAfterRetryAttempts", func(t *testing.T) {
+		// Arrange
+		server, calls, _ := newTestWebhook(t, http.StatusInternalServerError, http.StatusInternalServerError,
+			http.StatusInternalServerError, http.StatusInternalServerError)
+		notifier := newWebhookNotifier(testWebhookConfig(server.URL, 0))
+
+		// Act
+		notifier.Notify(context.Background(), &SyncResult{Status: "completed"}, nil)
+
+		// Assert
+		assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	})
+}
@@ -241,10 +241,10 @@ func (td *TestData) CreateSamplePropertyFilters() store.PropertyFilters {
 	return store.PropertyFilters{
 		City:      "London",
 		Country:   "gb",
-		MinStars:  3,
-		MaxStars:  5,
-		MinRating: 7.0,
-		MaxRating: 10.0,
+		MinStars:  intPtr(3),
+		MaxStars:  intPtr(5),
+		MinRating: float64Ptr(7.0),
+		MaxRating: float64Ptr(10.0),
 		HotelType: "Hotels",
 		Chain:     "Test Chain",
 	}
@@ -512,3 +512,7 @@ func intPtr(i int) *int {
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
@@ -0,0 +1,130 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// setupSpanRecorder installs a global tracer provider recording spans in memory
+func setupSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider := otel.GetTracerProvider()
+	previousPropagator := otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+// spanAttributes returns the attributes of a span keyed by name
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+// TestInit tests that tracing stays disabled without an OTLP endpoint
+func TestInit(t *testing.T) {
+	// Arrange
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	previous := otel.GetTracerProvider()
+
+	// Act
+	shutdown, err := Init(context.Background(), "cupid-api")
+
+	// Assert
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+	assert.Equal(t, previous, otel.GetTracerProvider())
+}
+
+// TestGinMiddleware tests the server span created for each request
+func TestGinMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("RecordsRouteAndStatus", func(t *testing.T) {
+		// Arrange
+		recorder := setupSpanRecorder(t)
+		router := gin.New()
+		router.Use(GinMiddleware())
+		router.GET("/api/v1/properties/:id", func(c *gin.Context) {
+			_, child := StartSpan(c.Request.Context(), "child")
+			child.End()
+			c.Status(http.StatusInternalServerError)
+		})
+
+		// Act
+		req, _ := http.NewRequest("GET", "/api/v1/properties/42", nil)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		// Assert
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+		child, server := spans[0], spans[1]
+		assert.Equal(t, "GET /api/v1/properties/:id", server.Name())
+		assert.Equal(t, trace.SpanKindServer, server.SpanKind())
+		assert.Equal(t, codes.Error, server.Status().Code)
+		attrs := spanAttributes(server)
+		assert.Equal(t, "/api/v1/properties/:id", attrs["http.route"].AsString())
+		assert.Equal(t, int64(500), attrs["http.response.status_code"].AsInt64())
+		assert.Equal(t, server.SpanContext().SpanID(), child.Parent().SpanID())
+	})
+
+	t.Run("ContinuesIncomingTrace", func(t *testing.T) {
+		// Arrange
+		recorder := setupSpanRecorder(t)
+		router := gin.New()
+		router.Use(GinMiddleware())
+		router.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+		traceparent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+		// Act
+		req, _ := http.NewRequest("GET", "/health", nil)
+		req.Header.Set("traceparent", traceparent)
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		// Assert
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	})
+}
+
+// TestEndSpan tests that errors are recorded on the span
+func TestEndSpan(t *testing.T) {
+	// Arrange
+	recorder := setupSpanRecorder(t)
+	_, span := StartSpan(context.Background(), "operation")
+
+	// Act
+	EndSpan(span, errors.New("boom"))
+
+	// Assert
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, "boom", spans[0].Status().Description)
+	require.Len(t, spans[0].Events(), 1)
+	assert.Equal(t, "exception", spans[0].Events()[0].Name)
+}